@@ -0,0 +1,130 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package converteddashboard
+
+import (
+	"context"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document/convert"
+	dashboards "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/v1/config/dashboards/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/v1/config/jsondashboards"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/config"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Converts a classic dashboard into the content of a platform dashboard, which can get managed via `dynatrace_document`",
+		ReadContext: logging.EnableDSCtx(DataSourceRead),
+		Schema: map[string]*schema.Schema{
+			"dashboard_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The ID of the classic dashboard to convert",
+				ExactlyOneOf: []string{"dashboard_id", "contents"},
+			},
+			"contents": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The JSON representation of the classic dashboard to convert, e.g. the attribute `contents` of a `dynatrace_json_dashboard`",
+				ExactlyOneOf: []string{"dashboard_id", "contents"},
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the classic dashboard",
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The content of the platform dashboard as JSON, suitable for the attribute `content` of a `dynatrace_document` of type `dashboard`",
+			},
+			"unconverted": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The tiles that couldn't get converted. The platform dashboard contains a markdown tile stating the reason in their place",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"index": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The position of the tile within the classic dashboard",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the tile",
+						},
+						"tile_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the tile within the classic dashboard",
+						},
+						"reason": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Why the tile couldn't get converted",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func DataSourceRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	contents := d.Get("contents").(string)
+	dashboardID := d.Get("dashboard_id").(string)
+	if len(dashboardID) > 0 {
+		creds, err := config.Credentials(m, config.CredValDefault)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		var dashboard dashboards.JSONDashboard
+		if err := jsondashboards.Service(creds).Get(ctx, dashboardID, &dashboard); err != nil {
+			return diag.FromErr(err)
+		}
+		contents = dashboard.Contents
+	}
+
+	result, err := convert.Dashboard([]byte(contents))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	unconverted := []any{}
+	for _, tile := range result.Unconverted {
+		unconverted = append(unconverted, map[string]any{
+			"index":     tile.Index,
+			"name":      tile.Name,
+			"tile_type": tile.TileType,
+			"reason":    tile.Reason,
+		})
+	}
+
+	if len(dashboardID) > 0 {
+		d.SetId(dashboardID)
+	} else {
+		d.SetId(result.Name)
+	}
+	d.Set("name", result.Name)
+	d.Set("content", result.Content)
+	d.Set("unconverted", unconverted)
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,101 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package snapshots
+
+import (
+	"context"
+
+	documents "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/config"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieve the snapshots the Dynatrace Platform keeps of a document (dashboard or notebook).",
+		ReadContext: logging.EnableDSCtx(DataSourceRead),
+		Schema: map[string]*schema.Schema{
+			"document_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the document",
+			},
+			"snapshots": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The snapshots of the document, ordered by their version",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"snapshot_version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version of the snapshot. Refer to it via `dynatrace_document_snapshot_restore` in order to roll the document back",
+						},
+						"document_version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version the document had when the snapshot got taken",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the snapshot",
+						},
+						"created_by": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the user who caused the snapshot to get taken",
+						},
+						"created_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the snapshot got taken",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func DataSourceRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	creds, err := config.Credentials(m, config.CredValAutomation)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	documentID := d.Get("document_id").(string)
+	snapshots, err := documents.NewSnapshotService(creds).List(ctx, documentID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	values := []map[string]any{}
+	for _, snapshot := range snapshots {
+		values = append(values, map[string]any{
+			"snapshot_version": snapshot.SnapshotVersion,
+			"document_version": snapshot.DocumentVersion,
+			"description":      snapshot.Description,
+			"created_by":       snapshot.ModificationInfo.CreatedBy,
+			"created_time":     snapshot.ModificationInfo.CreatedTime,
+		})
+	}
+	d.SetId(documentID)
+	d.Set("snapshots", values)
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,183 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package settingsschema
+
+import (
+	"context"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/generic/schemas"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/config"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieve the definition of a Settings 2.0 schema",
+		ReadContext: logging.EnableDSCtx(DataSourceRead),
+		Schema: map[string]*schema.Schema{
+			"schema_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the schema, e.g. `builtin:alerting.profile`",
+			},
+			"schema_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The version of the schema. Defaults to the latest version",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the schema",
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The display name of the schema",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The description of the schema",
+			},
+			"multi_object": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the schema allows for multiple settings objects per scope",
+			},
+			"ordered": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the order of the settings objects per scope is relevant",
+			},
+			"max_objects": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The maximum number of settings objects per scope",
+			},
+			"scopes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The types of scopes settings objects of this schema are allowed to target, e.g. `environment` or `HOST`",
+			},
+			"secrets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The paths of the properties holding secrets",
+			},
+			"properties": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The properties of the schema, including the properties of the complex types they refer to",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The names of the properties leading to this property, separated by `.`",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the property. Enums and complex types are reported as `enum:<name>` and `type:<name>`",
+						},
+						"items": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the elements if the property is a `list` or a `set`",
+						},
+						"display_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The display name of the property",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the property",
+						},
+						"nullable": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the property may be omitted",
+						},
+						"enum_values": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The allowed values if the property refers to an enum",
+						},
+					},
+				},
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The full definition of the schema as JSON",
+			},
+		},
+	}
+}
+
+func DataSourceRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	creds, err := config.Credentials(m, config.CredValDefault)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sch, err := schemas.Fetch(ctx, creds, d.Get("schema_id").(string), d.Get("schema_version").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	properties := []map[string]any{}
+	for _, property := range sch.Flatten() {
+		items := ""
+		t := property.Property.Type
+		if property.Property.Items != nil {
+			items = property.Property.Items.Type.String()
+			t = property.Property.Items.Type
+		}
+		properties = append(properties, map[string]any{
+			"path":         property.Path,
+			"type":         property.Property.Type.String(),
+			"items":        items,
+			"display_name": property.Property.DisplayName,
+			"description":  property.Property.Description,
+			"nullable":     property.Property.Nullable,
+			"enum_values":  sch.EnumValues(t),
+		})
+	}
+
+	d.SetId(sch.SchemaID + "@" + sch.Version)
+	d.Set("version", sch.Version)
+	d.Set("display_name", sch.DisplayName)
+	d.Set("description", sch.Description)
+	d.Set("multi_object", sch.MultiObject)
+	d.Set("ordered", sch.Ordered)
+	d.Set("max_objects", sch.MaxObjects)
+	d.Set("scopes", sch.AllowedScopes)
+	d.Set("secrets", sch.Secrets())
+	d.Set("properties", properties)
+	d.Set("json", string(sch.JSON))
+	return diag.Diagnostics{}
+}
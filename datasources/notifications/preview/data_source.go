@@ -0,0 +1,227 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package preview
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/problem/notifications/placeholders"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		Description: "Renders the template of a problem notification locally with a sample problem and reports placeholders not supported by the notification type",
+		ReadContext: logging.EnableDSCtx(DataSourceRead),
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The type of the notification. Possible values are " + possibleValues(),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(placeholders.Types(), false)),
+			},
+			"field": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The attribute of the notification the template is meant for, e.g. `subject` for notifications of type `EMAIL`. Defaults to the main content of the notification type (e.g. `body`, `message` or `payload`)",
+			},
+			"template": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The template to render, e.g. `Problem {ProblemID}: {ProblemTitle}`",
+			},
+			"problem": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Overrides the values of the sample problem the template gets rendered with",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"pid": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The unique system identifier of the problem (`{PID}`)",
+						},
+						"display_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The display number of the problem (`{ProblemID}`)",
+						},
+						"title": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The short description of the problem (`{ProblemTitle}`)",
+						},
+						"impact": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The impact level of the problem (`{ProblemImpact}`)",
+						},
+						"severity": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The severity level of the problem (`{ProblemSeverity}`)",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The state of the problem (`{State}`)",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The URL of the problem (`{ProblemURL}`)",
+						},
+						"tags": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The tags of the impacted entities in the format `key` or `key:value` (`{Tags}`)",
+						},
+						"impacted_entity": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The entities impacted by the problem (`{ImpactedEntities}`)",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The ID of the entity",
+									},
+									"name": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The name of the entity",
+									},
+									"type": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The type of the entity, e.g. `SERVICE`",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"payload": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The template with its placeholders replaced by the values of the sample problem",
+			},
+			"placeholders": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The placeholders the template refers to",
+			},
+			"unsupported": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The placeholders the template refers to, which are not supported by the notification type. These would get sent as they are",
+			},
+			"supported": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The placeholders supported by the notification type",
+			},
+		},
+	}
+}
+
+func possibleValues() string {
+	values := ""
+	for idx, t := range placeholders.Types() {
+		if idx > 0 {
+			values = values + ", "
+		}
+		values = values + "`" + t + "`"
+	}
+	return values
+}
+
+func DataSourceRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	notificationType := d.Get("type").(string)
+	field, err := placeholders.FieldOf(notificationType, d.Get("field").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	template := d.Get("template").(string)
+	problem := sampleProblem(d)
+
+	var diags diag.Diagnostics
+	if err := field.Validate(template); err != nil {
+		diags = append(diags, diag.Diagnostic{Severity: diag.Warning, Summary: "Unsupported placeholders", Detail: err.Error()})
+	}
+
+	d.SetId(fmt.Sprintf("%s.%s", notificationType, field.Attribute))
+	d.Set("field", field.Attribute)
+	d.Set("payload", problem.Render(template))
+	d.Set("placeholders", placeholders.Parse(template))
+	d.Set("unsupported", placeholders.Unsupported(template, field.Placeholders))
+	d.Set("supported", field.Placeholders)
+	return diags
+}
+
+// sampleProblem returns the sample problem with the values configured via `problem` applied
+func sampleProblem(d *schema.ResourceData) *placeholders.Problem {
+	problem := placeholders.SampleProblem()
+	if _, ok := d.GetOk("problem.#"); !ok {
+		return problem
+	}
+	overrides := map[string]*string{
+		"pid":        &problem.PID,
+		"display_id": &problem.DisplayID,
+		"title":      &problem.Title,
+		"impact":     &problem.Impact,
+		"severity":   &problem.Severity,
+		"state":      &problem.State,
+		"url":        &problem.URL,
+	}
+	for key, target := range overrides {
+		if value, ok := d.GetOk("problem.0." + key); ok {
+			*target = value.(string)
+		}
+	}
+	if value, ok := d.GetOk("problem.0.tags"); ok {
+		problem.Tags = []string{}
+		for _, tag := range value.([]any) {
+			problem.Tags = append(problem.Tags, tag.(string))
+		}
+	}
+	if value, ok := d.GetOk("problem.0.impacted_entity"); ok {
+		problem.ImpactedEntities = []placeholders.Entity{}
+		for _, elem := range value.([]any) {
+			entity := elem.(map[string]any)
+			problem.ImpactedEntities = append(problem.ImpactedEntities, placeholders.Entity{
+				ID:   entity["id"].(string),
+				Name: entity["name"].(string),
+				Type: entity["type"].(string),
+			})
+		}
+	}
+	return problem
+}
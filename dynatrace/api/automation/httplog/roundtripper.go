@@ -19,10 +19,12 @@ func InstallRoundTripper() {
 	lock.Lock()
 	defer lock.Unlock()
 	if _, ok := http.DefaultClient.Transport.(*RoundTripper); !ok {
+		// OAuth clients derive their transport from `http.DefaultClient`,
+		// which subjects them to the rate limits of their API family
 		if http.DefaultClient.Transport == nil {
-			http.DefaultClient.Transport = &RoundTripper{RoundTripper: http.DefaultTransport}
+			http.DefaultClient.Transport = &RoundTripper{RoundTripper: rest.NewRateLimitedTransport(http.DefaultTransport)}
 		} else {
-			http.DefaultClient.Transport = &RoundTripper{RoundTripper: http.DefaultClient.Transport}
+			http.DefaultClient.Transport = &RoundTripper{RoundTripper: rest.NewRateLimitedTransport(http.DefaultClient.Transport)}
 		}
 	}
 }
@@ -0,0 +1,166 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package workflows_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/automation/workflows"
+	workflowsettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/automation/workflows/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// updates forwards to the mock server and records the payloads of the workflow updates
+type updates struct {
+	mu       sync.Mutex
+	payloads []string
+}
+
+func (me *updates) serve(t *testing.T, server *mockserver.Server) *settings.Credentials {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			data, _ := io.ReadAll(r.Body)
+			me.mu.Lock()
+			me.payloads = append(me.payloads, string(data))
+			me.mu.Unlock()
+			r.Body = io.NopCloser(bytes.NewReader(data))
+		}
+		server.ServeHTTP(w, r)
+	}))
+	t.Cleanup(proxy.Close)
+	credentials := server.Credentials()
+	credentials.Automation.EnvironmentURL = proxy.URL
+	return credentials
+}
+
+// decoded produces the workflow the way the resource decodes it from the state, including `last_modified`
+func decoded(t *testing.T, workflow *workflowsettings.Workflow) *workflowsettings.Workflow {
+	t.Helper()
+	properties := hcl.Properties{}
+	if err := workflow.MarshalHCL(properties); err != nil {
+		t.Fatal(err)
+	}
+	d := schema.TestResourceDataRaw(t, new(workflowsettings.Workflow).Schema(), map[string]any{})
+	for k, v := range properties {
+		if err := d.Set(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	result := new(workflowsettings.Workflow)
+	if err := result.UnmarshalHCL(hcl.DecoderFrom(d)); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestUpdateConflict(t *testing.T) {
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	ctx := context.Background()
+	service := workflows.Service(server.Credentials())
+	stub, err := service.Create(ctx, &workflowsettings.Workflow{Title: "conflict", Description: "A"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote := new(workflowsettings.Workflow)
+	if err := service.Get(ctx, stub.ID, remote); err != nil {
+		t.Fatal(err)
+	}
+	known := decoded(t, remote)
+	if len(known.LastModified()) == 0 || known.LastModified() != remote.LastModified() {
+		t.Fatalf("expected the modification timestamp to be decoded from `last_modified`, got %q", known.LastModified())
+	}
+	stateCtx := context.WithValue(ctx, settings.ContextKeyStateConfig, known)
+
+	// the timestamp in the state matches the remote one
+	if err := service.Update(stateCtx, stub.ID, decoded(t, &workflowsettings.Workflow{Title: "conflict", Description: "B", ModificationInfo: known.ModificationInfo})); err != nil {
+		t.Fatal(err)
+	}
+
+	// the workflow has been modified since then
+	err = service.Update(stateCtx, stub.ID, decoded(t, &workflowsettings.Workflow{Title: "conflict", Description: "C", ModificationInfo: known.ModificationInfo}))
+	var conflictError *settings.ConflictError
+	if !errors.As(err, &conflictError) {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+	if detail := conflictError.Detail(); !strings.Contains(detail, `~ description: "A" => "B"`) {
+		t.Errorf("expected the remote change to be reported, got\n%s", detail)
+	}
+}
+
+func TestUpdateOverwrite(t *testing.T) {
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	recorded := &updates{}
+	credentials := recorded.serve(t, server)
+	credentials.OnConflict = settings.ConflictPolicies.Overwrite
+
+	ctx := context.Background()
+	service := workflows.Service(credentials)
+	stub, err := service.Create(ctx, &workflowsettings.Workflow{Title: "overwrite", Description: "A"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	known := new(workflowsettings.Workflow)
+	if err := service.Get(ctx, stub.ID, known); err != nil {
+		t.Fatal(err)
+	}
+	stateCtx := context.WithValue(ctx, settings.ContextKeyStateConfig, decoded(t, known))
+	if err := service.Update(ctx, stub.ID, &workflowsettings.Workflow{Title: "overwrite", Description: "B"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the outdated timestamp decoded from the state neither prevents the update nor is it getting sent
+	if err := service.Update(stateCtx, stub.ID, decoded(t, &workflowsettings.Workflow{Title: "overwrite", Description: "C", ModificationInfo: known.ModificationInfo})); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorded.payloads) != 2 {
+		t.Fatalf("expected 2 updates, got %d", len(recorded.payloads))
+	}
+	for _, payload := range recorded.payloads {
+		if strings.Contains(payload, "modificationInfo") {
+			t.Errorf("expected the read-only `modificationInfo` not to be sent, got %s", payload)
+		}
+	}
+	remote := new(workflowsettings.Workflow)
+	if err := service.Get(ctx, stub.ID, remote); err != nil {
+		t.Fatal(err)
+	}
+	if remote.Description != "C" {
+		t.Errorf("expected the remote changes to be overwritten, got %q", remote.Description)
+	}
+}
@@ -131,8 +131,14 @@ func (me *service) Create(ctx context.Context, v *workflows.Workflow) (stub *api
 }
 
 func (me *service) Update(ctx context.Context, id string, v *workflows.Workflow) (err error) {
+	if err = me.checkConflict(ctx, id); err != nil {
+		return err
+	}
+	// `modificationInfo` is read-only, the timestamp decoded from the state must not get sent
+	payload := *v
+	payload.ModificationInfo = nil
 	var data []byte
-	if data, err = json.Marshal(v); err != nil {
+	if data, err = json.Marshal(&payload); err != nil {
 		return err
 	}
 	var response automation.Response
@@ -149,6 +155,40 @@ func (me *service) Update(ctx context.Context, id string, v *workflows.Workflow)
 	return tfrest.Error{Code: response.StatusCode, Message: string(response.Data)}
 }
 
+// checkConflict ensures that the workflow hasn't been modified on the remote side
+// since its modification timestamp has been stored in the state
+func (me *service) checkConflict(ctx context.Context, id string) error {
+	if me.credentials.OnConflict == settings.ConflictPolicies.Overwrite {
+		return nil
+	}
+	known, ok := ctx.Value(settings.ContextKeyStateConfig).(*workflows.Workflow)
+	if !ok || len(known.LastModified()) == 0 {
+		return nil
+	}
+	var remote workflows.Workflow
+	if err := me.Get(ctx, id, &remote); err != nil {
+		return err
+	}
+	if len(remote.LastModified()) == 0 || remote.LastModified() == known.LastModified() {
+		return nil
+	}
+	knownData, err := json.Marshal(known)
+	if err != nil {
+		return err
+	}
+	remoteData, err := json.Marshal(&remote)
+	if err != nil {
+		return err
+	}
+	return &settings.ConflictError{
+		SchemaID: me.SchemaID(),
+		ID:       id,
+		Known:    known.LastModified(),
+		Remote:   remote.LastModified(),
+		Changes:  settings.JSONChanges("", knownData, remoteData),
+	}
+}
+
 func (me *service) Delete(ctx context.Context, id string) error {
 	response, err := me.client(ctx).Delete(ctx, apiClient.Workflows, id)
 	if response.StatusCode == 204 || response.StatusCode == 404 {
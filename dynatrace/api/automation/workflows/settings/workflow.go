@@ -36,6 +36,24 @@ type Workflow struct {
 	SchemaVersion int      `json:"schemaVersion,omitempty"`                      //
 	Trigger       *Trigger `json:"trigger,omitempty"`                            // Configures how executions of the workflows are getting triggered. If no trigger is specified it means the workflow is getting manually triggered
 	Tasks         Tasks    `json:"tasks,omitempty"`                              // The tasks to run for every execution of this workflow
+
+	ModificationInfo *ModificationInfo `json:"modificationInfo,omitempty"` // read-only, reported by the Automation API
+}
+
+// ModificationInfo contains read-only information about when and by whom a workflow has been modified
+type ModificationInfo struct {
+	CreatedBy        string `json:"createdBy,omitempty"`
+	CreatedTime      string `json:"createdTime,omitempty"`
+	LastModifiedBy   string `json:"lastModifiedBy,omitempty"`
+	LastModifiedTime string `json:"lastModifiedTime,omitempty"`
+}
+
+// LastModified returns the time the workflow has been modified the last time, or an empty string if not known
+func (me *Workflow) LastModified() string {
+	if me.ModificationInfo == nil {
+		return ""
+	}
+	return me.ModificationInfo.LastModifiedTime
 }
 
 func (me *Workflow) Name() string {
@@ -90,6 +108,11 @@ func (me *Workflow) Schema() map[string]*schema.Schema {
 			Required:    true,
 			Elem:        &schema.Resource{Schema: new(Tasks).Schema("tasks")},
 		},
+		"last_modified": {
+			Type:        schema.TypeString,
+			Description: "The time the workflow has been modified the last time. Used for detecting modifications outside of Terraform",
+			Computed:    true,
+		},
 	}
 }
 
@@ -103,10 +126,15 @@ func (me *Workflow) MarshalHCL(properties hcl.Properties) error {
 		"private": me.Private,
 		"trigger": me.Trigger,
 		"tasks":   me.Tasks,
+
+		"last_modified": me.LastModified(),
 	})
 }
 
 func (me *Workflow) UnmarshalHCL(decoder hcl.Decoder) error {
+	if value, ok := decoder.GetOk("last_modified"); ok && len(value.(string)) > 0 {
+		me.ModificationInfo = &ModificationInfo{LastModifiedTime: value.(string)}
+	}
 	return decoder.DecodeAll(map[string]any{
 		"title":       &me.Title,
 		"description": &me.Description,
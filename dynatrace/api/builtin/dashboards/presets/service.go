@@ -92,3 +92,17 @@ func (me *service) Delete(ctx context.Context, id string) error {
 	defer mu.Unlock()
 	return me.service.Delete(ctx, id)
 }
+
+func (me *service) Validate(ctx context.Context, v *presets.Settings) error {
+	if validator, ok := me.service.(settings.Validator[*presets.Settings]); ok {
+		return validator.Validate(ctx, v)
+	}
+	return nil
+}
+
+func (me *service) SupportsPlanValidation() bool {
+	if pv, ok := me.service.(settings.PlanValidator); ok {
+		return pv.SupportsPlanValidation()
+	}
+	return false
+}
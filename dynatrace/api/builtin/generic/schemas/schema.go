@@ -0,0 +1,207 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package schemas
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Schema is the definition of a Settings 2.0 schema, as returned by `/api/v2/settings/schemas/{schemaId}`.
+// Only the parts relevant for validating and documenting settings objects are getting unmarshalled.
+// The full definition remains available via `JSON`
+type Schema struct {
+	SchemaID      string               `json:"schemaId"`
+	Version       string               `json:"version"`
+	DisplayName   string               `json:"displayName"`
+	Description   string               `json:"description"`
+	MultiObject   bool                 `json:"multiObject"`
+	Ordered       bool                 `json:"ordered"`
+	MaxObjects    int                  `json:"maxObjects"`
+	AllowedScopes []string             `json:"allowedScopes"`
+	Properties    map[string]*Property `json:"properties"`
+	Types         map[string]*Type     `json:"types"`
+	Enums         map[string]*Enum     `json:"enums"`
+
+	JSON json.RawMessage `json:"-"`
+}
+
+// Type is a complex type defined within a schema and referred to by properties via `#/types/<name>`
+type Type struct {
+	DisplayName string               `json:"displayName"`
+	Description string               `json:"description"`
+	Properties  map[string]*Property `json:"properties"`
+}
+
+// Enum is an enumeration defined within a schema and referred to by properties via `#/enums/<name>`
+type Enum struct {
+	DisplayName string      `json:"displayName"`
+	Description string      `json:"description"`
+	Items       []*EnumItem `json:"items"`
+}
+
+// Values lists the values allowed for this enumeration
+func (me *Enum) Values() []string {
+	values := []string{}
+	for _, item := range me.Items {
+		values = append(values, item.Value)
+	}
+	return values
+}
+
+type EnumItem struct {
+	Value       string `json:"value"`
+	DisplayName string `json:"displayName"`
+}
+
+// Property describes a property of a schema or of a complex type.
+// Properties of type `list` or `set` describe their elements via `Items`
+type Property struct {
+	DisplayName   string          `json:"displayName"`
+	Description   string          `json:"description"`
+	Documentation string          `json:"documentation"`
+	Type          PropertyType    `json:"type"`
+	SubType       string          `json:"subType"`
+	Nullable      bool            `json:"nullable"`
+	Default       any             `json:"default"`
+	Constraints   []*Constraint   `json:"constraints"`
+	Items         *Property       `json:"items"`
+	MinObjects    *int            `json:"minObjects"`
+	MaxObjects    *int            `json:"maxObjects"`
+	Precondition  json.RawMessage `json:"precondition"`
+}
+
+// PropertyType is either the name of a primitive type (`text`, `boolean`, `integer`, `secret`, ...)
+// or a reference to a complex type or an enum defined by the schema
+type PropertyType struct {
+	Name string
+	Ref  string
+}
+
+func (me *PropertyType) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &me.Name); err == nil {
+		return nil
+	}
+	var ref struct {
+		Ref string `json:"$ref"`
+	}
+	if err := json.Unmarshal(data, &ref); err != nil {
+		return err
+	}
+	me.Ref = ref.Ref
+	return nil
+}
+
+func (me PropertyType) MarshalJSON() ([]byte, error) {
+	if len(me.Ref) > 0 {
+		return json.Marshal(map[string]string{"$ref": me.Ref})
+	}
+	return json.Marshal(me.Name)
+}
+
+// String produces `enum:<name>` and `type:<name>` for references and the name of the type otherwise
+func (me PropertyType) String() string {
+	if name, found := strings.CutPrefix(me.Ref, "#/enums/"); found {
+		return "enum:" + name
+	}
+	if name, found := strings.CutPrefix(me.Ref, "#/types/"); found {
+		return "type:" + name
+	}
+	if len(me.Ref) > 0 {
+		return me.Ref
+	}
+	return me.Name
+}
+
+// Constraint restricts the values of a property.
+// Constraints of types the provider doesn't know about are getting ignored
+type Constraint struct {
+	Type          string   `json:"type"`
+	MinLength     *int     `json:"minLength"`
+	MaxLength     *int     `json:"maxLength"`
+	Minimum       *float64 `json:"minimum"`
+	Maximum       *float64 `json:"maximum"`
+	Pattern       string   `json:"pattern"`
+	CustomMessage string   `json:"customMessage"`
+}
+
+// Parse unmarshals the definition of a schema
+func Parse(data []byte) (*Schema, error) {
+	schema := new(Schema)
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, err
+	}
+	schema.JSON = data
+	return schema, nil
+}
+
+// enum resolves the enum the given type refers to (if any)
+func (me *Schema) enum(t PropertyType) *Enum {
+	if name, found := strings.CutPrefix(t.Ref, "#/enums/"); found {
+		return me.Enums[name]
+	}
+	return nil
+}
+
+// EnumValues lists the values allowed for the given type if it refers to an enum
+func (me *Schema) EnumValues(t PropertyType) []string {
+	if enum := me.enum(t); enum != nil {
+		return enum.Values()
+	}
+	return []string{}
+}
+
+// complexType resolves the complex type the given type refers to (if any)
+func (me *Schema) complexType(t PropertyType) *Type {
+	if name, found := strings.CutPrefix(t.Ref, "#/types/"); found {
+		return me.Types[name]
+	}
+	return nil
+}
+
+// FlatProperty is a property of a schema, including the properties of complex types nested into it
+type FlatProperty struct {
+	Path     string
+	Property *Property
+}
+
+// Flatten lists the properties of the schema and the properties of the complex types they refer to.
+// The path of nested properties consists of the names of the properties leading to it, separated by `.`.
+// Elements of lists and sets don't contribute to the path
+func (me *Schema) Flatten() []*FlatProperty {
+	result := []*FlatProperty{}
+	me.flatten("", me.Properties, map[string]bool{}, &result)
+	return result
+}
+
+func (me *Schema) flatten(prefix string, properties map[string]*Property, visiting map[string]bool, result *[]*FlatProperty) {
+	for _, name := range sortedKeys(properties) {
+		property := properties[name]
+		*result = append(*result, &FlatProperty{Path: prefix + name, Property: property})
+		t := property.Type
+		if property.Items != nil {
+			t = property.Items.Type
+		}
+		// types may refer to themselves
+		if ct := me.complexType(t); ct != nil && !visiting[t.Ref] {
+			visiting[t.Ref] = true
+			me.flatten(prefix+name+".", ct.Properties, visiting, result)
+			delete(visiting, t.Ref)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package schemas_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/generic/schemas"
+	generic "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/generic/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/cache"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const definition = `{
+	"schemaId": "builtin:mock.notification",
+	"version": "1.2.3",
+	"displayName": "Notification",
+	"multiObject": true,
+	"maxObjects": 100,
+	"allowedScopes": ["environment", "HOST"],
+	"enums": {
+		"Mode": {"type": "enum", "items": [{"value": "EMAIL", "displayName": "Email"}, {"value": "WEBHOOK", "displayName": "Webhook"}]}
+	},
+	"types": {
+		"Header": {
+			"type": "object",
+			"properties": {
+				"name": {"type": "text", "nullable": false, "constraints": [{"type": "PATTERN", "pattern": "[A-Za-z\\-]+"}]},
+				"value": {"type": "secret", "nullable": false}
+			}
+		}
+	},
+	"properties": {
+		"name": {"type": "text", "nullable": false, "constraints": [{"type": "NOT_BLANK"}, {"type": "LENGTH", "minLength": 1, "maxLength": 20}]},
+		"enabled": {"type": "boolean", "nullable": false},
+		"mode": {"type": {"$ref": "#/enums/Mode"}, "nullable": false, "default": "EMAIL"},
+		"retries": {"type": "integer", "nullable": false, "default": 3, "constraints": [{"type": "RANGE", "minimum": 0, "maximum": 10}]},
+		"token": {"type": "secret", "nullable": true},
+		"url": {"type": "text", "nullable": false, "precondition": {"type": "EQUALS", "property": "mode", "expectedValue": "WEBHOOK"}},
+		"headers": {"type": "list", "nullable": false, "default": [], "maxObjects": 2, "items": {"type": {"$ref": "#/types/Header"}}}
+	}
+}`
+
+func parse(t *testing.T) *schemas.Schema {
+	t.Helper()
+	schema, err := schemas.Parse([]byte(definition))
+	require.NoError(t, err)
+	return schema
+}
+
+func TestValidate(t *testing.T) {
+	schema := parse(t)
+
+	assert.NoError(t, schema.Validate("environment", `{"name": "ops", "enabled": true}`))
+	assert.NoError(t, schema.Validate("HOST-0123456789ABCDEF", `{"name": "ops", "enabled": true, "mode": "WEBHOOK", "url": "https://example.com", "headers": [{"name": "X-Token", "value": "secret"}]}`))
+	assert.NoError(t, schema.Validate("", `{"name": "ops", "enabled": true}`), "an empty scope is not getting validated")
+
+	err := schema.Validate("APPLICATION-0123456789ABCDEF", `{"name": " ", "enabeld": true, "mode": "SMS", "retries": 11.5, "headers": [{"name": "X Token", "value": "a"}, {"name": "b", "value": "b"}, {"name": "c", "value": "c"}]}`)
+	require.Error(t, err)
+	for _, expected := range []string{
+		"scope `APPLICATION-0123456789ABCDEF` is not allowed, expected a scope of type `environment`, `HOST`",
+		"unknown property `enabeld`, did you mean `enabled`?",
+		"property `enabled` is required",
+		"property `name` must not be blank",
+		"property `mode` has the value `SMS`, expected one of `EMAIL`, `WEBHOOK`",
+		"property `retries` is expected to be an integer, but is `11.5`",
+		"property `headers` allows for at most 2 elements",
+		"property `headers[0].name` must match the pattern `[A-Za-z\\-]+`",
+	} {
+		assert.Contains(t, err.Error(), expected)
+	}
+	assert.NotContains(t, err.Error(), "`url`", "properties with preconditions are not required")
+
+	err = schema.Validate("environment", `{"name": "a name longer than allowed", "enabled": "yes", "retries": -1}`)
+	require.Error(t, err)
+	for _, expected := range []string{
+		"property `name` must be at most 20 characters long",
+		"property `enabled` is expected to be a boolean",
+		"property `retries` must be at least 0",
+	} {
+		assert.Contains(t, err.Error(), expected)
+	}
+
+	assert.ErrorContains(t, schema.Validate("environment", `[]`), "value is expected to be a JSON object")
+
+	// schemas without properties are not known well enough for validating values
+	unknown, err := schemas.Parse([]byte(`{"schemaId": "builtin:mock.unknown", "version": "1.0"}`))
+	require.NoError(t, err)
+	assert.NoError(t, unknown.Validate("environment", `{"anything": true}`))
+}
+
+func TestSecrets(t *testing.T) {
+	schema := parse(t)
+	assert.Equal(t, []string{"headers.value", "token"}, schema.Secrets())
+
+	state := &generic.Settings{SchemaID: "builtin:mock.notification", Value: `{"name":"ops","enabled":true,"token":"my-token","headers":[{"name":"X-Token","value":"my-header"}]}`}
+	// the remote side neither reveals secrets nor necessarily masks them in a recognizable way
+	remote := &generic.Settings{SchemaID: "builtin:mock.notification", Value: `{"name":"renamed","enabled":true,"token":"","headers":[{"name":"X-Token","value":"<masked>"}]}`}
+	state.MergeWith(remote, schema)
+
+	var merged map[string]any
+	require.NoError(t, json.Unmarshal([]byte(state.Value), &merged))
+	assert.Equal(t, "renamed", merged["name"])
+	assert.Equal(t, "my-token", merged["token"])
+	assert.Equal(t, "my-header", merged["headers"].([]any)[0].(map[string]any)["value"])
+}
+
+func TestFetch(t *testing.T) {
+	t.Setenv(cache.ENV_VAR_PERSISTENT_CACHE_FOLDER, t.TempDir())
+
+	server, err := mockserver.New()
+	require.NoError(t, err)
+	defer server.Close()
+	require.NoError(t, server.AddSchema([]byte(definition)))
+	require.NoError(t, server.AddSchema([]byte(strings.Replace(definition, `"1.2.3"`, `"1.3.0"`, 1))))
+
+	ctx := context.Background()
+	credentials := server.Credentials()
+
+	latest, err := schemas.Fetch(ctx, credentials, "builtin:mock.notification", "")
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.0", latest.Version)
+	assert.Len(t, latest.Properties, 7)
+
+	previous, err := schemas.Fetch(ctx, credentials, "builtin:mock.notification", "1.2.3")
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", previous.Version)
+
+	cached, err := schemas.Fetch(ctx, credentials, "builtin:mock.notification", "1.2.3")
+	require.NoError(t, err)
+	assert.Same(t, previous, cached)
+	_, err = os.Stat(path.Join(cache.PersistentFolder(credentials.URL, "schemas"), "builtin.mock.notification@1.2.3.json"))
+	assert.NoError(t, err, "specific versions of a schema are expected to be stored in the persistent cache")
+
+	_, err = schemas.Fetch(ctx, credentials, "builtin:mock.notification", "0.0.1")
+	require.Error(t, err)
+	if restErr, ok := err.(rest.Error); assert.True(t, ok) {
+		assert.Equal(t, 404, restErr.Code)
+	}
+}
@@ -0,0 +1,72 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package schemas
+
+// StripSecrets removes all properties of type `secret` from the given value of a settings object.
+// The Dynatrace Environment doesn't reveal the values of secrets, which therefore need to be
+// taken from the configuration instead
+func (me *Schema) StripSecrets(value map[string]any) {
+	me.stripSecrets(me.Properties, value, map[string]bool{})
+}
+
+func (me *Schema) stripSecrets(properties map[string]*Property, value map[string]any, visiting map[string]bool) {
+	for name, property := range properties {
+		v, found := value[name]
+		if !found {
+			continue
+		}
+		if property.Type.Name == "secret" || (property.Items != nil && property.Items.Type.Name == "secret") {
+			delete(value, name)
+			continue
+		}
+		switch tv := v.(type) {
+		case map[string]any:
+			me.stripNested(property.Type, tv, visiting)
+		case []any:
+			if property.Items == nil {
+				continue
+			}
+			for _, element := range tv {
+				if m, ok := element.(map[string]any); ok {
+					me.stripNested(property.Items.Type, m, visiting)
+				}
+			}
+		}
+	}
+}
+
+func (me *Schema) stripNested(t PropertyType, value map[string]any, visiting map[string]bool) {
+	ct := me.complexType(t)
+	if ct == nil || visiting[t.Ref] {
+		return
+	}
+	visiting[t.Ref] = true
+	me.stripSecrets(ct.Properties, value, visiting)
+	delete(visiting, t.Ref)
+}
+
+// Secrets lists the paths of all properties of type `secret`, as produced by `Flatten`
+func (me *Schema) Secrets() []string {
+	secrets := []string{}
+	for _, property := range me.Flatten() {
+		if property.Property.Type.Name == "secret" || (property.Property.Items != nil && property.Property.Items.Type.Name == "secret") {
+			secrets = append(secrets, property.Path)
+		}
+	}
+	return secrets
+}
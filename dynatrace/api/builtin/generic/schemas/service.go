@@ -0,0 +1,111 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package schemas
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/cache"
+)
+
+var fetched = map[string]*Schema{}
+var fetchedMutex sync.Mutex
+
+// fetchLocks ensures that the same schema is getting fetched only once, even if several resources
+// are requesting it concurrently. Fetching different schemas doesn't block each other
+var fetchLocks = map[string]*sync.Mutex{}
+
+func fetchLock(key string) *sync.Mutex {
+	fetchedMutex.Lock()
+	defer fetchedMutex.Unlock()
+	if lock, found := fetchLocks[key]; found {
+		return lock
+	}
+	lock := new(sync.Mutex)
+	fetchLocks[key] = lock
+	return lock
+}
+
+func lookup(key string) (*Schema, bool) {
+	fetchedMutex.Lock()
+	defer fetchedMutex.Unlock()
+	schema, found := fetched[key]
+	return schema, found
+}
+
+func store(key string, schema *Schema) {
+	fetchedMutex.Lock()
+	defer fetchedMutex.Unlock()
+	fetched[key] = schema
+}
+
+// Fetch retrieves the definition of the schema with the given ID from the Dynatrace Environment.
+// An empty version refers to the latest version of the schema.
+// Schemas are getting fetched only once per environment and process.
+// A specific version of a schema never changes, which is why these are additionally stored within
+// the persistent cache (if `DYNATRACE_PERSISTENT_CACHE_FOLDER` is set)
+func Fetch(ctx context.Context, credentials *settings.Credentials, schemaID string, schemaVersion string) (*Schema, error) {
+	key := credentials.URL + "|" + schemaID + "|" + schemaVersion
+
+	lock := fetchLock(key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if schema, found := lookup(key); found {
+		return schema, nil
+	}
+
+	fileName := ""
+	if folder := cache.PersistentFolder(credentials.URL, "schemas"); len(folder) > 0 && len(schemaVersion) > 0 {
+		fileName = path.Join(folder, strings.ReplaceAll(schemaID, ":", ".")+"@"+schemaVersion+".json")
+		if data, err := os.ReadFile(fileName); err == nil {
+			if schema, err := Parse(data); err == nil {
+				store(key, schema)
+				return schema, nil
+			}
+		}
+	}
+
+	u := "/api/v2/settings/schemas/" + url.PathEscape(schemaID)
+	if len(schemaVersion) > 0 {
+		u = u + "?schemaVersion=" + url.QueryEscape(schemaVersion)
+	}
+	var data json.RawMessage
+	if err := rest.DefaultClient(credentials.URL, credentials.Token).Get(ctx, u, 200).Finish(&data); err != nil {
+		return nil, err
+	}
+	schema, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(fileName) > 0 {
+		if err := os.MkdirAll(path.Dir(fileName), os.ModePerm); err == nil {
+			os.WriteFile(fileName, data, 0644)
+		}
+	}
+	store(key, schema)
+	return schema, nil
+}
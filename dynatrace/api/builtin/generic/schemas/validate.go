@@ -0,0 +1,293 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package schemas
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Validate checks the given JSON encoded value of a settings object and the scope it is targeting against this schema.
+// All violations found are reported at once.
+// Properties with a precondition or a default value are not required to be present, because whether the
+// Dynatrace Environment demands them depends on other properties.
+// The scope doesn't get validated if it is empty
+func (me *Schema) Validate(scope string, value string) error {
+	violations := []string{}
+	if len(scope) > 0 && len(me.AllowedScopes) > 0 && !me.allowsScope(scope) {
+		violations = append(violations, fmt.Sprintf("scope `%s` is not allowed, expected a scope of type %s", scope, quoteAll(me.AllowedScopes)))
+	}
+
+	// schemas not defining any properties are not known well enough for validating the value
+	if me.Properties != nil {
+		decoder := json.NewDecoder(bytes.NewReader([]byte(value)))
+		decoder.UseNumber()
+		var v any
+		if err := decoder.Decode(&v); err != nil {
+			violations = append(violations, fmt.Sprintf("value is not valid JSON: %s", err.Error()))
+		} else if m, ok := v.(map[string]any); !ok {
+			violations = append(violations, "value is expected to be a JSON object")
+		} else {
+			violations = append(violations, me.validateProperties("", me.Properties, m)...)
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return errors.New("the settings don't comply with the schema `" + me.SchemaID + "` (version " + me.Version + "):\n  - " + strings.Join(violations, "\n  - "))
+}
+
+// allowsScope compares the type of the given scope with the scopes allowed by this schema.
+// Scopes other than `environment` are entity IDs (e.g. `HOST-0123456789ABCDEF`) or carry a
+// prefix (e.g. `metric-builtin:host.cpu.usage`), which denotes their type
+func (me *Schema) allowsScope(scope string) bool {
+	scopeType, _, _ := strings.Cut(scope, "-")
+	for _, allowed := range me.AllowedScopes {
+		if allowed == scope || allowed == scopeType {
+			return true
+		}
+	}
+	return false
+}
+
+func (me *Schema) validateProperties(prefix string, properties map[string]*Property, value map[string]any) []string {
+	violations := []string{}
+	for _, name := range sortedKeys(value) {
+		if _, found := properties[name]; !found {
+			violation := fmt.Sprintf("unknown property `%s%s`", prefix, name)
+			if suggestion := closest(name, properties); len(suggestion) > 0 {
+				violation = violation + fmt.Sprintf(", did you mean `%s%s`?", prefix, suggestion)
+			}
+			violations = append(violations, violation)
+		}
+	}
+	for _, name := range sortedKeys(properties) {
+		property := properties[name]
+		v, found := value[name]
+		if !found || v == nil {
+			if !property.Nullable && property.Precondition == nil && property.Default == nil {
+				violations = append(violations, fmt.Sprintf("property `%s%s` is required", prefix, name))
+			}
+			continue
+		}
+		violations = append(violations, me.validateValue(prefix+name, property, v)...)
+	}
+	return violations
+}
+
+func (me *Schema) validateValue(path string, property *Property, value any) []string {
+	if enum := me.enum(property.Type); enum != nil {
+		s, ok := value.(string)
+		if !ok {
+			return []string{fmt.Sprintf("property `%s` is expected to be one of %s", path, quoteAll(enum.Values()))}
+		}
+		for _, allowed := range enum.Values() {
+			if s == allowed {
+				return nil
+			}
+		}
+		return []string{fmt.Sprintf("property `%s` has the value `%s`, expected one of %s", path, s, quoteAll(enum.Values()))}
+	}
+	if ct := me.complexType(property.Type); ct != nil {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("property `%s` is expected to be an object", path)}
+		}
+		return me.validateProperties(path+".", ct.Properties, m)
+	}
+
+	switch property.Type.Name {
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return []string{fmt.Sprintf("property `%s` is expected to be a boolean", path)}
+		}
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return []string{fmt.Sprintf("property `%s` is expected to be an integer", path)}
+		}
+		if _, err := n.Int64(); err != nil {
+			return []string{fmt.Sprintf("property `%s` is expected to be an integer, but is `%s`", path, n.String())}
+		}
+		return validateConstraints(path, property.Constraints, value)
+	case "float":
+		if _, ok := value.(json.Number); !ok {
+			return []string{fmt.Sprintf("property `%s` is expected to be a number", path)}
+		}
+		return validateConstraints(path, property.Constraints, value)
+	case "list", "set":
+		elements, ok := value.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("property `%s` is expected to be a %s", path, property.Type.Name)}
+		}
+		violations := []string{}
+		if property.MinObjects != nil && len(elements) < *property.MinObjects {
+			violations = append(violations, fmt.Sprintf("property `%s` requires at least %d elements", path, *property.MinObjects))
+		}
+		if property.MaxObjects != nil && len(elements) > *property.MaxObjects {
+			violations = append(violations, fmt.Sprintf("property `%s` allows for at most %d elements", path, *property.MaxObjects))
+		}
+		if property.Items != nil {
+			for idx, element := range elements {
+				if element != nil {
+					violations = append(violations, me.validateValue(fmt.Sprintf("%s[%d]", path, idx), property.Items, element)...)
+				}
+			}
+		}
+		return violations
+	case "text", "secret", "setting", "local_date", "local_time", "local_date_time", "zoned_date_time", "time_zone":
+		if _, ok := value.(string); !ok {
+			return []string{fmt.Sprintf("property `%s` is expected to be a string", path)}
+		}
+		return validateConstraints(path, property.Constraints, value)
+	}
+	return nil
+}
+
+func validateConstraints(path string, constraints []*Constraint, value any) []string {
+	violations := []string{}
+	for _, constraint := range constraints {
+		if violation := constraint.validate(value); len(violation) > 0 {
+			if len(constraint.CustomMessage) > 0 {
+				violation = violation + " (" + constraint.CustomMessage + ")"
+			}
+			violations = append(violations, fmt.Sprintf("property `%s` %s", path, violation))
+		}
+	}
+	return violations
+}
+
+// validate produces a description of the violation of this constraint - or an empty string if there is none
+func (me *Constraint) validate(value any) string {
+	s, isString := value.(string)
+	switch me.Type {
+	case "LENGTH":
+		if !isString {
+			return ""
+		}
+		length := utf8.RuneCountInString(s)
+		if me.MinLength != nil && length < *me.MinLength {
+			return fmt.Sprintf("must be at least %d characters long", *me.MinLength)
+		}
+		if me.MaxLength != nil && length > *me.MaxLength {
+			return fmt.Sprintf("must be at most %d characters long", *me.MaxLength)
+		}
+	case "RANGE":
+		n, ok := value.(json.Number)
+		if !ok {
+			return ""
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return ""
+		}
+		if me.Minimum != nil && f < *me.Minimum {
+			return fmt.Sprintf("must be at least %v", *me.Minimum)
+		}
+		if me.Maximum != nil && f > *me.Maximum {
+			return fmt.Sprintf("must be at most %v", *me.Maximum)
+		}
+	case "PATTERN":
+		if !isString {
+			return ""
+		}
+		// patterns are Java regular expressions - the ones RE2 doesn't support are getting skipped
+		reg, err := regexp.Compile("^(?:" + me.Pattern + ")$")
+		if err != nil {
+			return ""
+		}
+		if !reg.MatchString(s) {
+			return fmt.Sprintf("must match the pattern `%s`", me.Pattern)
+		}
+	case "NOT_BLANK":
+		if isString && len(strings.TrimSpace(s)) == 0 {
+			return "must not be blank"
+		}
+	case "TRIMMED":
+		if isString && s != strings.TrimSpace(s) {
+			return "must not start or end with whitespace"
+		}
+	case "NO_WHITESPACE":
+		if isString && strings.ContainsFunc(s, unicode.IsSpace) {
+			return "must not contain whitespace"
+		}
+	}
+	return ""
+}
+
+// closest finds the name of the property the given (unknown) name most likely is a typo of
+func closest(name string, properties map[string]*Property) string {
+	best := ""
+	bestDistance := 3
+	for _, candidate := range sortedKeys(properties) {
+		if strings.EqualFold(candidate, name) {
+			return candidate
+		}
+		if distance := levenshtein(strings.ToLower(name), strings.ToLower(candidate)); distance < bestDistance {
+			best = candidate
+			bestDistance = distance
+		}
+	}
+	return best
+}
+
+func levenshtein(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
+
+func quoteAll(values []string) string {
+	quoted := []string{}
+	for _, value := range values {
+		quoted = append(quoted, "`"+value+"`")
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -112,6 +112,7 @@ func (me *service) Get(ctx context.Context, id string, v *generic.Settings) erro
 	v.Value = string(settingsObject.Value)
 	v.Scope = settingsObject.Scope
 	v.SchemaID = settingsObject.SchemaID
+	v.SchemaVersion = settingsObject.SchemaVersion
 
 	return nil
 }
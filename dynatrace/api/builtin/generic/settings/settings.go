@@ -13,6 +13,8 @@ type Settings struct {
 	SchemaID     string `json:"schemaId"`
 	Value        string `json:"value"`
 	LocalStorage string `json:"-"`
+	// SchemaVersion is the version of the schema the settings have been stored with. Only known for settings read from the API
+	SchemaVersion string `json:"-"`
 }
 
 func (me *Settings) Name() string {
@@ -116,7 +118,21 @@ func (sm SecretMap) stripSecrets() {
 	}
 }
 
+// SecretStripper removes the properties holding secrets from the value of a settings object
+type SecretStripper interface {
+	StripSecrets(value map[string]any)
+}
+
+// Merge combines the value of these settings with the value of `other`, as received from the API.
+// Properties of `other` looking like masked secrets (`***nnn***`) are getting ignored.
 func (me *Settings) Merge(other *Settings) {
+	me.MergeWith(other, nil)
+}
+
+// MergeWith combines the value of these settings with the value of `other`, as received from the API.
+// The properties of `other` holding secrets are identified by `stripper`, falling back to
+// ignoring properties looking like masked secrets if `stripper` is nil.
+func (me *Settings) MergeWith(other *Settings, stripper SecretStripper) {
 	if other == nil {
 		return
 	}
@@ -128,7 +144,11 @@ func (me *Settings) Merge(other *Settings) {
 	if err := json.Unmarshal([]byte(other.Value), &otherm); err != nil {
 		return
 	}
-	otherm.stripSecrets()
+	if stripper != nil {
+		stripper.StripSecrets(otherm)
+	} else {
+		otherm.stripSecrets()
+	}
 	base.Merge(otherm)
 	data, err := json.Marshal(base)
 	if err != nil {
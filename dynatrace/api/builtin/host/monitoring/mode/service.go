@@ -192,3 +192,17 @@ func (me *service) Delete(ctx context.Context, id string) error {
 	// it always exists
 	return nil
 }
+
+func (me *service) Validate(ctx context.Context, v *mode.Settings) error {
+	if validator, ok := me.service.(settings.Validator[*mode.Settings]); ok {
+		return validator.Validate(ctx, v)
+	}
+	return nil
+}
+
+func (me *service) SupportsPlanValidation() bool {
+	if pv, ok := me.service.(settings.PlanValidator); ok {
+		return pv.SupportsPlanValidation()
+	}
+	return false
+}
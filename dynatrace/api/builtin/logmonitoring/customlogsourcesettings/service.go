@@ -120,6 +120,30 @@ func (me *service) Delete(ctx context.Context, id string) error {
 	return me.service.Delete(ctx, id)
 }
 
+func (me *service) Validate(ctx context.Context, v *customlogsourcesettings.Settings) error {
+	validator, ok := me.service.(settings.Validator[*customlogsourcesettings.Settings])
+	if !ok {
+		return nil
+	}
+	err := validator.Validate(ctx, v)
+	if err != nil && strings.Contains(err.Error(), errorMessage) && v.Custom_log_source != nil && len(v.Custom_log_source.Values) > 0 {
+		// `Create` and `Update` fall back to `values-and-enrichment`, the configured value must not get modified here though
+		value := *v
+		source := *v.Custom_log_source
+		value.Custom_log_source = &source
+		valuesToValuesAndEnrichment(&value)
+		return validator.Validate(ctx, &value)
+	}
+	return err
+}
+
+func (me *service) SupportsPlanValidation() bool {
+	if pv, ok := me.service.(settings.PlanValidator); ok {
+		return pv.SupportsPlanValidation()
+	}
+	return false
+}
+
 func (me *service) List(ctx context.Context) (api.Stubs, error) {
 	return me.service.List(ctx)
 }
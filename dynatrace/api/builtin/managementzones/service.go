@@ -129,6 +129,20 @@ func (me *service) Delete(ctx context.Context, id string) error {
 	return me.service.Delete(ctx, id)
 }
 
+func (me *service) Validate(ctx context.Context, v *managementzones.Settings) error {
+	if validator, ok := me.service.(settings.Validator[*managementzones.Settings]); ok {
+		return validator.Validate(ctx, v)
+	}
+	return nil
+}
+
+func (me *service) SupportsPlanValidation() bool {
+	if pv, ok := me.service.(settings.PlanValidator); ok {
+		return pv.SupportsPlanValidation()
+	}
+	return false
+}
+
 func (me *service) Get(ctx context.Context, id string, v *managementzones.Settings) error {
 	return me.service.Get(ctx, id, v)
 }
@@ -224,6 +224,23 @@ func (me *service) SchemaID() string {
 	return me.service.SchemaID()
 }
 
+func (me *service) Validate(ctx context.Context, v *opentelemetrymetrics.Settings) error {
+	if validator, ok := me.service.(settings.Validator[*opentelemetrymetrics.Settings]); ok {
+		// the flags are getting defaulted the same way `Create` does
+		effectiveValue := *v
+		setFlags(&effectiveValue, nil)
+		return validator.Validate(ctx, &effectiveValue)
+	}
+	return nil
+}
+
+func (me *service) SupportsPlanValidation() bool {
+	if pv, ok := me.service.(settings.PlanValidator); ok {
+		return pv.SupportsPlanValidation()
+	}
+	return false
+}
+
 func getKey(v any) string {
 	return reflect.ValueOf(v).Elem().FieldByName("AttributeKey").Interface().(string)
 }
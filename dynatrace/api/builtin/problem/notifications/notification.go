@@ -18,6 +18,7 @@
 package notifications
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -26,6 +27,7 @@ import (
 	jira "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/problem/notifications/jira/settings"
 	opsgenie "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/problem/notifications/opsgenie/settings"
 	pagerduty "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/problem/notifications/pagerduty/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/problem/notifications/placeholders"
 	servicenow "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/problem/notifications/servicenow/settings"
 	slack "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/problem/notifications/slack/settings"
 	trello "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/problem/notifications/trello/settings"
@@ -82,6 +84,16 @@ func (me *Notification) FillDemoValues() []string {
 	return nil
 }
 
+// CustomizeDiff reports placeholders (e.g. `{ProblemTitle}`) not supported by the notification type during `terraform plan`
+func (me *Notification) CustomizeDiff(ctx context.Context, rd *schema.ResourceDiff, i any) error {
+	for _, field := range placeholders.Fields(string(me.Type)) {
+		if err := field.ValidateDiff(rd, field.Attribute); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (me *Notification) Schema() map[string]*schema.Schema {
 	switch me.Type {
 	case Types.AnsibleTower:
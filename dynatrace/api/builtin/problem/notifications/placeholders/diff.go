@@ -0,0 +1,35 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package placeholders
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ValidateDiff validates the planned value of the attribute with the given key during `terraform plan`.
+// Values that are not known yet (e.g. because they refer to other resources) are skipped
+func (me Field) ValidateDiff(rd *schema.ResourceDiff, key string) error {
+	if !rd.NewValueKnown(key) {
+		return nil
+	}
+	template, ok := rd.Get(key).(string)
+	if !ok || len(template) == 0 {
+		return nil
+	}
+	return me.validate(key, template)
+}
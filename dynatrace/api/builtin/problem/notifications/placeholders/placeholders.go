@@ -0,0 +1,246 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package placeholders
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Placeholder is a value of the problem a notification gets sent for, which can get referred to as `{Name}` within the templated properties of a notification
+type Placeholder struct {
+	Name        string
+	Description string
+}
+
+// All contains every placeholder supported by any of the notification types
+var All = []Placeholder{
+	{"ImpactedEntities", "Details about the entities impacted by the problem in form of a JSON array"},
+	{"ImpactedEntity", "A short description of the problem and impacted entity (or multiple impacted entities)"},
+	{"ImpactedEntityNames", "The entity impacted by the problem (or multiple impacted entities)"},
+	{"NamesOfImpactedEntities", "The names of all entities that are impacted by the problem"},
+	{"PID", "Unique system identifier of the reported problem"},
+	{"ProblemDetailsHTML", "All problem event details including root cause as an HTML-formatted string"},
+	{"ProblemDetailsJSON", "Problem as JSON object following the structure of the Problems V1 API"},
+	{"ProblemDetailsJSONv2", "Problem as JSON object following the structure of the Problems V2 API"},
+	{"ProblemDetailsMarkdown", "All problem event details including root cause as a Markdown-formatted string"},
+	{"ProblemDetailsText", "All problem event details including root cause as a text-formatted string"},
+	{"ProblemID", "Display number of the reported problem"},
+	{"ProblemImpact", "Impact level of the problem. Possible values are `APPLICATION`, `SERVICE` and `INFRASTRUCTURE`"},
+	{"ProblemSeverity", "Severity level of the problem. Possible values are `AVAILABILITY`, `ERROR`, `PERFORMANCE`, `RESOURCE_CONTENTION` and `CUSTOM_ALERT`"},
+	{"ProblemTitle", "Short description of the problem"},
+	{"ProblemURL", "URL of the problem within Dynatrace"},
+	{"State", "Problem state. Possible values are `OPEN` and `RESOLVED`"},
+	{"Tags", "Comma separated list of tags that are defined for all impacted entities. `{Tags[key]}` refers to the value of the tag with the given key"},
+}
+
+// Field is a property of a notification type, which supports placeholders
+type Field struct {
+	// Property is the name of the property within the schema `builtin:problem.notifications`
+	Property string
+	// Attribute is the name of the attribute within the resource `dynatrace_<type>_notification`
+	Attribute string
+	// Placeholders are the names of the placeholders supported by this property
+	Placeholders []string
+}
+
+var (
+	basic         = []string{"ImpactedEntity", "ImpactedEntityNames", "NamesOfImpactedEntities", "PID", "ProblemID", "ProblemImpact", "ProblemSeverity", "ProblemTitle", "ProblemURL", "State", "Tags"}
+	detailed      = append([]string{"ImpactedEntities", "ProblemDetailsHTML", "ProblemDetailsJSON", "ProblemDetailsJSONv2", "ProblemDetailsMarkdown", "ProblemDetailsText"}, basic...)
+	withText      = append([]string{"ProblemDetailsText"}, basic...)
+	withMarkdown  = append([]string{"ProblemDetailsMarkdown"}, basic...)
+	withEntities  = append([]string{"ImpactedEntities", "ProblemDetailsText"}, basic...)
+	withoutPIDURL = []string{"ImpactedEntity", "ImpactedEntityNames", "NamesOfImpactedEntities", "ProblemDetailsText", "ProblemID", "ProblemImpact", "ProblemSeverity", "ProblemTitle", "ProblemURL", "State"}
+)
+
+// fields contains the properties supporting placeholders per notification type, as documented by the schema `builtin:problem.notifications`.
+// The first field of a notification type is its main content
+var fields = map[string][]Field{
+	"ANSIBLETOWER": {{"customMessage", "custom_message", withEntities}},
+	"EMAIL":        {{"body", "body", detailed}, {"subject", "subject", basic}},
+	"JIRA":         {{"summary", "summary", basic}, {"description", "description", withText}},
+	"OPS_GENIE":    {{"message", "message", []string{"ImpactedEntityNames", "ProblemID", "ProblemImpact", "ProblemSeverity", "ProblemTitle"}}},
+	"SERVICE_NOW":  {{"message", "message", without(append([]string{"ProblemDetailsHTML", "ProblemDetailsText"}, basic...), "ProblemURL")}},
+	"SLACK":        {{"message", "message", withText}},
+	"TRELLO":       {{"text", "text", basic}, {"description", "description", withMarkdown}},
+	"VICTOROPS":    {{"message", "message", withoutPIDURL}},
+	"WEBHOOK":      {{"payload", "payload", detailed}},
+	"XMATTERS":     {{"payload", "payload", detailed}},
+}
+
+func without(names []string, name string) []string {
+	result := []string{}
+	for _, n := range names {
+		if n != name {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// Types returns the notification types which are offering properties supporting placeholders, sorted alphabetically
+func Types() []string {
+	types := []string{}
+	for t := range fields {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Fields returns the properties of the given notification type (e.g. `SLACK`), which support placeholders
+func Fields(notificationType string) []Field {
+	return fields[notificationType]
+}
+
+// FieldOf looks up a property of the given notification type by either its attribute or property name.
+// An empty name resolves to the main content of the notification type
+func FieldOf(notificationType string, name string) (Field, error) {
+	candidates := Fields(notificationType)
+	if len(candidates) == 0 {
+		return Field{}, fmt.Errorf("notifications of type `%s` don't support placeholders", notificationType)
+	}
+	if len(name) == 0 {
+		return candidates[0], nil
+	}
+	names := []string{}
+	for _, field := range candidates {
+		if field.Attribute == name || field.Property == name {
+			return field, nil
+		}
+		names = append(names, "`"+field.Attribute+"`")
+	}
+	return Field{}, fmt.Errorf("notifications of type `%s` don't support placeholders in `%s`. Supported are %s", notificationType, name, strings.Join(names, ", "))
+}
+
+// placeholderRegex matches `{Name}` and `{Name[key]}`. JSON objects within payloads don't match, because their keys are quoted
+var placeholderRegex = regexp.MustCompile(`\{([A-Za-z][A-Za-z0-9]*)(?:\[([^\[\]{}]*)\])?\}`)
+
+// Parse returns the placeholders the template refers to without their braces (e.g. `ProblemTitle` or `Tags[team]`), in the order of their first occurrence
+func Parse(template string) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, match := range placeholderRegex.FindAllStringSubmatch(template, -1) {
+		name := strings.Trim(match[0], "{}")
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Unsupported returns the placeholders the template refers to, which are not contained in `supported`.
+// Only `Tags` supports referring to the value of a specific tag via `{Tags[key]}`.
+// Braces around names that don't resemble any placeholder (e.g. `{code}` within Jira markup) are not considered a placeholder
+func Unsupported(template string, supported []string) []string {
+	unsupported := []string{}
+	for _, name := range Parse(template) {
+		base, _, keyed := strings.Cut(name, "[")
+		if contains(supported, base) && (!keyed || base == "Tags") {
+			continue
+		}
+		if len(closest(base, names(All))) == 0 {
+			continue
+		}
+		unsupported = append(unsupported, name)
+	}
+	return unsupported
+}
+
+// Validate produces an error if the template refers to placeholders not supported by the given field
+func (me Field) Validate(template string) error {
+	return me.validate(me.Attribute, template)
+}
+
+func (me Field) validate(key string, template string) error {
+	unsupported := Unsupported(template, me.Placeholders)
+	if len(unsupported) == 0 {
+		return nil
+	}
+	messages := []string{}
+	for _, name := range unsupported {
+		message := "`{" + name + "}`"
+		base, _, _ := strings.Cut(name, "[")
+		if suggestion := closest(base, me.Placeholders); len(suggestion) > 0 && suggestion != base {
+			message = message + " (did you mean `{" + suggestion + "}`?)"
+		}
+		messages = append(messages, message)
+	}
+	return fmt.Errorf("`%s` contains unsupported placeholders: %s. Supported placeholders are {%s}", key, strings.Join(messages, ", "), strings.Join(me.Placeholders, "}, {"))
+}
+
+// closest returns the candidate the given name resembles the most, ignoring case.
+// Names of at least five characters may differ by up to two characters. An empty string signals that none of the candidates is close enough
+func closest(name string, candidates []string) string {
+	result := ""
+	best := 3
+	for _, candidate := range candidates {
+		if strings.EqualFold(name, candidate) {
+			return candidate
+		}
+		if len(name) < 5 {
+			continue
+		}
+		if distance := levenshtein(strings.ToLower(name), strings.ToLower(candidate)); distance < best {
+			result = candidate
+			best = distance
+		}
+	}
+	return result
+}
+
+// levenshtein calculates the number of single character edits needed to turn `a` into `b`
+func levenshtein(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+func names(placeholders []Placeholder) []string {
+	result := make([]string, len(placeholders))
+	for idx, placeholder := range placeholders {
+		result[idx] = placeholder.Name
+	}
+	return result
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
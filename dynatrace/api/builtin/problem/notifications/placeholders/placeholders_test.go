@@ -0,0 +1,144 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package placeholders_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/problem/notifications/placeholders"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/resources"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestParse(t *testing.T) {
+	template := `{"title":"{ProblemTitle}","team":"{Tags[team]}","again":"{ProblemTitle}","state":"{State}"}`
+	expected := []string{"ProblemTitle", "Tags[team]", "State"}
+	if actual := placeholders.Parse(template); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	field, err := placeholders.FieldOf("OPS_GENIE", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := field.Validate("{ProblemImpact} Problem {ProblemID}: {ProblemTitle}"); err != nil {
+		t.Errorf("expected no error, got %s", err.Error())
+	}
+	err = field.Validate("{ProblemTitle} {ProblemURL} {problemid} {Tags[team]}")
+	if err == nil {
+		t.Fatal("expected unsupported placeholders to get reported")
+	}
+	for _, expected := range []string{"`{ProblemURL}`", "`{problemid}` (did you mean `{ProblemID}`?)", "`{Tags[team]}`"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected `%s` within '%s'", expected, err.Error())
+		}
+	}
+	if strings.Contains(err.Error(), "`{ProblemTitle}`") {
+		t.Errorf("didn't expect `{ProblemTitle}` to get reported: '%s'", err.Error())
+	}
+
+	jira, err := placeholders.FieldOf("JIRA", "description")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := jira.Validate("{code}{ProblemDetailsText}{code} {color:red}{State}{color}"); err != nil {
+		t.Errorf("expected Jira markup not to get reported, got %s", err.Error())
+	}
+	err = jira.Validate("{ProblemTitel}")
+	if err == nil || !strings.Contains(err.Error(), "`{ProblemTitel}` (did you mean `{ProblemTitle}`?)") {
+		t.Errorf("expected misspelled placeholders to get reported, got %v", err)
+	}
+
+	if _, err := placeholders.FieldOf("EMAIL", "receivers"); err == nil {
+		t.Error("expected `receivers` of email notifications not to support placeholders")
+	}
+	if _, err := placeholders.FieldOf("PAGER_DUTY", ""); err == nil {
+		t.Error("expected PagerDuty notifications not to support placeholders")
+	}
+}
+
+func TestRender(t *testing.T) {
+	problem := placeholders.SampleProblem()
+	rendered := problem.Render(`{"id":"{ProblemID}","team":"{Tags[team]}","owner":"{Tags[owner]}","entities":{ImpactedEntities},"details":{ProblemDetailsJSONv2},"unknown":"{Unknown}"}`)
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(rendered), &payload); err != nil {
+		t.Fatalf("expected valid JSON, got %s: %s", rendered, err.Error())
+	}
+	expected := map[string]any{"id": problem.DisplayID, "team": "checkout", "owner": "{Tags[owner]}", "unknown": "{Unknown}"}
+	for key, value := range expected {
+		if payload[key] != value {
+			t.Errorf("expected `%s` to be '%v', got '%v'", key, value, payload[key])
+		}
+	}
+	if entities, ok := payload["entities"].([]any); !ok || len(entities) != len(problem.ImpactedEntities) {
+		t.Errorf("expected the impacted entities as JSON array, got %v", payload["entities"])
+	}
+	if details, ok := payload["details"].(map[string]any); !ok || details["title"] != problem.Title {
+		t.Errorf("expected the problem details as JSON object, got %v", payload["details"])
+	}
+}
+
+func TestCustomizeDiff(t *testing.T) {
+	tests := []struct {
+		resourceType export.ResourceType
+		config       func(message string) map[string]any
+	}{
+		{export.ResourceTypes.SlackNotification, func(message string) map[string]any {
+			return map[string]any{
+				"name":    "slack",
+				"active":  true,
+				"profile": "profile",
+				"url":     "https://hooks.slack.com/services/xyz",
+				"channel": "#alerts",
+				"message": message,
+			}
+		}},
+		{export.ResourceTypes.Notification, func(message string) map[string]any {
+			return map[string]any{
+				"slack": []any{map[string]any{
+					"name":             "slack",
+					"active":           true,
+					"alerting_profile": "profile",
+					"url":              "https://hooks.slack.com/services/xyz",
+					"channel":          "#alerts",
+					"title":            message,
+				}},
+			}
+		}},
+	}
+	for _, test := range tests {
+		t.Run(string(test.resourceType), func(t *testing.T) {
+			resource := resources.NewGeneric(test.resourceType).Resource()
+			if _, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(test.config("{ProblemTitle} {Tags[team]}")), nil); err != nil {
+				t.Errorf("expected no error, got %s", err.Error())
+			}
+			_, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(test.config("{ProblemTitle} {ProblemDetailsJSON}")), nil)
+			if err == nil || !strings.Contains(err.Error(), "`{ProblemDetailsJSON}`") {
+				t.Errorf("expected `{ProblemDetailsJSON}` to get reported as unsupported, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package placeholders
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Entity is an entity impacted by a problem
+type Entity struct {
+	ID   string `json:"entity"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Problem holds the values the placeholders are getting replaced with when rendering a template locally
+type Problem struct {
+	PID              string
+	DisplayID        string
+	Title            string
+	Impact           string
+	Severity         string
+	State            string
+	URL              string
+	Tags             []string
+	ImpactedEntities []Entity
+}
+
+// SampleProblem returns the problem templates are getting rendered with, unless specified otherwise
+func SampleProblem() *Problem {
+	return &Problem{
+		PID:       "-1234567890123456789_1700000000000V2",
+		DisplayID: "P-240101",
+		Title:     "Response time degradation",
+		Impact:    "SERVICE",
+		Severity:  "PERFORMANCE",
+		State:     "OPEN",
+		URL:       "https://environment.live.dynatrace.com/#problems/problemdetails;pid=-1234567890123456789_1700000000000V2",
+		Tags:      []string{"environment:production", "team:checkout"},
+		ImpactedEntities: []Entity{
+			{ID: "SERVICE-1234567890ABCDEF", Name: "CheckoutService", Type: "SERVICE"},
+		},
+	}
+}
+
+// Render replaces the placeholders within the template with the values of the given problem.
+// Placeholders which are not known are kept as they are, same as `{Tags[key]}` if no tag with that key exists
+func (me *Problem) Render(template string) string {
+	return placeholderRegex.ReplaceAllStringFunc(template, func(match string) string {
+		groups := placeholderRegex.FindStringSubmatch(match)
+		if strings.Contains(match, "[") {
+			if groups[1] != "Tags" {
+				return match
+			}
+			if value, found := me.tag(groups[2]); found {
+				return value
+			}
+			return match
+		}
+		if value, found := me.value(groups[1]); found {
+			return value
+		}
+		return match
+	})
+}
+
+func (me *Problem) tag(key string) (string, bool) {
+	for _, tag := range me.Tags {
+		k, v, _ := strings.Cut(tag, ":")
+		if k == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func (me *Problem) entityNames() string {
+	names := []string{}
+	for _, entity := range me.ImpactedEntities {
+		names = append(names, entity.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func (me *Problem) impactedEntity() string {
+	if len(me.ImpactedEntities) == 1 {
+		return me.ImpactedEntities[0].Name
+	}
+	return fmt.Sprintf("%d impacted entities", len(me.ImpactedEntities))
+}
+
+func (me *Problem) detailsText() string {
+	lines := []string{
+		fmt.Sprintf("Problem %s: %s", me.DisplayID, me.Title),
+		fmt.Sprintf("%s problem, severity %s, state %s", me.Impact, me.Severity, me.State),
+		fmt.Sprintf("Impacted entities: %s", me.entityNames()),
+	}
+	if len(me.Tags) > 0 {
+		lines = append(lines, fmt.Sprintf("Tags: %s", strings.Join(me.Tags, ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (me *Problem) detailsMarkdown() string {
+	lines := []string{
+		fmt.Sprintf("**Problem [%s](%s): %s**", me.DisplayID, me.URL, me.Title),
+		"",
+		fmt.Sprintf("%s problem, severity %s, state %s", me.Impact, me.Severity, me.State),
+		"",
+		"Impacted entities:",
+	}
+	for _, entity := range me.ImpactedEntities {
+		lines = append(lines, fmt.Sprintf("- %s (%s)", entity.Name, entity.Type))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (me *Problem) detailsHTML() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<h3><a href="%s">Problem %s</a>: %s</h3>`, html.EscapeString(me.URL), html.EscapeString(me.DisplayID), html.EscapeString(me.Title)))
+	sb.WriteString(fmt.Sprintf("<p>%s problem, severity %s, state %s</p>", html.EscapeString(me.Impact), html.EscapeString(me.Severity), html.EscapeString(me.State)))
+	sb.WriteString("<ul>")
+	for _, entity := range me.ImpactedEntities {
+		sb.WriteString(fmt.Sprintf("<li>%s (%s)</li>", html.EscapeString(entity.Name), html.EscapeString(entity.Type)))
+	}
+	sb.WriteString("</ul>")
+	return sb.String()
+}
+
+func (me *Problem) detailsJSON(v2 bool) string {
+	entities := []map[string]any{}
+	for _, entity := range me.ImpactedEntities {
+		if v2 {
+			entities = append(entities, map[string]any{"entityId": map[string]any{"id": entity.ID, "type": entity.Type}, "name": entity.Name})
+		} else {
+			entities = append(entities, map[string]any{"entityId": entity.ID, "entityName": entity.Name, "impactLevel": me.Impact})
+		}
+	}
+	var details map[string]any
+	if v2 {
+		details = map[string]any{
+			"problemId":        me.PID,
+			"displayId":        me.DisplayID,
+			"title":            me.Title,
+			"impactLevel":      me.Impact,
+			"severityLevel":    me.Severity,
+			"status":           me.State,
+			"affectedEntities": entities,
+			"entityTags":       me.Tags,
+		}
+	} else {
+		details = map[string]any{
+			"id":                     me.PID,
+			"displayName":            me.DisplayID,
+			"title":                  me.Title,
+			"impactLevel":            me.Impact,
+			"severityLevel":          me.Severity,
+			"status":                 me.State,
+			"rankedImpacts":          entities,
+			"tagsOfAffectedEntities": me.Tags,
+		}
+	}
+	data, _ := json.Marshal(details)
+	return string(data)
+}
+
+func (me *Problem) value(name string) (string, bool) {
+	switch name {
+	case "ImpactedEntities":
+		entities := me.ImpactedEntities
+		if entities == nil {
+			entities = []Entity{}
+		}
+		data, _ := json.Marshal(entities)
+		return string(data), true
+	case "ImpactedEntity":
+		return me.impactedEntity(), true
+	case "ImpactedEntityNames", "NamesOfImpactedEntities":
+		return me.entityNames(), true
+	case "PID":
+		return me.PID, true
+	case "ProblemDetailsHTML":
+		return me.detailsHTML(), true
+	case "ProblemDetailsJSON":
+		return me.detailsJSON(false), true
+	case "ProblemDetailsJSONv2":
+		return me.detailsJSON(true), true
+	case "ProblemDetailsMarkdown":
+		return me.detailsMarkdown(), true
+	case "ProblemDetailsText":
+		return me.detailsText(), true
+	case "ProblemID":
+		return me.DisplayID, true
+	case "ProblemImpact":
+		return me.Impact, true
+	case "ProblemSeverity":
+		return me.Severity, true
+	case "ProblemTitle":
+		return me.Title, true
+	case "ProblemURL":
+		return me.URL, true
+	case "State":
+		return me.State, true
+	case "Tags":
+		return strings.Join(me.Tags, ", "), true
+	}
+	return "", false
+}
@@ -99,6 +99,20 @@ func (me *service) Delete(ctx context.Context, id string) error {
 	return me.service.Delete(ctx, id)
 }
 
+func (me *service) Validate(ctx context.Context, v *appdetection.Settings) error {
+	if validator, ok := me.service.(settings.Validator[*appdetection.Settings]); ok {
+		return validator.Validate(ctx, v)
+	}
+	return nil
+}
+
+func (me *service) SupportsPlanValidation() bool {
+	if pv, ok := me.service.(settings.PlanValidator); ok {
+		return pv.SupportsPlanValidation()
+	}
+	return false
+}
+
 func Duplicates(ctx context.Context, service settings.RService[*appdetection.Settings], v *appdetection.Settings) (*api.Stub, error) {
 	if settings.RejectDuplicate("dynatrace_application_detection_rule_v2") {
 		var err error
@@ -93,3 +93,24 @@ func (me *service) Update(ctx context.Context, id string, v *autotagging.Setting
 func (me *service) Delete(ctx context.Context, id string) error {
 	return me.service.Delete(ctx, id)
 }
+
+func (me *service) Validate(ctx context.Context, v *autotagging.Settings) error {
+	validator, ok := me.service.(settings.Validator[*autotagging.Settings])
+	if !ok {
+		return nil
+	}
+	if v.RulesMaintainedExternally {
+		// the rules are not getting sent on create, see `Create`
+		value := *v
+		value.Rules = nil
+		v = &value
+	}
+	return validator.Validate(ctx, v)
+}
+
+func (me *service) SupportsPlanValidation() bool {
+	if pv, ok := me.service.(settings.PlanValidator); ok {
+		return pv.SupportsPlanValidation()
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+
+package documents_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	documentservice "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document"
+	documents "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+)
+
+// patches forwards to the mock server and records the `optimistic-locking-version` of the document updates
+type patches struct {
+	mu       sync.Mutex
+	versions []string
+}
+
+func (me *patches) serve(t *testing.T, server *mockserver.Server) *settings.Credentials {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			me.mu.Lock()
+			me.versions = append(me.versions, r.URL.Query().Get("optimistic-locking-version"))
+			me.mu.Unlock()
+		}
+		server.ServeHTTP(w, r)
+	}))
+	t.Cleanup(proxy.Close)
+	credentials := server.Credentials()
+	credentials.Automation.EnvironmentURL = proxy.URL
+	return credentials
+}
+
+func dashboard(markdown string) *documents.Document {
+	return &documents.Document{Name: "conflict", Type: "dashboard", Content: `{"tiles":{"0":{"type":"markdown","content":"` + markdown + `"}}}`}
+}
+
+func TestUpdateConflict(t *testing.T) {
+	for _, onConflict := range []settings.ConflictPolicy{settings.ConflictPolicies.Fail, settings.ConflictPolicies.Overwrite} {
+		t.Run(string(onConflict), func(t *testing.T) {
+			server, err := mockserver.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer server.Close()
+
+			recorded := &patches{}
+			credentials := recorded.serve(t, server)
+			credentials.OnConflict = onConflict
+
+			ctx := context.Background()
+			service := documentservice.Service(credentials)
+			stub, err := service.Create(ctx, dashboard("# A"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			known := new(documents.Document)
+			if err := service.Get(ctx, stub.ID, known); err != nil {
+				t.Fatal(err)
+			}
+			stateCtx := context.WithValue(ctx, settings.ContextKeyStateConfig, known)
+			knownVersion := strconv.Itoa(known.Version)
+			// only the updates are of interest, creating the document patches it as well
+			recorded.versions = nil
+
+			if err := service.Update(stateCtx, stub.ID, dashboard("# B")); err != nil {
+				t.Fatal(err)
+			}
+			err = service.Update(stateCtx, stub.ID, dashboard("# C"))
+
+			if onConflict == settings.ConflictPolicies.Overwrite {
+				// the version the document currently has on the remote side is getting used
+				if err != nil {
+					t.Fatal(err)
+				}
+				if expected := []string{knownVersion, strconv.Itoa(known.Version + 1)}; strings.Join(recorded.versions, ",") != strings.Join(expected, ",") {
+					t.Errorf("expected the updates to be based on versions %v, got %v", expected, recorded.versions)
+				}
+				return
+			}
+
+			// both updates are based on the version stored in the state, the second one gets refused
+			if expected := []string{knownVersion, knownVersion}; strings.Join(recorded.versions, ",") != strings.Join(expected, ",") {
+				t.Errorf("expected the updates to be based on versions %v, got %v", expected, recorded.versions)
+			}
+			var conflictError *settings.ConflictError
+			if !errors.As(err, &conflictError) {
+				t.Fatalf("expected a conflict, got %v", err)
+			}
+			if detail := conflictError.Detail(); !strings.Contains(detail, `~ content.tiles.0.content: "# A" => "# B"`) {
+				t.Errorf("expected the remote change to be reported, got\n%s", detail)
+			}
+		})
+	}
+}
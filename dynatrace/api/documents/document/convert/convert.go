@@ -0,0 +1,345 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	documents "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document/settings"
+	dashboards "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/v1/config/dashboards/settings"
+)
+
+// ContentVersion is the version of the content of platform dashboards produced by the conversion
+const ContentVersion = documents.DashboardContentVersion
+
+// Classic dashboards are laid out in pixels on a grid of 38 pixels.
+// Platform dashboards use a grid of 24 columns, which roughly matches two classic grid units per column.
+const (
+	classicGridUnit = 38
+	columnWidth     = 2 * classicGridUnit
+	rowHeight       = classicGridUnit
+)
+
+// Result is the outcome of converting a classic dashboard
+type Result struct {
+	Name        string            // the name of the classic dashboard
+	Content     string            // the content of the platform dashboard document as JSON
+	Unconverted []UnconvertedTile // the tiles that couldn't get converted
+}
+
+// UnconvertedTile describes a tile of a classic dashboard that couldn't get converted.
+// The platform dashboard contains a markdown tile in its place, which states the reason.
+type UnconvertedTile struct {
+	Index    int    // the position of the tile within the classic dashboard
+	Name     string // the name of the tile
+	TileType string // the type of the tile within the classic dashboard
+	Reason   string // why the tile couldn't get converted
+}
+
+func (me UnconvertedTile) String() string {
+	return fmt.Sprintf("Tile #%d '%s' (%s) could not be converted: %s", me.Index, me.Name, me.TileType, me.Reason)
+}
+
+type content struct {
+	Version   int            `json:"version"`
+	Variables []any          `json:"variables"`
+	Tiles     map[string]any `json:"tiles"`
+	Layouts   map[string]any `json:"layouts"`
+}
+
+type layout struct {
+	X int32 `json:"x"`
+	Y int32 `json:"y"`
+	W int32 `json:"w"`
+	H int32 `json:"h"`
+}
+
+type markdownTile struct {
+	Type    string `json:"type"`
+	Content string `json:"content"`
+}
+
+type dataTile struct {
+	Type          string `json:"type"`
+	Title         string `json:"title"`
+	Query         string `json:"query"`
+	Visualization string `json:"visualization"`
+}
+
+// Dashboard converts the JSON representation of a classic dashboard, as managed by `dynatrace_json_dashboard`,
+// into the content of a platform dashboard, as managed by `dynatrace_document`.
+// Tiles showing custom charts, data explorer results, markdown and headers are getting converted.
+// Any other tiles are reported within the result.
+func Dashboard(classic []byte) (*Result, error) {
+	var dashboard dashboards.Dashboard
+	if err := json.Unmarshal(classic, &dashboard); err != nil {
+		return nil, fmt.Errorf("invalid classic dashboard: %s", err.Error())
+	}
+	if dashboard.Metadata == nil {
+		return nil, errors.New("invalid classic dashboard: `dashboardMetadata` is missing")
+	}
+
+	// the platform dashboard orders tiles by their position rather than the order within the classic dashboard
+	indices := make([]int, len(dashboard.Tiles))
+	for idx := range dashboard.Tiles {
+		indices[idx] = idx
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		a := bounds(dashboard.Tiles[indices[i]])
+		b := bounds(dashboard.Tiles[indices[j]])
+		if a.Top != b.Top {
+			return a.Top < b.Top
+		}
+		return a.Left < b.Left
+	})
+
+	result := &Result{Name: dashboard.Metadata.Name}
+	doc := content{Version: ContentVersion, Variables: []any{}, Tiles: map[string]any{}, Layouts: map[string]any{}}
+	for position, idx := range indices {
+		tile := dashboard.Tiles[idx]
+		key := strconv.Itoa(position)
+		converted, reason := convertTile(tile)
+		if len(reason) > 0 {
+			unconverted := UnconvertedTile{Index: idx, Name: tileTitle(tile), TileType: string(tile.TileType), Reason: reason}
+			result.Unconverted = append(result.Unconverted, unconverted)
+			converted = &markdownTile{Type: "markdown", Content: fmt.Sprintf("**%s** (`%s`) could not be converted: %s", unconverted.Name, unconverted.TileType, reason)}
+		}
+		doc.Tiles[key] = converted
+		doc.Layouts[key] = toLayout(bounds(tile))
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	result.Content = string(data)
+	return result, nil
+}
+
+func bounds(tile *dashboards.Tile) *dashboards.TileBounds {
+	if tile.Bounds == nil {
+		return &dashboards.TileBounds{}
+	}
+	return tile.Bounds
+}
+
+func toLayout(bounds *dashboards.TileBounds) layout {
+	return layout{
+		X: bounds.Left / columnWidth,
+		Y: bounds.Top / rowHeight,
+		W: max(1, (bounds.Width+columnWidth/2)/columnWidth),
+		H: max(1, (bounds.Height+rowHeight/2)/rowHeight),
+	}
+}
+
+func tileTitle(tile *dashboards.Tile) string {
+	if tile.CustomName != nil && len(*tile.CustomName) > 0 {
+		return *tile.CustomName
+	}
+	if tile.FilterConfig != nil && len(tile.FilterConfig.CustomName) > 0 {
+		return tile.FilterConfig.CustomName
+	}
+	return tile.Name
+}
+
+// convertTile produces the platform dashboard tile for a classic tile, or the reason why that's not possible
+func convertTile(tile *dashboards.Tile) (any, string) {
+	switch tile.TileType {
+	case dashboards.TileTypes.Header:
+		return &markdownTile{Type: "markdown", Content: "## " + tile.Name}, ""
+	case dashboards.TileTypes.Markdown:
+		markdown := ""
+		if tile.Markdown != nil {
+			markdown = *tile.Markdown
+		}
+		return &markdownTile{Type: "markdown", Content: markdown}, ""
+	case dashboards.TileTypes.CustomCharting:
+		return convertCustomChart(tile)
+	case tileTypeDataExplorer:
+		return convertDataExplorer(tile)
+	}
+	return nil, "tiles of this type are not supported"
+}
+
+const tileTypeDataExplorer = dashboards.TileType("DATA_EXPLORER")
+
+// classicTimeframe matches relative timeframes like `-2h`, which can get expressed in DQL
+var classicTimeframe = regexp.MustCompile(`^-(\d+)([smhdw])$`)
+
+// tileFilter translates the filter of a classic tile into additional parameters of the `timeseries` command
+func tileFilter(tile *dashboards.Tile) (string, string) {
+	if tile.Filter == nil {
+		return "", ""
+	}
+	if tile.Filter.ManagementZone != nil {
+		return "", "management zone filters are not supported"
+	}
+	if tile.Filter.Timeframe == nil || len(*tile.Filter.Timeframe) == 0 {
+		return "", ""
+	}
+	matches := classicTimeframe.FindStringSubmatch(*tile.Filter.Timeframe)
+	if len(matches) != 3 {
+		return "", fmt.Sprintf("the timeframe `%s` is not supported", *tile.Filter.Timeframe)
+	}
+	return fmt.Sprintf(", from: now()-%s%s", matches[1], matches[2]), ""
+}
+
+func convertCustomChart(tile *dashboards.Tile) (any, string) {
+	if tile.FilterConfig == nil || tile.FilterConfig.ChartConfig == nil || len(tile.FilterConfig.ChartConfig.Series) == 0 {
+		return nil, "the tile doesn't chart any metrics"
+	}
+	for _, filters := range tile.FilterConfig.FiltersPerEntityType {
+		if len(filters) > 0 {
+			return nil, "entity filters are not supported"
+		}
+	}
+	timeframe, reason := tileFilter(tile)
+	if len(reason) > 0 {
+		return nil, reason
+	}
+	queries := []*metricQuery{}
+	for _, series := range tile.FilterConfig.ChartConfig.Series {
+		query := &metricQuery{Metric: series.Metric}
+		switch series.Aggregation {
+		case dashboards.Aggregations.Avg, dashboards.Aggregations.Min, dashboards.Aggregations.Max, dashboards.Aggregations.Sum, dashboards.Aggregations.Count, dashboards.Aggregations.Median:
+			query.Aggregation = strings.ToLower(string(series.Aggregation))
+		case dashboards.Aggregations.Percentile:
+			if series.Percentile == nil {
+				return nil, "percentile aggregation without percentile"
+			}
+			query.Aggregation = fmt.Sprintf("percentile(%d)", *series.Percentile)
+		default:
+			return nil, fmt.Sprintf("the aggregation `%s` is not supported", series.Aggregation)
+		}
+		for _, dimension := range series.Dimensions {
+			if len(dimension.Values) > 0 {
+				return nil, "dimension filters are not supported"
+			}
+			if dimension.Name != nil && len(*dimension.Name) > 0 {
+				query.SplitBy = append(query.SplitBy, *dimension.Name)
+			} else {
+				query.SplitBy = append(query.SplitBy, dimension.ID)
+			}
+		}
+		queries = append(queries, query)
+	}
+	dql, reason := toDQL(queries, timeframe)
+	if len(reason) > 0 {
+		return nil, reason
+	}
+
+	visualization := "lineChart"
+	switch tile.FilterConfig.ChartConfig.Type {
+	case dashboards.CustomFilterChartConfigTypes.Pie:
+		visualization = "pieChart"
+	case dashboards.CustomFilterChartConfigTypes.SingleValue:
+		visualization = "singleValue"
+	case dashboards.CustomFilterChartConfigTypes.TopList:
+		visualization = "categoricalBarChart"
+	case dashboards.CustomFilterChartConfigTypes.TimeSeries:
+		switch tile.FilterConfig.ChartConfig.Series[0].Type {
+		case "AREA":
+			visualization = "areaChart"
+		case "BAR":
+			visualization = "barChart"
+		}
+	}
+	return &dataTile{Type: "data", Title: tileTitle(tile), Query: dql, Visualization: visualization}, ""
+}
+
+// dataExplorerQuery is the part of a query of a classic data explorer tile relevant for the conversion
+type dataExplorerQuery struct {
+	ID               string   `json:"id"`
+	Metric           string   `json:"metric"`
+	SpaceAggregation string   `json:"spaceAggregation"`
+	SplitBy          []string `json:"splitBy"`
+	MetricSelector   string   `json:"metricSelector"`
+	Enabled          *bool    `json:"enabled"`
+}
+
+type dataExplorerVisualConfig struct {
+	Type string `json:"type"`
+}
+
+var dataExplorerVisualizations = map[string]string{
+	"GRAPH_CHART":    "lineChart",
+	"STACKED_AREA":   "areaChart",
+	"STACKED_COLUMN": "barChart",
+	"SINGLE_VALUE":   "singleValue",
+	"TABLE":          "table",
+	"PIE_CHART":      "pieChart",
+	"TOP_LIST":       "categoricalBarChart",
+	"HONEYCOMB":      "honeycomb",
+}
+
+func convertDataExplorer(tile *dashboards.Tile) (any, string) {
+	var explorerQueries []*dataExplorerQuery
+	if data, found := tile.Unknowns["queries"]; found {
+		if err := json.Unmarshal(data, &explorerQueries); err != nil {
+			return nil, fmt.Sprintf("the queries of the tile are invalid: %s", err.Error())
+		}
+	}
+	timeframe, reason := tileFilter(tile)
+	if len(reason) > 0 {
+		return nil, reason
+	}
+	queries := []*metricQuery{}
+	for _, explorerQuery := range explorerQueries {
+		if explorerQuery.Enabled != nil && !*explorerQuery.Enabled {
+			continue
+		}
+		var query *metricQuery
+		if len(explorerQuery.MetricSelector) > 0 {
+			if query, reason = parseMetricSelector(explorerQuery.MetricSelector); len(reason) > 0 {
+				return nil, reason
+			}
+		} else {
+			aggregation := strings.ToLower(explorerQuery.SpaceAggregation)
+			if percentile, found := strings.CutPrefix(aggregation, "percentile_"); found {
+				aggregation = fmt.Sprintf("percentile(%s)", percentile)
+			}
+			query = &metricQuery{Metric: explorerQuery.Metric, Aggregation: aggregation, SplitBy: explorerQuery.SplitBy}
+		}
+		query.Name = explorerQuery.ID
+		queries = append(queries, query)
+	}
+	if len(queries) == 0 {
+		return nil, "the tile doesn't contain any enabled queries"
+	}
+	dql, reason := toDQL(queries, timeframe)
+	if len(reason) > 0 {
+		return nil, reason
+	}
+
+	visualization := "lineChart"
+	if data, found := tile.Unknowns["visualConfig"]; found {
+		var visualConfig dataExplorerVisualConfig
+		if err := json.Unmarshal(data, &visualConfig); err == nil {
+			if v, found := dataExplorerVisualizations[visualConfig.Type]; found {
+				visualization = v
+			}
+		}
+	}
+	return &dataTile{Type: "data", Title: tileTitle(tile), Query: dql, Visualization: visualization}, ""
+}
@@ -0,0 +1,113 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package convert_test
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document/convert"
+)
+
+type platformDashboard struct {
+	Version int `json:"version"`
+	Tiles   map[string]struct {
+		Type          string `json:"type"`
+		Title         string `json:"title"`
+		Content       string `json:"content"`
+		Query         string `json:"query"`
+		Visualization string `json:"visualization"`
+	} `json:"tiles"`
+	Layouts map[string]struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+		W int `json:"w"`
+		H int `json:"h"`
+	} `json:"layouts"`
+}
+
+func TestDashboard(t *testing.T) {
+	classic, err := os.ReadFile("testdata/classic.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := convert.Dashboard(classic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Name != "Team Overview" {
+		t.Errorf("expected name 'Team Overview', got '%s'", result.Name)
+	}
+
+	var dashboard platformDashboard
+	if err := json.Unmarshal([]byte(result.Content), &dashboard); err != nil {
+		t.Fatal(err)
+	}
+	if dashboard.Version != convert.ContentVersion || len(dashboard.Tiles) != 7 || len(dashboard.Layouts) != 7 {
+		t.Fatalf("expected 7 tiles with layouts, got %s", result.Content)
+	}
+
+	// tiles are ordered by their position
+	expected := []struct {
+		Type          string
+		Content       string
+		Query         string
+		Visualization string
+	}{
+		{Type: "markdown", Content: "## Hosts"},
+		{Type: "markdown", Content: "See [runbook](https://example.com)"},
+		{Type: "data", Query: "timeseries avg(dt.host.cpu.usage), by:{dt.entity.host}, from: now()-2h", Visualization: "areaChart"},
+		{Type: "data", Query: "timeseries A = avg(dt.host.mem.usage), by:{dt.entity.host}\n| sort arrayAvg(A) desc\n| limit 10", Visualization: "categoricalBarChart"},
+		{Type: "markdown", Content: "could not be converted: the transformation `filter("},
+		{Type: "markdown", Content: "could not be converted: tiles of this type are not supported"},
+		{Type: "markdown", Content: "could not be converted: management zone filters are not supported"},
+	}
+	for idx, want := range expected {
+		tile := dashboard.Tiles[string(rune('0'+idx))]
+		if tile.Type != want.Type || tile.Query != want.Query || tile.Visualization != want.Visualization || !strings.Contains(tile.Content, want.Content) {
+			t.Errorf("tile %d: expected %+v, got %+v", idx, want, tile)
+		}
+	}
+	if layout := dashboard.Layouts["2"]; layout.X != 4 || layout.Y != 1 || layout.W != 8 || layout.H != 8 {
+		t.Errorf("unexpected layout of the custom chart: %+v", layout)
+	}
+
+	if len(result.Unconverted) != 3 {
+		t.Fatalf("expected 3 unconverted tiles, got %v", result.Unconverted)
+	}
+	for idx, want := range []convert.UnconvertedTile{
+		{Index: 4, Name: "Filtered", TileType: "DATA_EXPLORER"},
+		{Index: 5, Name: "Host health", TileType: "HOSTS"},
+		{Index: 6, Name: "Scoped chart", TileType: "CUSTOM_CHARTING"},
+	} {
+		got := result.Unconverted[idx]
+		if got.Index != want.Index || got.Name != want.Name || got.TileType != want.TileType || len(got.Reason) == 0 {
+			t.Errorf("expected unconverted tile %+v, got %+v", want, got)
+		}
+	}
+}
+
+func TestDashboardInvalid(t *testing.T) {
+	for _, classic := range []string{`not json`, `{"tiles":[]}`} {
+		if _, err := convert.Dashboard([]byte(classic)); err == nil {
+			t.Errorf("expected `%s` to get rejected", classic)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package convert
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// metricQuery is a metric query of a classic tile, reduced to what can get expressed with the DQL command `timeseries`
+type metricQuery struct {
+	Name          string
+	Metric        string
+	Aggregation   string
+	SplitBy       []string
+	SortAscending *bool
+	Limit         int
+}
+
+var (
+	prefixedMetricKey = regexp.MustCompile(`^[A-Za-z][\w\-]*:[\w\-]+(?:\.[\w\-]+)+`)
+	plainMetricKey    = regexp.MustCompile(`^[\w\-.]+`)
+	transformation    = regexp.MustCompile(`^(\w+)(?:\((.*)\))?$`)
+	sortByValue       = regexp.MustCompile(`^value\(\s*\w+\s*,\s*(ascending|descending)\s*\)$`)
+	dqlIdentifier     = regexp.MustCompile(`^[A-Za-z_][\w.]*$`)
+)
+
+// parseMetricSelector supports metric selectors consisting of a metric key and
+// the transformations `splitBy`, `sort`, `limit`, `names` and a single aggregation
+func parseMetricSelector(selector string) (*metricQuery, string) {
+	selector = strings.TrimSpace(selector)
+	key := prefixedMetricKey.FindString(selector)
+	if len(key) == 0 {
+		key = plainMetricKey.FindString(selector)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Sprintf("the metric selector `%s` is not supported", selector)
+	}
+	query := &metricQuery{Metric: key}
+	rest := selector[len(key):]
+	for _, part := range splitTransformations(rest) {
+		matches := transformation.FindStringSubmatch(part)
+		if len(matches) != 3 {
+			return nil, fmt.Sprintf("the transformation `%s` is not supported", part)
+		}
+		name, args := matches[1], strings.TrimSpace(matches[2])
+		switch name {
+		case "splitBy":
+			for _, dimension := range strings.Split(args, ",") {
+				if dimension = strings.Trim(strings.TrimSpace(dimension), `"`); len(dimension) > 0 {
+					query.SplitBy = append(query.SplitBy, dimension)
+				}
+			}
+		case "avg", "min", "max", "sum", "count", "median", "auto":
+			query.Aggregation = name
+		case "percentile":
+			query.Aggregation = part
+		case "sort":
+			sortMatches := sortByValue.FindStringSubmatch(args)
+			if len(sortMatches) != 2 {
+				return nil, fmt.Sprintf("the transformation `%s` is not supported", part)
+			}
+			ascending := sortMatches[1] == "ascending"
+			query.SortAscending = &ascending
+		case "limit":
+			limit, err := strconv.Atoi(args)
+			if err != nil {
+				return nil, fmt.Sprintf("the transformation `%s` is not supported", part)
+			}
+			query.Limit = limit
+		case "names":
+		default:
+			return nil, fmt.Sprintf("the transformation `%s` is not supported", part)
+		}
+	}
+	return query, ""
+}
+
+// splitTransformations splits `:a(x):b("y:z")` into `a(x)` and `b("y:z")`
+func splitTransformations(s string) []string {
+	parts := []string{}
+	depth := 0
+	quoted := false
+	current := strings.Builder{}
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == '(' && !quoted:
+			depth++
+		case r == ')' && !quoted:
+			depth--
+		case r == ':' && !quoted && depth == 0:
+			if current.Len() > 0 {
+				parts = append(parts, current.String())
+				current.Reset()
+			}
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if current.Len() > 0 {
+		parts = append(parts, current.String())
+	}
+	return parts
+}
+
+// toDQL combines the given queries into a single `timeseries` command.
+// That's only possible if they are split by the same dimensions.
+func toDQL(queries []*metricQuery, timeframe string) (string, string) {
+	splitBy := strings.Join(queries[0].SplitBy, ",")
+	for _, query := range queries[1:] {
+		if strings.Join(query.SplitBy, ",") != splitBy {
+			return "", "combining queries split by different dimensions is not supported"
+		}
+		if query.SortAscending != nil || query.Limit > 0 || queries[0].SortAscending != nil || queries[0].Limit > 0 {
+			return "", "sorting or limiting multiple queries is not supported"
+		}
+	}
+	if len(queries) == 1 && len(queries[0].Name) == 0 && (queries[0].SortAscending != nil || queries[0].Limit > 0) {
+		queries[0].Name = "value"
+	}
+
+	aggregations := []string{}
+	for idx, query := range queries {
+		aggregation, reason := toAggregation(query)
+		if len(reason) > 0 {
+			return "", reason
+		}
+		name := query.Name
+		if len(name) == 0 && len(queries) > 1 {
+			name = string(rune('A' + idx))
+		}
+		if len(name) > 0 {
+			aggregation = fmt.Sprintf("%s = %s", identifier(name), aggregation)
+		}
+		aggregations = append(aggregations, aggregation)
+	}
+
+	dql := "timeseries "
+	if len(aggregations) == 1 {
+		dql += aggregations[0]
+	} else {
+		dql += "{ " + strings.Join(aggregations, ", ") + " }"
+	}
+	if len(queries[0].SplitBy) > 0 {
+		dimensions := []string{}
+		for _, dimension := range queries[0].SplitBy {
+			dimensions = append(dimensions, identifier(dimension))
+		}
+		dql += ", by:{" + strings.Join(dimensions, ", ") + "}"
+	}
+	dql += timeframe
+
+	query := queries[0]
+	if query.SortAscending != nil {
+		direction := "desc"
+		if *query.SortAscending {
+			direction = "asc"
+		}
+		dql += fmt.Sprintf("\n| sort arrayAvg(%s) %s", identifier(query.Name), direction)
+	}
+	if query.Limit > 0 {
+		dql += fmt.Sprintf("\n| limit %d", query.Limit)
+	}
+	return dql, ""
+}
+
+var percentileAggregation = regexp.MustCompile(`^percentile\(\s*(\d+(?:\.\d+)?)\s*\)$`)
+
+func toAggregation(query *metricQuery) (string, string) {
+	metric := metricKey(query.Metric)
+	switch query.Aggregation {
+	case "", "auto", "avg":
+		return fmt.Sprintf("avg(%s)", metric), ""
+	case "min", "max", "sum", "count":
+		return fmt.Sprintf("%s(%s)", query.Aggregation, metric), ""
+	case "median":
+		return fmt.Sprintf("percentile(%s, 50)", metric), ""
+	}
+	if matches := percentileAggregation.FindStringSubmatch(query.Aggregation); len(matches) == 2 {
+		return fmt.Sprintf("percentile(%s, %s)", metric, matches[1]), ""
+	}
+	return "", fmt.Sprintf("the aggregation `%s` is not supported", query.Aggregation)
+}
+
+// metricKey translates the key of a classic metric into the key of the corresponding metric in Grail.
+// Built-in metrics are prefixed with `dt.` instead of `builtin:`, which is a best effort translation
+// that doesn't cover built-in metrics that have been renamed in Grail.
+// All other metric keys remain unchanged.
+func metricKey(key string) string {
+	if strings.HasPrefix(key, "builtin:") {
+		key = "dt." + strings.TrimPrefix(key, "builtin:")
+	}
+	return identifier(key)
+}
+
+// identifier quotes names that aren't valid DQL identifiers with backticks
+func identifier(name string) string {
+	if dqlIdentifier.MatchString(name) {
+		return name
+	}
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
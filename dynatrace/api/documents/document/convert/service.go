@@ -0,0 +1,108 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package convert
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	documentsservice "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document"
+	documents "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document/settings"
+	dashboards "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/v1/config/dashboards/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/v1/config/jsondashboards"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+// IDPrefix distinguishes the IDs of converted classic dashboards from the IDs of actual documents
+const IDPrefix = "classic-dashboard-"
+
+// Service lists, in addition to the documents, all classic dashboards as documents of type `dashboard`.
+// Documents are only getting listed if an OAuth client has been configured.
+// Any modifications are getting delegated to the documents service.
+func Service(credentials *settings.Credentials) settings.CRUDService[*documents.Document] {
+	return &service{
+		credentials: credentials,
+		documents:   documentsservice.Service(credentials),
+		dashboards:  jsondashboards.Service(credentials),
+	}
+}
+
+type service struct {
+	credentials *settings.Credentials
+	documents   settings.CRUDService[*documents.Document]
+	dashboards  settings.CRUDService[*dashboards.JSONDashboard]
+}
+
+func (me *service) List(ctx context.Context) (api.Stubs, error) {
+	stubs := api.Stubs{}
+	if len(me.credentials.Automation.ClientID) > 0 {
+		documentStubs, err := me.documents.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		stubs = append(stubs, documentStubs...)
+	}
+	dashboardStubs, err := me.dashboards.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, stub := range dashboardStubs {
+		stubs = append(stubs, &api.Stub{ID: IDPrefix + stub.ID, Name: stub.Name})
+	}
+	return stubs, nil
+}
+
+func (me *service) Get(ctx context.Context, id string, v *documents.Document) error {
+	dashboardID, converted := strings.CutPrefix(id, IDPrefix)
+	if !converted {
+		return me.documents.Get(ctx, id, v)
+	}
+	var dashboard dashboards.JSONDashboard
+	if err := me.dashboards.Get(ctx, dashboardID, &dashboard); err != nil {
+		return err
+	}
+	result, err := Dashboard([]byte(dashboard.Contents))
+	if err != nil {
+		return err
+	}
+	v.Name = result.Name
+	v.Type = "dashboard"
+	v.Content = result.Content
+	v.Warnings = []string{}
+	for _, unconverted := range result.Unconverted {
+		v.Warnings = append(v.Warnings, unconverted.String())
+	}
+	return nil
+}
+
+func (me *service) SchemaID() string {
+	return me.documents.SchemaID()
+}
+
+func (me *service) Create(ctx context.Context, v *documents.Document) (*api.Stub, error) {
+	return me.documents.Create(ctx, v)
+}
+
+func (me *service) Update(ctx context.Context, id string, v *documents.Document) error {
+	return me.documents.Update(ctx, id, v)
+}
+
+func (me *service) Delete(ctx context.Context, id string) error {
+	return me.documents.Delete(ctx, id)
+}
@@ -20,10 +20,15 @@ package documents
 import (
 	"context"
 	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
 
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	apidocuments "github.com/dynatrace/dynatrace-configuration-as-code-core/api/clients/documents"
+	corerest "github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients"
 	docclient "github.com/dynatrace/dynatrace-configuration-as-code-core/clients/documents"
 	"golang.org/x/oauth2/clientcredentials"
@@ -40,20 +45,26 @@ type service struct {
 	credentials *settings.Credentials
 }
 
-func (me *service) client() *docclient.Client {
+func (me *service) platformClient() (*corerest.Client, error) {
 	httplog.InstallRoundTripper()
 
-	clientsFactory := clients.Factory().
+	return clients.Factory().
 		WithPlatformURL(me.credentials.Automation.EnvironmentURL).
 		WithOAuthCredentials(clientcredentials.Config{
 			ClientID:     me.credentials.Automation.ClientID,
 			ClientSecret: me.credentials.Automation.ClientSecret,
 			TokenURL:     me.credentials.Automation.TokenURL,
 		}).
-		WithUserAgent("Dynatrace Terraform Provider")
+		WithUserAgent("Dynatrace Terraform Provider").
+		CreatePlatformClient()
+}
 
-	documentClient, _ := clientsFactory.DocumentClient()
-	return documentClient
+func (me *service) client() *docclient.Client {
+	restClient, err := me.platformClient()
+	if err != nil {
+		return nil
+	}
+	return docclient.NewClient(restClient)
 }
 
 func (me *service) Get(ctx context.Context, id string, v *documents.Document) (err error) {
@@ -122,9 +133,68 @@ func (me *service) createPrivate(ctx context.Context, v *documents.Document) (st
 }
 
 func (me *service) Update(ctx context.Context, id string, v *documents.Document) (err error) {
+	if known, ok := ctx.Value(settings.ContextKeyStateConfig).(*documents.Document); ok && known.Version != 0 && me.credentials.OnConflict != settings.ConflictPolicies.Overwrite {
+		return me.updateVersion(ctx, id, known, v)
+	}
 	return me.update(ctx, id, v)
 }
 
+// updateVersion updates the document only if it's still at the version stored in the state.
+// The Document API refuses the update with `409 Conflict` otherwise, which results in a `settings.ConflictError`
+// describing the remote changes.
+func (me *service) updateVersion(ctx context.Context, id string, known *documents.Document, v *documents.Document) error {
+	restClient, err := me.platformClient()
+	if err != nil {
+		return err
+	}
+	response, err := apidocuments.NewClient(restClient).Patch(ctx, id, known.Version, apidocuments.Document{
+		Kind:    v.Type,
+		Name:    v.Name,
+		Public:  !v.IsPrivate,
+		Content: []byte(v.Content),
+	})
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	switch {
+	case response.StatusCode == http.StatusConflict:
+		return me.conflict(ctx, id, known)
+	case response.StatusCode >= 400:
+		return rest.Error{Code: response.StatusCode, Message: string(data)}
+	}
+	return nil
+}
+
+// conflict describes the changes applied on the remote side since the document has been stored in the state
+func (me *service) conflict(ctx context.Context, id string, known *documents.Document) error {
+	var remote documents.Document
+	if err := me.Get(ctx, id, &remote); err != nil {
+		return err
+	}
+	changes := []string{}
+	if remote.Name != known.Name {
+		changes = append(changes, "~ name: "+strconv.Quote(known.Name)+" => "+strconv.Quote(remote.Name))
+	}
+	if remote.IsPrivate != known.IsPrivate {
+		changes = append(changes, "~ private: "+strconv.FormatBool(known.IsPrivate)+" => "+strconv.FormatBool(remote.IsPrivate))
+	}
+	if !documents.ContentEqual(known.Content, remote.Content) {
+		changes = append(changes, settings.JSONChanges("content", []byte(known.Content), []byte(remote.Content))...)
+	}
+	return &settings.ConflictError{
+		SchemaID: me.SchemaID(),
+		ID:       id,
+		Known:    strconv.Itoa(known.Version),
+		Remote:   strconv.Itoa(remote.Version),
+		Changes:  changes,
+	}
+}
+
 func (me *service) update(ctx context.Context, id string, v *documents.Document) (err error) {
 	c := me.client()
 	response, err := c.Update(ctx, id, v.Name, v.IsPrivate, []byte(v.Content), docclient.DocumentType(v.Type))
@@ -0,0 +1,184 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package documents_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	documents "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		old      string
+		new      string
+		expected bool
+	}{
+		{
+			name:     "key order and formatting",
+			old:      `{"tiles":{"0":{"type":"markdown","content":"# Hello"}},"variables":[]}`,
+			new:      "{\n  \"variables\": [],\n  \"tiles\": {\"0\": {\"content\": \"# Hello\", \"type\": \"markdown\"}}\n}",
+			expected: true,
+		},
+		{
+			name:     "upgraded version",
+			old:      `{"version":17,"tiles":{}}`,
+			new:      `{"version":15,"tiles":{}}`,
+			expected: true,
+		},
+		{
+			name:     "server side defaults",
+			old:      `{"tiles":{"0":{"type":"data","query":"fetch logs","davis":{"enabled":false,"davisVisualization":{"isAvailable":false}},"querySettings":{"maxResultRecords":0}}},"importedWithCode":false}`,
+			new:      `{"tiles":{"0":{"type":"data","query":"fetch logs"}}}`,
+			expected: true,
+		},
+		{
+			name:     "changed query",
+			old:      `{"tiles":{"0":{"type":"data","query":"fetch logs"}}}`,
+			new:      `{"tiles":{"0":{"type":"data","query":"fetch events"}}}`,
+			expected: false,
+		},
+		{
+			name:     "removed non default property",
+			old:      `{"tiles":{"0":{"type":"data","query":"fetch logs","davis":{"enabled":true}}}}`,
+			new:      `{"tiles":{"0":{"type":"data","query":"fetch logs"}}}`,
+			expected: false,
+		},
+		{
+			name:     "changed to default value",
+			old:      `{"tiles":{"0":{"type":"data","hidden":true}}}`,
+			new:      `{"tiles":{"0":{"type":"data","hidden":false}}}`,
+			expected: false,
+		},
+		{
+			name:     "invalid JSON",
+			old:      `{"tiles":{}}`,
+			new:      `{"tiles":`,
+			expected: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, documents.ContentEqual(tc.old, tc.new))
+		})
+	}
+}
+
+func TestDashboardContentJSON(t *testing.T) {
+	content := `{
+		"version": 15,
+		"importedWithCode": false,
+		"variables": [{"key": "Host", "type": "query", "input": "fetch dt.entity.host", "visible": true, "multiple": false, "defaultValue": "*"}],
+		"tiles": {
+			"10": {"type": "data", "title": "CPU", "query": "timeseries avg(dt.host.cpu.usage)", "visualization": "lineChart", "visualizationSettings": {"chartSettings": {"gapPolicy": "connect"}}, "davis": {"enabled": false}},
+			"2": {"type": "markdown", "content": "## Hosts"}
+		},
+		"layouts": {
+			"10": {"x": 0, "y": 2, "w": 12, "h": 6},
+			"2": {"x": 0, "y": 0, "w": 24, "h": 2}
+		}
+	}`
+	dashboard := new(documents.DashboardContent)
+	require.NoError(t, json.Unmarshal([]byte(content), dashboard))
+
+	require.Len(t, dashboard.Tiles, 2)
+	assert.Equal(t, "2", dashboard.Tiles[0].ID)
+	assert.Equal(t, "10", dashboard.Tiles[1].ID)
+	assert.Equal(t, 12, dashboard.Tiles[1].Layout.W)
+	assert.Equal(t, `{"chartSettings":{"gapPolicy":"connect"}}`, *dashboard.Tiles[1].VisualizationSettings)
+	require.Len(t, dashboard.Variables, 1)
+	assert.Equal(t, "Host", dashboard.Variables[0].Key)
+
+	data, err := json.Marshal(dashboard)
+	require.NoError(t, err)
+	assert.JSONEq(t, content, string(data))
+}
+
+func TestNotebookContentJSON(t *testing.T) {
+	content := `{
+		"version": "7",
+		"defaultTimeframe": {"from": "now()-2h", "to": "now()"},
+		"sections": [
+			{"id": "a", "type": "markdown", "markdown": "# Notes"},
+			{"id": "b", "type": "dql", "title": "Errors", "showTitle": false, "state": {"input": {"value": "fetch logs", "timeframe": {"from": "now()-1h"}}, "visualization": "table"}}
+		]
+	}`
+	notebook := new(documents.NotebookContent)
+	require.NoError(t, json.Unmarshal([]byte(content), notebook))
+
+	require.Len(t, notebook.Sections, 2)
+	assert.Equal(t, "fetch logs", *notebook.Sections[1].Query)
+	assert.JSONEq(t, `{"input":{"timeframe":{"from":"now()-1h"}},"visualization":"table"}`, *notebook.Sections[1].State)
+
+	data, err := json.Marshal(notebook)
+	require.NoError(t, err)
+	assert.JSONEq(t, content, string(data))
+}
+
+func TestDocumentStructuredDashboard(t *testing.T) {
+	document := new(documents.Document)
+	d := schema.TestResourceDataRaw(t, document.Schema(), map[string]any{
+		"name": "structured",
+		"type": "dashboard",
+		"dashboard": []any{map[string]any{
+			"tiles": []any{map[string]any{
+				"tile": []any{
+					map[string]any{"id": "1", "type": "data", "query": "fetch logs", "layout": []any{map[string]any{"x": 0, "y": 2, "w": 12, "h": 6}}},
+					map[string]any{"id": "0", "type": "markdown", "content": "# Logs"},
+				},
+			}},
+		}},
+	})
+	require.NoError(t, document.UnmarshalHCL(hcl.DecoderFrom(d)))
+	assert.JSONEq(t, `{
+		"version": 15,
+		"tiles": {"0": {"type": "markdown", "content": "# Logs"}, "1": {"type": "data", "query": "fetch logs"}},
+		"layouts": {"1": {"x": 0, "y": 2, "w": 12, "h": 6}}
+	}`, document.Content)
+
+	// the tiles are getting reported in the order of the configuration
+	require.NoError(t, document.PrepareMarshalHCL(hcl.DecoderFrom(d)))
+	require.NotNil(t, document.Dashboard)
+	assert.Equal(t, "1", document.Dashboard.Tiles[0].ID)
+	assert.Equal(t, "0", document.Dashboard.Tiles[1].ID)
+
+	properties := hcl.Properties{}
+	require.NoError(t, document.MarshalHCL(properties))
+	for k, v := range properties {
+		require.NoError(t, d.Set(k, v))
+	}
+	assert.Equal(t, "fetch logs", d.Get("dashboard.0.tiles.0.tile.0.query"))
+	assert.Equal(t, 12, d.Get("dashboard.0.tiles.0.tile.0.layout.0.w"))
+	assert.Equal(t, 15, d.Get("dashboard.0.version"))
+}
+
+func TestDocumentStructuredTypeMismatch(t *testing.T) {
+	document := new(documents.Document)
+	d := schema.TestResourceDataRaw(t, document.Schema(), map[string]any{
+		"name":     "mismatch",
+		"type":     "dashboard",
+		"notebook": []any{map[string]any{"version": "7"}},
+	})
+	assert.Error(t, document.UnmarshalHCL(hcl.DecoderFrom(d)))
+}
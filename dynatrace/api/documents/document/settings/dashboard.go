@@ -0,0 +1,567 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package documents
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/xjson"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DashboardContentVersion is the content version used for dashboards, in case the configuration doesn't specify one
+const DashboardContentVersion = 15
+
+// DashboardContent is the structured representation of the content of a document of type `dashboard`
+type DashboardContent struct {
+	Version   int
+	Variables DashboardVariables
+	Tiles     DashboardTiles
+	Unknowns  map[string]json.RawMessage
+}
+
+func (me *DashboardContent) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"version": {
+			Type:        schema.TypeInt,
+			Description: "The version of the dashboard content. Defaults to `15`",
+			Optional:    true,
+			Computed:    true,
+		},
+		"variables": {
+			Type:        schema.TypeList,
+			Description: "The variables of the dashboard",
+			Optional:    true,
+			MaxItems:    1,
+			Elem:        &schema.Resource{Schema: new(DashboardVariables).Schema()},
+		},
+		"tiles": {
+			Type:        schema.TypeList,
+			Description: "The tiles of the dashboard",
+			Optional:    true,
+			MaxItems:    1,
+			Elem:        &schema.Resource{Schema: new(DashboardTiles).Schema()},
+		},
+		"unknowns": {
+			Type:             schema.TypeString,
+			Description:      "allows for configuring properties that are not explicitly supported by the current version of this provider",
+			Optional:         true,
+			DiffSuppressFunc: SuppressEquivalentContent,
+		},
+	}
+}
+
+func (me *DashboardContent) MarshalHCL(properties hcl.Properties) error {
+	if err := properties.Unknowns(me.Unknowns); err != nil {
+		return err
+	}
+	return properties.EncodeAll(map[string]any{
+		"version":   me.Version,
+		"variables": me.Variables,
+		"tiles":     me.Tiles,
+	})
+}
+
+func (me *DashboardContent) UnmarshalHCL(decoder hcl.Decoder) error {
+	if value, ok := decoder.GetOk("unknowns"); ok && len(value.(string)) > 0 {
+		if err := json.Unmarshal([]byte(value.(string)), &me.Unknowns); err != nil {
+			return err
+		}
+		delete(me.Unknowns, "version")
+		delete(me.Unknowns, "variables")
+		delete(me.Unknowns, "tiles")
+		delete(me.Unknowns, "layouts")
+		if len(me.Unknowns) == 0 {
+			me.Unknowns = nil
+		}
+	}
+	return decoder.DecodeAll(map[string]any{
+		"version":   &me.Version,
+		"variables": &me.Variables,
+		"tiles":     &me.Tiles,
+	})
+}
+
+func (me *DashboardContent) MarshalJSON() ([]byte, error) {
+	version := me.Version
+	if version == 0 {
+		version = DashboardContentVersion
+	}
+	tiles := map[string]*DashboardTile{}
+	layouts := map[string]*DashboardTileLayout{}
+	for _, tile := range me.Tiles {
+		tiles[tile.ID] = tile
+		if tile.Layout != nil {
+			layouts[tile.ID] = tile.Layout
+		}
+	}
+	properties := xjson.NewProperties(me.Unknowns)
+	if err := properties.MarshalAll(map[string]any{
+		"version":   version,
+		"variables": me.Variables,
+		"tiles":     tiles,
+		"layouts":   layouts,
+	}); err != nil {
+		return nil, err
+	}
+	return json.Marshal(properties)
+}
+
+func (me *DashboardContent) UnmarshalJSON(data []byte) error {
+	properties := xjson.Properties{}
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return err
+	}
+	tiles := map[string]*DashboardTile{}
+	layouts := map[string]*DashboardTileLayout{}
+	if err := properties.UnmarshalAll(map[string]any{
+		"version":   &me.Version,
+		"variables": &me.Variables,
+		"tiles":     &tiles,
+		"layouts":   &layouts,
+	}); err != nil {
+		return err
+	}
+	me.Tiles = DashboardTiles{}
+	for id, tile := range tiles {
+		tile.ID = id
+		tile.Layout = layouts[id]
+		me.Tiles = append(me.Tiles, tile)
+	}
+	me.Tiles.Sort()
+	if len(properties) > 0 {
+		me.Unknowns = properties
+	}
+	return nil
+}
+
+// DashboardVariables is the list of variables of a dashboard
+type DashboardVariables []*DashboardVariable
+
+func (me *DashboardVariables) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"variable": {
+			Type:        schema.TypeList,
+			Description: "A variable of the dashboard",
+			Required:    true,
+			MinItems:    1,
+			Elem:        &schema.Resource{Schema: new(DashboardVariable).Schema()},
+		},
+	}
+}
+
+func (me DashboardVariables) MarshalHCL(properties hcl.Properties) error {
+	return properties.EncodeSlice("variable", me)
+}
+
+func (me *DashboardVariables) UnmarshalHCL(decoder hcl.Decoder) error {
+	return decoder.DecodeSlice("variable", me)
+}
+
+// DashboardVariable is a variable of a dashboard, which can get referred to via `$<key>` within the queries of the tiles
+type DashboardVariable struct {
+	Key      string
+	Type     string
+	Input    *string
+	Visible  *bool
+	Editable *bool
+	Multiple *bool
+	Unknowns map[string]json.RawMessage
+}
+
+func (me *DashboardVariable) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"key": {
+			Type:        schema.TypeString,
+			Description: "The name of the variable",
+			Required:    true,
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Description: "The type of the variable. Possible values are `query`, `csv` and `text`",
+			Required:    true,
+		},
+		"input": {
+			Type:        schema.TypeString,
+			Description: "The DQL query (type `query`) or the comma separated values (type `csv`) the variable gets its values from",
+			Optional:    true,
+		},
+		"visible": {
+			Type:        schema.TypeBool,
+			Description: "The variable is visible on the dashboard",
+			Optional:    true,
+		},
+		"editable": {
+			Type:        schema.TypeBool,
+			Description: "The value of the variable can be changed on the dashboard",
+			Optional:    true,
+		},
+		"multiple": {
+			Type:        schema.TypeBool,
+			Description: "More than one value can be selected",
+			Optional:    true,
+		},
+		"unknowns": {
+			Type:             schema.TypeString,
+			Description:      "allows for configuring properties that are not explicitly supported by the current version of this provider",
+			Optional:         true,
+			DiffSuppressFunc: SuppressEquivalentContent,
+		},
+	}
+}
+
+func (me *DashboardVariable) MarshalHCL(properties hcl.Properties) error {
+	if err := properties.Unknowns(me.Unknowns); err != nil {
+		return err
+	}
+	return properties.EncodeAll(map[string]any{
+		"key":      me.Key,
+		"type":     me.Type,
+		"input":    me.Input,
+		"visible":  me.Visible,
+		"editable": me.Editable,
+		"multiple": me.Multiple,
+	})
+}
+
+func (me *DashboardVariable) UnmarshalHCL(decoder hcl.Decoder) error {
+	if value, ok := decoder.GetOk("unknowns"); ok && len(value.(string)) > 0 {
+		if err := json.Unmarshal([]byte(value.(string)), &me.Unknowns); err != nil {
+			return err
+		}
+		for _, key := range []string{"key", "type", "input", "visible", "editable", "multiple"} {
+			delete(me.Unknowns, key)
+		}
+		if len(me.Unknowns) == 0 {
+			me.Unknowns = nil
+		}
+	}
+	return decoder.DecodeAll(map[string]any{
+		"key":      &me.Key,
+		"type":     &me.Type,
+		"input":    &me.Input,
+		"visible":  &me.Visible,
+		"editable": &me.Editable,
+		"multiple": &me.Multiple,
+	})
+}
+
+func (me *DashboardVariable) MarshalJSON() ([]byte, error) {
+	properties := xjson.NewProperties(me.Unknowns)
+	if err := properties.MarshalAll(map[string]any{
+		"key":      me.Key,
+		"type":     me.Type,
+		"input":    me.Input,
+		"visible":  me.Visible,
+		"editable": me.Editable,
+		"multiple": me.Multiple,
+	}); err != nil {
+		return nil, err
+	}
+	return json.Marshal(properties)
+}
+
+func (me *DashboardVariable) UnmarshalJSON(data []byte) error {
+	properties := xjson.Properties{}
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return err
+	}
+	if err := properties.UnmarshalAll(map[string]any{
+		"key":      &me.Key,
+		"type":     &me.Type,
+		"input":    &me.Input,
+		"visible":  &me.Visible,
+		"editable": &me.Editable,
+		"multiple": &me.Multiple,
+	}); err != nil {
+		return err
+	}
+	if len(properties) > 0 {
+		me.Unknowns = properties
+	}
+	return nil
+}
+
+// DashboardTiles is the list of tiles of a dashboard.
+// Within the JSON representation tiles and their layouts are stored as objects keyed by the ID of the tile.
+type DashboardTiles []*DashboardTile
+
+// Sort orders the tiles by their ID. Numeric IDs are getting sorted by their numeric value
+func (me DashboardTiles) Sort() {
+	sort.SliceStable(me, func(i, j int) bool {
+		ni, erri := strconv.Atoi(me[i].ID)
+		nj, errj := strconv.Atoi(me[j].ID)
+		if erri == nil && errj == nil {
+			return ni < nj
+		}
+		return me[i].ID < me[j].ID
+	})
+}
+
+// SortLike orders the tiles with the given IDs according to the order of these IDs. Any other tiles are getting placed afterwards
+func (me DashboardTiles) SortLike(ids []string) {
+	positions := map[string]int{}
+	for idx, id := range ids {
+		positions[id] = idx
+	}
+	sort.SliceStable(me, func(i, j int) bool {
+		pi, foundi := positions[me[i].ID]
+		pj, foundj := positions[me[j].ID]
+		if foundi && foundj {
+			return pi < pj
+		}
+		return foundi && !foundj
+	})
+}
+
+func (me *DashboardTiles) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"tile": {
+			Type:        schema.TypeList,
+			Description: "A tile of the dashboard",
+			Required:    true,
+			MinItems:    1,
+			Elem:        &schema.Resource{Schema: new(DashboardTile).Schema()},
+		},
+	}
+}
+
+func (me DashboardTiles) MarshalHCL(properties hcl.Properties) error {
+	return properties.EncodeSlice("tile", me)
+}
+
+func (me *DashboardTiles) UnmarshalHCL(decoder hcl.Decoder) error {
+	return decoder.DecodeSlice("tile", me)
+}
+
+// DashboardTile is a tile of a dashboard
+type DashboardTile struct {
+	ID                    string
+	Type                  string
+	Title                 *string
+	Content               *string
+	Query                 *string
+	Visualization         *string
+	VisualizationSettings *string
+	Layout                *DashboardTileLayout
+	Unknowns              map[string]json.RawMessage
+}
+
+func (me *DashboardTile) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Description: "The ID of the tile, unique within the dashboard",
+			Required:    true,
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Description: "The type of the tile, for example `data` or `markdown`",
+			Required:    true,
+		},
+		"title": {
+			Type:        schema.TypeString,
+			Description: "The title of the tile",
+			Optional:    true,
+		},
+		"content": {
+			Type:        schema.TypeString,
+			Description: "The markdown text of a tile of type `markdown`",
+			Optional:    true,
+		},
+		"query": {
+			Type:        schema.TypeString,
+			Description: "The DQL query of a tile of type `data`",
+			Optional:    true,
+		},
+		"visualization": {
+			Type:        schema.TypeString,
+			Description: "The visualization of the query results, for example `lineChart` or `table`",
+			Optional:    true,
+		},
+		"visualization_settings": {
+			Type:             schema.TypeString,
+			Description:      "The settings of the visualization as JSON",
+			Optional:         true,
+			DiffSuppressFunc: SuppressEquivalentContent,
+		},
+		"layout": {
+			Type:        schema.TypeList,
+			Description: "The position and size of the tile",
+			Optional:    true,
+			MaxItems:    1,
+			Elem:        &schema.Resource{Schema: new(DashboardTileLayout).Schema()},
+		},
+		"unknowns": {
+			Type:             schema.TypeString,
+			Description:      "allows for configuring properties that are not explicitly supported by the current version of this provider",
+			Optional:         true,
+			DiffSuppressFunc: SuppressEquivalentContent,
+		},
+	}
+}
+
+func (me *DashboardTile) MarshalHCL(properties hcl.Properties) error {
+	if err := properties.Unknowns(me.Unknowns); err != nil {
+		return err
+	}
+	return properties.EncodeAll(map[string]any{
+		"id":                     me.ID,
+		"type":                   me.Type,
+		"title":                  me.Title,
+		"content":                me.Content,
+		"query":                  me.Query,
+		"visualization":          me.Visualization,
+		"visualization_settings": me.VisualizationSettings,
+		"layout":                 me.Layout,
+	})
+}
+
+func (me *DashboardTile) UnmarshalHCL(decoder hcl.Decoder) error {
+	if value, ok := decoder.GetOk("unknowns"); ok && len(value.(string)) > 0 {
+		if err := json.Unmarshal([]byte(value.(string)), &me.Unknowns); err != nil {
+			return err
+		}
+		for _, key := range []string{"type", "title", "content", "query", "visualization", "visualizationSettings"} {
+			delete(me.Unknowns, key)
+		}
+		if len(me.Unknowns) == 0 {
+			me.Unknowns = nil
+		}
+	}
+	return decoder.DecodeAll(map[string]any{
+		"id":                     &me.ID,
+		"type":                   &me.Type,
+		"title":                  &me.Title,
+		"content":                &me.Content,
+		"query":                  &me.Query,
+		"visualization":          &me.Visualization,
+		"visualization_settings": &me.VisualizationSettings,
+		"layout":                 &me.Layout,
+	})
+}
+
+func (me *DashboardTile) MarshalJSON() ([]byte, error) {
+	properties := xjson.NewProperties(me.Unknowns)
+	if err := properties.MarshalAll(map[string]any{
+		"type":          me.Type,
+		"title":         me.Title,
+		"content":       me.Content,
+		"query":         me.Query,
+		"visualization": me.Visualization,
+	}); err != nil {
+		return nil, err
+	}
+	if me.VisualizationSettings != nil && len(*me.VisualizationSettings) > 0 {
+		properties["visualizationSettings"] = json.RawMessage(*me.VisualizationSettings)
+	}
+	return json.Marshal(properties)
+}
+
+func (me *DashboardTile) UnmarshalJSON(data []byte) error {
+	properties := xjson.Properties{}
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return err
+	}
+	if err := properties.UnmarshalAll(map[string]any{
+		"type":          &me.Type,
+		"title":         &me.Title,
+		"content":       &me.Content,
+		"query":         &me.Query,
+		"visualization": &me.Visualization,
+	}); err != nil {
+		return err
+	}
+	if value, found := properties["visualizationSettings"]; found {
+		me.VisualizationSettings = rawString(value)
+		delete(properties, "visualizationSettings")
+	}
+	if len(properties) > 0 {
+		me.Unknowns = properties
+	}
+	return nil
+}
+
+// DashboardTileLayout is the position and size of a tile on the grid of a dashboard
+type DashboardTileLayout struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+func (me *DashboardTileLayout) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"x": {
+			Type:        schema.TypeInt,
+			Description: "The column the tile starts at",
+			Required:    true,
+		},
+		"y": {
+			Type:        schema.TypeInt,
+			Description: "The row the tile starts at",
+			Required:    true,
+		},
+		"w": {
+			Type:        schema.TypeInt,
+			Description: "The width of the tile in columns",
+			Required:    true,
+		},
+		"h": {
+			Type:        schema.TypeInt,
+			Description: "The height of the tile in rows",
+			Required:    true,
+		},
+	}
+}
+
+func (me *DashboardTileLayout) MarshalHCL(properties hcl.Properties) error {
+	return properties.EncodeAll(map[string]any{
+		"x": me.X,
+		"y": me.Y,
+		"w": me.W,
+		"h": me.H,
+	})
+}
+
+func (me *DashboardTileLayout) UnmarshalHCL(decoder hcl.Decoder) error {
+	return decoder.DecodeAll(map[string]any{
+		"x": &me.X,
+		"y": &me.Y,
+		"w": &me.W,
+		"h": &me.H,
+	})
+}
+
+// rawString returns the compacted form of a JSON value, or `nil` in case the value is empty or `null`
+func rawString(data json.RawMessage) *string {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	b := bytes.NewBuffer(nil)
+	if err := json.Compact(b, data); err != nil {
+		s := string(data)
+		return &s
+	}
+	s := b.String()
+	return &s
+}
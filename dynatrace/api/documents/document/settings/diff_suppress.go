@@ -0,0 +1,131 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package documents
+
+import (
+	"encoding/json"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// SuppressEquivalentContent suppresses differences between two JSON documents which are semantically equal
+func SuppressEquivalentContent(k, old, new string, d *schema.ResourceData) bool {
+	return ContentEqual(old, new)
+}
+
+// ContentEqual compares two JSON documents semantically.
+// Differences in key order and formatting are getting ignored, as well as
+//   - the top level `version` property, which gets upgraded by the Dynatrace Platform
+//   - properties which are present only in one of the two documents, but hold a default value (`false`, `0`, `""`, `null`, `{}` or `[]`)
+func ContentEqual(old, new string) bool {
+	if hcl.JSONStringsEqual(old, new) {
+		return true
+	}
+	var vOld, vNew any
+	if err := json.Unmarshal([]byte(orEmptyObject(old)), &vOld); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(orEmptyObject(new)), &vNew); err != nil {
+		return false
+	}
+	if mOld, ok := vOld.(map[string]any); ok {
+		delete(mOld, "version")
+	}
+	if mNew, ok := vNew.(map[string]any); ok {
+		delete(mNew, "version")
+	}
+	vOld, vNew = dropDefaults(vOld, vNew)
+
+	bOld, err := json.Marshal(vOld)
+	if err != nil {
+		return false
+	}
+	bNew, err := json.Marshal(vNew)
+	if err != nil {
+		return false
+	}
+	return hcl.JSONStringsEqual(string(bOld), string(bNew))
+}
+
+func orEmptyObject(s string) string {
+	if len(s) == 0 {
+		return "{}"
+	}
+	return s
+}
+
+// dropDefaults removes the properties of two JSON objects, which exist only in one of them and hold a default value.
+// JSON arrays of the same length are getting compared element by element.
+func dropDefaults(a any, b any) (any, any) {
+	switch ta := a.(type) {
+	case map[string]any:
+		tb, ok := b.(map[string]any)
+		if !ok {
+			return a, b
+		}
+		for k, va := range ta {
+			if vb, found := tb[k]; found {
+				ta[k], tb[k] = dropDefaults(va, vb)
+			} else if isDefault(va) {
+				delete(ta, k)
+			}
+		}
+		for k, vb := range tb {
+			if _, found := ta[k]; !found && isDefault(vb) {
+				delete(tb, k)
+			}
+		}
+		return ta, tb
+	case []any:
+		tb, ok := b.([]any)
+		if !ok || len(ta) != len(tb) {
+			return a, b
+		}
+		for idx := range ta {
+			ta[idx], tb[idx] = dropDefaults(ta[idx], tb[idx])
+		}
+		return ta, tb
+	default:
+		return a, b
+	}
+}
+
+func isDefault(v any) bool {
+	switch tv := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !tv
+	case string:
+		return len(tv) == 0
+	case float64:
+		return tv == 0
+	case []any:
+		return len(tv) == 0
+	case map[string]any:
+		for _, elem := range tv {
+			if !isDefault(elem) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
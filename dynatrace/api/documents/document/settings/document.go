@@ -19,6 +19,7 @@ package documents
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -35,6 +36,16 @@ type Document struct {
 	Owner         string `json:"owner,omitempty" format:"uuid"`
 	Version       int    `json:"version,omitempty"`
 	SchemaVersion int    `json:"schemaVersion,omitempty"`
+
+	Dashboard *DashboardContent `json:"-"` // structured alternative to `content` for documents of type `dashboard`
+	Notebook  *NotebookContent  `json:"-"` // structured alternative to `content` for documents of type `notebook`
+
+	Warnings []string `json:"-"` // tiles that couldn't get converted, in case the document got produced from a classic dashboard
+}
+
+// Validate reports the tiles that couldn't get converted, in case the document got produced from a classic dashboard
+func (me *Document) Validate() []string {
+	return me.Warnings
 }
 
 func (me *Document) Schema() map[string]*schema.Schema {
@@ -72,9 +83,26 @@ func (me *Document) Schema() map[string]*schema.Schema {
 			ValidateDiagFunc: ValidateUUIDOrEmpty,
 		},
 		"content": {
-			Type:        schema.TypeString,
-			Description: "Document content as JSON",
-			Required:    true,
+			Type:             schema.TypeString,
+			Description:      "Document content as JSON. Differences in formatting, key order and default values added by the Dynatrace Platform are getting ignored. Exactly one of `content`, `dashboard` and `notebook` needs to be specified",
+			Optional:         true,
+			Computed:         true,
+			ExactlyOneOf:     []string{"content", "dashboard", "notebook"},
+			DiffSuppressFunc: SuppressEquivalentContent,
+		},
+		"dashboard": {
+			Type:        schema.TypeList,
+			Description: "The content of a document of type `dashboard` as structured configuration. Marshals to the same JSON as `content`",
+			Optional:    true,
+			MaxItems:    1,
+			Elem:        &schema.Resource{Schema: new(DashboardContent).Schema()},
+		},
+		"notebook": {
+			Type:        schema.TypeList,
+			Description: "The content of a document of type `notebook` as structured configuration. Marshals to the same JSON as `content`",
+			Optional:    true,
+			MaxItems:    1,
+			Elem:        &schema.Resource{Schema: new(NotebookContent).Schema()},
 		},
 		"version": {
 			Type:        schema.TypeInt,
@@ -84,28 +112,80 @@ func (me *Document) Schema() map[string]*schema.Schema {
 	}
 }
 
+// PrepareMarshalHCL ensures that the content of the document is getting represented
+// the same way as within the configuration, i.e. either as JSON or as structured configuration
+func (me *Document) PrepareMarshalHCL(decoder hcl.Decoder) error {
+	if value, ok := decoder.GetOk("dashboard.#"); ok && value.(int) > 0 {
+		me.Dashboard = new(DashboardContent)
+		if err := json.Unmarshal([]byte(me.Content), me.Dashboard); err != nil {
+			return err
+		}
+		ids := []string{}
+		if value, ok := decoder.GetOk("dashboard.0.tiles.0.tile.#"); ok {
+			for idx := 0; idx < value.(int); idx++ {
+				ids = append(ids, decoder.Get(fmt.Sprintf("dashboard.0.tiles.0.tile.%d.id", idx)).(string))
+			}
+		}
+		me.Dashboard.Tiles.SortLike(ids)
+	}
+	if value, ok := decoder.GetOk("notebook.#"); ok && value.(int) > 0 {
+		me.Notebook = new(NotebookContent)
+		if err := json.Unmarshal([]byte(me.Content), me.Notebook); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (me *Document) MarshalHCL(properties hcl.Properties) error {
 	return properties.EncodeAll(map[string]any{
-		"name":    me.Name,
-		"content": me.Content,
-		"private": me.IsPrivate,
-		"type":    me.Type,
-		"actor":   me.Actor,
-		"owner":   me.Owner,
-		"version": me.Version,
+		"name":      me.Name,
+		"content":   me.Content,
+		"dashboard": me.Dashboard,
+		"notebook":  me.Notebook,
+		"private":   me.IsPrivate,
+		"type":      me.Type,
+		"actor":     me.Actor,
+		"owner":     me.Owner,
+		"version":   me.Version,
 	})
 }
 
 func (me *Document) UnmarshalHCL(decoder hcl.Decoder) error {
-	return decoder.DecodeAll(map[string]any{
-		"name":    &me.Name,
-		"content": &me.Content,
-		"private": &me.IsPrivate,
-		"type":    &me.Type,
-		"actor":   &me.Actor,
-		"owner":   &me.Owner,
-		"version": &me.Version,
-	})
+	if err := decoder.DecodeAll(map[string]any{
+		"name":      &me.Name,
+		"content":   &me.Content,
+		"dashboard": &me.Dashboard,
+		"notebook":  &me.Notebook,
+		"private":   &me.IsPrivate,
+		"type":      &me.Type,
+		"actor":     &me.Actor,
+		"owner":     &me.Owner,
+		"version":   &me.Version,
+	}); err != nil {
+		return err
+	}
+	var content any
+	switch {
+	case me.Dashboard != nil:
+		if me.Type != "dashboard" {
+			return fmt.Errorf("`dashboard` can only be specified for documents of type `dashboard`, but the type is `%s`", me.Type)
+		}
+		content = me.Dashboard
+	case me.Notebook != nil:
+		if me.Type != "notebook" {
+			return fmt.Errorf("`notebook` can only be specified for documents of type `notebook`, but the type is `%s`", me.Type)
+		}
+		content = me.Notebook
+	default:
+		return nil
+	}
+	data, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+	me.Content = string(data)
+	return nil
 }
 
 func (me *Document) MarshalJSON() ([]byte, error) {
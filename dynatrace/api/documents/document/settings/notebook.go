@@ -0,0 +1,315 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package documents
+
+import (
+	"encoding/json"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/xjson"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// NotebookContent is the structured representation of the content of a document of type `notebook`
+type NotebookContent struct {
+	Version  *string
+	Sections NotebookSections
+	Unknowns map[string]json.RawMessage
+}
+
+func (me *NotebookContent) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"version": {
+			Type:        schema.TypeString,
+			Description: "The version of the notebook content",
+			Optional:    true,
+			Computed:    true,
+		},
+		"sections": {
+			Type:        schema.TypeList,
+			Description: "The sections of the notebook",
+			Optional:    true,
+			MaxItems:    1,
+			Elem:        &schema.Resource{Schema: new(NotebookSections).Schema()},
+		},
+		"unknowns": {
+			Type:             schema.TypeString,
+			Description:      "allows for configuring properties that are not explicitly supported by the current version of this provider",
+			Optional:         true,
+			DiffSuppressFunc: SuppressEquivalentContent,
+		},
+	}
+}
+
+func (me *NotebookContent) MarshalHCL(properties hcl.Properties) error {
+	if err := properties.Unknowns(me.Unknowns); err != nil {
+		return err
+	}
+	return properties.EncodeAll(map[string]any{
+		"version":  me.Version,
+		"sections": me.Sections,
+	})
+}
+
+func (me *NotebookContent) UnmarshalHCL(decoder hcl.Decoder) error {
+	if value, ok := decoder.GetOk("unknowns"); ok && len(value.(string)) > 0 {
+		if err := json.Unmarshal([]byte(value.(string)), &me.Unknowns); err != nil {
+			return err
+		}
+		delete(me.Unknowns, "version")
+		delete(me.Unknowns, "sections")
+		if len(me.Unknowns) == 0 {
+			me.Unknowns = nil
+		}
+	}
+	return decoder.DecodeAll(map[string]any{
+		"version":  &me.Version,
+		"sections": &me.Sections,
+	})
+}
+
+func (me *NotebookContent) MarshalJSON() ([]byte, error) {
+	properties := xjson.NewProperties(me.Unknowns)
+	if err := properties.MarshalAll(map[string]any{
+		"version":  me.Version,
+		"sections": me.Sections,
+	}); err != nil {
+		return nil, err
+	}
+	return json.Marshal(properties)
+}
+
+func (me *NotebookContent) UnmarshalJSON(data []byte) error {
+	properties := xjson.Properties{}
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return err
+	}
+	if value, found := properties["version"]; found {
+		// older notebooks are carrying a numeric version
+		var version any
+		if err := json.Unmarshal(value, &version); err != nil {
+			return err
+		}
+		if sVersion, ok := version.(string); ok {
+			me.Version = &sVersion
+		} else {
+			me.Version = rawString(value)
+		}
+		delete(properties, "version")
+	}
+	if err := properties.UnmarshalAll(map[string]any{
+		"sections": &me.Sections,
+	}); err != nil {
+		return err
+	}
+	if len(properties) > 0 {
+		me.Unknowns = properties
+	}
+	return nil
+}
+
+// NotebookSections is the list of sections of a notebook
+type NotebookSections []*NotebookSection
+
+func (me *NotebookSections) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"section": {
+			Type:        schema.TypeList,
+			Description: "A section of the notebook",
+			Required:    true,
+			MinItems:    1,
+			Elem:        &schema.Resource{Schema: new(NotebookSection).Schema()},
+		},
+	}
+}
+
+func (me NotebookSections) MarshalHCL(properties hcl.Properties) error {
+	return properties.EncodeSlice("section", me)
+}
+
+func (me *NotebookSections) UnmarshalHCL(decoder hcl.Decoder) error {
+	return decoder.DecodeSlice("section", me)
+}
+
+// NotebookSection is a section of a notebook.
+// The DQL query of a section is stored at `state.input.value` within the JSON representation.
+type NotebookSection struct {
+	ID       string
+	Type     string
+	Title    *string
+	Markdown *string
+	Query    *string
+	State    *string
+	Unknowns map[string]json.RawMessage
+}
+
+func (me *NotebookSection) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Description: "The ID of the section, unique within the notebook",
+			Required:    true,
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Description: "The type of the section, for example `markdown` or `dql`",
+			Required:    true,
+		},
+		"title": {
+			Type:        schema.TypeString,
+			Description: "The title of the section",
+			Optional:    true,
+		},
+		"markdown": {
+			Type:        schema.TypeString,
+			Description: "The markdown text of a section of type `markdown`",
+			Optional:    true,
+		},
+		"query": {
+			Type:        schema.TypeString,
+			Description: "The DQL query of a section of type `dql`",
+			Optional:    true,
+		},
+		"state": {
+			Type:             schema.TypeString,
+			Description:      "The remaining state of the section (visualization, timeframe, ...) as JSON",
+			Optional:         true,
+			DiffSuppressFunc: SuppressEquivalentContent,
+		},
+		"unknowns": {
+			Type:             schema.TypeString,
+			Description:      "allows for configuring properties that are not explicitly supported by the current version of this provider",
+			Optional:         true,
+			DiffSuppressFunc: SuppressEquivalentContent,
+		},
+	}
+}
+
+func (me *NotebookSection) MarshalHCL(properties hcl.Properties) error {
+	if err := properties.Unknowns(me.Unknowns); err != nil {
+		return err
+	}
+	return properties.EncodeAll(map[string]any{
+		"id":       me.ID,
+		"type":     me.Type,
+		"title":    me.Title,
+		"markdown": me.Markdown,
+		"query":    me.Query,
+		"state":    me.State,
+	})
+}
+
+func (me *NotebookSection) UnmarshalHCL(decoder hcl.Decoder) error {
+	if value, ok := decoder.GetOk("unknowns"); ok && len(value.(string)) > 0 {
+		if err := json.Unmarshal([]byte(value.(string)), &me.Unknowns); err != nil {
+			return err
+		}
+		for _, key := range []string{"id", "type", "title", "markdown", "state"} {
+			delete(me.Unknowns, key)
+		}
+		if len(me.Unknowns) == 0 {
+			me.Unknowns = nil
+		}
+	}
+	return decoder.DecodeAll(map[string]any{
+		"id":       &me.ID,
+		"type":     &me.Type,
+		"title":    &me.Title,
+		"markdown": &me.Markdown,
+		"query":    &me.Query,
+		"state":    &me.State,
+	})
+}
+
+func (me *NotebookSection) MarshalJSON() ([]byte, error) {
+	properties := xjson.NewProperties(me.Unknowns)
+	if err := properties.MarshalAll(map[string]any{
+		"id":       me.ID,
+		"type":     me.Type,
+		"title":    me.Title,
+		"markdown": me.Markdown,
+	}); err != nil {
+		return nil, err
+	}
+	state := xjson.Properties{}
+	if me.State != nil && len(*me.State) > 0 {
+		if err := json.Unmarshal([]byte(*me.State), &state); err != nil {
+			return nil, err
+		}
+	}
+	if me.Query != nil {
+		input := xjson.Properties{}
+		if value, found := state["input"]; found {
+			if err := json.Unmarshal(value, &input); err != nil {
+				return nil, err
+			}
+		}
+		if err := input.Marshal("value", *me.Query); err != nil {
+			return nil, err
+		}
+		if err := state.Marshal("input", input); err != nil {
+			return nil, err
+		}
+	}
+	if err := properties.Marshal("state", state); err != nil {
+		return nil, err
+	}
+	return json.Marshal(properties)
+}
+
+func (me *NotebookSection) UnmarshalJSON(data []byte) error {
+	properties := xjson.Properties{}
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return err
+	}
+	state := xjson.Properties{}
+	if err := properties.UnmarshalAll(map[string]any{
+		"id":       &me.ID,
+		"type":     &me.Type,
+		"title":    &me.Title,
+		"markdown": &me.Markdown,
+		"state":    &state,
+	}); err != nil {
+		return err
+	}
+	if value, found := state["input"]; found {
+		input := xjson.Properties{}
+		if err := json.Unmarshal(value, &input); err == nil {
+			var query string
+			if err := input.Unmarshal("value", &query); err == nil && len(query) > 0 {
+				me.Query = &query
+				if len(input) == 0 {
+					delete(state, "input")
+				} else if err := state.Marshal("input", input); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if len(state) > 0 {
+		data, err := json.Marshal(state)
+		if err != nil {
+			return err
+		}
+		me.State = rawString(data)
+	}
+	if len(properties) > 0 {
+		me.Unknowns = properties
+	}
+	return nil
+}
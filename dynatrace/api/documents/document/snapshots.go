@@ -0,0 +1,122 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package documents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/automation/httplog"
+	documents "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document/settings"
+	tfrest "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/monaco/pkg/client/auth"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/monaco/pkg/rest"
+)
+
+const documentsPath = "/platform/document/v1/documents"
+
+// Snapshot describes a previous state of a document kept by the Document API
+type Snapshot struct {
+	SnapshotVersion  int    `json:"snapshotVersion"`       // the version of the snapshot
+	DocumentVersion  int    `json:"documentVersion"`       // the version the document had when the snapshot got taken
+	Description      string `json:"description,omitempty"` // an optional description of the snapshot
+	ModificationInfo struct {
+		CreatedBy   string `json:"createdBy"`   // the user who caused the snapshot to get taken
+		CreatedTime string `json:"createdTime"` // the time the snapshot got taken
+	} `json:"modificationInfo"`
+}
+
+// SnapshotService lists the snapshots of documents and restores documents to one of their snapshots
+type SnapshotService struct {
+	credentials *settings.Credentials
+}
+
+func NewSnapshotService(credentials *settings.Credentials) *SnapshotService {
+	return &SnapshotService{credentials}
+}
+
+func (me *SnapshotService) client(ctx context.Context) *http.Client {
+	httplog.InstallRoundTripper()
+	return auth.NewOAuthClient(ctx, auth.OauthCredentials{
+		ClientID:     me.credentials.Automation.ClientID,
+		ClientSecret: me.credentials.Automation.ClientSecret,
+		TokenURL:     me.credentials.Automation.TokenURL,
+	})
+}
+
+func (me *SnapshotService) url(documentID string) string {
+	return me.credentials.Automation.EnvironmentURL + documentsPath + "/" + url.PathEscape(documentID) + "/snapshots"
+}
+
+// List returns the snapshots of the given document, ordered by their version
+func (me *SnapshotService) List(ctx context.Context, documentID string) ([]*Snapshot, error) {
+	client := me.client(ctx)
+	snapshots := []*Snapshot{}
+	nextPageKey := ""
+	for {
+		u := me.url(documentID)
+		if len(nextPageKey) > 0 {
+			u = u + "?page-key=" + url.QueryEscape(nextPageKey)
+		}
+		response, err := rest.Get(ctx, client, u)
+		if err != nil {
+			return nil, err
+		}
+		if !response.Success() {
+			return nil, tfrest.Error{Code: response.StatusCode, Message: string(response.Body)}
+		}
+		var page struct {
+			Snapshots   []*Snapshot `json:"snapshots"`
+			NextPageKey *string     `json:"nextPageKey"`
+		}
+		if err := json.Unmarshal(response.Body, &page); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, page.Snapshots...)
+		if page.NextPageKey == nil || len(*page.NextPageKey) == 0 {
+			return snapshots, nil
+		}
+		nextPageKey = *page.NextPageKey
+	}
+}
+
+// Restore rolls the given document back to the state of the given snapshot.
+// The current state of the document is preserved as a new snapshot by the Document API.
+// Returns the version of the document after it has been restored.
+func (me *SnapshotService) Restore(ctx context.Context, documentID string, snapshotVersion int) (int, error) {
+	var document documents.Document
+	if err := Service(me.credentials).Get(ctx, documentID, &document); err != nil {
+		return 0, err
+	}
+	u := fmt.Sprintf("%s/%d:restore?optimistic-locking-version=%d", me.url(documentID), snapshotVersion, document.Version)
+	response, err := rest.Post(ctx, me.client(ctx), u, nil)
+	if err != nil {
+		return 0, err
+	}
+	if !response.Success() {
+		return 0, tfrest.Error{Code: response.StatusCode, Message: string(response.Body)}
+	}
+	if err := Service(me.credentials).Get(ctx, documentID, &document); err != nil {
+		return 0, err
+	}
+	return document.Version, nil
+}
@@ -0,0 +1,85 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package documents_test
+
+import (
+	"context"
+	"testing"
+
+	documentservice "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document"
+	documents "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+)
+
+func TestSnapshots(t *testing.T) {
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	ctx := context.Background()
+	service := documentservice.Service(server.Credentials())
+	stub, err := service.Create(ctx, &documents.Document{Name: "snapshots", Type: "notebook", Content: `{"sections":[{"id":"a","type":"markdown","markdown":"v1"}]}`, IsPrivate: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, content := range []string{
+		`{"sections":[{"id":"a","type":"markdown","markdown":"v2"}]}`,
+		`{"sections":[{"id":"a","type":"markdown","markdown":"v3"}]}`,
+	} {
+		if err := service.Update(ctx, stub.ID, &documents.Document{Name: "snapshots", Type: "notebook", Content: content, IsPrivate: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snapshotService := documentservice.NewSnapshotService(server.Credentials())
+	snapshots, err := snapshotService.List(ctx, stub.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// creating a document already results in a snapshot, because the content gets patched right after creation
+	if len(snapshots) != 3 {
+		t.Fatalf("expected 3 snapshots, got %d", len(snapshots))
+	}
+	if snapshots[0].SnapshotVersion != 1 || snapshots[0].DocumentVersion != 1 || len(snapshots[0].ModificationInfo.CreatedBy) == 0 || len(snapshots[0].ModificationInfo.CreatedTime) == 0 {
+		t.Errorf("unexpected snapshot %+v", snapshots[0])
+	}
+
+	version, err := snapshotService.Restore(ctx, stub.ID, snapshots[0].SnapshotVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var restored documents.Document
+	if err := service.Get(ctx, stub.ID, &restored); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Content != `{"sections":[{"id":"a","type":"markdown","markdown":"v1"}]}` {
+		t.Errorf("expected the content of the first version to be restored, got %s", restored.Content)
+	}
+	if restored.Version != version || version != 5 {
+		t.Errorf("expected version 5 after the restore, got %d (reported %d)", restored.Version, version)
+	}
+	if snapshots, err = snapshotService.List(ctx, stub.ID); err != nil || len(snapshots) != 4 {
+		t.Errorf("expected the state before the restore to be kept as snapshot, got %d snapshots (%v)", len(snapshots), err)
+	}
+
+	if _, err := snapshotService.Restore(ctx, stub.ID, 42); err == nil {
+		t.Error("expected restoring an unknown snapshot to fail")
+	}
+}
@@ -0,0 +1,62 @@
+resource "dynatrace_document" "#name#" {
+  name = "#name#"
+  type = "dashboard"
+  dashboard {
+    variables {
+      variable {
+        key      = "Host"
+        type     = "query"
+        input    = "fetch dt.entity.host | fields entity.name"
+        visible  = true
+        editable = true
+        multiple = false
+      }
+    }
+    tiles {
+      tile {
+        id      = "0"
+        type    = "markdown"
+        content = "## #name#"
+        layout {
+          x = 0
+          y = 0
+          w = 24
+          h = 2
+        }
+      }
+      tile {
+        id            = "1"
+        type          = "data"
+        title         = "CPU usage"
+        query         = "timeseries cpu = avg(dt.host.cpu.usage), by:{dt.entity.host}"
+        visualization = "lineChart"
+        layout {
+          x = 0
+          y = 2
+          w = 12
+          h = 6
+        }
+      }
+    }
+  }
+}
+
+resource "dynatrace_document" "#name#-notebook" {
+  name = "#name#-notebook"
+  type = "notebook"
+  notebook {
+    sections {
+      section {
+        id       = "c7b5b4a1-0b1f-4c5e-9a3e-6f5d2b7e8a10"
+        type     = "markdown"
+        markdown = "# #name#"
+      }
+      section {
+        id    = "d2e6f8a3-1c2b-4d7e-8f9a-0b1c2d3e4f50"
+        type  = "dql"
+        title = "Errors"
+        query = "fetch logs | filter loglevel == \"ERROR\" | limit 10"
+      }
+    }
+  }
+}
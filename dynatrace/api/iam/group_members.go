@@ -0,0 +1,77 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package iam
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+)
+
+type GroupMemberStub struct {
+	UID   string `json:"uid"`
+	Email string `json:"email"`
+}
+
+type ListGroupMembersResponse struct {
+	Count int                `json:"count"`
+	Items []*GroupMemberStub `json:"items"`
+}
+
+func accountURL(auth Authenticator) string {
+	return fmt.Sprintf("%s/iam/v1/accounts/%s", auth.EndpointURL(), strings.TrimPrefix(auth.AccountID(), "urn:dtaccount:"))
+}
+
+// GroupMembers returns the email addresses of the users belonging to the given group
+func GroupMembers(ctx context.Context, auth Authenticator, groupID string) ([]string, error) {
+	var response ListGroupMembersResponse
+	if err := GET(NewIAMClient(auth), ctx, fmt.Sprintf("%s/groups/%s/users", accountURL(auth), groupID), 200, false, &response); err != nil {
+		if strings.Contains(err.Error(), fmt.Sprintf("Group %s not found", groupID)) {
+			return nil, rest.Error{Code: 404, Message: err.Error()}
+		}
+		return nil, err
+	}
+	members := []string{}
+	for _, item := range response.Items {
+		members = append(members, item.Email)
+	}
+	return members, nil
+}
+
+// AddGroupMembers adds the users with the given email addresses to the given group.
+// Users already belonging to the group remain untouched
+func AddGroupMembers(ctx context.Context, auth Authenticator, groupID string, emails []string) error {
+	if len(emails) == 0 {
+		return nil
+	}
+	_, err := NewIAMClient(auth).POST(ctx, fmt.Sprintf("%s/groups/%s/users", accountURL(auth), groupID), emails, 200, false)
+	return err
+}
+
+// RemoveGroupMember removes the user with the given email address from the given group.
+// Neither the user nor the group are getting deleted
+func RemoveGroupMember(ctx context.Context, auth Authenticator, groupID string, email string) error {
+	_, err := NewIAMClient(auth).DELETE(ctx, fmt.Sprintf("%s/users/%s/groups?group-uuid=%s", accountURL(auth), url.PathEscape(email), url.QueryEscape(groupID)), 200, false)
+	if err != nil && (strings.Contains(err.Error(), fmt.Sprintf("User %s not found", email)) || strings.Contains(err.Error(), fmt.Sprintf("Group %s not found", groupID))) {
+		return nil
+	}
+	return err
+}
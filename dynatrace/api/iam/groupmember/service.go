@@ -0,0 +1,140 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package groupmember
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam"
+	groupmember "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/groupmember/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/groups"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+type GroupMemberServiceClient struct {
+	credentials  *settings.Credentials
+	clientID     string
+	accountID    string
+	clientSecret string
+	tokenURL     string
+	endpointURL  string
+}
+
+func (me *GroupMemberServiceClient) ClientID() string {
+	return me.clientID
+}
+
+func (me *GroupMemberServiceClient) AccountID() string {
+	return me.accountID
+}
+
+func (me *GroupMemberServiceClient) ClientSecret() string {
+	return me.clientSecret
+}
+
+func (me *GroupMemberServiceClient) TokenURL() string {
+	return me.tokenURL
+}
+
+func (me *GroupMemberServiceClient) EndpointURL() string {
+	return me.endpointURL
+}
+
+func Service(credentials *settings.Credentials) settings.CRUDService[*groupmember.GroupMember] {
+	return &GroupMemberServiceClient{credentials: credentials, clientID: credentials.IAM.ClientID, accountID: credentials.IAM.AccountID, clientSecret: credentials.IAM.ClientSecret, tokenURL: credentials.IAM.TokenURL, endpointURL: credentials.IAM.EndpointURL}
+}
+
+func (me *GroupMemberServiceClient) SchemaID() string {
+	return "accounts:iam:groupmember"
+}
+
+func (me *GroupMemberServiceClient) Create(ctx context.Context, v *groupmember.GroupMember) (*api.Stub, error) {
+	if err := iam.AddGroupMembers(ctx, me, v.GroupID, []string{v.Email}); err != nil {
+		return nil, err
+	}
+	id := joinID(v.GroupID, v.Email)
+	return &api.Stub{ID: id, Name: v.Email}, nil
+}
+
+func (me *GroupMemberServiceClient) Get(ctx context.Context, id string, v *groupmember.GroupMember) error {
+	groupID, email, err := splitID(id)
+	if err != nil {
+		return err
+	}
+	members, err := iam.GroupMembers(ctx, me, groupID)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		if strings.EqualFold(member, email) {
+			v.GroupID = groupID
+			v.Email = member
+			return nil
+		}
+	}
+	return rest.Error{Code: 404, Message: fmt.Sprintf("User %s is not a member of group %s", email, groupID)}
+}
+
+// Update is never getting called, because changing either group or email results in a new resource
+func (me *GroupMemberServiceClient) Update(ctx context.Context, id string, v *groupmember.GroupMember) error {
+	return iam.AddGroupMembers(ctx, me, v.GroupID, []string{v.Email})
+}
+
+// List returns a stub for every user in every group
+func (me *GroupMemberServiceClient) List(ctx context.Context) (api.Stubs, error) {
+	groupStubs, err := groups.Service(me.credentials).List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var stubs api.Stubs
+	for _, groupStub := range groupStubs {
+		members, err := iam.GroupMembers(ctx, me, groupStub.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			stubs = append(stubs, &api.Stub{ID: joinID(groupStub.ID, member), Name: groupStub.Name + " " + member})
+		}
+	}
+	return stubs, nil
+}
+
+// Delete removes the user from the group. Neither the user nor the group are getting deleted
+func (me *GroupMemberServiceClient) Delete(ctx context.Context, id string) error {
+	groupID, email, err := splitID(id)
+	if err != nil {
+		return err
+	}
+	return iam.RemoveGroupMember(ctx, me, groupID, email)
+}
+
+func splitID(id string) (groupID string, email string, err error) {
+	parts := strings.Split(id, "#-#")
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("%s is not a valid ID for a group membership", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func joinID(groupID string, email string) string {
+	return fmt.Sprintf("%s#-#%s", groupID, email)
+}
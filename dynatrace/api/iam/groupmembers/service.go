@@ -0,0 +1,152 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package groupmembers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam"
+	groupmembers "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/groupmembers/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/groups"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+type GroupMembersServiceClient struct {
+	credentials  *settings.Credentials
+	clientID     string
+	accountID    string
+	clientSecret string
+	tokenURL     string
+	endpointURL  string
+}
+
+func (me *GroupMembersServiceClient) ClientID() string {
+	return me.clientID
+}
+
+func (me *GroupMembersServiceClient) AccountID() string {
+	return me.accountID
+}
+
+func (me *GroupMembersServiceClient) ClientSecret() string {
+	return me.clientSecret
+}
+
+func (me *GroupMembersServiceClient) TokenURL() string {
+	return me.tokenURL
+}
+
+func (me *GroupMembersServiceClient) EndpointURL() string {
+	return me.endpointURL
+}
+
+func Service(credentials *settings.Credentials) settings.CRUDService[*groupmembers.GroupMembers] {
+	return &GroupMembersServiceClient{credentials: credentials, clientID: credentials.IAM.ClientID, accountID: credentials.IAM.AccountID, clientSecret: credentials.IAM.ClientSecret, tokenURL: credentials.IAM.TokenURL, endpointURL: credentials.IAM.EndpointURL}
+}
+
+func (me *GroupMembersServiceClient) SchemaID() string {
+	return "accounts:iam:groupmembers"
+}
+
+func (me *GroupMembersServiceClient) Create(ctx context.Context, v *groupmembers.GroupMembers) (*api.Stub, error) {
+	if err := me.Update(ctx, v.GroupID, v); err != nil {
+		return nil, err
+	}
+	return &api.Stub{ID: v.GroupID, Name: v.GroupID}, nil
+}
+
+func (me *GroupMembersServiceClient) Get(ctx context.Context, groupID string, v *groupmembers.GroupMembers) error {
+	members, err := iam.GroupMembers(ctx, me, groupID)
+	if err != nil {
+		return err
+	}
+	v.GroupID = groupID
+	v.Members = members
+	if settings.ExportRunning {
+		// the name of the group results in more readable names of exported resources
+		stubs, err := groups.Service(me.credentials).List(ctx)
+		if err != nil {
+			return err
+		}
+		for _, stub := range stubs {
+			if stub.ID == groupID {
+				v.GroupName = stub.Name
+			}
+		}
+	}
+	return nil
+}
+
+// Update adds the users missing in the group and removes the ones not configured
+func (me *GroupMembersServiceClient) Update(ctx context.Context, groupID string, v *groupmembers.GroupMembers) error {
+	current, err := iam.GroupMembers(ctx, me, groupID)
+	if err != nil {
+		return err
+	}
+	additions := []string{}
+	for _, member := range v.Members {
+		if !contains(current, member) {
+			additions = append(additions, member)
+		}
+	}
+	if err := iam.AddGroupMembers(ctx, me, groupID, additions); err != nil {
+		return err
+	}
+	for _, member := range current {
+		if !contains(v.Members, member) {
+			if err := iam.RemoveGroupMember(ctx, me, groupID, member); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// List returns a stub for every group. Groups without members are included, because an empty membership is a valid configuration as well
+func (me *GroupMembersServiceClient) List(ctx context.Context) (api.Stubs, error) {
+	return groups.Service(me.credentials).List(ctx)
+}
+
+// Delete removes all users from the group. Neither the users nor the group are getting deleted
+func (me *GroupMembersServiceClient) Delete(ctx context.Context, groupID string) error {
+	current, err := iam.GroupMembers(ctx, me, groupID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil
+		}
+		return err
+	}
+	for _, member := range current {
+		if err := iam.RemoveGroupMember(ctx, me, groupID, member); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// contains compares email addresses case insensitive
+func contains(emails []string, email string) bool {
+	for _, candidate := range emails {
+		if strings.EqualFold(candidate, email) {
+			return true
+		}
+	}
+	return false
+}
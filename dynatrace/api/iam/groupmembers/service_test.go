@@ -0,0 +1,163 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package groupmembers_test
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/groupmember"
+	groupmembersettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/groupmember/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/groupmembers"
+	groupmemberssettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/groupmembers/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/groups"
+	groupsettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/groups/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/users"
+	usersettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/users/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+)
+
+// setup creates a group and users without any group memberships
+func setup(t *testing.T, emails ...string) (*settings.Credentials, string) {
+	t.Helper()
+	limits := rest.GetRateLimits()
+	t.Cleanup(func() { rest.SetRateLimits(limits) })
+	unlimited := rest.GetRateLimits()
+	delete(unlimited.Families, rest.RateLimitFamilyIAM)
+	rest.SetRateLimits(unlimited)
+
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+	credentials := server.Credentials()
+
+	ctx := context.Background()
+	stub, err := groups.Service(credentials).Create(ctx, &groupsettings.Group{Name: "Developers"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, email := range emails {
+		if _, err := users.Service(credentials).Create(ctx, &usersettings.User{Email: email}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return credentials, stub.ID
+}
+
+func members(t *testing.T, credentials *settings.Credentials, groupID string) string {
+	t.Helper()
+	var v groupmemberssettings.GroupMembers
+	if err := groupmembers.Service(credentials).Get(context.Background(), groupID, &v); err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(v.Members)
+	return strings.Join(v.Members, ",")
+}
+
+func TestGroupMembers(t *testing.T) {
+	credentials, groupID := setup(t, "a@example.com", "b@example.com", "c@example.com")
+	ctx := context.Background()
+	service := groupmembers.Service(credentials)
+
+	stub, err := service.Create(ctx, &groupmemberssettings.GroupMembers{GroupID: groupID, Members: []string{"a@example.com", "B@example.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stub.ID != groupID {
+		t.Errorf("expected the ID of the group, got %s", stub.ID)
+	}
+	if actual := members(t, credentials, groupID); actual != "a@example.com,b@example.com" {
+		t.Errorf("unexpected members %s", actual)
+	}
+
+	// users with `ignore_groups` don't touch memberships
+	if err := users.Service(credentials).Update(ctx, "a@example.com", &usersettings.User{Email: "a@example.com", IgnoreGroups: true}); err != nil {
+		t.Fatal(err)
+	}
+	// a member added from the user side is getting removed on the next update
+	if err := users.Service(credentials).Update(ctx, "c@example.com", &usersettings.User{Email: "c@example.com", Groups: []string{groupID}}); err != nil {
+		t.Fatal(err)
+	}
+	if actual := members(t, credentials, groupID); actual != "a@example.com,b@example.com,c@example.com" {
+		t.Errorf("unexpected members %s", actual)
+	}
+	if err := service.Update(ctx, groupID, &groupmemberssettings.GroupMembers{GroupID: groupID, Members: []string{"b@example.com"}}); err != nil {
+		t.Fatal(err)
+	}
+	if actual := members(t, credentials, groupID); actual != "b@example.com" {
+		t.Errorf("unexpected members %s", actual)
+	}
+
+	// deleting removes the memberships, but keeps users and group
+	if err := service.Delete(ctx, groupID); err != nil {
+		t.Fatal(err)
+	}
+	if actual := members(t, credentials, groupID); actual != "" {
+		t.Errorf("expected no members, got %s", actual)
+	}
+	var user usersettings.User
+	if err := users.Service(credentials).Get(ctx, "b@example.com", &user); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGroupMember(t *testing.T) {
+	credentials, groupID := setup(t, "a@example.com", "b@example.com")
+	ctx := context.Background()
+	service := groupmember.Service(credentials)
+
+	stub, err := service.Create(ctx, &groupmembersettings.GroupMember{GroupID: groupID, Email: "a@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// memberships of other users remain untouched
+	if _, err := service.Create(ctx, &groupmembersettings.GroupMember{GroupID: groupID, Email: "b@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	var v groupmembersettings.GroupMember
+	if err := service.Get(ctx, stub.ID, &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.GroupID != groupID || v.Email != "a@example.com" {
+		t.Errorf("unexpected membership %+v", v)
+	}
+	stubs, err := service.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stubs) != 2 {
+		t.Errorf("expected 2 memberships, got %d", len(stubs))
+	}
+
+	if err := service.Delete(ctx, stub.ID); err != nil {
+		t.Fatal(err)
+	}
+	err = service.Get(ctx, stub.ID, &v)
+	if restErr, ok := err.(rest.Error); !ok || restErr.Code != 404 {
+		t.Errorf("expected the membership to be gone, got %v", err)
+	}
+	if actual := members(t, credentials, groupID); actual != "b@example.com" {
+		t.Errorf("unexpected members %s", actual)
+	}
+}
@@ -0,0 +1,68 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package groupmembers
+
+import (
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type GroupMembers struct {
+	GroupID   string   `json:"-"`
+	Members   []string `json:"-"`
+	GroupName string   `json:"-"` // only known during export
+}
+
+func (me *GroupMembers) Name() string {
+	if len(me.GroupName) > 0 {
+		return me.GroupName
+	}
+	return me.GroupID
+}
+
+func (me *GroupMembers) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"group": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "The UUID of the group",
+		},
+		"members": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "The email addresses of the users belonging to the group. Users not listed here are getting removed from the group. Omitting this attribute results in a group without members",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+func (me *GroupMembers) MarshalHCL(properties hcl.Properties) error {
+	return properties.EncodeAll(map[string]any{
+		"group":   me.GroupID,
+		"members": me.Members,
+	})
+}
+
+func (me *GroupMembers) UnmarshalHCL(decoder hcl.Decoder) error {
+	return decoder.DecodeAll(map[string]any{
+		"group":   &me.GroupID,
+		"members": &me.Members,
+	})
+}
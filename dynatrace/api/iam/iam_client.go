@@ -8,13 +8,9 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"math"
 	"net/http"
 	"os"
-	"strconv"
 	"strings"
-	"sync"
-	"time"
 
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
 	"github.com/google/uuid"
@@ -88,74 +84,26 @@ func (me *iamClient) DELETE_MULTI_RESPONSE(ctx context.Context, url string, expe
 	return me.request(ctx, url, http.MethodDelete, expectedResponseCodes, forceNewBearer, 0, nil, nil)
 }
 
-type RateLimiter struct {
-	lastCall time.Time
-	mutex    sync.Mutex
-}
-
-func NewRateLimiter() *RateLimiter {
-	return &RateLimiter{}
-}
-
-var DISABLE_RATE_LIMITER = (os.Getenv("DYNATRACE_DISABLE_IAM_RATE_LIMITER") == "true")
-
-const MAX_RATE_LIMITER_RATE = int64(5000)
-const DEFAULT_RATE_LIMITER_RATE = int64(1000)
-
-var rateLimiterRate = evalRateLimiterRate()
-
-func evalRateLimiterRate() int64 {
-	sRateLimiterRate := strings.TrimSpace(os.Getenv("DYNATRACE_IAM_RATE_LIMITER_RATE"))
-	if len(sRateLimiterRate) == 0 {
-		return DEFAULT_RATE_LIMITER_RATE
-	}
-	var err error
-	iRateLimiterRate := DEFAULT_RATE_LIMITER_RATE
+// httpClient subjects requests to the rate limits of the API family `iam` (see `rest.RateLimitFamilyIAM`),
+// which are configured via the provider block `http_rate_limit` or `DYNATRACE_IAM_RATE_LIMITER_RATE`
+var httpClient = &http.Client{Transport: rest.NewRateLimitedTransport(nil)}
 
-	if iRateLimiterRate, err = strconv.ParseInt(sRateLimiterRate, 10, 0); err != nil {
-		return DEFAULT_RATE_LIMITER_RATE
-	}
-	if iRateLimiterRate > MAX_RATE_LIMITER_RATE {
-		return MAX_RATE_LIMITER_RATE
-	}
-	return iRateLimiterRate
+// retryPolicy returns the configured retry policy. The Account Management API occasionally
+// responds with a 504 without having processed the request. Hence also POST requests are
+// retried on it, no matter whether non idempotent requests are configured to get retried
+func retryPolicy() rest.RetryPolicy {
+	policy := rest.GetRetryPolicy()
+	policy.NonIdempotentStatusCodes = append(append([]int{}, policy.NonIdempotentStatusCodes...), http.StatusGatewayTimeout)
+	return policy
 }
 
-func (rl *RateLimiter) CanCall() bool {
-	rl.mutex.Lock()
-	defer rl.mutex.Unlock()
-	if rateLimiterRate <= 0 || DISABLE_RATE_LIMITER {
-		return true
-	}
-	now := time.Now()
-	if now.Sub(rl.lastCall) >= time.Duration(rateLimiterRate)*time.Millisecond {
-		rl.lastCall = now
-		return true
-	}
-	return false
-}
-
-var limiter = NewRateLimiter()
-
 func (me *iamClient) request(ctx context.Context, url string, method string, expectedResponseCodes []int, forceNewBearer bool, forceNewBearerRetryCount int, payload any, headers map[string]string) ([]byte, error) {
-	for {
-		if limiter.CanCall() {
-			return me._request(ctx, url, method, expectedResponseCodes, forceNewBearer, forceNewBearerRetryCount, payload, headers)
-		}
-		time.Sleep(100 * time.Millisecond)
-	}
-}
-
-func (me *iamClient) _request(ctx context.Context, url string, method string, expectedResponseCodes []int, forceNewBearer bool, forceNewBearerRetryCount int, payload any, headers map[string]string) ([]byte, error) {
 	// httplog(fmt.Sprintf("[%s] %s", method, url))
 
 	id := uuid.NewString()
-	num504Retries := 0
-	sleepTime429 := int64(500)
 
 	for {
 		var err error
-		var httpRequest *http.Request
 		var httpResponse *http.Response
 		var responseBytes []byte
 		var requestBody []byte
@@ -169,43 +117,39 @@ func (me *iamClient) _request(ctx context.Context, url string, method string, ex
 			rest.Logger.Printf(ctx, "[%s] [PAYLOAD] %s", id, string(requestBody))
 		}
 
-		var body io.Reader
-
-		if payload != nil {
-			body = bytes.NewReader(requestBody)
-		}
+		// transient failures (e.g. a 504 from api.dynatrace.com) are retried according to the configured policy
+		httpResponse, err = retryPolicy().Retry(ctx, method, url, func(ctx context.Context) (*http.Response, error) {
+			var body io.Reader
+			if payload != nil {
+				body = bytes.NewReader(requestBody)
+			}
 
-		if httpRequest, err = http.NewRequest(method, url, body); err != nil {
-			return nil, err
-		}
+			httpRequest, err := http.NewRequestWithContext(ctx, method, url, body)
+			if err != nil {
+				return nil, err
+			}
 
-		if err = me.authenticate(ctx, httpRequest, forceNewBearer); err != nil {
-			return nil, err
-		}
+			if err = me.authenticate(ctx, httpRequest, forceNewBearer); err != nil {
+				return nil, err
+			}
 
-		for k, v := range headers {
-			httpRequest.Header.Add(k, v)
-		}
+			for k, v := range headers {
+				httpRequest.Header.Add(k, v)
+			}
 
-		if httpResponse, err = http.DefaultClient.Do(httpRequest); err != nil {
+			return httpClient.Do(httpRequest)
+		})
+		if err != nil {
 			return nil, err
 		}
 
-		if responseBytes, err = io.ReadAll(httpResponse.Body); err != nil {
+		responseBytes, err = io.ReadAll(httpResponse.Body)
+		httpResponse.Body.Close()
+		if err != nil {
 			return nil, err
 		}
 		rest.Logger.Printf(ctx, "[%s] [RESPONSE] %d %s", id, httpResponse.StatusCode, string(responseBytes))
 
-		if httpResponse.StatusCode == 504 {
-			// httplog("-------------------- FIVE-O-FOUR --------------------")
-			num504Retries++
-			if num504Retries > 5 {
-				return nil, fmt.Errorf("response code %d (expected: %d)", 504, expectedResponseCodes)
-			}
-			time.Sleep(time.Second)
-			continue
-		}
-
 		isNotExpectedResponseCode := true
 		for _, erc := range expectedResponseCodes {
 			if httpResponse.StatusCode == erc {
@@ -216,9 +160,7 @@ func (me *iamClient) _request(ctx context.Context, url string, method string, ex
 
 		if isNotExpectedResponseCode {
 			if httpResponse.StatusCode == 429 {
-				time.Sleep(time.Duration(sleepTime429) * time.Millisecond)
-				// logging.File.Println(".... 429 ... waiting for another", sleepTime429, "milliseconds")
-				sleepTime429 = int64(math.Round(float64(sleepTime429) * float64(1.6)))
+				// the rate limiter holds back the next attempt until the limit resets
 				continue
 			}
 			var iamErr IAMError
@@ -0,0 +1,139 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package iam_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+)
+
+type testAuthenticator struct {
+	url string
+}
+
+func (me *testAuthenticator) ClientID() string     { return "client-id" }
+func (me *testAuthenticator) AccountID() string    { return "account-id" }
+func (me *testAuthenticator) ClientSecret() string { return "client-secret" }
+func (me *testAuthenticator) TokenURL() string     { return me.url + "/sso/oauth2/token" }
+func (me *testAuthenticator) EndpointURL() string  { return me.url }
+
+// iamServer responds to the first `failures` requests against the IAM API with the given status code
+func iamServer(t *testing.T, failures int32, status int, header http.Header) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/oauth2/token") {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token":"bearer"}`))
+			return
+		}
+		if calls.Add(1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"uuid":"1234"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, calls
+}
+
+func withTestPolicies(t *testing.T) {
+	t.Helper()
+	retryPolicy := rest.GetRetryPolicy()
+	rateLimits := rest.GetRateLimits()
+	t.Cleanup(func() {
+		rest.SetRetryPolicy(retryPolicy)
+		rest.SetRateLimits(rateLimits)
+	})
+	rest.SetRetryPolicy(rest.RetryPolicy{
+		MaxAttempts:  3,
+		MinBackoff:   time.Millisecond,
+		MaxBackoff:   4 * time.Millisecond,
+		StatusCodes:  rest.DefaultRetryStatusCodes,
+		ErrorClasses: rest.ErrorClasses,
+	})
+	unlimited := rest.GetRateLimits()
+	delete(unlimited.Families, rest.RateLimitFamilyIAM)
+	rest.SetRateLimits(unlimited)
+}
+
+func TestIAMClientRetriesPostOn504(t *testing.T) {
+	withTestPolicies(t)
+	server, calls := iamServer(t, 2, http.StatusGatewayTimeout, nil)
+
+	client := iam.NewIAMClient(&testAuthenticator{url: server.URL})
+	data, err := client.POST(context.Background(), server.URL+"/iam/v1/accounts/account-id/groups", map[string]string{"name": "group"}, 201, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"uuid":"1234"}` {
+		t.Errorf("unexpected response %s", string(data))
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestIAMClientDoesNotRetryPostOn502(t *testing.T) {
+	withTestPolicies(t)
+	server, calls := iamServer(t, 1, http.StatusBadGateway, nil)
+
+	client := iam.NewIAMClient(&testAuthenticator{url: server.URL})
+	if _, err := client.POST(context.Background(), server.URL+"/iam/v1/accounts/account-id/groups", map[string]string{"name": "group"}, 201, false); err == nil {
+		t.Error("expected the request to fail")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 call, got %d", calls.Load())
+	}
+}
+
+func TestIAMClientResendsOn429(t *testing.T) {
+	withTestPolicies(t)
+	server, calls := iamServer(t, 1, http.StatusTooManyRequests, http.Header{"X-Ratelimit-Reset": []string{strconv.FormatInt(time.Now().UnixMicro(), 10)}})
+
+	client := iam.NewIAMClient(&testAuthenticator{url: server.URL})
+	start := time.Now()
+	data, err := client.POST(context.Background(), server.URL+"/iam/v1/accounts/account-id/groups", map[string]string{"name": "group"}, 201, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"uuid":"1234"}` {
+		t.Errorf("unexpected response %s", string(data))
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 calls, got %d", calls.Load())
+	}
+	// the rate limiter holds back the second attempt
+	if elapsed := time.Since(start); elapsed < rest.MinWaitTime {
+		t.Errorf("expected the request to be resent after at least %s, took %s", rest.MinWaitTime, elapsed)
+	}
+}
@@ -3,6 +3,8 @@ package policies
 import (
 	"strings"
 
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/policies/statements"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -38,9 +40,11 @@ func (me *Policy) Schema() map[string]*schema.Schema {
 			Elem:        &schema.Schema{Type: schema.TypeString},
 		},
 		"statement_query": {
-			Type:        schema.TypeString,
-			Required:    true,
-			Description: "The Statement Query of the policy",
+			Type:             schema.TypeString,
+			Required:         true,
+			Description:      "The Statement Query of the policy. Differences in whitespace and the case of keywords are ignored",
+			ValidateDiagFunc: statements.ValidateQuery,
+			DiffSuppressFunc: statements.SuppressEquivalent,
 		},
 		"account": {
 			Type:          schema.TypeString,
@@ -68,10 +72,15 @@ func (me *Policy) Schema() map[string]*schema.Schema {
 }
 
 func (me *Policy) MarshalHCL(properties hcl.Properties) error {
+	statementQuery := me.StatementQuery
+	if settings.ExportRunning {
+		// exported policies contain one statement per line
+		statementQuery = statements.Format(statementQuery)
+	}
 	return properties.EncodeAll(map[string]any{
 		"name":            me.Name,
 		"description":     me.Description,
-		"statement_query": me.StatementQuery,
+		"statement_query": statementQuery,
 		"account":         me.Account,
 		"environment":     me.Environment,
 		"tags":            me.Tags,
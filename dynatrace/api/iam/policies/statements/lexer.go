@@ -0,0 +1,204 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package statements
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenEquals
+	tokenNotEquals
+	tokenComma
+	tokenSemicolon
+	tokenLParen
+	tokenRParen
+	tokenLess
+	tokenLessEquals
+	tokenGreater
+	tokenGreaterEquals
+	tokenComment
+)
+
+func (me tokenKind) String() string {
+	switch me {
+	case tokenEOF:
+		return "end of query"
+	case tokenIdent:
+		return "identifier"
+	case tokenString:
+		return "string"
+	case tokenEquals:
+		return "`=`"
+	case tokenNotEquals:
+		return "`!=`"
+	case tokenComma:
+		return "`,`"
+	case tokenSemicolon:
+		return "`;`"
+	case tokenLParen:
+		return "`(`"
+	case tokenRParen:
+		return "`)`"
+	case tokenLess:
+		return "`<`"
+	case tokenLessEquals:
+		return "`<=`"
+	case tokenGreater:
+		return "`>`"
+	case tokenGreaterEquals:
+		return "`>=`"
+	case tokenComment:
+		return "comment"
+	}
+	return "unknown token"
+}
+
+// Position is a 1-based location within a statement query
+type Position struct {
+	Line   int
+	Column int
+}
+
+func (me Position) String() string {
+	return fmt.Sprintf("line %d, column %d", me.Line, me.Column)
+}
+
+type token struct {
+	Kind tokenKind
+	// Text is the text of the token as it appears in the query. String literals include their quotes
+	Text string
+	Pos  Position
+}
+
+// keyword returns the upper case text of identifiers that could be a keyword
+func (me token) keyword() string {
+	if me.Kind != tokenIdent || strings.Contains(me.Text, ":") {
+		return ""
+	}
+	return strings.ToUpper(me.Text)
+}
+
+func (me token) describe() string {
+	switch me.Kind {
+	case tokenIdent, tokenString:
+		return fmt.Sprintf("`%s`", me.Text)
+	}
+	return me.Kind.String()
+}
+
+// SyntaxError reports where parsing a statement query has failed
+type SyntaxError struct {
+	Pos     Position
+	Message string
+	// Unsupported is set if parsing has failed on a keyword, operator or character unknown to the parser.
+	// The Dynatrace API may support it nevertheless
+	Unsupported bool
+}
+
+func (me *SyntaxError) Error() string {
+	return fmt.Sprintf("%s: %s", me.Pos, me.Message)
+}
+
+func isIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune(":-_.*", r)
+}
+
+// tokenize splits a statement query into tokens. Whitespace is insignificant, comments start with `//` and end with the line
+func tokenize(query string) ([]token, error) {
+	tokens := []token{}
+	runes := []rune(query)
+	pos := Position{Line: 1, Column: 1}
+	advance := func(i int) {
+		if runes[i] == '\n' {
+			pos.Line++
+			pos.Column = 1
+		} else {
+			pos.Column++
+		}
+	}
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := pos
+		switch {
+		case unicode.IsSpace(r):
+			advance(i)
+			i++
+		case r == '"':
+			j := i + 1
+			advance(i)
+			closed := false
+			for j < len(runes) {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					advance(j)
+					j++
+				} else if runes[j] == '"' {
+					closed = true
+					break
+				} else if runes[j] == '\n' {
+					break
+				}
+				advance(j)
+				j++
+			}
+			if !closed {
+				return nil, &SyntaxError{Pos: start, Message: "unterminated string"}
+			}
+			advance(j)
+			tokens = append(tokens, token{Kind: tokenString, Text: string(runes[i : j+1]), Pos: start})
+			i = j + 1
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			j := i
+			for j < len(runes) && runes[j] != '\n' {
+				advance(j)
+				j++
+			}
+			tokens = append(tokens, token{Kind: tokenComment, Text: string(runes[i:j]), Pos: start})
+			i = j
+		case i+1 < len(runes) && runes[i+1] == '=' && strings.ContainsRune("!<>", r):
+			kind := map[rune]tokenKind{'!': tokenNotEquals, '<': tokenLessEquals, '>': tokenGreaterEquals}[r]
+			tokens = append(tokens, token{Kind: kind, Text: string(runes[i : i+2]), Pos: start})
+			advance(i)
+			advance(i + 1)
+			i += 2
+		case strings.ContainsRune("=,;()<>", r):
+			kind := map[rune]tokenKind{'=': tokenEquals, ',': tokenComma, ';': tokenSemicolon, '(': tokenLParen, ')': tokenRParen, '<': tokenLess, '>': tokenGreater}[r]
+			tokens = append(tokens, token{Kind: kind, Text: string(r), Pos: start})
+			advance(i)
+			i++
+		case isIdentRune(r):
+			j := i
+			for j < len(runes) && isIdentRune(runes[j]) {
+				advance(j)
+				j++
+			}
+			tokens = append(tokens, token{Kind: tokenIdent, Text: string(runes[i:j]), Pos: start})
+			i = j
+		default:
+			return nil, &SyntaxError{Pos: start, Message: fmt.Sprintf("unexpected character `%c`", r), Unsupported: true}
+		}
+	}
+	return append(tokens, token{Kind: tokenEOF, Pos: pos}), nil
+}
@@ -0,0 +1,92 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package statements
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+//go:embed catalog.json
+var catalogJSON []byte
+
+// Service lists the permissions and condition keys of a service known to the provider, without the service prefix
+type Service struct {
+	Permissions []string `json:"permissions"`
+	Conditions  []string `json:"conditions"`
+}
+
+// Catalog contains the services, permissions and condition keys policies can refer to.
+// It reflects the documented services at the time of the release of the provider and may therefore be incomplete
+var Catalog = func() map[string]*Service {
+	catalog := map[string]*Service{}
+	if err := json.Unmarshal(catalogJSON, &catalog); err != nil {
+		panic(err)
+	}
+	return catalog
+}()
+
+// Warning reports a reference to a service, permission or condition key not contained in the Catalog
+type Warning struct {
+	Pos     Position
+	Message string
+}
+
+func (me Warning) String() string {
+	return fmt.Sprintf("%s: %s", me.Pos, me.Message)
+}
+
+// Lint parses the query and reports services, permissions and condition keys unknown to the Catalog.
+// Syntax errors are returned as error
+func Lint(query string) ([]Warning, error) {
+	statements, err := Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	warnings := []Warning{}
+	for _, statement := range statements {
+		for _, permission := range statement.Permissions {
+			service, found := Catalog[permission.Service()]
+			if !found {
+				warnings = append(warnings, Warning{Pos: permission.Pos, Message: fmt.Sprintf("unknown service `%s` in permission `%s`", permission.Service(), permission.Name)})
+			} else if !contains(service.Permissions, strings.TrimPrefix(permission.Name, permission.Service()+":")) {
+				warnings = append(warnings, Warning{Pos: permission.Pos, Message: fmt.Sprintf("unknown permission `%s`", permission.Name)})
+			}
+		}
+		for _, condition := range statement.Conditions {
+			service, found := Catalog[condition.Service()]
+			if !found {
+				warnings = append(warnings, Warning{Pos: condition.Pos, Message: fmt.Sprintf("unknown service `%s` in condition key `%s`", condition.Service(), condition.Key)})
+			} else if !contains(service.Conditions, strings.TrimPrefix(condition.Key, condition.Service()+":")) {
+				warnings = append(warnings, Warning{Pos: condition.Pos, Message: fmt.Sprintf("unknown condition key `%s`", condition.Key)})
+			}
+		}
+	}
+	return warnings, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
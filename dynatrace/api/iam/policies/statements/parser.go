@@ -0,0 +1,320 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+// Package statements parses the statement queries of IAM policies, e.g.
+//
+//	ALLOW settings:objects:read, settings:schemas:read WHERE settings:schemaId = "builtin:alerting.profile";
+//
+// A query consists of statements, each of them terminated by `;`. Whitespace, comments and the case of keywords are insignificant
+package statements
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Statement is a single `ALLOW` or `DENY` statement of a policy
+type Statement struct {
+	Pos         Position
+	Effect      string
+	Permissions []Permission
+	Conditions  []Condition
+}
+
+// Permission is a permission granted or denied by a statement, e.g. `settings:objects:read`
+type Permission struct {
+	Pos  Position
+	Name string
+}
+
+// Service returns the service the permission belongs to, e.g. `settings` for `settings:objects:read`
+func (me Permission) Service() string {
+	return strings.SplitN(me.Name, ":", 2)[0]
+}
+
+// Condition restricts a statement, e.g. `settings:schemaId = "builtin:alerting.profile"`
+type Condition struct {
+	Pos      Position
+	Key      string
+	Operator string
+	// Values are the string literals including their quotes. Operators `IN`, `NOT IN`, `MATCH` and `NOT MATCH` accept multiple values
+	Values []string
+	// List is set if the values are enclosed in parentheses
+	List bool
+}
+
+// Service returns the service the condition key belongs to, e.g. `settings` for `settings:schemaId`
+func (me Condition) Service() string {
+	return strings.SplitN(me.Key, ":", 2)[0]
+}
+
+// operator describes whether a condition operator accepts a single value, a list of values in parentheses or both
+type operator struct {
+	single bool
+	list   bool
+}
+
+var operators = map[string]operator{
+	"=":              {single: true},
+	"!=":             {single: true},
+	"<":              {single: true},
+	"<=":             {single: true},
+	">":              {single: true},
+	">=":             {single: true},
+	"IN":             {list: true},
+	"NOT IN":         {list: true},
+	"STARTSWITH":     {single: true},
+	"NOT STARTSWITH": {single: true},
+	"MATCH":          {single: true, list: true},
+	"NOT MATCH":      {single: true, list: true},
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (me *parser) peek() token {
+	return me.tokens[me.pos]
+}
+
+func (me *parser) next() token {
+	t := me.tokens[me.pos]
+	if t.Kind != tokenEOF {
+		me.pos++
+	}
+	return t
+}
+
+func (me *parser) expect(kind tokenKind, what string) (token, error) {
+	t := me.next()
+	if t.Kind != kind {
+		return t, &SyntaxError{Pos: t.Pos, Message: fmt.Sprintf("expected %s, found %s", what, t.describe())}
+	}
+	return t, nil
+}
+
+// Parse parses a statement query. The first syntax error is reported as *SyntaxError
+func Parse(query string) ([]*Statement, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: withoutComments(tokens)}
+	statements := []*Statement{}
+	for p.peek().Kind != tokenEOF {
+		statement, err := p.statement()
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+	if len(statements) == 0 {
+		return nil, &SyntaxError{Pos: p.peek().Pos, Message: "expected at least one statement"}
+	}
+	return statements, nil
+}
+
+func withoutComments(tokens []token) []token {
+	result := []token{}
+	for _, t := range tokens {
+		if t.Kind != tokenComment {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+func (me *parser) statement() (*Statement, error) {
+	t := me.next()
+	effect := t.keyword()
+	if effect != "ALLOW" && effect != "DENY" {
+		return nil, &SyntaxError{Pos: t.Pos, Message: fmt.Sprintf("expected `ALLOW` or `DENY`, found %s", t.describe()), Unsupported: t.Kind == tokenIdent}
+	}
+	statement := &Statement{Pos: t.Pos, Effect: effect}
+	for {
+		permission, err := me.permission()
+		if err != nil {
+			return nil, err
+		}
+		statement.Permissions = append(statement.Permissions, permission)
+		if me.peek().Kind != tokenComma {
+			break
+		}
+		me.next()
+	}
+	if me.peek().keyword() == "WHERE" {
+		me.next()
+		for {
+			condition, err := me.condition()
+			if err != nil {
+				return nil, err
+			}
+			statement.Conditions = append(statement.Conditions, condition)
+			if me.peek().keyword() != "AND" {
+				break
+			}
+			me.next()
+		}
+	}
+	if t := me.next(); t.Kind != tokenSemicolon {
+		expected := "`,`, `WHERE` or `;`"
+		if len(statement.Conditions) > 0 {
+			expected = "`AND` or `;`"
+		}
+		return nil, &SyntaxError{Pos: t.Pos, Message: fmt.Sprintf("expected %s, found %s", expected, t.describe()), Unsupported: t.Kind == tokenIdent}
+	}
+	return statement, nil
+}
+
+func (me *parser) permission() (Permission, error) {
+	t, err := me.expect(tokenIdent, "a permission")
+	if err != nil {
+		return Permission{}, err
+	}
+	parts := strings.Split(t.Text, ":")
+	if len(parts) < 3 {
+		return Permission{}, &SyntaxError{Pos: t.Pos, Message: fmt.Sprintf("expected a permission of the form `<service>:<resource>:<action>`, found `%s`", t.Text)}
+	}
+	for _, part := range parts {
+		if len(part) == 0 {
+			return Permission{}, &SyntaxError{Pos: t.Pos, Message: fmt.Sprintf("expected a permission of the form `<service>:<resource>:<action>`, found `%s`", t.Text)}
+		}
+	}
+	return Permission{Pos: t.Pos, Name: t.Text}, nil
+}
+
+func (me *parser) condition() (Condition, error) {
+	t, err := me.expect(tokenIdent, "a condition key")
+	if err != nil {
+		return Condition{}, err
+	}
+	if parts := strings.SplitN(t.Text, ":", 2); len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return Condition{}, &SyntaxError{Pos: t.Pos, Message: fmt.Sprintf("expected a condition key of the form `<service>:<key>`, found `%s`", t.Text)}
+	}
+	condition := Condition{Pos: t.Pos, Key: t.Text}
+
+	op := me.next()
+	switch op.Kind {
+	case tokenEquals, tokenNotEquals, tokenLess, tokenLessEquals, tokenGreater, tokenGreaterEquals:
+		condition.Operator = op.Text
+	case tokenIdent:
+		condition.Operator = op.keyword()
+		if condition.Operator == "NOT" {
+			condition.Operator = "NOT " + me.next().keyword()
+		}
+	}
+	accepts, known := operators[condition.Operator]
+	if !known {
+		return Condition{}, &SyntaxError{Pos: op.Pos, Message: fmt.Sprintf("expected one of `=`, `!=`, `<`, `<=`, `>`, `>=`, `IN`, `NOT IN`, `STARTSWITH`, `NOT STARTSWITH`, `MATCH` or `NOT MATCH`, found %s", op.describe()), Unsupported: op.Kind == tokenIdent}
+	}
+	if !accepts.list || (accepts.single && me.peek().Kind != tokenLParen) {
+		value, err := me.expect(tokenString, "a string")
+		if err != nil {
+			return Condition{}, err
+		}
+		condition.Values = []string{value.Text}
+		return condition, nil
+	}
+	if _, err := me.expect(tokenLParen, "`(`"); err != nil {
+		return Condition{}, err
+	}
+	condition.List = true
+	for {
+		value, err := me.expect(tokenString, "a string")
+		if err != nil {
+			return Condition{}, err
+		}
+		condition.Values = append(condition.Values, value.Text)
+		t := me.next()
+		if t.Kind == tokenRParen {
+			return condition, nil
+		}
+		if t.Kind != tokenComma {
+			return Condition{}, &SyntaxError{Pos: t.Pos, Message: fmt.Sprintf("expected `,` or `)`, found %s", t.describe())}
+		}
+	}
+}
+
+// String formats the statement on a single line with normalized whitespace and upper case keywords
+func (me *Statement) String() string {
+	var sb strings.Builder
+	sb.WriteString(me.Effect)
+	for i, permission := range me.Permissions {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString(" ")
+		sb.WriteString(permission.Name)
+	}
+	for i, condition := range me.Conditions {
+		if i == 0 {
+			sb.WriteString(" WHERE ")
+		} else {
+			sb.WriteString(" AND ")
+		}
+		sb.WriteString(condition.Key)
+		sb.WriteString(" ")
+		sb.WriteString(condition.Operator)
+		sb.WriteString(" ")
+		if condition.List {
+			sb.WriteString("(" + strings.Join(condition.Values, ", ") + ")")
+		} else {
+			sb.WriteString(condition.Values[0])
+		}
+	}
+	sb.WriteString(";")
+	return sb.String()
+}
+
+// Format formats a statement query with one statement per line.
+// Queries containing syntax errors or comments are returned as they are
+func Format(query string) string {
+	statements, err := Parse(query)
+	if err != nil {
+		return query
+	}
+	if tokens, _ := tokenize(query); len(withoutComments(tokens)) != len(tokens) {
+		return query
+	}
+	lines := []string{}
+	for _, statement := range statements {
+		lines = append(lines, statement.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Equivalent returns true if both queries differ in whitespace and the case of keywords only
+func Equivalent(a string, b string) bool {
+	if strings.TrimSpace(a) == strings.TrimSpace(b) {
+		return true
+	}
+	sa, err := Parse(a)
+	if err != nil {
+		return false
+	}
+	sb, err := Parse(b)
+	if err != nil || len(sa) != len(sb) {
+		return false
+	}
+	for i := range sa {
+		if sa[i].String() != sb[i].String() {
+			return false
+		}
+	}
+	return true
+}
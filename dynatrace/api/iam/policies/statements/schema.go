@@ -0,0 +1,73 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package statements
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ValidateQuery reports syntax errors during `terraform plan`.
+// Services, permissions and condition keys unknown to the Catalog are reported as warnings.
+// Keywords, operators and characters unknown to the parser are reported as warnings too,
+// unless the environment variable `DYNATRACE_STRICT_POLICY_STATEMENTS` is set to `true`
+func ValidateQuery(i any, path cty.Path) diag.Diagnostics {
+	query, ok := i.(string)
+	if !ok {
+		return nil
+	}
+	warnings, err := Lint(query)
+	var syntaxErr *SyntaxError
+	if errors.As(err, &syntaxErr) && syntaxErr.Unsupported && strings.TrimSpace(os.Getenv("DYNATRACE_STRICT_POLICY_STATEMENTS")) != "true" {
+		return diag.Diagnostics{{
+			Severity:      diag.Warning,
+			Summary:       "Statement query contains unsupported constructs",
+			Detail:        fmt.Sprintf("%s. The query is getting sent as it is, but the Dynatrace API may reject it", err.Error()),
+			AttributePath: path,
+		}}
+	}
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid statement query",
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+	var diags diag.Diagnostics
+	for _, warning := range warnings {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "Statement query refers to unknown names",
+			Detail:        fmt.Sprintf("%s. The query is getting sent as it is, but the Dynatrace API may reject it", warning),
+			AttributePath: path,
+		})
+	}
+	return diags
+}
+
+// SuppressEquivalent suppresses differences in whitespace and the case of keywords
+func SuppressEquivalent(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	return Equivalent(oldValue, newValue)
+}
@@ -0,0 +1,151 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package statements_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/policies/statements"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestFormat(t *testing.T) {
+	query := `allow settings:objects:read,settings:schemas:read
+	  where settings:schemaId  =  "builtin:alerting.profile" and settings:schemaGroup IN ("group:a","group:\"b\"");
+DENY storage:logs:read WHERE storage:k8s.namespace.name not startswith "kube-";  ALLOW environment:roles:viewer;`
+	expected := `ALLOW settings:objects:read, settings:schemas:read WHERE settings:schemaId = "builtin:alerting.profile" AND settings:schemaGroup IN ("group:a", "group:\"b\"");
+DENY storage:logs:read WHERE storage:k8s.namespace.name NOT STARTSWITH "kube-";
+ALLOW environment:roles:viewer;`
+	if actual := statements.Format(query); actual != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, actual)
+	}
+	if !statements.Equivalent(query, expected) {
+		t.Error("expected the queries to be equivalent")
+	}
+	if statements.Equivalent(query, strings.Replace(expected, "kube-", "kube", 1)) {
+		t.Error("expected queries with different values not to be equivalent")
+	}
+}
+
+func TestExtendedSyntax(t *testing.T) {
+	query := `// read access to the logs of the team
+ALLOW storage:logs:read WHERE storage:dt.security_context MATCH ("team-*", "shared") AND storage:k8s.namespace.name not match "kube-*"; // no system namespaces
+deny storage:buckets:read where storage:bucket-name >= "a" AND storage:bucket-name < "m";`
+	parsed, err := statements.Parse(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual := []string{}
+	for _, statement := range parsed {
+		actual = append(actual, statement.String())
+	}
+	expected := []string{
+		`ALLOW storage:logs:read WHERE storage:dt.security_context MATCH ("team-*", "shared") AND storage:k8s.namespace.name NOT MATCH "kube-*";`,
+		`DENY storage:buckets:read WHERE storage:bucket-name >= "a" AND storage:bucket-name < "m";`,
+	}
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
+
+func TestFormatKeepsComments(t *testing.T) {
+	query := "// viewers\nALLOW environment:roles:viewer;"
+	if actual := statements.Format(query); actual != query {
+		t.Errorf("expected queries with comments to remain untouched, got\n%s", actual)
+	}
+	if !statements.Equivalent(query, "allow environment:roles:viewer;") {
+		t.Error("expected comments to be insignificant")
+	}
+}
+
+func TestSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{``, "line 1, column 1: expected at least one statement"},
+		{`ALLOW settings:objects:read`, "line 1, column 28: expected `,`, `WHERE` or `;`, found end of query"},
+		{`PERMIT settings:objects:read;`, "line 1, column 1: expected `ALLOW` or `DENY`, found `PERMIT`"},
+		{"ALLOW settings:objects:read;\nALLOW settings:read;", "line 2, column 7: expected a permission of the form `<service>:<resource>:<action>`, found `settings:read`"},
+		{"ALLOW settings:objects:read\n  WHERE settings:schemaId LIKE \"a\";", "line 2, column 27: expected one of `=`, `!=`, `<`, `<=`, `>`, `>=`, `IN`, `NOT IN`, `STARTSWITH`, `NOT STARTSWITH`, `MATCH` or `NOT MATCH`, found `LIKE`"},
+		{"ALLOW settings:objects:read WHERE settings:schemaId MATCH (\"a\";", "line 1, column 63: expected `,` or `)`, found `;`"},
+		{"// comment\nALLOW settings:objects:read // comment;", "line 2, column 40: expected `,`, `WHERE` or `;`, found end of query"},
+		{`ALLOW settings:objects:read WHERE settings:schemaId = "a" OR settings:schemaId = "b";`, "line 1, column 59: expected `AND` or `;`, found `OR`"},
+		{`ALLOW settings:objects:read WHERE settings:schemaId IN ("a" "b");`, "line 1, column 61: expected `,` or `)`, found `\"b\"`"},
+		{`ALLOW settings:objects:read WHERE settings:schemaId = "a;`, "line 1, column 55: unterminated string"},
+		{`ALLOW settings:objects:read WHERE schemaId = "a";`, "line 1, column 35: expected a condition key of the form `<service>:<key>`, found `schemaId`"},
+	}
+	for _, test := range tests {
+		_, err := statements.Parse(test.query)
+		if err == nil {
+			t.Errorf("%s: expected a syntax error", test.query)
+			continue
+		}
+		if _, ok := err.(*statements.SyntaxError); !ok || err.Error() != test.expected {
+			t.Errorf("%s:\nexpected %s\ngot      %s", test.query, test.expected, err.Error())
+		}
+		if statements.Format(test.query) != test.query {
+			t.Errorf("%s: expected invalid queries to remain untouched", test.query)
+		}
+	}
+}
+
+func TestLint(t *testing.T) {
+	warnings, err := statements.Lint(`ALLOW settings:objects:read, settings:objects:delete, unknown:things:read
+WHERE settings:schemaId = "builtin:alerting.profile" AND settings:owner = "me";`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual := []string{}
+	for _, warning := range warnings {
+		actual = append(actual, warning.String())
+	}
+	expected := []string{
+		"line 1, column 30: unknown permission `settings:objects:delete`",
+		"line 1, column 55: unknown service `unknown` in permission `unknown:things:read`",
+		"line 2, column 58: unknown condition key `settings:owner`",
+	}
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
+
+func TestValidateQuery(t *testing.T) {
+	path := cty.GetAttrPath("statement_query")
+	if diags := statements.ValidateQuery(`ALLOW settings:objects:read;`, path); len(diags) != 0 {
+		t.Errorf("expected no diagnostics, got %v", diags)
+	}
+	if diags := statements.ValidateQuery(`ALLOW settings:objects:read`, path); !diags.HasError() {
+		t.Errorf("expected an error, got %v", diags)
+	}
+	diags := statements.ValidateQuery(`ALLOW settings:objects:delete;`, path)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected a warning, got %v", diags)
+	}
+
+	unsupported := `ALLOW settings:objects:read WHERE settings:schemaId ENDSWITH "profile";`
+	if diags := statements.ValidateQuery(unsupported, path); len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected a warning for an unsupported operator, got %v", diags)
+	}
+	t.Setenv("DYNATRACE_STRICT_POLICY_STATEMENTS", "true")
+	if diags := statements.ValidateQuery(unsupported, path); !diags.HasError() {
+		t.Errorf("expected an error for an unsupported operator in strict mode, got %v", diags)
+	}
+}
@@ -68,12 +68,15 @@ func (me *UserServiceClient) Create(ctx context.Context, user *users.User) (*api
 		return nil, err
 	}
 
-	groups := []string{}
-	if len(user.Groups) > 0 {
-		groups = user.Groups
-	}
-	if _, err = client.PUT(ctx, fmt.Sprintf("%s/iam/v1/accounts/%s/users/%s/groups", me.endpointURL, strings.TrimPrefix(me.AccountID(), "urn:dtaccount:"), user.Email), groups, 200, false); err != nil {
-		return nil, err
+	// with `ignore_groups` the memberships are managed elsewhere
+	if !user.IgnoreGroups {
+		groups := []string{}
+		if len(user.Groups) > 0 {
+			groups = user.Groups
+		}
+		if _, err = client.PUT(ctx, fmt.Sprintf("%s/iam/v1/accounts/%s/users/%s/groups", me.endpointURL, strings.TrimPrefix(me.AccountID(), "urn:dtaccount:"), user.Email), groups, 200, false); err != nil {
+			return nil, err
+		}
 	}
 
 	return &api.Stub{ID: user.Email, Name: user.Email}, nil
@@ -112,6 +115,10 @@ func (me *UserServiceClient) Get(ctx context.Context, email string, v *users.Use
 	for _, group := range response.Groups {
 		v.Groups = append(v.Groups, group.UUID)
 	}
+	if known, ok := ctx.Value(settings.ContextKeyStateConfig).(*users.User); ok && known.IgnoreGroups {
+		v.Groups = nil
+		v.IgnoreGroups = true
+	}
 
 	return nil
 }
@@ -119,6 +126,10 @@ func (me *UserServiceClient) Get(ctx context.Context, email string, v *users.Use
 func (me *UserServiceClient) Update(ctx context.Context, email string, user *users.User) error {
 	var err error
 
+	// with `ignore_groups` the memberships are managed elsewhere
+	if user.IgnoreGroups {
+		return nil
+	}
 	groups := []string{}
 	if len(user.Groups) > 0 {
 		groups = user.Groups
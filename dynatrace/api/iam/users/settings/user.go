@@ -7,9 +7,10 @@ import (
 )
 
 type User struct {
-	Email  string   `json:"email"`
-	UID    string   `json:"uid"`
-	Groups []string `json:"-"`
+	Email        string   `json:"email"`
+	UID          string   `json:"uid"`
+	Groups       []string `json:"-"`
+	IgnoreGroups bool     `json:"-"`
 }
 
 func (me *User) Name() string {
@@ -23,10 +24,18 @@ func (me *User) Schema() map[string]*schema.Schema {
 			Required: true,
 		},
 		"groups": {
-			Type:     schema.TypeSet,
-			Optional: true,
-			MinItems: 1,
-			Elem:     &schema.Schema{Type: schema.TypeString},
+			Type:          schema.TypeSet,
+			Optional:      true,
+			MinItems:      1,
+			Description:   "The UUIDs of the groups the user belongs to. If omitted, the user doesn't belong to any group, unless `ignore_groups` is set",
+			Elem:          &schema.Schema{Type: schema.TypeString},
+			ConflictsWith: []string{"ignore_groups"},
+		},
+		"ignore_groups": {
+			Type:          schema.TypeBool,
+			Optional:      true,
+			Description:   "If `true`, the group memberships of the user are not managed by this resource, e.g. because they are managed by `dynatrace_iam_group_members` or `dynatrace_iam_group_member`. Otherwise these resources and this one would revert each other's changes",
+			ConflictsWith: []string{"groups"},
 		},
 		"uid": {
 			Type:     schema.TypeString,
@@ -37,16 +46,18 @@ func (me *User) Schema() map[string]*schema.Schema {
 
 func (me *User) MarshalHCL(properties hcl.Properties) error {
 	return properties.EncodeAll(map[string]any{
-		"email":  me.Email,
-		"groups": me.Groups,
-		"uid":    me.UID,
+		"email":         me.Email,
+		"groups":        me.Groups,
+		"ignore_groups": me.IgnoreGroups,
+		"uid":           me.UID,
 	})
 }
 
 func (me *User) UnmarshalHCL(decoder hcl.Decoder) error {
 	return decoder.DecodeAll(map[string]any{
-		"email":  &me.Email,
-		"groups": &me.Groups,
-		"uid":    &me.UID,
+		"email":         &me.Email,
+		"groups":        &me.Groups,
+		"ignore_groups": &me.IgnoreGroups,
+		"uid":           &me.UID,
 	})
 }
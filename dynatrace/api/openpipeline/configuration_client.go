@@ -0,0 +1,188 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package openpipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	openpipeline "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	coreapi "github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	caclib "github.com/dynatrace/dynatrace-configuration-as-code-core/clients/openpipeline"
+)
+
+// MaxModifyAttempts is the number of read-modify-write cycles attempted by `ConfigurationClient.Modify`
+// before giving up, in case the configuration keeps getting modified concurrently
+const MaxModifyAttempts = 5
+
+// kindLocks serializes modifications of the same kind within this process.
+// Resources applied in parallel by Terraform would otherwise constantly run into version conflicts
+var kindLocks = map[string]*sync.Mutex{}
+var kindLocksMutex sync.Mutex
+
+func kindLock(kind string) *sync.Mutex {
+	kindLocksMutex.Lock()
+	defer kindLocksMutex.Unlock()
+	if lock, found := kindLocks[kind]; found {
+		return lock
+	}
+	lock := new(sync.Mutex)
+	kindLocks[kind] = lock
+	return lock
+}
+
+// ConfigurationClient reads and modifies the configuration of a single OpenPipeline kind
+// (logs, events, ...) on behalf of resources that manage only parts of it
+type ConfigurationClient struct {
+	kind        string
+	credentials *settings.Credentials
+}
+
+func NewConfigurationClient(credentials *settings.Credentials, kind string) *ConfigurationClient {
+	return &ConfigurationClient{kind: kind, credentials: credentials}
+}
+
+func (me *ConfigurationClient) Kind() string {
+	return me.kind
+}
+
+func (me *ConfigurationClient) createClient() (*caclib.Client, error) {
+	return (&service{credentials: me.credentials, kind: me.kind}).createClient()
+}
+
+// Get fetches the current configuration. Configuration items provided by Dynatrace are removed
+func (me *ConfigurationClient) Get(ctx context.Context) (*openpipeline.Configuration, error) {
+	client, err := me.createClient()
+	if err != nil {
+		return nil, err
+	}
+	return me.get(ctx, client)
+}
+
+func (me *ConfigurationClient) get(ctx context.Context, client *caclib.Client) (*openpipeline.Configuration, error) {
+	response, err := client.Get(ctx, me.kind)
+	if err != nil {
+		return nil, me.apiError(err, http.MethodGet)
+	}
+
+	var config openpipeline.Configuration
+	if err := json.Unmarshal(response.Data, &config); err != nil {
+		return nil, err
+	}
+	config.RemoveFixed()
+	if config.Endpoints == nil {
+		config.Endpoints = &openpipeline.Endpoints{}
+	}
+	if config.Pipelines == nil {
+		config.Pipelines = &openpipeline.Pipelines{}
+	}
+	if config.Routing == nil {
+		config.Routing = &openpipeline.RoutingTable{}
+	}
+	return &config, nil
+}
+
+// Modify performs a read-modify-write cycle on the configuration.
+// In case somebody else has changed the configuration in the meantime, the cycle gets repeated based on
+// the latest version, which ensures that concurrent changes aren't getting overwritten.
+func (me *ConfigurationClient) Modify(ctx context.Context, modify func(config *openpipeline.Configuration) error) error {
+	lock := kindLock(me.kind)
+	lock.Lock()
+	defer lock.Unlock()
+
+	client, err := me.createClient()
+	if err != nil {
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		config, err := me.get(ctx, client)
+		if err != nil {
+			return err
+		}
+		if err := modify(config); err != nil {
+			return err
+		}
+		data, err := json.Marshal(config)
+		if err != nil {
+			return err
+		}
+		// the client sends the configuration with the version it reads right before updating,
+		// changes made since `config` has been read are therefore detected by comparing the versions
+		latest, err := me.get(ctx, client)
+		if err != nil {
+			return err
+		}
+		if latest.Version == config.Version {
+			if _, err = client.Update(ctx, me.kind, data); err == nil {
+				return nil
+			}
+			var apiErr coreapi.APIError
+			if !errors.As(err, &apiErr) {
+				return err
+			}
+			if !isVersionConflict(apiErr.StatusCode) || attempt >= MaxModifyAttempts {
+				return me.error(apiErr.StatusCode, apiErr.Body, http.MethodPut)
+			}
+		} else if attempt >= MaxModifyAttempts {
+			return me.error(http.StatusConflict, []byte(fmt.Sprintf("the configuration has been modified %d times in a row while applying changes", attempt)), http.MethodPut)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt*200+rand.Intn(300)) * time.Millisecond):
+		}
+	}
+}
+
+func isVersionConflict(statusCode int) bool {
+	return statusCode == http.StatusConflict || statusCode == http.StatusPreconditionFailed
+}
+
+func (me *ConfigurationClient) url() string {
+	return me.credentials.Automation.EnvironmentURL + "/platform/openpipeline/v1/configurations/" + me.kind
+}
+
+func (me *ConfigurationClient) error(statusCode int, data []byte, method string) error {
+	if err := rest.Envelope(data, me.url(), method); err != nil {
+		return err
+	}
+	return rest.Error{Code: statusCode, Method: method, URL: me.url(), Message: string(data)}
+}
+
+// apiError converts errors reported by the client for a non-successful response
+func (me *ConfigurationClient) apiError(err error, method string) error {
+	var apiErr coreapi.APIError
+	if errors.As(err, &apiErr) {
+		return me.error(apiErr.StatusCode, apiErr.Body, method)
+	}
+	return err
+}
+
+// NotFound produces the error a resource reports if the part of the configuration it manages doesn't exist
+func (me *ConfigurationClient) NotFound(what string, id string) error {
+	return rest.Error{Code: http.StatusNotFound, Method: http.MethodGet, URL: me.url(), Message: fmt.Sprintf("%s `%s` doesn't exist in the OpenPipeline configuration for %s", what, id, me.kind)}
+}
@@ -0,0 +1,116 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package openpipeline_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline"
+	openpipelinesettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+// configurationServer simulates the OpenPipeline configuration endpoint for `logs`.
+// The first `conflicts` updates are getting rejected as if somebody else has modified the configuration in the meantime
+func configurationServer(t *testing.T, conflicts int32) (*httptest.Server, *atomic.Int32, *[]string) {
+	updates := &atomic.Int32{}
+	versions := &[]string{}
+	version := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+		case r.URL.Path == "/platform/openpipeline/v1/configurations/logs" && r.Method == http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"logs","editable":true,"version":"` + strconv.Itoa(version) + `","endpoints":[],"pipelines":[],"routing":{"editable":true,"entries":[]}}`))
+		case r.URL.Path == "/platform/openpipeline/v1/configurations/logs" && r.Method == http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			var config openpipelinesettings.Configuration
+			if err := json.Unmarshal(data, &config); err != nil {
+				t.Errorf("invalid payload: %s", err.Error())
+			}
+			*versions = append(*versions, config.Version)
+			if updates.Add(1) <= conflicts {
+				// somebody else was faster
+				version++
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"error":{"code":409,"message":"version conflict"}}`))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server, updates, versions
+}
+
+func credentials(server *httptest.Server) *settings.Credentials {
+	credentials := &settings.Credentials{}
+	credentials.Automation.ClientID = "client-id"
+	credentials.Automation.ClientSecret = "client-secret"
+	credentials.Automation.TokenURL = server.URL + "/token"
+	credentials.Automation.EnvironmentURL = server.URL
+	return credentials
+}
+
+func TestConfigurationClientModifyRetriesOnConflict(t *testing.T) {
+	server, updates, versions := configurationServer(t, 2)
+	defer server.Close()
+
+	client := openpipeline.NewConfigurationClient(credentials(server), "logs")
+	err := client.Modify(context.Background(), func(config *openpipelinesettings.Configuration) error {
+		config.Routing.Entries = append(config.Routing.Entries, &openpipelinesettings.RoutingTableEntry{Enabled: true, Matcher: "true", Note: "all", PipelineId: "default"})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updates.Load() != 3 {
+		t.Errorf("expected 3 updates, got %d", updates.Load())
+	}
+	// every attempt is expected to be based on the latest version
+	expected := []string{"1", "2", "3"}
+	for idx, version := range *versions {
+		if version != expected[idx] {
+			t.Errorf("attempt %d: expected version %s, got %s", idx+1, expected[idx], version)
+		}
+	}
+}
+
+func TestConfigurationClientModifyGivesUp(t *testing.T) {
+	server, updates, _ := configurationServer(t, 100)
+	defer server.Close()
+
+	client := openpipeline.NewConfigurationClient(credentials(server), "logs")
+	if err := client.Modify(context.Background(), func(config *openpipelinesettings.Configuration) error { return nil }); err == nil {
+		t.Error("expected the modification to fail")
+	}
+	if updates.Load() != openpipeline.MaxModifyAttempts {
+		t.Errorf("expected %d updates, got %d", openpipeline.MaxModifyAttempts, updates.Load())
+	}
+}
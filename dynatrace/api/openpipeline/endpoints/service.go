@@ -0,0 +1,131 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package endpoints
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline"
+	endpoints "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/endpoints/settings"
+	openpipelinesettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+func LogsService(credentials *settings.Credentials) settings.CRUDService[*endpoints.Endpoint] {
+	return &service{client: openpipeline.NewConfigurationClient(credentials, "logs"), schemaSuffix: "logs"}
+}
+
+func EventsService(credentials *settings.Credentials) settings.CRUDService[*endpoints.Endpoint] {
+	return &service{client: openpipeline.NewConfigurationClient(credentials, "events"), schemaSuffix: "events"}
+}
+
+func BusinessEventsService(credentials *settings.Credentials) settings.CRUDService[*endpoints.Endpoint] {
+	return &service{client: openpipeline.NewConfigurationClient(credentials, "bizevents"), schemaSuffix: "events.business"}
+}
+
+func SecurityEventsService(credentials *settings.Credentials) settings.CRUDService[*endpoints.Endpoint] {
+	return &service{client: openpipeline.NewConfigurationClient(credentials, "events.security"), schemaSuffix: "events.security"}
+}
+
+func SDLCEventsService(credentials *settings.Credentials) settings.CRUDService[*endpoints.Endpoint] {
+	return &service{client: openpipeline.NewConfigurationClient(credentials, "events.sdlc"), schemaSuffix: "events.sdlc"}
+}
+
+// service manages single ingest sources within the OpenPipeline configuration of a kind
+type service struct {
+	client       *openpipeline.ConfigurationClient
+	schemaSuffix string
+}
+
+func (s *service) SchemaID() string {
+	return "platform:openpipeline." + s.schemaSuffix + ".endpoints"
+}
+
+func (s *service) List(ctx context.Context) (api.Stubs, error) {
+	config, err := s.client.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stubs := api.Stubs{}
+	for _, endpoint := range config.Endpoints.Endpoints {
+		v := &endpoints.Endpoint{EndpointDefinition: *endpoint}
+		stubs = append(stubs, &api.Stub{ID: v.Segment, Name: v.Name()})
+	}
+	return stubs, nil
+}
+
+func (s *service) Get(ctx context.Context, id string, v *endpoints.Endpoint) error {
+	config, err := s.client.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if idx := find(config, id); idx >= 0 {
+		v.EndpointDefinition = *config.Endpoints.Endpoints[idx]
+		return nil
+	}
+	return s.client.NotFound("ingest source", id)
+}
+
+func (s *service) Create(ctx context.Context, v *endpoints.Endpoint) (*api.Stub, error) {
+	if err := s.client.Modify(ctx, func(config *openpipelinesettings.Configuration) error {
+		if find(config, v.Segment) >= 0 {
+			return fmt.Errorf("an ingest source with segment `%s` already exists in the OpenPipeline configuration for %s", v.Segment, s.client.Kind())
+		}
+		endpoint := v.EndpointDefinition
+		config.Endpoints.Endpoints = append(config.Endpoints.Endpoints, &endpoint)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &api.Stub{ID: v.Segment, Name: v.Name()}, nil
+}
+
+func (s *service) Update(ctx context.Context, id string, v *endpoints.Endpoint) error {
+	return s.client.Modify(ctx, func(config *openpipelinesettings.Configuration) error {
+		idx := find(config, id)
+		if idx < 0 {
+			return s.client.NotFound("ingest source", id)
+		}
+		v.Segment = id
+		endpoint := v.EndpointDefinition
+		config.Endpoints.Endpoints[idx] = &endpoint
+		return nil
+	})
+}
+
+func (s *service) Delete(ctx context.Context, id string) error {
+	return s.client.Modify(ctx, func(config *openpipelinesettings.Configuration) error {
+		idx := find(config, id)
+		if idx < 0 {
+			return nil
+		}
+		config.Endpoints.Endpoints = append(config.Endpoints.Endpoints[:idx], config.Endpoints.Endpoints[idx+1:]...)
+		return nil
+	})
+}
+
+func find(config *openpipelinesettings.Configuration, segment string) int {
+	for idx, endpoint := range config.Endpoints.Endpoints {
+		if endpoint.Segment == segment {
+			return idx
+		}
+	}
+	return -1
+}
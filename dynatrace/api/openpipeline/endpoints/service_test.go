@@ -0,0 +1,96 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package endpoints_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/endpoints"
+	endpointsettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/endpoints/settings"
+	openpipeline "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+)
+
+func newEndpoint(segment string, displayName string, pipelineID string) *endpointsettings.Endpoint {
+	return &endpointsettings.Endpoint{EndpointDefinition: openpipeline.EndpointDefinition{
+		DisplayName: &displayName,
+		Enabled:     true,
+		Segment:     segment,
+		Routing:     &openpipeline.Routing{Type: openpipeline.StaticRoutingType, PipelineId: &pipelineID},
+		Processors:  &openpipeline.EndpointProcessors{},
+	}}
+}
+
+func TestEndpoints(t *testing.T) {
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	ctx := context.Background()
+	service := endpoints.LogsService(server.Credentials())
+
+	for _, v := range []*endpointsettings.Endpoint{newEndpoint("first", "First", "default"), newEndpoint("second", "Second", "default")} {
+		stub, err := service.Create(ctx, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stub.ID != v.Segment || stub.Name != *v.DisplayName {
+			t.Errorf("unexpected stub %s (%s)", stub.ID, stub.Name)
+		}
+	}
+	if _, err := service.Create(ctx, newEndpoint("first", "Duplicate", "default")); err == nil {
+		t.Error("expected creating an ingest source with an already existing segment to fail")
+	}
+
+	stubs, err := service.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stubs) != 2 {
+		t.Fatalf("expected 2 ingest sources, got %d", len(stubs))
+	}
+
+	if err := service.Update(ctx, "first", newEndpoint("first", "Renamed", "errors")); err != nil {
+		t.Fatal(err)
+	}
+	var first endpointsettings.Endpoint
+	if err := service.Get(ctx, "first", &first); err != nil {
+		t.Fatal(err)
+	}
+	if first.Name() != "Renamed" || first.Routing == nil || first.Routing.PipelineId == nil || *first.Routing.PipelineId != "errors" {
+		t.Errorf("expected the ingest source to get updated, got %s", first.Name())
+	}
+
+	if err := service.Delete(ctx, "first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Get(ctx, "first", &first); !rest.Is404(err) {
+		t.Errorf("expected the deleted ingest source to be gone, got %v", err)
+	}
+	var second endpointsettings.Endpoint
+	if err := service.Get(ctx, "second", &second); err != nil {
+		t.Fatal(err)
+	}
+	if second.Name() != "Second" {
+		t.Errorf("expected the other ingest source to stay untouched, got %s", second.Name())
+	}
+}
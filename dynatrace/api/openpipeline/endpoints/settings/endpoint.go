@@ -0,0 +1,53 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package endpoints
+
+import (
+	openpipeline "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Endpoint is a single ingest source of an OpenPipeline configuration.
+// Ingest sources are identified by their segment, which is unique within the configuration
+type Endpoint struct {
+	openpipeline.EndpointDefinition
+}
+
+func (e *Endpoint) Name() string {
+	if e.DisplayName != nil && len(*e.DisplayName) > 0 {
+		return *e.DisplayName
+	}
+	return e.Segment
+}
+
+func (e *Endpoint) Schema() map[string]*schema.Schema {
+	s := e.EndpointDefinition.Schema()
+	s["segment"].ForceNew = true
+	return s
+}
+
+func (e *Endpoint) UnmarshalHCL(decoder hcl.Decoder) error {
+	if err := e.EndpointDefinition.UnmarshalHCL(decoder); err != nil {
+		return err
+	}
+	if e.Processors == nil {
+		e.Processors = &openpipeline.EndpointProcessors{}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package pipelines
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline"
+	pipelines "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/pipelines/settings"
+	openpipelinesettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+func LogsService(credentials *settings.Credentials) settings.CRUDService[*pipelines.Pipeline] {
+	return &service{client: openpipeline.NewConfigurationClient(credentials, "logs"), schemaSuffix: "logs"}
+}
+
+func EventsService(credentials *settings.Credentials) settings.CRUDService[*pipelines.Pipeline] {
+	return &service{client: openpipeline.NewConfigurationClient(credentials, "events"), schemaSuffix: "events"}
+}
+
+func BusinessEventsService(credentials *settings.Credentials) settings.CRUDService[*pipelines.Pipeline] {
+	return &service{client: openpipeline.NewConfigurationClient(credentials, "bizevents"), schemaSuffix: "events.business"}
+}
+
+func SecurityEventsService(credentials *settings.Credentials) settings.CRUDService[*pipelines.Pipeline] {
+	return &service{client: openpipeline.NewConfigurationClient(credentials, "events.security"), schemaSuffix: "events.security"}
+}
+
+func SDLCEventsService(credentials *settings.Credentials) settings.CRUDService[*pipelines.Pipeline] {
+	return &service{client: openpipeline.NewConfigurationClient(credentials, "events.sdlc"), schemaSuffix: "events.sdlc"}
+}
+
+// service manages single pipelines within the OpenPipeline configuration of a kind.
+// Every modification is a read-modify-write of the whole configuration, leaving all
+// other pipelines, routing entries and ingest sources untouched
+type service struct {
+	client       *openpipeline.ConfigurationClient
+	schemaSuffix string
+}
+
+func (s *service) SchemaID() string {
+	return "platform:openpipeline." + s.schemaSuffix + ".pipelines"
+}
+
+func (s *service) List(ctx context.Context) (api.Stubs, error) {
+	config, err := s.client.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stubs := api.Stubs{}
+	for _, pipeline := range config.Pipelines.Pipelines {
+		// classic pipelines are getting managed by Dynatrace
+		if pipeline.Default() == nil {
+			continue
+		}
+		v := &pipelines.Pipeline{DefaultPipeline: *pipeline.Default()}
+		stubs = append(stubs, &api.Stub{ID: v.Id, Name: v.Name()})
+	}
+	return stubs, nil
+}
+
+func (s *service) Get(ctx context.Context, id string, v *pipelines.Pipeline) error {
+	config, err := s.client.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if idx := find(config, id); idx >= 0 {
+		v.DefaultPipeline = *config.Pipelines.Pipelines[idx].Default()
+		return nil
+	}
+	return s.client.NotFound("pipeline", id)
+}
+
+func (s *service) Create(ctx context.Context, v *pipelines.Pipeline) (*api.Stub, error) {
+	if err := s.client.Modify(ctx, func(config *openpipelinesettings.Configuration) error {
+		if find(config, v.Id) >= 0 {
+			return fmt.Errorf("a pipeline with id `%s` already exists in the OpenPipeline configuration for %s", v.Id, s.client.Kind())
+		}
+		config.Pipelines.Pipelines = append(config.Pipelines.Pipelines, openpipelinesettings.NewPipeline(&v.DefaultPipeline))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &api.Stub{ID: v.Id, Name: v.Name()}, nil
+}
+
+func (s *service) Update(ctx context.Context, id string, v *pipelines.Pipeline) error {
+	return s.client.Modify(ctx, func(config *openpipelinesettings.Configuration) error {
+		idx := find(config, id)
+		if idx < 0 {
+			return s.client.NotFound("pipeline", id)
+		}
+		v.Id = id
+		config.Pipelines.Pipelines[idx] = openpipelinesettings.NewPipeline(&v.DefaultPipeline)
+		return nil
+	})
+}
+
+func (s *service) Delete(ctx context.Context, id string) error {
+	return s.client.Modify(ctx, func(config *openpipelinesettings.Configuration) error {
+		idx := find(config, id)
+		if idx < 0 {
+			return nil
+		}
+		config.Pipelines.Pipelines = append(config.Pipelines.Pipelines[:idx], config.Pipelines.Pipelines[idx+1:]...)
+		return nil
+	})
+}
+
+func find(config *openpipelinesettings.Configuration, id string) int {
+	for idx, pipeline := range config.Pipelines.Pipelines {
+		if pipeline.Default() != nil && pipeline.ID() == id {
+			return idx
+		}
+	}
+	return -1
+}
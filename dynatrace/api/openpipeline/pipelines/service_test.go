@@ -0,0 +1,99 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package pipelines_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/pipelines"
+	pipelinesettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/pipelines/settings"
+	openpipeline "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+)
+
+func newPipeline(id string, displayName string) *pipelinesettings.Pipeline {
+	return &pipelinesettings.Pipeline{DefaultPipeline: openpipeline.DefaultPipeline{BasePipeline: openpipeline.BasePipeline{
+		Id:          id,
+		DisplayName: &displayName,
+		Enabled:     true,
+	}}}
+}
+
+func TestPipelines(t *testing.T) {
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	ctx := context.Background()
+	service := pipelines.LogsService(server.Credentials())
+
+	for _, v := range []*pipelinesettings.Pipeline{newPipeline("first", "First"), newPipeline("second", "Second")} {
+		stub, err := service.Create(ctx, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stub.ID != v.Id || stub.Name != *v.DisplayName {
+			t.Errorf("unexpected stub %s (%s)", stub.ID, stub.Name)
+		}
+	}
+	if _, err := service.Create(ctx, newPipeline("first", "Duplicate")); err == nil {
+		t.Error("expected creating a pipeline with an already existing id to fail")
+	}
+
+	stubs, err := service.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stubs) != 2 {
+		t.Fatalf("expected 2 pipelines, got %d", len(stubs))
+	}
+
+	update := newPipeline("first", "Renamed")
+	update.Enabled = false
+	if err := service.Update(ctx, "first", update); err != nil {
+		t.Fatal(err)
+	}
+	var first pipelinesettings.Pipeline
+	if err := service.Get(ctx, "first", &first); err != nil {
+		t.Fatal(err)
+	}
+	if first.Name() != "Renamed" || first.Enabled {
+		t.Errorf("expected the pipeline to get updated, got %s (enabled: %v)", first.Name(), first.Enabled)
+	}
+
+	if err := service.Delete(ctx, "first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Get(ctx, "first", &first); !rest.Is404(err) {
+		t.Errorf("expected the deleted pipeline to be gone, got %v", err)
+	}
+	var second pipelinesettings.Pipeline
+	if err := service.Get(ctx, "second", &second); err != nil {
+		t.Fatal(err)
+	}
+	if second.Name() != "Second" {
+		t.Errorf("expected the other pipeline to stay untouched, got %s", second.Name())
+	}
+	if err := service.Update(ctx, "first", newPipeline("first", "First")); !rest.Is404(err) {
+		t.Errorf("expected updating a deleted pipeline to fail with 404, got %v", err)
+	}
+}
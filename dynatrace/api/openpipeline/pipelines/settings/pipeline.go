@@ -0,0 +1,64 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package pipelines
+
+import (
+	openpipeline "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Pipeline is a single pipeline of an OpenPipeline configuration, managed independently of the other pipelines.
+// The attribute `id` of the pipeline definition is exposed as `pipeline_id`, because `id` is reserved by Terraform
+type Pipeline struct {
+	openpipeline.DefaultPipeline
+}
+
+func (p *Pipeline) Name() string {
+	if p.DisplayName != nil && len(*p.DisplayName) > 0 {
+		return *p.DisplayName
+	}
+	return p.Id
+}
+
+func (p *Pipeline) Schema() map[string]*schema.Schema {
+	s := p.DefaultPipeline.Schema()
+	delete(s, "id")
+	s["pipeline_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Identifier of the pipeline. Must be unique within the configuration",
+		Required:    true,
+		ForceNew:    true,
+	}
+	return s
+}
+
+func (p *Pipeline) MarshalHCL(properties hcl.Properties) error {
+	if err := p.DefaultPipeline.MarshalHCL(properties); err != nil {
+		return err
+	}
+	delete(properties, "id")
+	return properties.Encode("pipeline_id", p.Id)
+}
+
+func (p *Pipeline) UnmarshalHCL(decoder hcl.Decoder) error {
+	if err := p.DefaultPipeline.UnmarshalHCL(decoder); err != nil {
+		return err
+	}
+	return decoder.Decode("pipeline_id", &p.Id)
+}
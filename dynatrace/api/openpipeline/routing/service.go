@@ -0,0 +1,153 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package routing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline"
+	routing "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/routing/settings"
+	openpipelinesettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+func LogsService(credentials *settings.Credentials) settings.CRUDService[*routing.Entry] {
+	return &service{client: openpipeline.NewConfigurationClient(credentials, "logs"), schemaSuffix: "logs"}
+}
+
+func EventsService(credentials *settings.Credentials) settings.CRUDService[*routing.Entry] {
+	return &service{client: openpipeline.NewConfigurationClient(credentials, "events"), schemaSuffix: "events"}
+}
+
+func BusinessEventsService(credentials *settings.Credentials) settings.CRUDService[*routing.Entry] {
+	return &service{client: openpipeline.NewConfigurationClient(credentials, "bizevents"), schemaSuffix: "events.business"}
+}
+
+func SecurityEventsService(credentials *settings.Credentials) settings.CRUDService[*routing.Entry] {
+	return &service{client: openpipeline.NewConfigurationClient(credentials, "events.security"), schemaSuffix: "events.security"}
+}
+
+func SDLCEventsService(credentials *settings.Credentials) settings.CRUDService[*routing.Entry] {
+	return &service{client: openpipeline.NewConfigurationClient(credentials, "events.sdlc"), schemaSuffix: "events.sdlc"}
+}
+
+// service manages single entries of the dynamic routing table within the OpenPipeline configuration of a kind.
+// New entries are getting appended to the end of the routing table.
+// Routing entries don't have an identifier of their own. Their ID is therefore derived from
+// the pipeline they route into, their matcher and their note (see `ID`)
+type service struct {
+	client       *openpipeline.ConfigurationClient
+	schemaSuffix string
+}
+
+func (s *service) SchemaID() string {
+	return "platform:openpipeline." + s.schemaSuffix + ".routing"
+}
+
+func (s *service) List(ctx context.Context) (api.Stubs, error) {
+	config, err := s.client.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stubs := api.Stubs{}
+	for _, entry := range config.Routing.Entries {
+		stubs = append(stubs, &api.Stub{ID: ID(entry), Name: entry.Note})
+	}
+	return stubs, nil
+}
+
+func (s *service) Get(ctx context.Context, id string, v *routing.Entry) error {
+	config, err := s.client.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if idx := find(config, id); idx >= 0 {
+		v.RoutingTableEntry = *config.Routing.Entries[idx]
+		return nil
+	}
+	return s.client.NotFound("routing entry", id)
+}
+
+func (s *service) Create(ctx context.Context, v *routing.Entry) (*api.Stub, error) {
+	if err := s.client.Modify(ctx, func(config *openpipelinesettings.Configuration) error {
+		if find(config, ID(&v.RoutingTableEntry)) >= 0 {
+			return fmt.Errorf("a routing entry with note `%s` routing `%s` into pipeline `%s` already exists in the OpenPipeline configuration for %s", v.Note, v.Matcher, v.PipelineId, s.client.Kind())
+		}
+		entry := v.RoutingTableEntry
+		config.Routing.Entries = append(config.Routing.Entries, &entry)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &api.Stub{ID: ID(&v.RoutingTableEntry), Name: v.Note}, nil
+}
+
+func (s *service) Update(ctx context.Context, id string, v *routing.Entry) error {
+	return s.client.Modify(ctx, func(config *openpipelinesettings.Configuration) error {
+		idx := find(config, id)
+		if idx < 0 {
+			return s.client.NotFound("routing entry", id)
+		}
+		entry := v.RoutingTableEntry
+		config.Routing.Entries[idx] = &entry
+		return nil
+	})
+}
+
+func (s *service) Delete(ctx context.Context, id string) error {
+	return s.client.Modify(ctx, func(config *openpipelinesettings.Configuration) error {
+		idx := find(config, id)
+		if idx < 0 {
+			return nil
+		}
+		config.Routing.Entries = append(config.Routing.Entries[:idx], config.Routing.Entries[idx+1:]...)
+		return nil
+	})
+}
+
+// ID produces the identifier of a routing entry, consisting of the ID of the pipeline it routes into
+// and a hash of its matcher and note, e.g. `my-pipeline#3f2a9c0d17e4b6a8`.
+// Two entries only share the same ID if they are interchangeable anyway
+func ID(entry *openpipelinesettings.RoutingTableEntry) string {
+	hash := sha256.Sum256([]byte(entry.Matcher + "\n" + entry.Note))
+	return entry.PipelineId + "#" + hex.EncodeToString(hash[:8])
+}
+
+// find looks up the routing entry with the given ID.
+// IDs consisting of just the note of an entry are still supported, as long as that note is unique
+func find(config *openpipelinesettings.Configuration, id string) int {
+	for idx, entry := range config.Routing.Entries {
+		if ID(entry) == id {
+			return idx
+		}
+	}
+	found := -1
+	for idx, entry := range config.Routing.Entries {
+		if entry.Note == id {
+			if found >= 0 {
+				return -1
+			}
+			found = idx
+		}
+	}
+	return found
+}
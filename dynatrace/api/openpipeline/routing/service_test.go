@@ -0,0 +1,124 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package routing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/routing"
+	routingsettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/routing/settings"
+	openpipeline "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+)
+
+func newEntry(note string, matcher string, pipelineID string) *routingsettings.Entry {
+	return &routingsettings.Entry{RoutingTableEntry: openpipeline.RoutingTableEntry{
+		Enabled:    true,
+		Matcher:    matcher,
+		Note:       note,
+		PipelineId: pipelineID,
+	}}
+}
+
+func TestRoutingEntries(t *testing.T) {
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	ctx := context.Background()
+	service := routing.LogsService(server.Credentials())
+
+	// entries sharing the same note are still distinguishable
+	entries := []*routingsettings.Entry{
+		newEntry("route", `matchesValue(loglevel, "ERROR")`, "errors"),
+		newEntry("route", `matchesValue(loglevel, "WARN")`, "warnings"),
+		newEntry("unique", "true", "default"),
+	}
+	ids := []string{}
+	for _, v := range entries {
+		stub, err := service.Create(ctx, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stub.ID != routing.ID(&v.RoutingTableEntry) {
+			t.Errorf("expected ID %s, got %s", routing.ID(&v.RoutingTableEntry), stub.ID)
+		}
+		ids = append(ids, stub.ID)
+	}
+	if ids[0] == ids[1] {
+		t.Fatalf("expected entries with the same note to get different IDs, got %s twice", ids[0])
+	}
+	if _, err := service.Create(ctx, newEntry("route", `matchesValue(loglevel, "ERROR")`, "errors")); err == nil {
+		t.Error("expected creating an identical routing entry to fail")
+	}
+
+	stubs, err := service.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stubs) != 3 {
+		t.Fatalf("expected 3 routing entries, got %d", len(stubs))
+	}
+	for idx, stub := range stubs {
+		if stub.ID != ids[idx] {
+			t.Errorf("expected the routing entries to be listed in order, got %s at position %d", stub.ID, idx)
+		}
+	}
+
+	var entry routingsettings.Entry
+	if err := service.Get(ctx, ids[1], &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.PipelineId != "warnings" {
+		t.Errorf("expected the entry routing into `warnings`, got %s", entry.PipelineId)
+	}
+
+	// entries with a unique note can still be addressed by it
+	if err := service.Get(ctx, "unique", &entry); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Get(ctx, "route", &entry); !rest.Is404(err) {
+		t.Errorf("expected an ambiguous note not to resolve to an entry, got %v", err)
+	}
+
+	update := newEntry("route", `matchesValue(loglevel, "ERROR")`, "errors")
+	update.Enabled = false
+	if err := service.Update(ctx, ids[0], update); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Get(ctx, ids[0], &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Enabled {
+		t.Error("expected the routing entry to get disabled")
+	}
+
+	if err := service.Delete(ctx, ids[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Get(ctx, ids[0], &entry); !rest.Is404(err) {
+		t.Errorf("expected the deleted routing entry to be gone, got %v", err)
+	}
+	if err := service.Get(ctx, ids[1], &entry); err != nil {
+		t.Errorf("expected the routing entry with the same note to stay untouched, got %v", err)
+	}
+}
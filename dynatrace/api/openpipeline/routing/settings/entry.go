@@ -0,0 +1,42 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package routing
+
+import (
+	openpipeline "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/settings"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Entry is a single entry of the dynamic routing table of an OpenPipeline configuration.
+// Entries are identified by the pipeline they route into, their matcher and their note.
+// Changing any of them therefore replaces the entry
+type Entry struct {
+	openpipeline.RoutingTableEntry
+}
+
+func (e *Entry) Name() string {
+	return e.Note
+}
+
+func (e *Entry) Schema() map[string]*schema.Schema {
+	s := e.RoutingTableEntry.Schema()
+	s["note"].ForceNew = true
+	s["matcher"].ForceNew = true
+	s["pipeline_id"].ForceNew = true
+	return s
+}
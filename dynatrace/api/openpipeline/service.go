@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/automation/httplog"
 	openpipeline "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/settings"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
@@ -57,6 +58,7 @@ type service struct {
 }
 
 func (s *service) createClient() (*caclib.Client, error) {
+	httplog.InstallRoundTripper()
 	factory := clients.Factory().
 		WithUserAgent("Dynatrace Terraform Provider").
 		WithPlatformURL(s.credentials.Automation.EnvironmentURL).
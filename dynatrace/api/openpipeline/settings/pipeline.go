@@ -60,6 +60,25 @@ type Pipeline struct {
 	defaultPipeline *DefaultPipeline
 }
 
+func NewPipeline(defaultPipeline *DefaultPipeline) *Pipeline {
+	return &Pipeline{defaultPipeline: defaultPipeline}
+}
+
+// Default returns the pipeline definition, unless it is a classic pipeline
+func (ep *Pipeline) Default() *DefaultPipeline {
+	return ep.defaultPipeline
+}
+
+func (ep *Pipeline) ID() string {
+	if ep.classicPipeline != nil {
+		return ep.classicPipeline.Id
+	}
+	if ep.defaultPipeline != nil {
+		return ep.defaultPipeline.Id
+	}
+	return ""
+}
+
 func (ep *Pipeline) Schema() map[string]*schema.Schema {
 	return new(DefaultPipeline).Schema()
 }
@@ -46,6 +46,7 @@ type service struct {
 }
 
 func (me *service) client(_ context.Context) *bucket.Client {
+	httplog.InstallRoundTripper()
 	factory := clients.Factory().
 		WithUserAgent("Dynatrace Terraform Provider").
 		WithPlatformURL(me.credentials.Automation.EnvironmentURL).
@@ -19,6 +19,7 @@ package api
 
 import (
 	"sort"
+	"time"
 )
 
 type Stub struct {
@@ -28,6 +29,8 @@ type Stub struct {
 	Value    any     `json:"-"`
 	LegacyID *string `json:"legacyID,omitempty"`
 	ParentID *string `json:"parentID,omitempty"`
+	// LastModified is known only for services reporting modification info when listing (e.g. Settings 2.0)
+	LastModified *time.Time `json:"lastModified,omitempty"`
 }
 
 type Stubs []*Stub
@@ -51,7 +54,7 @@ func (me *Stubs) ToStubs() Stubs {
 		if len(stub.ID) == 0 && len(stub.EntityID) != 0 {
 			stub.ID = stub.EntityID
 		}
-		res = append(res, &Stub{ID: stub.ID, Name: stub.Name, Value: stub.Value, EntityID: stub.EntityID, LegacyID: stub.LegacyID, ParentID: stub.ParentID})
+		res = append(res, &Stub{ID: stub.ID, Name: stub.Name, Value: stub.Value, EntityID: stub.EntityID, LegacyID: stub.LegacyID, ParentID: stub.ParentID, LastModified: stub.LastModified})
 	}
 	return res
 }
@@ -18,8 +18,10 @@
 package notifications
 
 import (
+	"context"
 	"encoding/json"
 
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/problem/notifications/placeholders"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
 
@@ -227,6 +229,43 @@ func (me *NotificationRecord) MarshalHCL(properties hcl.Properties) error {
 	return nil
 }
 
+// templatedBlocks are the blocks containing the configuration of notification types, which support placeholders
+var templatedBlocks = []struct {
+	block string
+	t     Type
+}{
+	{"ansible_tower", Types.Ansibletower},
+	{"email", Types.Email},
+	{"jira", Types.Jira},
+	{"ops_genie", Types.OpsGenie},
+	{"service_now", Types.ServiceNow},
+	{"slack", Types.Slack},
+	{"trello", Types.Trello},
+	{"victor_ops", Types.Victorops},
+	{"web_hook", Types.Webhook},
+	{"xmatters", Types.Xmatters},
+}
+
+// CustomizeDiff reports placeholders (e.g. `{ProblemTitle}`) not supported by the notification type during `terraform plan`
+func (me *NotificationRecord) CustomizeDiff(ctx context.Context, rd *schema.ResourceDiff, i any) error {
+	for _, templated := range templatedBlocks {
+		if _, ok := rd.GetOk(templated.block + ".#"); !ok {
+			continue
+		}
+		for _, field := range placeholders.Fields(string(templated.t)) {
+			attribute := field.Attribute
+			// the message of Slack notifications is called `title` here
+			if templated.t == Types.Slack && attribute == "message" {
+				attribute = "title"
+			}
+			if err := field.ValidateDiff(rd, templated.block+".0."+attribute); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 type MarshalPreparer interface {
 	PrepareMarshalHCL(hcl.Decoder) error
 }
@@ -0,0 +1,310 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package packages
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	packages "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/v2/extensions/packages/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+const SchemaID = "v2:extensions:packages"
+
+func Service(credentials *settings.Credentials) settings.CRUDService[*packages.Settings] {
+	return &service{credentials: credentials}
+}
+
+type service struct {
+	credentials *settings.Credentials
+}
+
+func (me *service) client() rest.Client {
+	return rest.DefaultClient(me.credentials.URL, me.credentials.Token)
+}
+
+// stateConfig returns the settings known from the state, in order to figure out which version has been uploaded
+func stateConfig(ctx context.Context) *packages.Settings {
+	if stateConfig, ok := ctx.Value(settings.ContextKeyStateConfig).(*packages.Settings); ok && stateConfig != nil {
+		return stateConfig
+	}
+	return &packages.Settings{}
+}
+
+func (me *service) Get(ctx context.Context, id string, v *packages.Settings) error {
+	known := stateConfig(ctx)
+	activeVersion, err := me.activeVersion(ctx, id)
+	if err != nil {
+		return err
+	}
+	version := known.Version
+	if len(version) == 0 {
+		// on import the state doesn't tell which version has been uploaded
+		versions, err := me.versions(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(versions) == 0 {
+			return rest.Error{Code: 404, Message: fmt.Sprintf("Extension %s not found", id)}
+		}
+		version = versions[len(versions)-1]
+		for _, candidate := range versions {
+			if candidate == activeVersion {
+				version = candidate
+			}
+		}
+		known.Active = version == activeVersion
+	}
+	var response ExtensionVersion
+	if err := me.client().Get(ctx, fmt.Sprintf("/api/v2/extensions/%s/%s", url.PathEscape(id), url.PathEscape(version)), 200).Finish(&response); err != nil {
+		return err
+	}
+	v.Name = id
+	v.Version = version
+	v.File = known.File
+	v.Hash = known.Hash
+	// whether the version is active is only of interest if the configuration demands it
+	v.Active = known.Active && version == activeVersion
+	return nil
+}
+
+// List doesn't return anything. The archives of uploaded extensions are not part of an export
+func (me *service) List(ctx context.Context) (api.Stubs, error) {
+	return api.Stubs{}, nil
+}
+
+func (me *service) Create(ctx context.Context, v *packages.Settings) (*api.Stub, error) {
+	if err := me.upload(ctx, v); err != nil {
+		return nil, err
+	}
+	if v.Active {
+		if err := me.activate(ctx, v.Name, v.Version); err != nil {
+			return nil, err
+		}
+	}
+	return &api.Stub{ID: v.Name, Name: v.Name}, nil
+}
+
+// upload uploads the archive and fills in name, version and hash.
+// The Extensions API refuses archives whose version has already been uploaded, even if their content differs
+func (me *service) upload(ctx context.Context, v *packages.Settings) error {
+	archive, err := packages.ReadArchive(v.File)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(v.File)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	var response ExtensionVersion
+	if err := me.client().Upload(ctx, "/api/v2/extensions", file, v.File, 200, 201).Finish(&response); err != nil {
+		if restErr, ok := err.(rest.Error); ok && (restErr.Code == 400 || restErr.Code == 409) && strings.Contains(strings.ToLower(restErr.Message), "already") {
+			return fmt.Errorf("version %s of extension %s is already uploaded; bump the extension version", archive.Version, archive.Name)
+		}
+		return err
+	}
+	v.Name = archive.Name
+	v.Version = archive.Version
+	v.Hash = archive.Hash
+	return nil
+}
+
+func (me *service) Update(ctx context.Context, id string, v *packages.Settings) error {
+	known := stateConfig(ctx)
+	// the archive only gets uploaded again if its content has changed
+	if len(known.Hash) == 0 || known.Hash != v.Hash {
+		if err := me.upload(ctx, v); err != nil {
+			return err
+		}
+	}
+	if v.Active {
+		if err := me.activate(ctx, v.Name, v.Version); err != nil {
+			return err
+		}
+	} else if known.Active {
+		if err := me.deactivate(ctx, id, known.Version); err != nil {
+			return err
+		}
+	}
+	if len(known.Version) == 0 || known.Version == v.Version {
+		return nil
+	}
+	// the previous version is getting removed, unless it is still in use
+	activeVersion, err := me.activeVersion(ctx, id)
+	if err != nil {
+		return err
+	}
+	if activeVersion == known.Version {
+		return nil
+	}
+	references, err := me.monitoringConfigurations(ctx, id, known.Version)
+	if err != nil {
+		return err
+	}
+	if len(references) > 0 {
+		return nil
+	}
+	return me.removeVersion(ctx, id, known.Version)
+}
+
+// Delete removes the uploaded version. Versions still referenced by monitoring configurations are not getting removed.
+// If the version has been activated by this resource, the environment configuration is getting removed first
+func (me *service) Delete(ctx context.Context, id string) error {
+	known := stateConfig(ctx)
+	if len(known.Version) == 0 {
+		return nil
+	}
+	references, err := me.monitoringConfigurations(ctx, id, known.Version)
+	if err != nil {
+		return err
+	}
+	if len(references) > 0 {
+		return fmt.Errorf("version %s of extension %s is still referenced by %d monitoring configuration(s) (%s). Remove them before removing the version", known.Version, id, len(references), strings.Join(references, ", "))
+	}
+	activeVersion, err := me.activeVersion(ctx, id)
+	if err != nil {
+		return err
+	}
+	if activeVersion == known.Version {
+		if !known.Active {
+			return fmt.Errorf("version %s of extension %s is the active version of the extension. Activate a different version before removing it", known.Version, id)
+		}
+		if err := me.deactivate(ctx, id, known.Version); err != nil {
+			return err
+		}
+	}
+	return me.removeVersion(ctx, id, known.Version)
+}
+
+func (me *service) removeVersion(ctx context.Context, name string, version string) error {
+	err := me.client().Delete(ctx, fmt.Sprintf("/api/v2/extensions/%s/%s", url.PathEscape(name), url.PathEscape(version)), 200).Finish()
+	if restErr, ok := err.(rest.Error); ok && restErr.Code == 404 {
+		return nil
+	}
+	return err
+}
+
+func (me *service) activate(ctx context.Context, name string, version string) error {
+	return me.client().Put(ctx, fmt.Sprintf("/api/v2/extensions/%s/environmentConfiguration", url.PathEscape(name)), &EnvironmentConfiguration{Version: version}, 200).Finish()
+}
+
+// deactivate removes the environment configuration of the extension, if the given version is the active one
+func (me *service) deactivate(ctx context.Context, name string, version string) error {
+	activeVersion, err := me.activeVersion(ctx, name)
+	if err != nil {
+		return err
+	}
+	if activeVersion != version {
+		return nil
+	}
+	return me.client().Delete(ctx, fmt.Sprintf("/api/v2/extensions/%s/environmentConfiguration", url.PathEscape(name)), 200).Finish()
+}
+
+// activeVersion returns the version of the environment configuration of the extension, if there is one
+func (me *service) activeVersion(ctx context.Context, name string) (string, error) {
+	var response EnvironmentConfiguration
+	if err := me.client().Get(ctx, fmt.Sprintf("/api/v2/extensions/%s/environmentConfiguration", url.PathEscape(name)), 200).Finish(&response); err != nil {
+		if restErr, ok := err.(rest.Error); ok && restErr.Code == 404 {
+			return "", nil
+		}
+		return "", err
+	}
+	return response.Version, nil
+}
+
+// versions returns the uploaded versions of the extension
+func (me *service) versions(ctx context.Context, name string) ([]string, error) {
+	versions := []string{}
+	nextPageKey := ""
+	for {
+		u := fmt.Sprintf("/api/v2/extensions/%s", url.PathEscape(name))
+		if len(nextPageKey) > 0 {
+			u = u + "?nextPageKey=" + url.QueryEscape(nextPageKey)
+		}
+		var response ExtensionList
+		if err := me.client().Get(ctx, u, 200).Finish(&response); err != nil {
+			if restErr, ok := err.(rest.Error); ok && restErr.Code == 404 {
+				return versions, nil
+			}
+			return nil, err
+		}
+		for _, extension := range response.Extensions {
+			versions = append(versions, extension.Version)
+		}
+		if nextPageKey = response.NextPageKey; len(nextPageKey) == 0 {
+			return versions, nil
+		}
+	}
+}
+
+// monitoringConfigurations returns the IDs of the monitoring configurations using the given version of the extension
+func (me *service) monitoringConfigurations(ctx context.Context, name string, version string) ([]string, error) {
+	ids := []string{}
+	nextPageKey := ""
+	for {
+		u := fmt.Sprintf("/api/v2/extensions/%s/monitoringConfigurations?version=%s", url.PathEscape(name), url.QueryEscape(version))
+		if len(nextPageKey) > 0 {
+			u = fmt.Sprintf("/api/v2/extensions/%s/monitoringConfigurations?nextPageKey=%s", url.PathEscape(name), url.QueryEscape(nextPageKey))
+		}
+		var response MonitoringConfigurationList
+		if err := me.client().Get(ctx, u, 200).Finish(&response); err != nil {
+			if restErr, ok := err.(rest.Error); ok && restErr.Code == 404 {
+				return ids, nil
+			}
+			return nil, err
+		}
+		for _, item := range response.Items {
+			ids = append(ids, item.ObjectID)
+		}
+		if nextPageKey = response.NextPageKey; len(nextPageKey) == 0 {
+			return ids, nil
+		}
+	}
+}
+
+func (me *service) SchemaID() string {
+	return SchemaID
+}
+
+type ExtensionVersion struct {
+	Name    string `json:"extensionName"`
+	Version string `json:"version"`
+}
+
+type ExtensionList struct {
+	Extensions  []ExtensionVersion `json:"extensions"`
+	NextPageKey string             `json:"nextPageKey,omitempty"`
+}
+
+type EnvironmentConfiguration struct {
+	Version string `json:"version"`
+}
+
+type MonitoringConfigurationList struct {
+	Items []struct {
+		ObjectID string `json:"objectId"`
+	} `json:"items"`
+	NextPageKey string `json:"nextPageKey,omitempty"`
+}
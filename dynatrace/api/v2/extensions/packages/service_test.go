@@ -0,0 +1,197 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package packages_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	extensionpackages "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/v2/extensions/packages"
+	packages "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/v2/extensions/packages/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+)
+
+const extensionName = "custom:com.example.test"
+
+// writeArchive writes a signed extension archive, containing `extension.zip` and a (fake) signature
+func writeArchive(t *testing.T, version string) string {
+	t.Helper()
+	zipped := func(files map[string][]byte) []byte {
+		var buf bytes.Buffer
+		writer := zip.NewWriter(&buf)
+		for name, data := range files {
+			w, err := writer.Create(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := w.Write(data); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	inner := zipped(map[string][]byte{"extension.yaml": []byte("name: " + extensionName + "\nversion: " + version + "\nminDynatraceVersion: \"1.285\"\n")})
+	file := filepath.Join(t.TempDir(), "extension-"+version+".zip")
+	if err := os.WriteFile(file, zipped(map[string][]byte{"extension.zip": inner, "extension.zip.sig": []byte("signature")}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestReadArchive(t *testing.T) {
+	archive, err := packages.ReadArchive(writeArchive(t, "1.0.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if archive.Name != extensionName || archive.Version != "1.0.0" || len(archive.Hash) != 64 {
+		t.Errorf("unexpected archive %+v", archive)
+	}
+
+	invalid := filepath.Join(t.TempDir(), "invalid.zip")
+	if err := os.WriteFile(invalid, []byte("not a zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := packages.ReadArchive(invalid); err == nil || !strings.Contains(err.Error(), "is not a valid extension archive") {
+		t.Errorf("expected an invalid archive to be rejected, got %v", err)
+	}
+}
+
+func TestExtensionPackage(t *testing.T) {
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	ctx := context.Background()
+	service := extensionpackages.Service(server.Credentials())
+	client := rest.DefaultClient(server.Credentials().URL, server.Credentials().Token)
+	withState := func(state *packages.Settings) context.Context {
+		return context.WithValue(ctx, settings.ContextKeyStateConfig, state)
+	}
+
+	first := &packages.Settings{File: writeArchive(t, "1.0.0"), Active: true}
+	stub, err := service.Create(ctx, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stub.ID != extensionName || first.Version != "1.0.0" || len(first.Hash) == 0 {
+		t.Fatalf("unexpected result %+v / %+v", stub, first)
+	}
+
+	// uploading an archive whose version already exists fails
+	if _, err := service.Create(ctx, &packages.Settings{File: first.File}); err == nil || !strings.Contains(err.Error(), "version 1.0.0 of extension "+extensionName+" is already uploaded; bump the extension version") {
+		t.Errorf("expected uploading version 1.0.0 again to be refused, got %v", err)
+	}
+
+	// on import the active version is getting picked
+	imported := new(packages.Settings)
+	if err := service.Get(ctx, stub.ID, imported); err != nil {
+		t.Fatal(err)
+	}
+	if imported.Version != "1.0.0" || !imported.Active {
+		t.Errorf("unexpected imported state %+v", imported)
+	}
+
+	// updating to a new version activates it and removes the previous one
+	second := &packages.Settings{File: writeArchive(t, "1.1.0"), Active: true}
+	if err := service.Update(withState(first), stub.ID, second); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Get(withState(first), stub.ID, new(packages.Settings)); !isNotFound(err) {
+		t.Errorf("expected version 1.0.0 to be removed, got %v", err)
+	}
+	current := new(packages.Settings)
+	if err := service.Get(withState(second), stub.ID, current); err != nil {
+		t.Fatal(err)
+	}
+	if current.Version != "1.1.0" || !current.Active || current.File != second.File || current.Hash != second.Hash {
+		t.Errorf("unexpected state %+v", current)
+	}
+
+	// deactivating the version removes the environment configuration without uploading the archive again
+	inactive := &packages.Settings{File: second.File, Hash: second.Hash, Name: second.Name, Version: second.Version, Active: false}
+	if err := service.Update(withState(second), stub.ID, inactive); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Get(withState(second), stub.ID, current); err != nil {
+		t.Fatal(err)
+	}
+	if current.Active {
+		t.Errorf("expected version 1.1.0 not to be active anymore, got %+v", current)
+	}
+	second = inactive
+
+	// a version referenced by monitoring configurations doesn't get removed
+	monitoringConfigurations := []map[string]any{{"scope": "environment", "value": map[string]any{"version": "1.1.0", "enabled": true}}}
+	if err := client.Post(ctx, "/api/v2/extensions/"+extensionName+"/monitoringConfigurations", monitoringConfigurations, 200).Finish(); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Delete(withState(second), stub.ID); err == nil || !strings.Contains(err.Error(), "still referenced by 1 monitoring configuration") {
+		t.Errorf("expected deleting a referenced version to be refused, got %v", err)
+	}
+}
+
+func TestExtensionPackageDelete(t *testing.T) {
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	ctx := context.Background()
+	service := extensionpackages.Service(server.Credentials())
+	client := rest.DefaultClient(server.Credentials().URL, server.Credentials().Token)
+
+	// a version activated by somebody else remains in place
+	inactive := &packages.Settings{File: writeArchive(t, "2.0.0")}
+	stub, err := service.Create(ctx, inactive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Put(ctx, "/api/v2/extensions/"+extensionName+"/environmentConfiguration", &extensionpackages.EnvironmentConfiguration{Version: "2.0.0"}, 200).Finish(); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Delete(context.WithValue(ctx, settings.ContextKeyStateConfig, inactive), stub.ID); err == nil || !strings.Contains(err.Error(), "is the active version") {
+		t.Errorf("expected deleting the active version to be refused, got %v", err)
+	}
+
+	// a version activated by the resource gets deactivated and removed
+	inactive.Active = true
+	if err := service.Delete(context.WithValue(ctx, settings.ContextKeyStateConfig, inactive), stub.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Get(context.WithValue(ctx, settings.ContextKeyStateConfig, inactive), stub.ID, new(packages.Settings)); !isNotFound(err) {
+		t.Errorf("expected the extension to be removed, got %v", err)
+	}
+}
+
+func isNotFound(err error) bool {
+	restErr, ok := err.(rest.Error)
+	return ok && restErr.Code == 404
+}
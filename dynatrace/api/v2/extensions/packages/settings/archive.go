@@ -0,0 +1,91 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package packages
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Archive contains the metadata of a signed extension archive
+type Archive struct {
+	Name    string
+	Version string
+	// Hash is the hex encoded SHA-256 hash of the archive
+	Hash string
+}
+
+// ReadArchive reads name and version of the extension from `extension.yaml`.
+// Signed archives contain `extension.zip` (containing `extension.yaml`) and its signature
+func ReadArchive(file string) (*Archive, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(data)
+
+	manifest, err := findManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("`%s` is not a valid extension archive: %s", file, err.Error())
+	}
+	var extension struct {
+		Name    string `yaml:"name"`
+		Version string `yaml:"version"`
+	}
+	if err := yaml.Unmarshal(manifest, &extension); err != nil {
+		return nil, fmt.Errorf("`%s` is not a valid extension archive: %s", file, err.Error())
+	}
+	if len(extension.Name) == 0 || len(extension.Version) == 0 {
+		return nil, fmt.Errorf("`%s` is not a valid extension archive: `extension.yaml` doesn't define `name` and `version`", file)
+	}
+	return &Archive{Name: extension.Name, Version: extension.Version, Hash: hex.EncodeToString(hash[:])}, nil
+}
+
+func findManifest(data []byte) ([]byte, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	if manifest, err := readEntry(reader, "extension.yaml"); err == nil {
+		return manifest, nil
+	}
+	inner, err := readEntry(reader, "extension.zip")
+	if err != nil {
+		return nil, err
+	}
+	if reader, err = zip.NewReader(bytes.NewReader(inner), int64(len(inner))); err != nil {
+		return nil, err
+	}
+	return readEntry(reader, "extension.yaml")
+}
+
+func readEntry(reader *zip.Reader, name string) ([]byte, error) {
+	file, err := reader.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("`%s` not found", name)
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
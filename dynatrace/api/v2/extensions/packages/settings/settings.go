@@ -0,0 +1,129 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package packages
+
+import (
+	"context"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type Settings struct {
+	File    string `json:"-"`
+	Hash    string `json:"-"`
+	Name    string `json:"-"`
+	Version string `json:"-"`
+	Active  bool   `json:"-"`
+}
+
+func (me *Settings) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"file": {
+			Type:        schema.TypeString,
+			Description: "The path to the signed extension archive (`.zip`) to upload. It contains `extension.zip` and its signature",
+			Required:    true,
+		},
+		"active": {
+			Type:        schema.TypeBool,
+			Description: "If `true` the uploaded version becomes the active version of the extension (environment configuration). Changing it to `false` removes the environment configuration. Defaults to `false`",
+			Optional:    true,
+			Default:     false,
+		},
+		"hash": {
+			Type:        schema.TypeString,
+			Description: "The SHA-256 hash of the archive. The archive is getting uploaded again whenever its content changes, which requires a new version of the extension",
+			Computed:    true,
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Description: "The fully qualified name of the extension as defined within the archive, e.g. `custom:com.example.my-extension`. A different name results in a new resource",
+			Computed:    true,
+			ForceNew:    true,
+		},
+		"version": {
+			Type:        schema.TypeString,
+			Description: "The version of the extension as defined within the archive",
+			Computed:    true,
+		},
+	}
+}
+
+func (me *Settings) MarshalHCL(properties hcl.Properties) error {
+	return properties.EncodeAll(map[string]any{
+		"file":    me.File,
+		"active":  me.Active,
+		"hash":    me.Hash,
+		"name":    me.Name,
+		"version": me.Version,
+	})
+}
+
+func (me *Settings) UnmarshalHCL(decoder hcl.Decoder) error {
+	return decoder.DecodeAll(map[string]any{
+		"file":    &me.File,
+		"active":  &me.Active,
+		"hash":    &me.Hash,
+		"name":    &me.Name,
+		"version": &me.Version,
+	})
+}
+
+// PrepareMarshalHCL keeps the path of the archive and its hash as they are within the state.
+// The Dynatrace Environment doesn't know about either of them
+func (me *Settings) PrepareMarshalHCL(decoder hcl.Decoder) error {
+	if len(me.File) == 0 {
+		if file, ok := decoder.GetOk("file"); ok {
+			me.File = file.(string)
+		}
+	}
+	if len(me.Hash) == 0 {
+		if hash, ok := decoder.GetOk("hash"); ok {
+			me.Hash = hash.(string)
+		}
+	}
+	return nil
+}
+
+// CustomizeDiff reads name, version and hash from the configured archive.
+// If the content of the archive has changed, the new version is getting uploaded during apply
+func (me *Settings) CustomizeDiff(ctx context.Context, rd *schema.ResourceDiff, i any) error {
+	if !rd.NewValueKnown("file") {
+		for _, key := range []string{"hash", "name", "version"} {
+			if err := rd.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	archive, err := ReadArchive(rd.Get("file").(string))
+	if err != nil {
+		return err
+	}
+	if rd.Get("hash").(string) == archive.Hash {
+		return nil
+	}
+	if err := rd.SetNew("hash", archive.Hash); err != nil {
+		return err
+	}
+	if err := rd.SetNew("name", archive.Name); err != nil {
+		return err
+	}
+	return rd.SetNew("version", archive.Version)
+}
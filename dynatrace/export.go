@@ -18,12 +18,14 @@
 package dynatrace
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/config"
 )
 
@@ -59,6 +61,20 @@ func Export(args []string, cfgGetter config.Getter) bool {
 			}
 			return true
 		}
+		if strings.TrimSpace(args[2]) == "-decrypt-secrets" {
+			folder := os.Getenv("DYNATRACE_TARGET_FOLDER")
+			if len(args) > 3 {
+				folder = args[3]
+			}
+			if len(folder) == 0 {
+				folder = "configuration"
+			}
+			if err := export.DecryptSecrets(folder); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			return true
+		}
 	}
 
 	// defer export.CleanUp.Finish()
@@ -71,6 +87,7 @@ func Export(args []string, cfgGetter config.Getter) bool {
 func runExport(cfgGetter config.Getter) (err error) {
 	start := time.Now()
 	defer func() {
+		rest.LogRateLimitMetrics(context.Background())
 		fmt.Printf("... finished after %v seconds\n", int64(time.Since(start).Seconds()))
 	}()
 	os.Remove("terraform-provider-dynatrace.export.log")
@@ -80,6 +97,11 @@ func runExport(cfgGetter config.Getter) (err error) {
 		return err
 	}
 
+	if environment.Flags.Drift {
+		_, err = environment.Drift()
+		return err
+	}
+
 	err = environment.RunQuickInit()
 	if err != nil {
 		return err
@@ -0,0 +1,77 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+)
+
+func TestExportConvertDashboards(t *testing.T) {
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	classic := `{"dashboardMetadata":{"name":"Team Overview","owner":"someone@example.com"},"tiles":[` +
+		`{"name":"Hosts","tileType":"HEADER","configured":true,"bounds":{"top":0,"left":0,"width":304,"height":38},"tileFilter":{}},` +
+		`{"name":"Host health","tileType":"HOSTS","configured":true,"bounds":{"top":38,"left":0,"width":304,"height":304},"tileFilter":{}}]}`
+	request, _ := http.NewRequest(http.MethodPost, server.URL+"/api/config/v1/dashboards", strings.NewReader(classic))
+	request.Header.Set("Authorization", "Api-Token "+mockserver.Token)
+	request.Header.Set("Content-Type", "application/json")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	folder := t.TempDir()
+	env := &export.Environment{
+		OutputFolder: folder,
+		Credentials:  server.Credentials(),
+		Modules:      map[export.ResourceType]*export.Module{},
+		Flags:        export.Flags{ConvertDashboards: true, SkipTerraformInit: true},
+		ResArgs:      map[string][]string{string(export.ResourceTypes.Documents): nil},
+	}
+	if err := env.Export(); err != nil {
+		t.Fatal(err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(folder, "modules", "document", "*.document.tf"))
+	if len(files) != 1 {
+		t.Fatalf("expected one converted dashboard, got %v", files)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the attributes are getting aligned
+	config := strings.Join(strings.Fields(string(data)), " ")
+	for _, expected := range []string{`type = "dashboard"`, `name = "Team Overview"`, "## Hosts", "ATTENTION Tile #1 'Host health' (HOSTS) could not be converted"} {
+		if !strings.Contains(config, expected) {
+			t.Errorf("expected `%s` within\n%s", expected, config)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package export
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -17,6 +18,18 @@ type DataSource struct {
 	Name       string
 	Kind       DataSourceKind
 	UniqueName string
+	// Selector is the entity selector the entity is looked up with in case of `-promote-tag`, instead of type and name
+	Selector string
+}
+
+// entityLookup produces the arguments of the `dynatrace_entity` data source looking up the entity
+func (me *DataSource) entityLookup() string {
+	if len(me.Selector) > 0 {
+		selector, _ := json.Marshal(me.Selector)
+		return fmt.Sprintf("entity_selector = %s", string(selector))
+	}
+	name, _ := json.Marshal(me.Name)
+	return fmt.Sprintf("type = \"%s\"\nname = %s", me.Type, string(name))
 }
 
 func AsDataSource(resource *Resource) string {
@@ -0,0 +1,312 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// pathSegments splits the path of a dependency rule into its segments.
+// `$.rules[*].condition` and `rules.condition` are equivalent
+func pathSegments(path string) []string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	segments := []string{}
+	for _, segment := range strings.Split(path, ".") {
+		if idx := strings.Index(segment, "["); idx >= 0 {
+			segment = segment[:idx]
+		}
+		if len(segment) > 0 {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// replaceAtPath applies `replace` to every value found at the given path.
+// `s` is either the body of an exported resource (starting with `" {`), a JSON payload or a complete HCL file.
+// Contents at other locations remain untouched, including their formatting
+func replaceAtPath(s string, segments []string, replace func(string) string) string {
+	trimmed := strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(s, `" {`):
+		return replaceInHCL(s, `resource "a" "b`, segments, replace)
+	case strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "["):
+		return replaceInJSON(s, segments, replace)
+	}
+	return replaceInHCL(s, "", segments, replace)
+}
+
+func replaceInHCL(s string, header string, segments []string, replace func(string) string) string {
+	file, diags := hclwrite.ParseConfig([]byte(header+s), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return s
+	}
+	changed := false
+	for _, block := range file.Body().Blocks() {
+		changed = replaceInBody(block.Body(), segments, replace) || changed
+	}
+	if !changed {
+		return s
+	}
+	return strings.TrimPrefix(string(file.Bytes()), header)
+}
+
+func replaceInBody(body *hclwrite.Body, segments []string, replace func(string) string) bool {
+	changed := false
+	name := segments[0]
+	if attribute := body.GetAttribute(name); attribute != nil {
+		expr := string(attribute.Expr().BuildTokens(nil).Bytes())
+		var replaced string
+		if len(segments) == 1 {
+			replaced = replace(expr)
+		} else {
+			replaced = replaceInEmbeddedJSON(expr, segments[1:], replace)
+		}
+		if replaced != expr {
+			body.SetAttributeRaw(name, hclwrite.Tokens{&hclwrite.Token{Type: hclsyntax.TokenStringLit, Bytes: []byte(replaced)}})
+			changed = true
+		}
+	}
+	if len(segments) > 1 {
+		for _, block := range body.Blocks() {
+			if block.Type() == name {
+				changed = replaceInBody(block.Body(), segments[1:], replace) || changed
+			}
+		}
+	}
+	return changed
+}
+
+// replaceInEmbeddedJSON handles attributes containing JSON, i.e. `jsonencode(...)` or heredoc strings
+func replaceInEmbeddedJSON(expr string, segments []string, replace func(string) string) string {
+	start := strings.IndexAny(expr, "{[")
+	end := strings.LastIndexAny(expr, "}]")
+	if start < 0 || end < start {
+		return expr
+	}
+	return expr[:start] + replaceInJSON(expr[start:end+1], segments, replace) + expr[end+1:]
+}
+
+// replaceInJSON applies `replace` to the JSON values found at the given path.
+// Arrays are traversed implicitly and strings containing JSON documents are traversed with the remaining segments.
+// Property names match irrespective of case and underscores, so `alerting_profile` matches `alertingProfile`.
+// Documents which can't be parsed remain untouched
+func replaceInJSON(s string, segments []string, replace func(string) string) string {
+	scanner := &jsonScanner{s: s, replace: replace}
+	scanner.skipSpace()
+	if !scanner.value(segments) || len(scanner.edits) == 0 {
+		return s
+	}
+	result := s
+	for i := len(scanner.edits) - 1; i >= 0; i-- {
+		edit := scanner.edits[i]
+		result = result[:edit.start] + edit.text + result[edit.end:]
+	}
+	return result
+}
+
+type jsonEdit struct {
+	start int
+	end   int
+	text  string
+}
+
+type jsonScanner struct {
+	s       string
+	pos     int
+	replace func(string) string
+	edits   []jsonEdit
+}
+
+func (me *jsonScanner) value(segments []string) bool {
+	if me.pos >= len(me.s) {
+		return false
+	}
+	start := me.pos
+	if len(segments) == 0 {
+		if !me.skipValue() {
+			return false
+		}
+		if replaced := me.replace(me.s[start:me.pos]); replaced != me.s[start:me.pos] {
+			me.edits = append(me.edits, jsonEdit{start, me.pos, replaced})
+		}
+		return true
+	}
+	switch me.s[me.pos] {
+	case '{':
+		return me.object(segments)
+	case '[':
+		return me.array(segments)
+	case '"':
+		raw, ok := me.str()
+		if !ok {
+			return false
+		}
+		var embedded string
+		if json.Unmarshal([]byte(raw), &embedded) != nil {
+			return true
+		}
+		if trimmed := strings.TrimSpace(embedded); !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+			return true
+		}
+		if replaced := replaceInJSON(embedded, segments, me.replace); replaced != embedded {
+			var buf bytes.Buffer
+			encoder := json.NewEncoder(&buf)
+			encoder.SetEscapeHTML(false)
+			encoder.Encode(replaced)
+			me.edits = append(me.edits, jsonEdit{start, me.pos, strings.TrimSuffix(buf.String(), "\n")})
+		}
+		return true
+	}
+	return me.skipValue()
+}
+
+func (me *jsonScanner) object(segments []string) bool {
+	me.pos++
+	for {
+		me.skipSpace()
+		if me.pos >= len(me.s) {
+			return false
+		}
+		if me.s[me.pos] == '}' {
+			me.pos++
+			return true
+		}
+		if me.s[me.pos] == ',' {
+			me.pos++
+			continue
+		}
+		raw, ok := me.str()
+		if !ok {
+			return false
+		}
+		var key string
+		if json.Unmarshal([]byte(raw), &key) != nil {
+			return false
+		}
+		me.skipSpace()
+		if me.pos >= len(me.s) || me.s[me.pos] != ':' {
+			return false
+		}
+		me.pos++
+		me.skipSpace()
+		if propertyNameMatches(key, segments[0]) {
+			ok = me.value(segments[1:])
+		} else {
+			ok = me.skipValue()
+		}
+		if !ok {
+			return false
+		}
+	}
+}
+
+func (me *jsonScanner) array(segments []string) bool {
+	me.pos++
+	for {
+		me.skipSpace()
+		if me.pos >= len(me.s) {
+			return false
+		}
+		switch me.s[me.pos] {
+		case ']':
+			me.pos++
+			return true
+		case ',':
+			me.pos++
+			continue
+		}
+		if !me.value(segments) {
+			return false
+		}
+	}
+}
+
+func (me *jsonScanner) str() (string, bool) {
+	if me.pos >= len(me.s) || me.s[me.pos] != '"' {
+		return "", false
+	}
+	start := me.pos
+	me.pos++
+	for me.pos < len(me.s) {
+		switch me.s[me.pos] {
+		case '\\':
+			me.pos += 2
+			continue
+		case '"':
+			me.pos++
+			return me.s[start:me.pos], true
+		}
+		me.pos++
+	}
+	return "", false
+}
+
+func (me *jsonScanner) skipValue() bool {
+	if me.pos >= len(me.s) {
+		return false
+	}
+	switch me.s[me.pos] {
+	case '"':
+		_, ok := me.str()
+		return ok
+	case '{', '[':
+		depth := 0
+		for me.pos < len(me.s) {
+			switch me.s[me.pos] {
+			case '"':
+				if _, ok := me.str(); !ok {
+					return false
+				}
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					me.pos++
+					return true
+				}
+			}
+			me.pos++
+		}
+		return false
+	}
+	start := me.pos
+	for me.pos < len(me.s) && !strings.ContainsRune(",}] \t\r\n", rune(me.s[me.pos])) {
+		me.pos++
+	}
+	return me.pos > start
+}
+
+func (me *jsonScanner) skipSpace() {
+	for me.pos < len(me.s) && strings.ContainsRune(" \t\r\n", rune(me.s[me.pos])) {
+		me.pos++
+	}
+}
+
+func propertyNameMatches(key string, segment string) bool {
+	normalize := func(s string) string { return strings.ToLower(strings.ReplaceAll(s, "_", "")) }
+	return key == segment || normalize(key) == normalize(segment)
+}
@@ -0,0 +1,393 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// DependencyRule declares a reference from the configuration of one resource type to another resource type or to entities.
+// Rules extend the `Dependencies` hard-wired into the `ResourceDescriptor` of a resource type. They're getting loaded via `-dependency-rules`.
+type DependencyRule struct {
+	// Resource is the resource type containing the reference
+	Resource ResourceType `json:"resource"`
+	// Schema restricts a rule for `dynatrace_generic_setting` to settings of the given schema
+	Schema string `json:"schema,omitempty"`
+	// Path is the location of the reference within the configuration, e.g. `rules.condition.key` or `value.alertingProfile`.
+	// Segments are names of blocks and attributes. Attributes containing JSON (e.g. the `value` of `dynatrace_generic_setting`)
+	// are traversed with the remaining segments. Arrays and repeated blocks are traversed implicitly, a `$.` prefix and `[*]` are optional.
+	// Without a path the whole configuration is subject to the rule.
+	Path string `json:"path,omitempty"`
+	// Target is the referenced resource type
+	Target ResourceType `json:"target,omitempty"`
+	// Entity is the referenced entity type, e.g. `HOST`. Only applicable with match mode `entity`
+	Entity string `json:"entity,omitempty"`
+	// Match defines how references are getting detected and which attribute of the target they are getting replaced with
+	Match MatchMode `json:"match"`
+	// Parent declares the target to be the parent of the resource
+	Parent bool `json:"parent,omitempty"`
+	// Coalesce refers to all entities of the configured type via a single data source. Only applicable with match mode `entity`
+	Coalesce bool `json:"coalesce,omitempty"`
+}
+
+type MatchMode string
+
+var MatchModes = struct {
+	ID                 MatchMode
+	QuotedID           MatchMode
+	WeakID             MatchMode
+	LegacyID           MatchMode
+	ResourceID         MatchMode
+	Name               MatchMode
+	Entity             MatchMode
+	DashboardLink      MatchMode
+	DashboardHyperLink MatchMode
+	Tenant             MatchMode
+	GlobalPolicy       MatchMode
+}{
+	"id",
+	"quoted_id",
+	"weak_id",
+	"legacy_id",
+	"resource_id",
+	"name",
+	"entity",
+	"dashboard_link",
+	"dashboard_hyperlink",
+	"tenant",
+	"global_policy",
+}
+
+// LoadDependencyRules reads the dependency rules contained in the given JSON file
+func LoadDependencyRules(file string) ([]*DependencyRule, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := ParseDependencyRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return rules, nil
+}
+
+// ParseDependencyRules parses and validates a JSON array of dependency rules
+func ParseDependencyRules(data []byte) ([]*DependencyRule, error) {
+	var rules []*DependencyRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, err
+	}
+	for idx, rule := range rules {
+		if _, err := rule.Dependency(); err != nil {
+			return nil, fmt.Errorf("rule #%d: %w", idx, err)
+		}
+	}
+	return rules, nil
+}
+
+// ApplyDependencyRules produces a copy of the given resource descriptors with the dependencies declared by the given rules added.
+// The given descriptors remain untouched
+func ApplyDependencyRules(descriptors map[ResourceType]ResourceDescriptor, rules []*DependencyRule) (map[ResourceType]ResourceDescriptor, error) {
+	result := make(map[ResourceType]ResourceDescriptor, len(descriptors))
+	for resourceType, descriptor := range descriptors {
+		result[resourceType] = descriptor
+	}
+	for _, rule := range rules {
+		dependency, err := rule.Dependency()
+		if err != nil {
+			return nil, err
+		}
+		descriptor := result[rule.Resource]
+		descriptor.Dependencies = append(slices.Clone(descriptor.Dependencies), dependency)
+		result[rule.Resource] = descriptor
+	}
+	return result, nil
+}
+
+// BuiltinDependencyRules expresses the dependencies of all resource types as dependency rules
+func BuiltinDependencyRules() ([]*DependencyRule, error) {
+	rules := []*DependencyRule{}
+	for _, resourceType := range sortedResourceTypes() {
+		for _, dependency := range AllResources[resourceType].Dependencies {
+			rule := describeDependency(dependency)
+			if rule == nil {
+				return nil, fmt.Errorf("the dependency %T of `%s` can't be expressed as rule", dependency, resourceType)
+			}
+			rule.Resource = resourceType
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+// Dependency produces the `Dependency` implementing the rule
+func (me *DependencyRule) Dependency() (Dependency, error) {
+	if _, found := AllResources[me.Resource]; !found {
+		return nil, fmt.Errorf("unknown resource type `%s`", me.Resource)
+	}
+	if len(me.Schema) > 0 && me.Resource != ResourceTypes.GenericSetting {
+		return nil, fmt.Errorf("`schema` is only supported for `%s`", ResourceTypes.GenericSetting)
+	}
+	if me.Match == MatchModes.Entity {
+		if len(me.Entity) == 0 || len(me.Target) > 0 {
+			return nil, errors.New("match mode `entity` requires `entity` instead of `target`")
+		}
+	} else {
+		if len(me.Entity) > 0 || me.Coalesce {
+			return nil, errors.New("`entity` and `coalesce` are only supported by match mode `entity`")
+		}
+		if len(me.Target) > 0 {
+			if _, found := AllResources[me.Target]; !found {
+				return nil, fmt.Errorf("unknown target resource type `%s`", me.Target)
+			}
+		}
+	}
+	if me.Parent && me.Match != MatchModes.ResourceID && me.Match != MatchModes.DashboardLink {
+		return nil, errors.New("`parent` is only supported by the match modes `resource_id` and `dashboard_link`")
+	}
+
+	switch me.Match {
+	case MatchModes.ID, MatchModes.QuotedID, MatchModes.WeakID, MatchModes.LegacyID, MatchModes.ResourceID, MatchModes.Name:
+		if len(me.Target) == 0 {
+			return nil, fmt.Errorf("match mode `%s` requires a `target`", me.Match)
+		}
+	case MatchModes.DashboardLink, MatchModes.DashboardHyperLink, MatchModes.Tenant, MatchModes.GlobalPolicy:
+		if len(me.Target) > 0 {
+			return nil, fmt.Errorf("match mode `%s` doesn't support a `target`", me.Match)
+		}
+	}
+
+	var dependency Dependency
+	switch me.Match {
+	case MatchModes.ID, MatchModes.QuotedID, MatchModes.WeakID:
+		dependency = &iddep{resourceType: me.Target, quoted: me.Match == MatchModes.QuotedID, onlyNonPostProcessed: me.Match == MatchModes.WeakID}
+	case MatchModes.LegacyID:
+		dependency = &legacyID{me.Target}
+	case MatchModes.ResourceID:
+		dependency = &resourceIDDep{me.Target, me.Parent}
+	case MatchModes.Name:
+		switch {
+		case me.Target == ResourceTypes.ManagementZoneV2 && len(me.Path) == 0:
+			dependency = &mgmzdep{me.Target}
+		case me.Target == ResourceTypes.RequestAttribute && len(me.Path) == 0:
+			dependency = &reqAttName{me.Target}
+		case len(me.Path) == 0:
+			// names are way too ambiguous for getting replaced everywhere within the configuration
+			return nil, fmt.Errorf("match mode `name` requires a `path` for target `%s`", me.Target)
+		default:
+			dependency = &namedep{me.Target}
+		}
+	case MatchModes.Entity:
+		dependency = &entityds{Type: me.Entity, Pattern: me.Entity + "-[A-Z0-9]{16}", Coalesce: me.Coalesce}
+	case MatchModes.DashboardLink:
+		dependency = &dashlinkdep{ResourceTypes.JSONDashboardBase, me.Parent}
+	case MatchModes.DashboardHyperLink:
+		dependency = &dashdep{ResourceTypes.JSONDashboardBase, false}
+	case MatchModes.Tenant:
+		dependency = &tenantds{}
+	case MatchModes.GlobalPolicy:
+		dependency = &policyds{}
+	default:
+		return nil, fmt.Errorf("unsupported match mode `%s`", me.Match)
+	}
+	if len(me.Path) == 0 && len(me.Schema) == 0 {
+		return dependency, nil
+	}
+	return &ruledep{rule: *me, segments: pathSegments(me.Path), base: dependency}, nil
+}
+
+// describeDependency is the inverse of `DependencyRule.Dependency`
+func describeDependency(dependency Dependency) *DependencyRule {
+	switch dep := dependency.(type) {
+	case *iddep:
+		match := MatchModes.ID
+		if dep.quoted {
+			match = MatchModes.QuotedID
+		} else if dep.onlyNonPostProcessed {
+			match = MatchModes.WeakID
+		}
+		return &DependencyRule{Target: dep.resourceType, Match: match}
+	case *legacyID:
+		return &DependencyRule{Target: dep.resourceType, Match: MatchModes.LegacyID}
+	case *resourceIDDep:
+		return &DependencyRule{Target: dep.resourceType, Match: MatchModes.ResourceID, Parent: dep.parent}
+	case *mgmzdep:
+		return &DependencyRule{Target: dep.resourceType, Match: MatchModes.Name}
+	case *reqAttName:
+		return &DependencyRule{Target: dep.resourceType, Match: MatchModes.Name}
+	case *namedep:
+		return &DependencyRule{Target: dep.resourceType, Match: MatchModes.Name}
+	case *entityds:
+		return &DependencyRule{Entity: dep.Type, Match: MatchModes.Entity, Coalesce: dep.Coalesce}
+	case *dashlinkdep:
+		return &DependencyRule{Match: MatchModes.DashboardLink, Parent: dep.parent}
+	case *dashdep:
+		return &DependencyRule{Match: MatchModes.DashboardHyperLink}
+	case *tenantds:
+		return &DependencyRule{Match: MatchModes.Tenant}
+	case *policyds:
+		return &DependencyRule{Match: MatchModes.GlobalPolicy}
+	case *ruledep:
+		rule := dep.rule
+		return &rule
+	}
+	return nil
+}
+
+// ruledep restricts another dependency to the configuration of a specific schema and/or to a specific location within the configuration
+type ruledep struct {
+	rule     DependencyRule
+	segments []string
+	base     Dependency
+}
+
+func (me *ruledep) IsParent() bool {
+	return me.base.IsParent()
+}
+
+func (me *ruledep) ResourceType() ResourceType {
+	return me.base.ResourceType()
+}
+
+func (me *ruledep) DataSourceType() DataSourceType {
+	return me.base.DataSourceType()
+}
+
+func (me *ruledep) Replace(environment *Environment, s string, replacingIn ResourceType, resourceId string, nonPostProcessedResources []*Resource) (string, []any) {
+	if len(me.rule.Schema) > 0 && !containsSchema(s, me.rule.Schema) {
+		return s, []any{}
+	}
+	if len(me.segments) == 0 {
+		return me.base.Replace(environment, s, replacingIn, resourceId, nonPostProcessedResources)
+	}
+
+	// the IDs extracted while resolving other dependencies are cached per resource.
+	// Extracting them from parts of the configuration must neither use nor pollute that cache
+	resource := environment.Module(replacingIn).Resources[resourceId]
+	if resource != nil {
+		extracted := resource.ExtractedIdsPerDependencyModule
+		defer func() { resource.ExtractedIdsPerDependencyModule = extracted }()
+	}
+
+	found := []any{}
+	replace := func(fragment string) string {
+		if resource != nil {
+			resource.ExtractedIdsPerDependencyModule = map[string]map[string]bool{}
+		}
+		replaced, items := me.base.Replace(environment, fragment, replacingIn, resourceId, nonPostProcessedResources)
+		found = append(found, items...)
+		return replaced
+	}
+	return replaceAtPath(s, me.segments, replace), uniqueItems(found)
+}
+
+func sortedResourceTypes() []ResourceType {
+	resourceTypes := make([]ResourceType, 0, len(AllResources))
+	for resourceType := range AllResources {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Slice(resourceTypes, func(i, j int) bool { return resourceTypes[i] < resourceTypes[j] })
+	return resourceTypes
+}
+
+var hclSchemaRegex = regexp.MustCompile(`(?m)^\s*schema\s*=\s*"([^"]*)"`)
+var jsonSchemaRegex = regexp.MustCompile(`"schemaId"\s*:\s*"([^"]*)"`)
+
+func containsSchema(s string, schemaID string) bool {
+	for _, regex := range []*regexp.Regexp{hclSchemaRegex, jsonSchemaRegex} {
+		if match := regex.FindStringSubmatch(s); match != nil {
+			return match[1] == schemaID
+		}
+	}
+	return false
+}
+
+func uniqueItems(items []any) []any {
+	result := []any{}
+	for _, item := range items {
+		found := false
+		for _, existing := range result {
+			if existing == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// namedep replaces the names of the resources of the target type with references to their `name` attribute.
+// It's only available for dependency rules with a path, because names are too ambiguous for being replaced within the whole configuration
+type namedep struct {
+	resourceType ResourceType
+}
+
+func (me *namedep) IsParent() bool {
+	return false
+}
+
+func (me *namedep) ResourceType() ResourceType {
+	return me.resourceType
+}
+
+func (me *namedep) DataSourceType() DataSourceType {
+	return ""
+}
+
+func (me *namedep) Replace(environment *Environment, s string, replacingIn ResourceType, resourceId string, nonPostProcessedResources []*Resource) (string, []any) {
+	resources := []any{}
+	for _, resource := range environment.Module(me.resourceType).Resources {
+		if resource.Status.IsOneOf(ResourceStati.Erronous, ResourceStati.Excluded) || len(resource.Name) == 0 {
+			continue
+		}
+		quoted, _ := json.Marshal(resource.Name)
+		if !strings.Contains(s, string(quoted)) {
+			continue
+		}
+		var replacePattern string
+		switch {
+		case resource.Type == replacingIn:
+			replacePattern = "${%s.%s.name}"
+		case resource.IsReferencedAsDataSource():
+			replacePattern = "${data.%s.%s.name}"
+		case environment.Flags.Flat:
+			replacePattern = "${%s.%s.name}"
+		case ATOMIC_DEPENDENCIES:
+			replacePattern = "${var.%s_%s.value.name}"
+		default:
+			replacePattern = "${var.%s.%s.name}"
+		}
+		resOrDsType := string(me.resourceType)
+		if resource.Type != replacingIn && resource.IsReferencedAsDataSource() {
+			resOrDsType = string(me.resourceType.AsDataSource())
+		}
+		s = strings.ReplaceAll(s, string(quoted), `"`+fmt.Sprintf(replacePattern, resOrDsType, resource.UniqueName)+`"`)
+		resources = append(resources, resource)
+	}
+	return s, resources
+}
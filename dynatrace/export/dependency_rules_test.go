@@ -0,0 +1,196 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+)
+
+func TestBuiltinDependencyRules(t *testing.T) {
+	rules, err := export.BuiltinDependencyRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := export.ParseDependencyRules(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed) != len(rules) {
+		t.Fatalf("expected %d rules, got %d", len(rules), len(parsed))
+	}
+
+	// every built-in dependency gets reproduced by the rule describing it
+	for idx, rule := range parsed {
+		dependency, err := rule.Dependency()
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := false
+		for _, existing := range export.AllResources[rule.Resource].Dependencies {
+			if reflect.DeepEqual(existing, dependency) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("rule #%d %+v doesn't reproduce any of the dependencies of `%s`", idx, rule, rule.Resource)
+		}
+	}
+}
+
+func TestParseDependencyRules(t *testing.T) {
+	for _, test := range []struct {
+		rules    string
+		expected string
+	}{
+		{`[{"resource":"dynatrace_unknown","target":"dynatrace_alerting","match":"id"}]`, "rule #0: unknown resource type `dynatrace_unknown`"},
+		{`[{"resource":"dynatrace_generic_setting","target":"dynatrace_unknown","match":"id"}]`, "rule #0: unknown target resource type `dynatrace_unknown`"},
+		{`[{"resource":"dynatrace_generic_setting","match":"id"}]`, "rule #0: match mode `id` requires a `target`"},
+		{`[{"resource":"dynatrace_generic_setting","target":"dynatrace_alerting","match":"whatever"}]`, "rule #0: unsupported match mode `whatever`"},
+		{`[{"resource":"dynatrace_alerting","schema":"app:my.app:schema","target":"dynatrace_alerting","match":"id"}]`, "rule #0: `schema` is only supported for `dynatrace_generic_setting`"},
+		{`[{"resource":"dynatrace_generic_setting","target":"dynatrace_alerting","match":"name"}]`, "rule #0: match mode `name` requires a `path` for target `dynatrace_alerting`"},
+		{`[{"resource":"dynatrace_generic_setting","target":"dynatrace_alerting","match":"entity"}]`, "rule #0: match mode `entity` requires `entity` instead of `target`"},
+		{`[{"resource":"dynatrace_generic_setting","target":"dynatrace_alerting","match":"id","parent":true}]`, "rule #0: `parent` is only supported by the match modes `resource_id` and `dashboard_link`"},
+	} {
+		if _, err := export.ParseDependencyRules([]byte(test.rules)); err == nil || err.Error() != test.expected {
+			t.Errorf("expected error `%s`, got `%v`", test.expected, err)
+		}
+	}
+
+	rules, err := export.ParseDependencyRules([]byte(`[{"resource":"dynatrace_generic_setting","schema":"app:my.app:schema","path":"$.value.profiles[*].alertingProfile","target":"dynatrace_alerting","match":"id"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 1 || rules[0].Path != "$.value.profiles[*].alertingProfile" {
+		t.Errorf("unexpected rules %+v", rules)
+	}
+}
+
+const dependencyRulesSchema = `{
+	"schemaId": "app:my.app:routing",
+	"version": "1.0.0",
+	"properties": {
+		"alertingProfile": {"type": "text", "nullable": false},
+		"fallbackProfile": {"type": "text", "nullable": false}
+	}
+}`
+
+func TestDependencyRulesGenericSetting(t *testing.T) {
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+	if err := server.AddSchema([]byte(dependencyRulesSchema)); err != nil {
+		t.Fatal(err)
+	}
+
+	profileID := createSettingsObject(t, server, "builtin:alerting.profile", `{"name":"team-a alerts","severityRules":[]}`)
+	createSettingsObject(t, server, "app:my.app:routing", `{"alertingProfile":"`+profileID+`","fallbackProfile":"`+profileID+`"}`)
+
+	builtin := len(export.AllResources[export.ResourceTypes.GenericSetting].Dependencies)
+	rules, err := export.ParseDependencyRules([]byte(`[
+		{"resource":"dynatrace_generic_setting","schema":"app:my.app:routing","path":"value.alertingProfile","target":"dynatrace_alerting","match":"id"},
+		{"resource":"dynatrace_generic_setting","schema":"app:my.app:other","target":"dynatrace_alerting","match":"id"}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	descriptors, err := export.ApplyDependencyRules(export.AllResources, rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(export.AllResources[export.ResourceTypes.GenericSetting].Dependencies) != builtin {
+		t.Fatal("expected the built-in resource descriptors to remain untouched")
+	}
+	if len(descriptors[export.ResourceTypes.GenericSetting].Dependencies) != builtin+2 {
+		t.Fatalf("expected %d dependencies, got %d", builtin+2, len(descriptors[export.ResourceTypes.GenericSetting].Dependencies))
+	}
+
+	for _, format := range []export.OutputFormat{export.OutputFormats.HCL, export.OutputFormats.Monaco} {
+		t.Run(string(format), func(t *testing.T) {
+			folder := t.TempDir()
+			env := &export.Environment{
+				OutputFolder: folder,
+				Credentials:  server.Credentials(),
+				Modules:      map[export.ResourceType]*export.Module{},
+				Flags:        export.Flags{FollowReferences: true, Format: format},
+				Descriptors:  descriptors,
+				ResArgs: map[string][]string{
+					string(export.ResourceTypes.GenericSetting): nil,
+					string(export.ResourceTypes.Alerting):       nil,
+				},
+			}
+			if err := env.Export(); err != nil {
+				t.Fatal(err)
+			}
+
+			config := readGenericSetting(t, folder, format)
+			if !regexp.MustCompile(`alertingProfile\\?"\s*:\s*\\?"(\$\{var\.dynatrace_alerting\.team-a_alerts\.id\}|\{\{\.alerting__team_a_alerts__id\}\})`).MatchString(config) {
+				t.Errorf("expected `alertingProfile` to refer to the alerting profile:\n%s", config)
+			}
+			if !regexp.MustCompile(`fallbackProfile\\?"\s*:\s*\\?"` + regexp.QuoteMeta(profileID)).MatchString(config) {
+				t.Errorf("expected `fallbackProfile` to remain untouched:\n%s", config)
+			}
+		})
+	}
+}
+
+func readGenericSetting(t *testing.T, folder string, format export.OutputFormat) string {
+	t.Helper()
+	var result string
+	filepath.Walk(folder, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		if !strings.HasSuffix(path, ".tf") && !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		// monaco templates only contain the value, the schema is part of the config.yaml next to them
+		schemaAware := string(data)
+		if format == export.OutputFormats.Monaco {
+			config, _ := os.ReadFile(filepath.Join(filepath.Dir(path), "config.yaml"))
+			schemaAware = string(config)
+		}
+		if strings.Contains(schemaAware, "app:my.app:routing") && strings.Contains(string(data), "fallbackProfile") {
+			result = string(data)
+		}
+		return nil
+	})
+	if len(result) == 0 {
+		t.Fatalf("no %s configuration found for the generic setting", format)
+	}
+	return result
+}
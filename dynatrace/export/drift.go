@@ -0,0 +1,578 @@
+/**
+* @license
+* Copyright 2023 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/spf13/afero"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+var DRIFT_REPORT_FOLDER = os.Getenv("DYNATRACE_DRIFT_REPORT_FOLDER")
+
+type DriftStatus string
+
+var DriftStati = struct {
+	Added   DriftStatus
+	Removed DriftStatus
+	Changed DriftStatus
+}{
+	"added",
+	"removed",
+	"changed",
+}
+
+// AttributeDiff describes a single attribute that differs between the baseline and the tenant.
+// Paths are flattened, e.g. `rules.0.conditions.1.operator`
+type AttributeDiff struct {
+	Path   string `json:"path"`
+	Before any    `json:"before,omitempty"`
+	After  any    `json:"after,omitempty"`
+}
+
+type DriftEntry struct {
+	ID                  string          `json:"id,omitempty"`
+	Name                string          `json:"name"`
+	Status              DriftStatus     `json:"status"`
+	Fingerprint         string          `json:"fingerprint,omitempty"`
+	PreviousFingerprint string          `json:"previous_fingerprint,omitempty"`
+	Diffs               []AttributeDiff `json:"diffs,omitempty"`
+}
+
+type DriftReport struct {
+	ResourceType ResourceType `json:"resource_type"`
+	Added        int          `json:"added"`
+	Removed      int          `json:"removed"`
+	Changed      int          `json:"changed"`
+	Unchanged    int          `json:"unchanged"`
+	Entries      []DriftEntry `json:"entries"`
+}
+
+func (me *DriftReport) HasDrift() bool {
+	return me.Added+me.Removed+me.Changed > 0
+}
+
+// DriftSnapshot is the flattened configuration of a single resource,
+// either parsed from a previously exported .tf file or from a terraform state file
+type DriftSnapshot struct {
+	ID         string
+	Name       string
+	Attributes map[string]any
+}
+
+func (me *DriftSnapshot) Fingerprint() string {
+	data, _ := json.Marshal(me.Attributes)
+	return GetHashName(string(data))
+}
+
+// DriftSnapshots holds the snapshots of resources, grouped by resource type
+type DriftSnapshots map[ResourceType][]*DriftSnapshot
+
+func (me DriftSnapshots) add(resourceType ResourceType, snapshot *DriftSnapshot) {
+	me[resourceType] = append(me[resourceType], snapshot)
+}
+
+var driftEvalContext = &hcl.EvalContext{
+	Functions: map[string]function.Function{
+		"jsonencode": stdlib.JSONEncodeFunc,
+	},
+}
+
+// LoadDriftFolder parses all `.tf` files within the given folder (including the module folders)
+// and returns the configuration of every resource block found in there.
+// The IDs of the resources are known only if the folder has been exported using the flag `-id`
+func LoadDriftFolder(folder string) (DriftSnapshots, error) {
+	snapshots := DriftSnapshots{}
+	err := filepath.Walk(folder, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			// `.terraform`, `.requires_attention` and `.flawed` only contain duplicates or foreign files
+			if filePath != folder && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(info.Name(), ".tf") {
+			return nil
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		return parseDriftFile(filePath, data, snapshots)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+func parseDriftFile(filePath string, data []byte, snapshots DriftSnapshots) error {
+	file, diags := hclsyntax.ParseConfig(data, filePath, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return fmt.Errorf("unable to parse `%s`: %s", filePath, diags.Error())
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil
+	}
+	lines := strings.Split(string(data), "\n")
+	for _, block := range body.Blocks {
+		if block.Type != "resource" || len(block.Labels) != 2 {
+			continue
+		}
+		resourceType := ResourceType(block.Labels[0])
+		if _, found := AllResources[resourceType]; !found {
+			continue
+		}
+		attributes := map[string]any{}
+		flattenDriftBody(block.Body, data, "", attributes)
+		snapshots.add(resourceType, &DriftSnapshot{
+			ID:         driftCommentedID(lines, block.DefRange().Start.Line),
+			Name:       block.Labels[1],
+			Attributes: attributes,
+		})
+	}
+	return nil
+}
+
+// driftCommentedID looks for a comment `# ID ...` right above the resource block starting at the given line
+func driftCommentedID(lines []string, blockLine int) string {
+	for idx := blockLine - 2; idx >= 0 && idx < len(lines); idx-- {
+		line := strings.TrimSpace(lines[idx])
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		if strings.HasPrefix(line, "# ID ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "# ID "))
+		}
+	}
+	return ""
+}
+
+func flattenDriftBody(body *hclsyntax.Body, src []byte, prefix string, attributes map[string]any) {
+	for name, attribute := range body.Attributes {
+		flattenDriftValue(prefix+name, driftExpressionValue(attribute.Expr, src), attributes)
+	}
+	counts := map[string]int{}
+	for _, block := range body.Blocks {
+		idx := counts[block.Type]
+		counts[block.Type] = idx + 1
+		flattenDriftBody(block.Body, src, fmt.Sprintf("%s%s.%d.", prefix, block.Type, idx), attributes)
+	}
+}
+
+// driftExpressionValue evaluates the given expression. Expressions which can't get evaluated
+// without a terraform context (references to variables, other resources or data sources)
+// are represented by their source code
+func driftExpressionValue(expr hclsyntax.Expression, src []byte) any {
+	value, diags := expr.Value(driftEvalContext)
+	if diags.HasErrors() || !value.IsWhollyKnown() {
+		return strings.TrimSpace(string(expr.Range().SliceBytes(src)))
+	}
+	if value.IsNull() {
+		return nil
+	}
+	data, err := ctyjson.Marshal(value, value.Type())
+	if err != nil {
+		return strings.TrimSpace(string(expr.Range().SliceBytes(src)))
+	}
+	var result any
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil
+	}
+	return result
+}
+
+// flattenDriftValue stores the given value with its full path into `attributes`.
+// Empty values are getting omitted, because terraform treats them the same way as absent values
+func flattenDriftValue(key string, value any, attributes map[string]any) {
+	switch v := value.(type) {
+	case nil:
+		return
+	case map[string]any:
+		for mk, mv := range v {
+			flattenDriftValue(key+"."+mk, mv, attributes)
+		}
+	case []any:
+		for idx, elem := range v {
+			flattenDriftValue(fmt.Sprintf("%s.%d", key, idx), elem, attributes)
+		}
+	case string:
+		if len(v) == 0 {
+			return
+		}
+		attributes[key] = normalizeDriftString(v)
+	default:
+		attributes[key] = v
+	}
+}
+
+// normalizeDriftString ensures that JSON payloads (dashboards, ...) which are
+// semantically equal are also equal as strings, regardless of formatting and key order
+func normalizeDriftString(s string) string {
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return s
+	}
+	var v any
+	if err := json.Unmarshal([]byte(trimmed), &v); err != nil {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return s
+	}
+	return string(data)
+}
+
+type driftStateFile struct {
+	Resources []struct {
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Instances []struct {
+			Attributes map[string]any `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+// LoadDriftState reads the managed resources from a terraform state file
+func LoadDriftState(filePath string) (DriftSnapshots, error) {
+	data, err := afero.ReadFile(afero.NewOsFs(), filePath)
+	if err != nil {
+		return nil, err
+	}
+	var stateFile driftStateFile
+	if err := json.Unmarshal(data, &stateFile); err != nil {
+		return nil, fmt.Errorf("unable to parse state file `%s`: %s", filePath, err.Error())
+	}
+	snapshots := DriftSnapshots{}
+	for _, res := range stateFile.Resources {
+		if res.Mode != "managed" {
+			continue
+		}
+		resourceType := ResourceType(res.Type)
+		if _, found := AllResources[resourceType]; !found {
+			continue
+		}
+		for _, inst := range res.Instances {
+			attributes := map[string]any{}
+			id := ""
+			for k, v := range inst.Attributes {
+				if k == "id" {
+					id, _ = v.(string)
+					continue
+				}
+				flattenDriftValue(k, v, attributes)
+			}
+			snapshots.add(resourceType, &DriftSnapshot{ID: id, Name: res.Name, Attributes: attributes})
+		}
+	}
+	return snapshots, nil
+}
+
+// CompareDrift calculates for every given resource type which resources have been added to, removed from
+// or changed on the tenant compared to the baseline.
+// Resources are matched by ID, if the baseline knows about them, otherwise by their unique name.
+// If `lenient` is set, attributes only known to the baseline are ignored. That's necessary when the
+// baseline is a state file, which also contains computed attributes and defaults
+func CompareDrift(resourceTypes []ResourceType, baseline DriftSnapshots, current DriftSnapshots, lenient bool) []*DriftReport {
+	reports := []*DriftReport{}
+	for _, resourceType := range resourceTypes {
+		report := &DriftReport{ResourceType: resourceType, Entries: []DriftEntry{}}
+
+		currentByID := map[string]*DriftSnapshot{}
+		currentByName := map[string]*DriftSnapshot{}
+		for _, snapshot := range current[resourceType] {
+			if len(snapshot.ID) > 0 {
+				currentByID[snapshot.ID] = snapshot
+			}
+			currentByName[snapshot.Name] = snapshot
+		}
+		matched := map[*DriftSnapshot]bool{}
+
+		for _, before := range baseline[resourceType] {
+			var after *DriftSnapshot
+			if len(before.ID) > 0 {
+				after = currentByID[before.ID]
+			} else {
+				after = currentByName[before.Name]
+			}
+			if after == nil || matched[after] {
+				report.Removed++
+				report.Entries = append(report.Entries, DriftEntry{ID: before.ID, Name: before.Name, Status: DriftStati.Removed, PreviousFingerprint: before.Fingerprint()})
+				continue
+			}
+			matched[after] = true
+			fingerprint, previousFingerprint := after.Fingerprint(), before.Fingerprint()
+			if fingerprint == previousFingerprint {
+				report.Unchanged++
+				continue
+			}
+			diffs := diffDriftAttributes(before.Attributes, after.Attributes, lenient)
+			if len(diffs) == 0 {
+				report.Unchanged++
+				continue
+			}
+			report.Changed++
+			report.Entries = append(report.Entries, DriftEntry{ID: after.ID, Name: after.Name, Status: DriftStati.Changed, Fingerprint: fingerprint, PreviousFingerprint: previousFingerprint, Diffs: diffs})
+		}
+
+		for _, after := range current[resourceType] {
+			if matched[after] {
+				continue
+			}
+			report.Added++
+			report.Entries = append(report.Entries, DriftEntry{ID: after.ID, Name: after.Name, Status: DriftStati.Added, Fingerprint: after.Fingerprint()})
+		}
+
+		sort.SliceStable(report.Entries, func(i, j int) bool {
+			if report.Entries[i].Status != report.Entries[j].Status {
+				return report.Entries[i].Status < report.Entries[j].Status
+			}
+			return report.Entries[i].Name < report.Entries[j].Name
+		})
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+func diffDriftAttributes(before map[string]any, after map[string]any, lenient bool) []AttributeDiff {
+	keys := map[string]bool{}
+	for k := range after {
+		keys[k] = true
+	}
+	if !lenient {
+		for k := range before {
+			keys[k] = true
+		}
+	}
+	sortedKeys := []string{}
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+
+	diffs := []AttributeDiff{}
+	for _, k := range sortedKeys {
+		if !reflect.DeepEqual(before[k], after[k]) {
+			diffs = append(diffs, AttributeDiff{Path: k, Before: before[k], After: after[k]})
+		}
+	}
+	return diffs
+}
+
+// WriteDriftReports writes a JSON and a Markdown report per resource type
+// plus a summary into the given folder
+func WriteDriftReports(folder string, reports []*DriftReport) error {
+	if err := os.MkdirAll(folder, os.ModePerm); err != nil {
+		return err
+	}
+	for _, report := range reports {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err = os.WriteFile(path.Join(folder, string(report.ResourceType)+".json"), data, 0664); err != nil {
+			return err
+		}
+		if err = os.WriteFile(path.Join(folder, string(report.ResourceType)+".md"), report.Markdown(), 0664); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(path.Join(folder, "summary.json"), data, 0664); err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	w := bufio.NewWriter(buf)
+	fmt.Fprintln(w, "# Drift Report")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Resource Type | Added | Removed | Changed | Unchanged |")
+	fmt.Fprintln(w, "|---|---:|---:|---:|---:|")
+	for _, report := range reports {
+		fmt.Fprintf(w, "| [%s](%s.md) | %d | %d | %d | %d |\n", report.ResourceType, report.ResourceType, report.Added, report.Removed, report.Changed, report.Unchanged)
+	}
+	w.Flush()
+	return os.WriteFile(path.Join(folder, "summary.md"), buf.Bytes(), 0664)
+}
+
+func (me *DriftReport) Markdown() []byte {
+	buf := new(bytes.Buffer)
+	w := bufio.NewWriter(buf)
+	fmt.Fprintf(w, "# %s\n\n", me.ResourceType)
+	fmt.Fprintf(w, "Added: %d, Removed: %d, Changed: %d, Unchanged: %d\n", me.Added, me.Removed, me.Changed, me.Unchanged)
+	for _, status := range []DriftStatus{DriftStati.Added, DriftStati.Removed, DriftStati.Changed} {
+		first := true
+		for _, entry := range me.Entries {
+			if entry.Status != status {
+				continue
+			}
+			if first {
+				fmt.Fprintf(w, "\n## %s%s\n\n", strings.ToUpper(string(status[:1])), status[1:])
+				first = false
+			}
+			title := "`" + entry.Name + "`"
+			if len(entry.ID) > 0 {
+				title = title + " (ID `" + entry.ID + "`)"
+			}
+			if status != DriftStati.Changed {
+				fmt.Fprintf(w, "- %s\n", title)
+				continue
+			}
+			fmt.Fprintf(w, "### %s\n\n", title)
+			fmt.Fprintln(w, "| Attribute | Before | After |")
+			fmt.Fprintln(w, "|---|---|---|")
+			for _, diff := range entry.Diffs {
+				fmt.Fprintf(w, "| `%s` | %s | %s |\n", diff.Path, markdownDriftValue(diff.Before), markdownDriftValue(diff.After))
+			}
+			fmt.Fprintln(w)
+		}
+	}
+	w.Flush()
+	return buf.Bytes()
+}
+
+func markdownDriftValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return "`" + strings.ReplaceAll(string(data), "|", "\\|") + "`"
+}
+
+// Drift downloads the configuration of the requested resource types into a temporary folder and compares it with
+// the configuration exported previously into the target folder - or, if `DYNATRACE_PREV_STATE_PATH_THIS`
+// is set, with the resources stored in that state file. The terraform binary isn't required for that.
+func (me *Environment) Drift() (reports []*DriftReport, err error) {
+	var baseline DriftSnapshots
+	lenient := false
+	if len(PREV_STATE_PATH_THIS) > 0 {
+		fmt.Printf("Loading baseline from state file `%s` ...\n", PREV_STATE_PATH_THIS)
+		if baseline, err = LoadDriftState(PREV_STATE_PATH_THIS); err != nil {
+			return nil, err
+		}
+		lenient = true
+	} else {
+		fmt.Printf("Loading baseline from folder `%s` ...\n", me.OutputFolder)
+		if _, err = os.Stat(me.OutputFolder); err != nil {
+			return nil, fmt.Errorf("no baseline to compare with: %s", err.Error())
+		}
+		if baseline, err = LoadDriftFolder(me.OutputFolder); err != nil {
+			return nil, err
+		}
+	}
+
+	reportFolder := DRIFT_REPORT_FOLDER
+	if len(reportFolder) == 0 {
+		reportFolder = path.Join(me.OutputFolder, ".drift")
+	}
+
+	var downloadFolder string
+	if downloadFolder, err = os.MkdirTemp("", "dynatrace-drift-"); err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(downloadFolder)
+	baselineFolder := me.OutputFolder
+	me.OutputFolder = downloadFolder
+	defer func() { me.OutputFolder = baselineFolder }()
+	// the commented IDs allow for matching the downloaded resources with the baseline
+	me.Flags.PersistIDs = true
+
+	if err = me.PreProcess(); err != nil {
+		return nil, err
+	}
+	if err = me.InitialDownload(); err != nil {
+		return nil, err
+	}
+	if err = me.PostProcess(); err != nil {
+		return nil, err
+	}
+
+	var current DriftSnapshots
+	if current, err = LoadDriftFolder(downloadFolder); err != nil {
+		return nil, err
+	}
+
+	resourceTypes := []ResourceType{}
+	for sResourceType, keys := range me.ResArgs {
+		resourceType := ResourceType(sResourceType)
+		if module, found := me.Modules[resourceType]; found && module.Status == ModuleStati.Erronous {
+			// an inaccessible module would otherwise get reported as entirely removed
+			fmt.Printf("Skipping `%s`: %v\n", resourceType, module.Error)
+			continue
+		}
+		if len(keys) > 0 {
+			baseline[resourceType] = filterDriftSnapshots(baseline[resourceType], keys)
+		}
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Slice(resourceTypes, func(i, j int) bool { return resourceTypes[i] < resourceTypes[j] })
+
+	reports = CompareDrift(resourceTypes, baseline, current, lenient)
+	if err = WriteDriftReports(reportFolder, reports); err != nil {
+		return nil, err
+	}
+
+	for _, report := range reports {
+		if report.HasDrift() {
+			fmt.Printf("- %s: %d added, %d removed, %d changed\n", report.ResourceType, report.Added, report.Removed, report.Changed)
+		}
+	}
+	fmt.Printf("Drift report written to `%s`\n", reportFolder)
+	return reports, nil
+}
+
+// filterDriftSnapshots keeps only the snapshots with the given IDs, for exports of individual resources
+func filterDriftSnapshots(snapshots []*DriftSnapshot, ids []string) []*DriftSnapshot {
+	result := []*DriftSnapshot{}
+	for _, snapshot := range snapshots {
+		for _, id := range ids {
+			if snapshot.ID == id {
+				result = append(result, snapshot)
+				break
+			}
+		}
+	}
+	return result
+}
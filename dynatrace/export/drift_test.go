@@ -0,0 +1,158 @@
+/**
+* @license
+* Copyright 2023 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+)
+
+const driftBaselineTF = `# ID vu9U3hXa3q0AAAABABhidWlsdGluOmFsZXJ0aW5nLnByb2ZpbGUABnRlbmFudAAGdGVuYW50ACRhYmM
+resource "dynatrace_alerting" "Default" {
+  name = "Default"
+  rules {
+    rule {
+      include_mode     = "NONE"
+      delay_in_minutes = 0
+      severity_level   = "AVAILABILITY"
+    }
+  }
+}
+
+resource "dynatrace_alerting" "Removed" {
+  name = "Removed"
+}
+`
+
+const driftCurrentTF = `# ID vu9U3hXa3q0AAAABABhidWlsdGluOmFsZXJ0aW5nLnByb2ZpbGUABnRlbmFudAAGdGVuYW50ACRhYmM
+resource "dynatrace_alerting" "Default_renamed" {
+  name = "Default"
+  rules {
+    rule {
+      include_mode     = "NONE"
+      delay_in_minutes = 30
+      severity_level   = "AVAILABILITY"
+    }
+  }
+}
+
+resource "dynatrace_alerting" "Added" {
+  name = "Added"
+  management_zone = var.dynatrace_management_zone_v2.id
+}
+`
+
+func writeDriftFolder(t *testing.T, content string) string {
+	folder := t.TempDir()
+	moduleFolder := filepath.Join(folder, "modules", "alerting")
+	if err := os.MkdirAll(moduleFolder, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(moduleFolder, "alerting.tf"), []byte(content), 0664); err != nil {
+		t.Fatal(err)
+	}
+	return folder
+}
+
+func TestDriftFromFolder(t *testing.T) {
+	baseline, err := export.LoadDriftFolder(writeDriftFolder(t, driftBaselineTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := export.LoadDriftFolder(writeDriftFolder(t, driftCurrentTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(baseline[export.ResourceTypes.Alerting]) != 2 {
+		t.Fatalf("expected 2 resources in baseline, found %d", len(baseline[export.ResourceTypes.Alerting]))
+	}
+
+	reports := export.CompareDrift([]export.ResourceType{export.ResourceTypes.Alerting}, baseline, current, false)
+	if len(reports) != 1 {
+		t.Fatalf("expected 1 report, got %d", len(reports))
+	}
+	report := reports[0]
+	if report.Added != 1 || report.Removed != 1 || report.Changed != 1 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	for _, entry := range report.Entries {
+		if entry.Status != export.DriftStati.Changed {
+			continue
+		}
+		if len(entry.Diffs) != 1 {
+			t.Fatalf("expected exactly one attribute diff, got %+v", entry.Diffs)
+		}
+		diff := entry.Diffs[0]
+		if diff.Path != "rules.0.rule.0.delay_in_minutes" || diff.Before != float64(0) || diff.After != float64(30) {
+			t.Errorf("unexpected diff %+v", diff)
+		}
+	}
+
+	reportFolder := t.TempDir()
+	if err := export.WriteDriftReports(reportFolder, reports); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"dynatrace_alerting.json", "dynatrace_alerting.md", "summary.json", "summary.md"} {
+		if _, err := os.Stat(filepath.Join(reportFolder, name)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestDriftFromState(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "terraform.tfstate")
+	if err := os.WriteFile(stateFile, []byte(`{
+  "version": 4,
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "dynatrace_alerting",
+      "name": "Default",
+      "instances": [
+        {
+          "attributes": {
+            "id": "vu9U3hXa3q0AAAABABhidWlsdGluOmFsZXJ0aW5nLnByb2ZpbGUABnRlbmFudAAGdGVuYW50ACRhYmM",
+            "name": "Default",
+            "legacy_id": "computed",
+            "management_zone": "",
+            "rules": [{"rule": [{"include_mode": "NONE", "delay_in_minutes": 30, "severity_level": "AVAILABILITY", "tags": null}]}]
+          }
+        }
+      ]
+    }
+  ]
+}`), 0664); err != nil {
+		t.Fatal(err)
+	}
+	baseline, err := export.LoadDriftState(stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := export.LoadDriftFolder(writeDriftFolder(t, driftCurrentTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := export.CompareDrift([]export.ResourceType{export.ResourceTypes.Alerting}, baseline, current, true)[0]
+	if report.Added != 1 || report.Removed != 0 || report.Changed != 0 || report.Unchanged != 1 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+}
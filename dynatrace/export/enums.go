@@ -473,6 +473,24 @@ var ResourceTypes = struct {
 	KubernetesSPM                       ResourceType
 	LogAgentFeatureFlags                ResourceType
 	ProblemRecordPropagationRules       ResourceType
+	OpenPipelineLogsPipeline            ResourceType
+	OpenPipelineLogsRouting             ResourceType
+	OpenPipelineLogsEndpoint            ResourceType
+	OpenPipelineEventsPipeline          ResourceType
+	OpenPipelineEventsRouting           ResourceType
+	OpenPipelineEventsEndpoint          ResourceType
+	OpenPipelineSecurityEventsPipeline  ResourceType
+	OpenPipelineSecurityEventsRouting   ResourceType
+	OpenPipelineSecurityEventsEndpoint  ResourceType
+	OpenPipelineBusinessEventsPipeline  ResourceType
+	OpenPipelineBusinessEventsRouting   ResourceType
+	OpenPipelineBusinessEventsEndpoint  ResourceType
+	OpenPipelineSDLCEventsPipeline      ResourceType
+	OpenPipelineSDLCEventsRouting       ResourceType
+	OpenPipelineSDLCEventsEndpoint      ResourceType
+	ExtensionPackage                    ResourceType
+	IAMGroupMembers                     ResourceType
+	IAMGroupMember                      ResourceType
 }{
 	"dynatrace_autotag",
 	"dynatrace_autotag_v2",
@@ -793,6 +811,24 @@ var ResourceTypes = struct {
 	"dynatrace_kubernetes_spm",
 	"dynatrace_log_agent_feature_flags",
 	"dynatrace_problem_record_propagation_rules",
+	"dynatrace_openpipeline_logs_pipeline",
+	"dynatrace_openpipeline_logs_routing_entry",
+	"dynatrace_openpipeline_logs_endpoint",
+	"dynatrace_openpipeline_events_pipeline",
+	"dynatrace_openpipeline_events_routing_entry",
+	"dynatrace_openpipeline_events_endpoint",
+	"dynatrace_openpipeline_security_events_pipeline",
+	"dynatrace_openpipeline_security_events_routing_entry",
+	"dynatrace_openpipeline_security_events_endpoint",
+	"dynatrace_openpipeline_business_events_pipeline",
+	"dynatrace_openpipeline_business_events_routing_entry",
+	"dynatrace_openpipeline_business_events_endpoint",
+	"dynatrace_openpipeline_sdlc_events_pipeline",
+	"dynatrace_openpipeline_sdlc_events_routing_entry",
+	"dynatrace_openpipeline_sdlc_events_endpoint",
+	"dynatrace_extension_package",
+	"dynatrace_iam_group_members",
+	"dynatrace_iam_group_member",
 }
 
 func (me ResourceType) GetFolderName(override string) string {
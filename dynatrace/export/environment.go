@@ -66,6 +66,20 @@ type Environment struct {
 	ChildParentGroups     map[ResourceType]ResourceType
 	IsParentMap           map[ResourceType]bool
 	HasDependenciesTo     map[ResourceType]bool
+	Manifest              *Manifest
+	Filter                *ResourceFilter
+	Variables             *Variables
+	Descriptors           map[ResourceType]ResourceDescriptor // AllResources plus the dependencies declared via `-dependency-rules`. Defaults to AllResources
+}
+
+// Descriptor returns the descriptor of the given resource type used for this export
+func (me *Environment) Descriptor(resourceType ResourceType) (ResourceDescriptor, bool) {
+	descriptors := AllResources
+	if me != nil && me.Descriptors != nil {
+		descriptors = me.Descriptors
+	}
+	descriptor, found := descriptors[resourceType]
+	return descriptor, found
 }
 
 func (me *Environment) TenantID() string {
@@ -125,7 +139,7 @@ func (me *Environment) FetchEntity(id string) *DataSource {
 	service := cache.Read(entity.DataSourceService(me.Credentials))
 	var entity entitysettings.Entity
 	if err := service.Get(context.Background(), id, &entity); err == nil {
-		return &DataSource{ID: *entity.EntityId, Name: *entity.DisplayName, Type: *entity.Type, Kind: DataSourceKindEntity}
+		return &DataSource{ID: *entity.EntityId, Name: *entity.DisplayName, Type: *entity.Type, Kind: DataSourceKindEntity, Selector: me.promotionSelector(&entity)}
 	}
 	return nil
 }
@@ -154,7 +168,12 @@ func (me *Environment) PreProcess() error {
 	me.ProcessChildParentGroups()
 	me.ProcessHasDependenciesTo()
 
-	err := me.LoadImportState()
+	err := me.InitManifest()
+	if err != nil {
+		return err
+	}
+
+	err = me.LoadImportState()
 	if err != nil {
 		return err
 	}
@@ -188,7 +207,8 @@ func (me *Environment) ProcessChildParentGroups() {
 func (me *Environment) ProcessHasDependenciesTo() {
 	me.HasDependenciesTo = map[ResourceType]bool{}
 
-	for _, resource := range AllResources {
+	for resourceType := range AllResources {
+		resource, _ := me.Descriptor(resourceType)
 		for _, dep := range resource.Dependencies {
 			resSource := dep.ResourceType()
 			if resSource == "" {
@@ -324,7 +344,7 @@ func (me *Environment) InitialDownload() error {
 		}
 	}
 
-	return nil
+	return me.Manifest.Save()
 }
 
 func (me *Environment) PostProcess() error {
@@ -354,6 +374,7 @@ func (me *Environment) PostProcess() error {
 					if err := resource.PostProcess(resources); err != nil {
 						return err
 					}
+					me.Manifest.Record(resource)
 					fmt.Print("\r")
 					fmt.Printf("- [POSTPROCESS] %s - %s", resource.Type, resource.UniqueName)
 
@@ -415,6 +436,7 @@ func (me *Environment) PostProcess() error {
 					if err := resource.PostProcess(resources); err != nil {
 						return err
 					}
+					me.Manifest.Record(resource)
 					fmt.Print(ClearLine)
 					fmt.Print("\r")
 					fmt.Printf("- [POSTPROCESS] %s (%d of %d)", k, idx+1, len(reslist))
@@ -429,6 +451,15 @@ func (me *Environment) PostProcess() error {
 		}
 	}
 
+	if err := me.Manifest.Save(); err != nil {
+		return err
+	}
+
+	// JSON payloads of child resources remain separate files
+	if !me.Flags.Format.IsHCL() {
+		return nil
+	}
+
 	resourcesToVoid := map[ResourceType]map[string]*Resource{}
 
 	fmt.Println("Post-Processing Resources - Group child configs with parent configs ...")
@@ -555,6 +586,19 @@ func (me *Environment) Finish() (err error) {
 		return nil
 	}
 
+	if me.Flags.Graph {
+		if err = me.WriteGraph(); err != nil {
+			return err
+		}
+	}
+
+	if !me.Flags.Format.IsHCL() {
+		if err = me.WritePayloadManifests(); err != nil {
+			return err
+		}
+		return me.Manifest.Finish()
+	}
+
 	if err = me.WriteResourceFiles(); err != nil {
 		return err
 	}
@@ -570,10 +614,13 @@ func (me *Environment) Finish() (err error) {
 	if err = me.WriteProviderFiles(); err != nil {
 		return err
 	}
+	if err = me.WriteInputVariablesFiles(); err != nil {
+		return err
+	}
 	if err = me.RemoveNonReferencedModules(); err != nil {
 		return err
 	}
-	return nil
+	return me.Manifest.Finish()
 }
 
 func (me *Environment) Module(resType ResourceType) *Module {
@@ -594,6 +641,7 @@ func (me *Environment) Module(resType ResourceType) *Module {
 		DataSourceLock:       new(sync.Mutex),
 		ChildModules:         map[ResourceType]*Module{},
 	}
+	me.Manifest.blockNames(module, me.ChildParentGroups)
 
 	me.Modules[resType] = module
 	return module
@@ -1001,11 +1049,13 @@ func (me *Environment) WriteMainFile() error {
 				}
 			}
 		}
+		me.writeVariableArguments(mainFile, resourceType)
 		writeClosingMainSection(mainFile)
 
 		if module.SplitPathModuleNameMap != nil {
 			for _, splitName := range module.SplitPathModuleNameMap {
 				me.writeOpeningMainSection(mainFile, splitName, fmt.Sprintf("./modules/%s", splitName))
+				me.writeVariableArguments(mainFile, resourceType)
 				writeClosingMainSection(mainFile)
 			}
 		}
@@ -1177,7 +1227,9 @@ func (me *Environment) FinishExport() error {
 	address.SaveOriginalMap(me.OutputFolder)
 	address.SaveCompletedMap(me.OutputFolder)
 
-	if QUICK_INIT {
+	if !me.Flags.Format.IsHCL() {
+		// pass
+	} else if QUICK_INIT {
 		err := me.WriteQuickModulesJSON()
 		if err != nil {
 			return err
@@ -1245,7 +1297,7 @@ type TerraformInitModule struct {
 
 func (me *Environment) RunQuickInit() error {
 
-	if QUICK_INIT {
+	if QUICK_INIT && me.Flags.Format.IsHCL() {
 		// pass
 	} else {
 		return nil
@@ -0,0 +1,255 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export/multiuse"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
+)
+
+// stringsFlag allows a command line flag to get specified multiple times
+type stringsFlag []string
+
+func (me *stringsFlag) String() string {
+	return strings.Join(*me, ",")
+}
+
+func (me *stringsFlag) Set(value string) error {
+	*me = append(*me, value)
+	return nil
+}
+
+// FilterArgs contains the command line flags `-name-regex`, `-management-zone`, `-modified-since` and `-match-any`
+type FilterArgs struct {
+	NameRegexes     []string
+	ManagementZones []string
+	ModifiedSince   string
+	MatchAny        bool
+}
+
+// ResourceFilter decides during discovery which resources are getting exported.
+//
+// Every kind of predicate matches if any of its values matches, e.g. one of several name patterns.
+// The kinds of predicates are combined with AND, or with OR if `MatchAny` is set.
+// Predicates that can't get evaluated for a resource (e.g. the modification time for
+// resource types that don't report it) are ignored for that resource.
+type ResourceFilter struct {
+	NameRegexes     []*regexp.Regexp
+	ManagementZones []string
+	ModifiedSince   *time.Time
+	MatchAny        bool
+
+	mzOnce  sync.Once
+	mzNames map[string]bool
+	mzRegex *regexp.Regexp
+	mzErr   error
+}
+
+// NewResourceFilter validates the given arguments.
+// It returns `nil` if no filter has been specified
+func NewResourceFilter(args FilterArgs) (*ResourceFilter, error) {
+	if len(args.NameRegexes) == 0 && len(args.ManagementZones) == 0 && len(args.ModifiedSince) == 0 {
+		if args.MatchAny {
+			return nil, errors.New("-match-any requires at least one of -name-regex, -management-zone or -modified-since")
+		}
+		return nil, nil
+	}
+	filter := &ResourceFilter{MatchAny: args.MatchAny, ManagementZones: args.ManagementZones}
+	for _, expr := range args.NameRegexes {
+		regex, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value `%s` for -name-regex: %s", expr, err.Error())
+		}
+		filter.NameRegexes = append(filter.NameRegexes, regex)
+	}
+	if len(args.ModifiedSince) > 0 {
+		modifiedSince, err := time.Parse(time.RFC3339, args.ModifiedSince)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value `%s` for -modified-since: expected a timestamp like `2024-01-31T00:00:00Z`", args.ModifiedSince)
+		}
+		filter.ModifiedSince = &modifiedSince
+	}
+	return filter, nil
+}
+
+// String produces a stable representation of the filter, which allows to detect
+// whether a resumed export uses the same filter as the interrupted one
+func (me *ResourceFilter) String() string {
+	if me == nil {
+		return ""
+	}
+	parts := []string{}
+	for _, regex := range me.NameRegexes {
+		parts = append(parts, "-name-regex="+regex.String())
+	}
+	mzs := append([]string{}, me.ManagementZones...)
+	sort.Strings(mzs)
+	for _, mz := range mzs {
+		parts = append(parts, "-management-zone="+mz)
+	}
+	if me.ModifiedSince != nil {
+		parts = append(parts, "-modified-since="+me.ModifiedSince.Format(time.RFC3339))
+	}
+	if me.MatchAny {
+		parts = append(parts, "-match-any")
+	}
+	return strings.Join(parts, " ")
+}
+
+// Matches evaluates the filter against a stub returned by the `List` function of the given module.
+// The management zone predicate requires the configuration of the resource. If the `List` function
+// didn't deliver it, it's getting fetched via `Get`.
+func (me *ResourceFilter) Matches(module *Module, stub *api.Stub) (bool, error) {
+	if me == nil || module == nil {
+		return me.MatchesStub(nil, "", stub, nil)
+	}
+	return me.MatchesStub(module.Environment.Credentials, module.Type, stub, func() (any, error) {
+		settngs := module.GetDescriptor().NewSettings()
+		if err := module.Service.Get(context.Background(), multiuse.EncodeIDParent(stub.ID, stub.ParentID), settngs); err != nil {
+			return nil, err
+		}
+		return settngs, nil
+	})
+}
+
+// MatchesStub evaluates the filter against a stub outside of an export.
+// The function `fetch` gets invoked in case the management zone predicate requires the
+// configuration of the resource, but the stub doesn't contain it.
+func (me *ResourceFilter) MatchesStub(credentials *settings.Credentials, resourceType ResourceType, stub *api.Stub, fetch func() (any, error)) (bool, error) {
+	if me == nil {
+		return true, nil
+	}
+	predicates := []func() (matches bool, applicable bool, err error){
+		func() (bool, bool, error) { return me.matchesName(stub) },
+		func() (bool, bool, error) { return me.matchesModificationTime(stub) },
+		func() (bool, bool, error) { return me.matchesManagementZone(credentials, resourceType, stub, fetch) },
+	}
+	evaluated := false
+	for _, predicate := range predicates {
+		matches, applicable, err := predicate()
+		if err != nil {
+			return false, err
+		}
+		if !applicable {
+			continue
+		}
+		evaluated = true
+		if me.MatchAny && matches {
+			return true, nil
+		}
+		if !me.MatchAny && !matches {
+			return false, nil
+		}
+	}
+	return !me.MatchAny || !evaluated, nil
+}
+
+func (me *ResourceFilter) matchesName(stub *api.Stub) (bool, bool, error) {
+	if len(me.NameRegexes) == 0 {
+		return false, false, nil
+	}
+	for _, regex := range me.NameRegexes {
+		if regex.MatchString(stub.Name) {
+			return true, true, nil
+		}
+	}
+	return false, true, nil
+}
+
+func (me *ResourceFilter) matchesModificationTime(stub *api.Stub) (bool, bool, error) {
+	if me.ModifiedSince == nil || stub.LastModified == nil {
+		return false, false, nil
+	}
+	return !stub.LastModified.Before(*me.ModifiedSince), true, nil
+}
+
+func (me *ResourceFilter) matchesManagementZone(credentials *settings.Credentials, resourceType ResourceType, stub *api.Stub, fetch func() (any, error)) (bool, bool, error) {
+	if len(me.ManagementZones) == 0 {
+		return false, false, nil
+	}
+	me.mzOnce.Do(func() { me.mzErr = me.resolveManagementZones(credentials) })
+	if me.mzErr != nil {
+		return false, false, me.mzErr
+	}
+
+	// management zones match themselves
+	if resourceType == ResourceTypes.ManagementZoneV2 || resourceType == ResourceTypes.ManagementZone {
+		return me.mzNames[stub.Name], true, nil
+	}
+
+	value := stub.Value
+	if value == nil && fetch == nil {
+		return false, false, nil
+	}
+	if value == nil {
+		var err error
+		if value, err = fetch(); err != nil {
+			logging.Debug.Warn.Printf("[DISCOVER] [%s] [%s] Unable to evaluate -management-zone: %s", resourceType, stub.ID, err.Error())
+			return false, false, nil
+		}
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, false, err
+	}
+	return me.mzRegex.Match(data), true, nil
+}
+
+// resolveManagementZones looks up the IDs of the management zones specified by name.
+// Configuration refers to management zones either via their Settings 2.0 object ID or their numeric ID.
+func (me *ResourceFilter) resolveManagementZones(credentials *settings.Credentials) error {
+	service := AllResources[ResourceTypes.ManagementZoneV2].Service(credentials)
+	stubs, err := service.List(context.Background())
+	if err != nil {
+		return fmt.Errorf("unable to resolve -management-zone: %s", err.Error())
+	}
+	me.mzNames = map[string]bool{}
+	ids := []string{}
+	for _, name := range me.ManagementZones {
+		found := false
+		for _, stub := range stubs {
+			if stub.Name != name {
+				continue
+			}
+			found = true
+			ids = append(ids, regexp.QuoteMeta(stub.ID))
+			if stub.LegacyID != nil {
+				ids = append(ids, regexp.QuoteMeta(*stub.LegacyID))
+			}
+		}
+		if !found {
+			return fmt.Errorf("management zone `%s` doesn't exist", name)
+		}
+		me.mzNames[name] = true
+	}
+	// IDs need to match as a whole, not as part of another ID
+	me.mzRegex = regexp.MustCompile(`(?:^|[^\w+/=-])(?:` + strings.Join(ids, "|") + `)(?:[^\w+/=-]|$)`)
+	return nil
+}
@@ -0,0 +1,144 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+)
+
+func TestResourceFilter(t *testing.T) {
+	lastWeek := time.Date(2024, 1, 24, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	teamStub := &api.Stub{ID: "1", Name: "team-a: availability", LastModified: &lastWeek}
+	recentStub := &api.Stub{ID: "2", Name: "team-b: errors", LastModified: &today}
+	configV1Stub := &api.Stub{ID: "3", Name: "team-b: dashboard"}
+
+	tests := []struct {
+		name    string
+		args    export.FilterArgs
+		matches []*api.Stub
+	}{
+		{"name", export.FilterArgs{NameRegexes: []string{"^team-a:"}}, []*api.Stub{teamStub}},
+		{"names", export.FilterArgs{NameRegexes: []string{"^team-a:", "dashboard$"}}, []*api.Stub{teamStub, configV1Stub}},
+		{"modified-since", export.FilterArgs{ModifiedSince: "2024-01-30T00:00:00Z"}, []*api.Stub{recentStub, configV1Stub}},
+		{"and", export.FilterArgs{NameRegexes: []string{"^team-b:"}, ModifiedSince: "2024-01-30T00:00:00Z"}, []*api.Stub{recentStub, configV1Stub}},
+		{"or", export.FilterArgs{NameRegexes: []string{"^team-a:"}, ModifiedSince: "2024-01-30T00:00:00Z", MatchAny: true}, []*api.Stub{teamStub, recentStub}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := export.NewResourceFilter(test.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, stub := range []*api.Stub{teamStub, recentStub, configV1Stub} {
+				expected := false
+				for _, match := range test.matches {
+					expected = expected || match == stub
+				}
+				matches, err := filter.Matches(nil, stub)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if matches != expected {
+					t.Errorf("'%s': expected match %v, got %v", stub.Name, expected, matches)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceFilterInvalidArgs(t *testing.T) {
+	for _, args := range []export.FilterArgs{
+		{NameRegexes: []string{"team-("}},
+		{ModifiedSince: "yesterday"},
+		{MatchAny: true},
+	} {
+		if _, err := export.NewResourceFilter(args); err == nil {
+			t.Errorf("expected %+v to get rejected", args)
+		}
+	}
+	if filter, err := export.NewResourceFilter(export.FilterArgs{}); err != nil || filter != nil {
+		t.Error("no filter expected without arguments")
+	}
+}
+
+func TestResourceFilterManagementZone(t *testing.T) {
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	request, _ := http.NewRequest(http.MethodPost, server.URL+"/api/v2/settings/objects", strings.NewReader(`[{"schemaId":"builtin:management-zones","scope":"environment","value":{"name":"team-a","rules":[]}}]`))
+	request.Header.Set("Authorization", "Api-Token "+mockserver.Token)
+	request.Header.Set("Content-Type", "application/json")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	env := &export.Environment{Credentials: server.Credentials(), Modules: map[export.ResourceType]*export.Module{}}
+	mzStubs, err := export.AllResources[export.ResourceTypes.ManagementZoneV2].Service(env.Credentials).List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mzStubs) != 1 || mzStubs[0].LegacyID == nil {
+		t.Fatalf("expected management zone with legacy ID, got %+v", mzStubs)
+	}
+	legacyID := *mzStubs[0].LegacyID
+
+	filter, err := export.NewResourceFilter(export.FilterArgs{ManagementZones: []string{"team-a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	alerting := env.Module(export.ResourceTypes.Alerting)
+	for _, test := range []struct {
+		stub     *api.Stub
+		expected bool
+	}{
+		{&api.Stub{ID: "1", Name: "in zone", Value: map[string]any{"managementZone": legacyID}}, true},
+		{&api.Stub{ID: "2", Name: "other zone", Value: map[string]any{"managementZone": legacyID + "1"}}, false},
+		{&api.Stub{ID: "3", Name: "no zone", Value: map[string]any{}}, false},
+	} {
+		matches, err := filter.Matches(alerting, test.stub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if matches != test.expected {
+			t.Errorf("'%s': expected match %v, got %v", test.stub.Name, test.expected, matches)
+		}
+	}
+	if matches, _ := filter.Matches(env.Module(export.ResourceTypes.ManagementZoneV2), mzStubs[0]); !matches {
+		t.Error("management zone doesn't match itself")
+	}
+
+	filter, _ = export.NewResourceFilter(export.FilterArgs{ManagementZones: []string{"team-b"}})
+	if _, err := filter.Matches(alerting, &api.Stub{ID: "1", Name: "x", Value: map[string]any{}}); err == nil {
+		t.Error("expected an error for a management zone that doesn't exist")
+	}
+}
@@ -0,0 +1,308 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// GraphJSONFileName is the name of the file containing the reference graph in case of `-graph`
+const GraphJSONFileName = "dependency-graph.json"
+
+// GraphDOTFileName is the name of the Graphviz file containing the reference graph in case of `-graph`
+const GraphDOTFileName = "dependency-graph.dot"
+
+// GraphNode is a resource within the reference graph.
+// Its ID is the address of the resource, e.g. `dynatrace_alerting.team_a`, or `data.dynatrace_alerting.team_a`
+// in case it is referred to as data source
+type GraphNode struct {
+	ID         string         `json:"id"`
+	Type       ResourceType   `json:"type"`
+	Module     string         `json:"module,omitempty"`
+	ResourceID string         `json:"resource_id"`
+	Name       string         `json:"name"`
+	Status     ResourceStatus `json:"status"`
+	Exported   bool           `json:"exported"`
+	DataSource bool           `json:"data_source,omitempty"`
+}
+
+// GraphEdge is a reference from the configuration of one resource to another one
+type GraphEdge struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Parent bool   `json:"parent,omitempty"`
+}
+
+// Graph contains the references between the exported resources, as well as their analysis
+type Graph struct {
+	Nodes []*GraphNode `json:"nodes"`
+	Edges []*GraphEdge `json:"edges"`
+	// Cycles lists groups of resources referring to each other, directly or indirectly
+	Cycles [][]string `json:"cycles"`
+	// Orphans lists exported resources no other resource refers to
+	Orphans []string `json:"orphans"`
+	// NotExported lists resources which are referred to, but are not part of the export.
+	// They are either referred to as data sources, or their download has failed or has been excluded
+	NotExported []string `json:"not_exported"`
+}
+
+func graphNodeID(resource *Resource) string {
+	if resource.IsReferencedAsDataSource() {
+		return fmt.Sprintf("data.%s.%s", resource.Type.AsDataSource(), resource.UniqueName)
+	}
+	return fmt.Sprintf("%s.%s", resource.Type, resource.UniqueName)
+}
+
+// BuildGraph collects the references between the resources known after post processing
+func (me *Environment) BuildGraph() *Graph {
+	me.mu.Lock()
+	modules := make([]*Module, 0, len(me.Modules))
+	for _, module := range me.Modules {
+		modules = append(modules, module)
+	}
+	me.mu.Unlock()
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Type < modules[j].Type })
+
+	graph := &Graph{Nodes: []*GraphNode{}, Edges: []*GraphEdge{}}
+	nodes := map[*Resource]*GraphNode{}
+	addNode := func(resource *Resource) *GraphNode {
+		if node, found := nodes[resource]; found {
+			return node
+		}
+		node := &GraphNode{
+			ID:         graphNodeID(resource),
+			Type:       resource.Type,
+			ResourceID: resource.ID,
+			Name:       resource.Name,
+			Status:     resource.Status,
+			Exported:   resource.Status == ResourceStati.PostProcessed && !resource.IsReferencedAsDataSource(),
+			DataSource: resource.IsReferencedAsDataSource(),
+		}
+		if !me.Flags.Flat {
+			node.Module = resource.Module.GetFolder(true)
+		}
+		nodes[resource] = node
+		graph.Nodes = append(graph.Nodes, node)
+		return node
+	}
+
+	for _, module := range modules {
+		for _, resource := range module.GetPostProcessedResources() {
+			from := addNode(resource)
+			seen := map[*Resource]bool{}
+			for _, reference := range resource.ResourceReferences {
+				if seen[reference] {
+					continue
+				}
+				seen[reference] = true
+				to := addNode(reference)
+				graph.Edges = append(graph.Edges, &GraphEdge{From: from.ID, To: to.ID, Parent: resource.GetParent() == reference})
+			}
+		}
+	}
+
+	sort.Slice(graph.Nodes, func(i, j int) bool { return graph.Nodes[i].ID < graph.Nodes[j].ID })
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].From == graph.Edges[j].From {
+			return graph.Edges[i].To < graph.Edges[j].To
+		}
+		return graph.Edges[i].From < graph.Edges[j].From
+	})
+	graph.Analyze()
+	return graph
+}
+
+// Analyze determines the cycles, orphans and resources not exported based on the nodes and edges of the graph
+func (me *Graph) Analyze() {
+	me.Cycles = [][]string{}
+	me.Orphans = []string{}
+	me.NotExported = []string{}
+
+	successors := map[string][]string{}
+	referred := map[string]bool{}
+	for _, edge := range me.Edges {
+		successors[edge.From] = append(successors[edge.From], edge.To)
+		if edge.From != edge.To {
+			referred[edge.To] = true
+		}
+	}
+	for _, node := range me.Nodes {
+		if !node.Exported {
+			me.NotExported = append(me.NotExported, node.ID)
+		} else if !referred[node.ID] {
+			me.Orphans = append(me.Orphans, node.ID)
+		}
+	}
+
+	// Tarjan's algorithm for strongly connected components
+	index := 0
+	indices := map[string]int{}
+	lowlinks := map[string]int{}
+	onStack := map[string]bool{}
+	stack := []string{}
+	var connect func(id string)
+	connect = func(id string) {
+		indices[id] = index
+		lowlinks[id] = index
+		index++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		selfReference := false
+		for _, successor := range successors[id] {
+			if successor == id {
+				selfReference = true
+			}
+			if _, visited := indices[successor]; !visited {
+				connect(successor)
+				lowlinks[id] = min(lowlinks[id], lowlinks[successor])
+			} else if onStack[successor] {
+				lowlinks[id] = min(lowlinks[id], indices[successor])
+			}
+		}
+		if lowlinks[id] != indices[id] {
+			return
+		}
+		component := []string{}
+		for {
+			member := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[member] = false
+			component = append(component, member)
+			if member == id {
+				break
+			}
+		}
+		if len(component) > 1 || selfReference {
+			sort.Strings(component)
+			me.Cycles = append(me.Cycles, component)
+		}
+	}
+	for _, node := range me.Nodes {
+		if _, visited := indices[node.ID]; !visited {
+			connect(node.ID)
+		}
+	}
+	sort.Slice(me.Cycles, func(i, j int) bool { return me.Cycles[i][0] < me.Cycles[j][0] })
+}
+
+// DOT renders the graph in the Graphviz format. Resources are grouped by module.
+// Resources not being exported are dashed, references being part of a cycle are red
+func (me *Graph) DOT() []byte {
+	inCycle := map[string]int{}
+	for idx, cycle := range me.Cycles {
+		for _, id := range cycle {
+			inCycle[id] = idx + 1
+		}
+	}
+	quote := func(s string) string {
+		return `"` + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), `"`, `\"`) + `"`
+	}
+	writeNode := func(w *bufio.Writer, indent string, node *GraphNode) {
+		attributes := []string{"label=" + quote(node.ID)}
+		if !node.Exported {
+			attributes = append(attributes, "style=dashed")
+		}
+		fmt.Fprintf(w, "%s%s [%s];\n", indent, quote(node.ID), strings.Join(attributes, ", "))
+	}
+
+	buf := new(bytes.Buffer)
+	w := bufio.NewWriter(buf)
+	fmt.Fprintln(w, "digraph dependencies {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, "  node [shape=box];")
+
+	modules := []string{}
+	nodesPerModule := map[string][]*GraphNode{}
+	for _, node := range me.Nodes {
+		if _, found := nodesPerModule[node.Module]; !found {
+			modules = append(modules, node.Module)
+		}
+		nodesPerModule[node.Module] = append(nodesPerModule[node.Module], node)
+	}
+	sort.Strings(modules)
+	for _, module := range modules {
+		if len(module) == 0 {
+			for _, node := range nodesPerModule[module] {
+				writeNode(w, "  ", node)
+			}
+			continue
+		}
+		fmt.Fprintf(w, "  subgraph %s {\n", quote("cluster_"+module))
+		fmt.Fprintf(w, "    label=%s;\n", quote(module))
+		for _, node := range nodesPerModule[module] {
+			writeNode(w, "    ", node)
+		}
+		fmt.Fprintln(w, "  }")
+	}
+	for _, edge := range me.Edges {
+		attributes := []string{}
+		if edge.Parent {
+			attributes = append(attributes, `label="parent"`)
+		}
+		if cycle := inCycle[edge.From]; cycle > 0 && cycle == inCycle[edge.To] {
+			attributes = append(attributes, "color=red")
+		}
+		if len(attributes) == 0 {
+			fmt.Fprintf(w, "  %s -> %s;\n", quote(edge.From), quote(edge.To))
+		} else {
+			fmt.Fprintf(w, "  %s -> %s [%s];\n", quote(edge.From), quote(edge.To), strings.Join(attributes, ", "))
+		}
+	}
+	fmt.Fprintln(w, "}")
+	w.Flush()
+	return buf.Bytes()
+}
+
+// WriteGraph writes the reference graph as JSON and as Graphviz file into the target folder
+// and reports cycles, orphans and resources which are referred to but not exported
+func (me *Environment) WriteGraph() error {
+	graph := me.BuildGraph()
+	data, err := json.MarshalIndent(graph, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(me.OutputFolder, os.ModePerm); err != nil {
+		return err
+	}
+	fmt.Println("Writing " + GraphJSONFileName)
+	if err = os.WriteFile(path.Join(me.OutputFolder, GraphJSONFileName), data, 0664); err != nil {
+		return err
+	}
+	fmt.Println("Writing " + GraphDOTFileName)
+	if err = os.WriteFile(path.Join(me.OutputFolder, GraphDOTFileName), graph.DOT(), 0664); err != nil {
+		return err
+	}
+	for _, cycle := range graph.Cycles {
+		fmt.Printf("Reference cycle: %s\n", strings.Join(cycle, ", "))
+	}
+	if len(graph.NotExported) > 0 {
+		fmt.Printf("%d referenced resources are not part of the export, see %s\n", len(graph.NotExported), GraphJSONFileName)
+	}
+	if len(graph.Orphans) > 0 {
+		fmt.Printf("%d exported resources are not referred to by any other resource, see %s\n", len(graph.Orphans), GraphJSONFileName)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+)
+
+func TestGraphAnalyze(t *testing.T) {
+	graph := &export.Graph{
+		Nodes: []*export.GraphNode{
+			{ID: "a", Exported: true},
+			{ID: "b", Exported: true},
+			{ID: "c", Exported: true},
+			{ID: "d", Exported: true},
+			{ID: "e", Exported: true},
+			{ID: "data.x", Exported: false, DataSource: true},
+		},
+		Edges: []*export.GraphEdge{
+			{From: "a", To: "b"},
+			{From: "b", To: "c"},
+			{From: "c", To: "a"},
+			{From: "c", To: "data.x"},
+			{From: "d", To: "d"},
+			{From: "d", To: "b"},
+		},
+	}
+	graph.Analyze()
+
+	if expected := [][]string{{"a", "b", "c"}, {"d"}}; !reflect.DeepEqual(graph.Cycles, expected) {
+		t.Errorf("expected cycles %v, got %v", expected, graph.Cycles)
+	}
+	if expected := []string{"d", "e"}; !reflect.DeepEqual(graph.Orphans, expected) {
+		t.Errorf("expected orphans %v, got %v", expected, graph.Orphans)
+	}
+	if expected := []string{"data.x"}; !reflect.DeepEqual(graph.NotExported, expected) {
+		t.Errorf("expected resources not exported %v, got %v", expected, graph.NotExported)
+	}
+
+	dot := string(graph.DOT())
+	for _, expected := range []string{
+		`"a" -> "b" [color=red];`,
+		`"c" -> "data.x";`,
+		`"data.x" [label="data.x", style=dashed];`,
+	} {
+		if !strings.Contains(dot, expected) {
+			t.Errorf("expected `%s` within:\n%s", expected, dot)
+		}
+	}
+}
+
+func TestExportGraph(t *testing.T) {
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+
+	createSettingsObject(t, server, "builtin:management-zones", `{"name":"team-a","rules":[]}`)
+	mzStubs, err := export.AllResources[export.ResourceTypes.ManagementZoneV2].Service(server.Credentials()).List(context.Background())
+	if err != nil || len(mzStubs) != 1 {
+		t.Fatalf("unable to list management zones: %v", err)
+	}
+	createSettingsObject(t, server, "builtin:alerting.profile", `{"name":"team-a alerts","managementZone":"`+*mzStubs[0].LegacyID+`","severityRules":[]}`)
+	createSettingsObject(t, server, "builtin:alerting.profile", `{"name":"other alerts","severityRules":[]}`)
+
+	folder := t.TempDir()
+	env := &export.Environment{
+		OutputFolder: folder,
+		Credentials:  server.Credentials(),
+		Modules:      map[export.ResourceType]*export.Module{},
+		Flags:        export.Flags{FollowReferences: true, DataSources: true, Graph: true, SkipTerraformInit: true},
+		ResArgs: map[string][]string{
+			string(export.ResourceTypes.Alerting): nil,
+		},
+	}
+	if err := env.Export(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(folder, export.GraphJSONFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var graph export.Graph
+	if err := json.Unmarshal(data, &graph); err != nil {
+		t.Fatal(err)
+	}
+	expectedEdges := []*export.GraphEdge{{From: "dynatrace_alerting.team-a_alerts", To: "data.dynatrace_management_zone_v2.team-a"}}
+	if !reflect.DeepEqual(graph.Edges, expectedEdges) {
+		t.Errorf("unexpected edges %s", string(data))
+	}
+	if expected := []string{"dynatrace_alerting.other_alerts", "dynatrace_alerting.team-a_alerts"}; !reflect.DeepEqual(graph.Orphans, expected) {
+		t.Errorf("expected orphans %v, got %v", expected, graph.Orphans)
+	}
+	if expected := []string{"data.dynatrace_management_zone_v2.team-a"}; !reflect.DeepEqual(graph.NotExported, expected) {
+		t.Errorf("expected resources not exported %v, got %v", expected, graph.NotExported)
+	}
+	if len(graph.Cycles) != 0 {
+		t.Errorf("expected no cycles, got %v", graph.Cycles)
+	}
+	for _, node := range graph.Nodes {
+		if node.Type == export.ResourceTypes.Alerting && node.Module != "modules/alerting" {
+			t.Errorf("unexpected module `%s` of `%s`", node.Module, node.ID)
+		}
+	}
+
+	dot, err := os.ReadFile(filepath.Join(folder, export.GraphDOTFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(dot), `"dynatrace_alerting.team-a_alerts" -> "data.dynatrace_management_zone_v2.team-a";`) {
+		t.Errorf("unexpected graph:\n%s", string(dot))
+	}
+}
@@ -0,0 +1,83 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/users"
+	usersettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/users/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+// IAMMembershipLayout defines how group memberships of users are getting exported (`-iam-memberships`)
+type IAMMembershipLayout string
+
+var IAMMembershipLayouts = struct {
+	User   IAMMembershipLayout
+	Group  IAMMembershipLayout
+	Member IAMMembershipLayout
+}{
+	"user",
+	"group",
+	"member",
+}
+
+// IsUser returns true if memberships are getting exported as attribute `groups` of `dynatrace_iam_user`
+func (me IAMMembershipLayout) IsUser() bool {
+	return me == "" || me == IAMMembershipLayouts.User
+}
+
+func (me IAMMembershipLayout) Validate() error {
+	switch me {
+	case "", IAMMembershipLayouts.User, IAMMembershipLayouts.Group, IAMMembershipLayouts.Member:
+		return nil
+	}
+	return fmt.Errorf("unsupported value `%s` for -iam-memberships. supported are `user`, `group` and `member`", me)
+}
+
+// ResourceType returns the resource type the memberships are getting exported as, unless they're part of `dynatrace_iam_user`
+func (me IAMMembershipLayout) ResourceType() ResourceType {
+	switch me {
+	case IAMMembershipLayouts.Group:
+		return ResourceTypes.IAMGroupMembers
+	case IAMMembershipLayouts.Member:
+		return ResourceTypes.IAMGroupMember
+	}
+	return ""
+}
+
+// usersWithoutGroups exports `dynatrace_iam_user` with `ignore_groups` instead of attribute `groups`,
+// because the memberships are getting exported as separate resources
+type usersWithoutGroups struct {
+	settings.CRUDService[*usersettings.User]
+}
+
+func (me *usersWithoutGroups) Get(ctx context.Context, id string, v *usersettings.User) error {
+	if err := me.CRUDService.Get(ctx, id, v); err != nil {
+		return err
+	}
+	v.Groups = nil
+	v.IgnoreGroups = true
+	return nil
+}
+
+func newUsersWithoutGroups(credentials *settings.Credentials) settings.CRUDService[settings.Settings] {
+	return &settings.GenericCRUDService[*usersettings.User]{Service: &usersWithoutGroups{users.Service(credentials)}}
+}
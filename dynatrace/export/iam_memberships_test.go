@@ -0,0 +1,99 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/groups"
+	groupsettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/groups/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/users"
+	usersettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/users/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+)
+
+func TestExportIAMMembershipsPerGroup(t *testing.T) {
+	limits := rest.GetRateLimits()
+	unlimited := rest.GetRateLimits()
+	delete(unlimited.Families, rest.RateLimitFamilyIAM)
+	rest.SetRateLimits(unlimited)
+	settings.ExportRunning = true
+	defer func() {
+		rest.SetRateLimits(limits)
+		settings.ExportRunning = false
+	}()
+
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	credentials := server.Credentials()
+
+	ctx := context.Background()
+	group, err := groups.Service(credentials).Create(ctx, &groupsettings.Group{Name: "Developers"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := users.Service(credentials).Create(ctx, &usersettings.User{Email: "jane.doe@example.com", Groups: []string{group.ID}}); err != nil {
+		t.Fatal(err)
+	}
+
+	folder := t.TempDir()
+	env := &export.Environment{
+		OutputFolder: folder,
+		Credentials:  credentials,
+		Modules:      map[export.ResourceType]*export.Module{},
+		Flags:        export.Flags{SkipTerraformInit: true, IAMMemberships: export.IAMMembershipLayouts.Group},
+		ResArgs: map[string][]string{
+			string(export.ResourceTypes.IAMUser):         nil,
+			string(export.ResourceTypes.IAMGroupMembers): nil,
+		},
+	}
+	if err := env.Export(); err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(elem ...string) string {
+		t.Helper()
+		matches, err := filepath.Glob(filepath.Join(append([]string{folder}, elem...)...))
+		if err != nil || len(matches) != 1 {
+			t.Fatalf("expected exactly one file matching %v, got %v", elem, matches)
+		}
+		data, err := os.ReadFile(matches[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	if user := read("modules", "iam_user", "*.iam_user.tf"); regexp.MustCompile(`(?m)^\s*groups\s*=`).MatchString(user) || !regexp.MustCompile(`(?m)^\s*ignore_groups\s*=\s*true`).MatchString(user) {
+		t.Errorf("expected dynatrace_iam_user with ignore_groups instead of groups:\n%s", user)
+	}
+	members := read("modules", "iam_group_members", "Developers.iam_group_members.tf")
+	if !strings.Contains(members, group.ID) || !strings.Contains(members, "jane.doe@example.com") {
+		t.Errorf("expected the membership within dynatrace_iam_group_members:\n%s", members)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
@@ -31,14 +32,58 @@ import (
 )
 
 func Initialize(cfgGetter config.Getter) (environment *Environment, err error) {
-	flags, tailArgs := createFlags()
+	flags, filterArgs, tailArgs := createFlags()
 	if flags.FlagMigrationOutput && flags.FollowReferences {
 		return nil, errors.New("-ref and -migrate are mutually exclusive")
 	}
+	if flags.FlagMigrationOutput && flags.IsPromotion() {
+		return nil, errors.New("-migrate and -promote are mutually exclusive")
+	}
 	if flags.FlagMigrationOutput {
 		flags.FollowReferences = true
 		flags.PersistIDs = true
 	}
+	if len(flags.PromoteTag) > 0 && !flags.IsPromotion() {
+		return nil, errors.New("-promote-tag requires -promote")
+	}
+	if flags.IsPromotion() {
+		flags.FollowReferences = true
+	}
+	if err = flags.Format.Validate(); err != nil {
+		return nil, err
+	}
+	if !flags.Format.IsHCL() && (flags.ImportStateV2 || flags.Drift || flags.IsPromotion() || flags.SecretVars) {
+		return nil, errors.New("-format=json and -format=monaco are mutually exclusive with -import-state, -drift, -promote and -secret-vars")
+	}
+	if err = flags.IAMMemberships.Validate(); err != nil {
+		return nil, err
+	}
+	if flags.SecretVars && flags.Drift {
+		return nil, errors.New("-secret-vars is mutually exclusive with -drift")
+	}
+	if flags.Format == OutputFormats.Monaco && flags.Flat {
+		return nil, errors.New("-format=monaco and -flat are mutually exclusive")
+	}
+	if flags.ConvertDashboards && (flags.ImportStateV2 || flags.Drift) {
+		return nil, errors.New("-convert-dashboards is mutually exclusive with -import-state and -drift")
+	}
+	if flags.Graph && flags.Drift {
+		return nil, errors.New("-graph is mutually exclusive with -drift")
+	}
+	filter, err := NewResourceFilter(filterArgs)
+	if err != nil {
+		return nil, err
+	}
+	descriptors := AllResources
+	for _, file := range flags.DependencyRules {
+		var rules []*DependencyRule
+		if rules, err = LoadDependencyRules(file); err != nil {
+			return nil, err
+		}
+		if descriptors, err = ApplyDependencyRules(descriptors, rules); err != nil {
+			return nil, err
+		}
+	}
 	if err = ConfigureRESTLog(); err != nil {
 		return nil, errors.New("unable to configure log file for REST activity: " + err.Error())
 	}
@@ -165,12 +210,30 @@ func Initialize(cfgGetter config.Getter) (environment *Environment, err error) {
 		}
 	}
 
+	// converted classic dashboards are getting exported as documents
+	if _, found := resArgs[string(ResourceTypes.Documents)]; flags.ConvertDashboards && !found {
+		resArgs[string(ResourceTypes.Documents)] = nil
+	}
+
+	// group memberships of exported users are getting exported as separate resources
+	if _, found := resArgs[string(ResourceTypes.IAMUser)]; found && !flags.IAMMemberships.IsUser() {
+		if _, found := resArgs[string(flags.IAMMemberships.ResourceType())]; !found {
+			resArgs[string(flags.IAMMemberships.ResourceType())] = nil
+		}
+	}
+
+	for _, resourceType := range excludeOpenPipelineConfigurations(resArgs) {
+		fmt.Printf("%s is not getting exported, because the resources managing parts of it have been requested\n", resourceType)
+	}
+
 	targetFolder := os.Getenv("DYNATRACE_TARGET_FOLDER")
 	if targetFolder == "" {
 		fmt.Println("The environment variable DYNATRACE_TARGET_FOLDER has not been set - using folder 'configuration' as default")
 		targetFolder = "configuration"
 	}
-	if os.Getenv("DYNATRACE_CLEAN_TARGET_FOLDER") == "true" {
+	// in drift mode the target folder contains the baseline to compare with
+	// when resuming it contains the results of the interrupted export
+	if os.Getenv("DYNATRACE_CLEAN_TARGET_FOLDER") == "true" && !flags.Drift && !flags.Resume {
 		os.RemoveAll(targetFolder)
 	}
 
@@ -202,10 +265,12 @@ func Initialize(cfgGetter config.Getter) (environment *Environment, err error) {
 		Flags:                 flags,
 		ResArgs:               resArgs,
 		ChildResourceOverride: requestingOnlyChildResources,
+		Filter:                filter,
+		Descriptors:           descriptors,
 	}, nil
 }
 
-func createFlags() (flags Flags, tailArgs []string) {
+func createFlags() (flags Flags, filterArgs FilterArgs, tailArgs []string) {
 	flag.Bool("export", true, "")
 	refArg := flag.Bool("ref", false, "enable data sources and dependencies. mutually exclusive with -migrate")
 	dataSourceArg := flag.Bool("datasources", false, "when resolving dependencies eligible resources will be referred as data sources")
@@ -219,6 +284,21 @@ func createFlags() (flags Flags, tailArgs []string) {
 	importState := flag.Bool("import-state", false, "automatically initialize the terraform module and import downloaded resources to the state")
 	exclude := flag.Bool("exclude", false, "exclude specified resources")
 	skipTerraformInit := flag.Bool("skip-terraform-init", false, "prevent the command line `terraform init` from getting executed after all the configuration files have been created")
+	var nameRegexes, managementZones, dependencyRules stringsFlag
+	flag.Var(&nameRegexes, "name-regex", "export only resources whose name matches the given regular expression. can be specified multiple times")
+	flag.Var(&managementZones, "management-zone", "export only resources referring to the management zone with the given name. can be specified multiple times")
+	modifiedSince := flag.String("modified-since", "", "export only resources modified after the given RFC3339 timestamp (Settings 2.0 based resources only)")
+	matchAny := flag.Bool("match-any", false, "export resources matching any instead of all of -name-regex, -management-zone and -modified-since")
+	format := flag.String("format", string(OutputFormats.HCL), "the output format. `hcl` produces Terraform configuration, `json` the JSON payloads plus resources.json, `monaco` a monaco project")
+	resume := flag.Bool("resume", false, "continue an interrupted export into the same target folder, skipping modules and resources that have already been exported")
+	convertDashboards := flag.Bool("convert-dashboards", false, "additionally export classic dashboards as dynatrace_document resources, converted into platform dashboards")
+	drift := flag.Bool("drift", false, "compare the configuration on the environment with a previous export (or the state file configured via DYNATRACE_PREV_STATE_PATH_THIS) and write a drift report instead of exporting")
+	graph := flag.Bool("graph", false, "additionally write the references between the exported resources as "+GraphDOTFileName+" and "+GraphJSONFileName+" into the target folder")
+	promote := flag.String("promote", "", "replace environment specific values with variables and data sources and write a skeleton <name>.tfvars for each of the given comma separated environments. implies -ref, mutually exclusive with -migrate")
+	promoteTag := flag.String("promote-tag", "", "in combination with -promote, entities carrying a tag with the given key are looked up by that tag instead of their name")
+	iamMemberships := flag.String("iam-memberships", string(IAMMembershipLayouts.User), "how group memberships are getting exported. `user` exports them as attribute `groups` of dynatrace_iam_user, `group` as one authoritative dynatrace_iam_group_members per group, `member` as one dynatrace_iam_group_member per membership")
+	secretVars := flag.Bool("secret-vars", false, "replace sensitive attributes with variables and write their values into "+SecretsVarsFileName+". encrypted if DYNATRACE_SECRETS_KEY is set")
+	flag.Var(&dependencyRules, "dependency-rules", "a JSON file containing additional dependency rules to resolve references with. can be specified multiple times")
 
 	flag.Parse()
 
@@ -236,6 +316,21 @@ func createFlags() (flags Flags, tailArgs []string) {
 		Exclude:             *exclude,
 		DataSources:         *dataSourceArg,
 		SkipTerraformInit:   *skipTerraformInit,
+		Drift:               *drift,
+		Resume:              *resume,
+		Format:              OutputFormat(*format),
+		ConvertDashboards:   *convertDashboards,
+		DependencyRules:     dependencyRules,
+		Graph:               *graph,
+		Promote:             splitPromotionEnvironments(*promote),
+		PromoteTag:          *promoteTag,
+		SecretVars:          *secretVars,
+		IAMMemberships:      IAMMembershipLayout(*iamMemberships),
+	}, FilterArgs{
+		NameRegexes:     nameRegexes,
+		ManagementZones: managementZones,
+		ModifiedSince:   *modifiedSince,
+		MatchAny:        *matchAny,
 	}, flag.Args()
 }
 
@@ -290,4 +385,49 @@ type Flags struct {
 	DataSources         bool
 	SkipTerraformInit   bool
 	Include             bool
+	Drift               bool
+	Resume              bool
+	Format              OutputFormat
+	ConvertDashboards   bool
+	DependencyRules     []string
+	Graph               bool
+	Promote             []string
+	PromoteTag          string
+	SecretVars          bool
+	IAMMemberships      IAMMembershipLayout
+}
+
+// IsPromotion returns true if environment specific values should get replaced (`-promote`)
+func (me Flags) IsPromotion() bool {
+	return len(me.Promote) > 0
+}
+
+func splitPromotionEnvironments(s string) []string {
+	var environments []string
+	for _, environment := range strings.Split(s, ",") {
+		if environment = strings.TrimSpace(environment); len(environment) > 0 {
+			environments = append(environments, environment)
+		}
+	}
+	return environments
+}
+
+// excludeOpenPipelineConfigurations removes the OpenPipeline configuration resources from the requested resources
+// in case resources managing parts of the same configuration have been requested too. It returns the removed resources
+func excludeOpenPipelineConfigurations(resArgs map[string][]string) []ResourceType {
+	excluded := []ResourceType{}
+	for configuration, parts := range openPipelineParts {
+		if _, found := resArgs[string(configuration)]; !found {
+			continue
+		}
+		for _, part := range parts {
+			if _, found := resArgs[string(part)]; found {
+				delete(resArgs, string(configuration))
+				excluded = append(excluded, configuration)
+				break
+			}
+		}
+	}
+	slices.Sort(excluded)
+	return excluded
 }
@@ -0,0 +1,58 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOpenPipelinePartsAreExcludeListed(t *testing.T) {
+	excluded := GetExcludeListedResources()
+	for configuration, parts := range openPipelineParts {
+		if slices.Contains(excluded, configuration) {
+			t.Errorf("expected %s to get exported by default", configuration)
+		}
+		for _, part := range parts {
+			if !slices.Contains(excluded, part) {
+				t.Errorf("expected %s not to get exported by default", part)
+			}
+		}
+	}
+}
+
+func TestExcludeOpenPipelineConfigurations(t *testing.T) {
+	resArgs := map[string][]string{
+		string(ResourceTypes.OpenPipelineLogs):               nil,
+		string(ResourceTypes.OpenPipelineLogsRouting):        nil,
+		string(ResourceTypes.OpenPipelineEvents):             nil,
+		string(ResourceTypes.OpenPipelineSDLCEventsPipeline): {"default"},
+	}
+	excluded := excludeOpenPipelineConfigurations(resArgs)
+	if len(excluded) != 1 || excluded[0] != ResourceTypes.OpenPipelineLogs {
+		t.Errorf("expected only %s to get excluded, got %v", ResourceTypes.OpenPipelineLogs, excluded)
+	}
+	if _, found := resArgs[string(ResourceTypes.OpenPipelineLogs)]; found {
+		t.Errorf("expected %s not to get exported together with %s", ResourceTypes.OpenPipelineLogs, ResourceTypes.OpenPipelineLogsRouting)
+	}
+	for _, resourceType := range []ResourceType{ResourceTypes.OpenPipelineLogsRouting, ResourceTypes.OpenPipelineEvents, ResourceTypes.OpenPipelineSDLCEventsPipeline} {
+		if _, found := resArgs[string(resourceType)]; !found {
+			t.Errorf("expected %s to remain requested", resourceType)
+		}
+	}
+}
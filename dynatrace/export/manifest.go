@@ -0,0 +1,437 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/address"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
+)
+
+// ManifestFileName is the name of the file within the target folder the export keeps its progress in
+const ManifestFileName = "export-manifest.json"
+
+const manifestVersion = 1
+
+// manifestSaveInterval limits how often the manifest gets written to disk while resources are getting downloaded
+const manifestSaveInterval = 5 * time.Second
+
+// Manifest is the machine readable summary of an export.
+// It gets written continuously into the target folder, which allows `-resume` to skip
+// modules and resources that have been exported successfully by a previous, interrupted run.
+type Manifest struct {
+	Version        int                              `json:"version"`
+	EnvironmentURL string                           `json:"environment_url"`
+	Flags          Flags                            `json:"flags"`
+	Filter         string                           `json:"filter,omitempty"`
+	Started        time.Time                        `json:"started"`
+	Updated        time.Time                        `json:"updated"`
+	Finished       bool                             `json:"finished"`
+	Modules        map[ResourceType]*ModuleManifest `json:"modules"`
+
+	mu       sync.Mutex
+	file     string
+	folder   string
+	lastSave time.Time
+	previous *Manifest
+}
+
+type ModuleManifest struct {
+	Status    ModuleStatus                 `json:"status"`
+	Error     string                       `json:"error,omitempty"`
+	Complete  bool                         `json:"complete"`
+	Resources map[string]*ResourceManifest `json:"resources"`
+}
+
+type ResourceManifest struct {
+	Name              string            `json:"name"`
+	UniqueName        string            `json:"unique_name,omitempty"`
+	LegacyID          string            `json:"legacy_id,omitempty"`
+	ParentID          *string           `json:"parent_id,omitempty"`
+	Status            ResourceStatus    `json:"status"`
+	Flawed            bool              `json:"flawed,omitempty"`
+	RequiresAttention bool              `json:"requires_attention,omitempty"`
+	File              string            `json:"file,omitempty"`
+	Checksum          string            `json:"checksum,omitempty"`
+	Bundled           bool              `json:"bundled,omitempty"`
+	Parent            *ResourceLocator  `json:"parent,omitempty"`
+	References        []ResourceLocator `json:"references,omitempty"`
+	Error             string            `json:"error,omitempty"`
+}
+
+type ResourceLocator struct {
+	Type ResourceType `json:"type"`
+	ID   string       `json:"id"`
+}
+
+// LoadManifest reads the manifest stored in the given folder.
+// It returns `nil` without an error if the folder doesn't contain a manifest.
+func LoadManifest(folder string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(folder, ManifestFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	manifest := new(Manifest)
+	if err = json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %s", ManifestFileName, err.Error())
+	}
+	if manifest.Version != manifestVersion {
+		return nil, fmt.Errorf("%s has been written by an incompatible version of the export (version %d)", ManifestFileName, manifest.Version)
+	}
+	if manifest.Modules == nil {
+		manifest.Modules = map[ResourceType]*ModuleManifest{}
+	}
+	manifest.folder = folder
+	manifest.file = filepath.Join(folder, ManifestFileName)
+	return manifest, nil
+}
+
+// InitManifest prepares the manifest for the current export.
+// In case the flag `-resume` has been specified the manifest of the previous run is getting loaded.
+// It needs to have been written for the same environment using the same flags.
+func (me *Environment) InitManifest() error {
+	flags := me.Flags
+	flags.Resume = false
+
+	manifest := &Manifest{
+		Version:        manifestVersion,
+		EnvironmentURL: me.Credentials.URL,
+		Flags:          flags,
+		Filter:         me.Filter.String(),
+		Started:        time.Now(),
+		Modules:        map[ResourceType]*ModuleManifest{},
+		folder:         me.OutputFolder,
+		file:           filepath.Join(me.OutputFolder, ManifestFileName),
+	}
+	me.Manifest = manifest
+
+	if !me.Flags.Resume {
+		return nil
+	}
+
+	previous, err := LoadManifest(me.OutputFolder)
+	if err != nil {
+		return err
+	}
+	if previous == nil {
+		fmt.Printf("No %s found in '%s' - exporting from scratch\n", ManifestFileName, me.OutputFolder)
+		return nil
+	}
+	if previous.EnvironmentURL != manifest.EnvironmentURL {
+		return fmt.Errorf("unable to resume: the export in '%s' belongs to environment '%s'", me.OutputFolder, previous.EnvironmentURL)
+	}
+	if !reflect.DeepEqual(previous.Flags, manifest.Flags) || previous.Filter != manifest.Filter {
+		return errors.New("unable to resume: the flags differ from the ones used for the interrupted export")
+	}
+
+	// the resources of the previous run remain part of the manifest
+	// until they are getting processed again
+	if current, err := LoadManifest(me.OutputFolder); err == nil {
+		manifest.Modules = current.Modules
+	}
+	manifest.previous = previous
+
+	fmt.Printf("Resuming export in '%s' (started %s)\n", me.OutputFolder, previous.Started.Format(time.RFC3339))
+	return nil
+}
+
+// Save writes the manifest into the target folder.
+// The file gets replaced atomically, so an interruption never leaves a truncated manifest behind.
+func (me *Manifest) Save() error {
+	if me == nil {
+		return nil
+	}
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	return me.save()
+}
+
+func (me *Manifest) save() error {
+	me.Updated = time.Now()
+	data, err := json.MarshalIndent(me, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(me.folder, os.ModePerm); err != nil {
+		return err
+	}
+	tmpFile := me.file + ".tmp"
+	if err = os.WriteFile(tmpFile, data, 0644); err != nil {
+		return err
+	}
+	if err = os.Rename(tmpFile, me.file); err != nil {
+		return err
+	}
+	me.lastSave = time.Now()
+	return nil
+}
+
+func (me *Manifest) saveIfDue() {
+	if time.Since(me.lastSave) < manifestSaveInterval {
+		return
+	}
+	if err := me.save(); err != nil {
+		logging.Debug.Warn.Printf("[MANIFEST] Unable to save %s: %s", ManifestFileName, err.Error())
+	}
+}
+
+// Finish flags the export as finished and saves the manifest
+func (me *Manifest) Finish() error {
+	if me == nil {
+		return nil
+	}
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	me.Finished = true
+	return me.save()
+}
+
+func (me *Manifest) module(resourceType ResourceType) *ModuleManifest {
+	module, found := me.Modules[resourceType]
+	if !found {
+		module = &ModuleManifest{Resources: map[string]*ResourceManifest{}}
+		me.Modules[resourceType] = module
+	}
+	if module.Resources == nil {
+		module.Resources = map[string]*ResourceManifest{}
+	}
+	return module
+}
+
+// RecordModule notes down the status of the given module.
+// `complete` signals that all resources of the module have been processed.
+func (me *Manifest) RecordModule(module *Module, complete bool) {
+	if me == nil {
+		return
+	}
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	record := me.module(module.Type)
+	record.Status = module.Status
+	record.Complete = complete
+	record.Error = ""
+	if module.Error != nil {
+		record.Error = module.Error.Error()
+	}
+	if complete {
+		module.ModuleMutex.Lock()
+		for _, resource := range module.Resources {
+			if _, found := record.Resources[resource.ID]; !found {
+				record.Resources[resource.ID] = me.newRecord(resource)
+			}
+		}
+		module.ModuleMutex.Unlock()
+		if err := me.save(); err != nil {
+			logging.Debug.Warn.Printf("[MANIFEST] Unable to save %s: %s", ManifestFileName, err.Error())
+		}
+	}
+}
+
+// Record notes down the current status of the given resource
+func (me *Manifest) Record(resource *Resource) {
+	if me == nil {
+		return
+	}
+	record := me.newRecord(resource)
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	me.module(resource.Type).Resources[resource.ID] = record
+	me.saveIfDue()
+}
+
+func (me *Manifest) newRecord(resource *Resource) *ResourceManifest {
+	record := &ResourceManifest{
+		Name:              resource.Name,
+		UniqueName:        resource.UniqueName,
+		LegacyID:          resource.LegacyID,
+		ParentID:          resource.ParentID,
+		Status:            resource.Status,
+		Flawed:            resource.Flawed,
+		RequiresAttention: resource.RequiresAttention,
+		Bundled:           len(resource.BundleFilePath) > 0,
+	}
+	if resource.Error != nil {
+		record.Error = resource.Error.Error()
+	}
+	if resource.XParent != nil {
+		record.Parent = &ResourceLocator{Type: resource.XParent.Type, ID: resource.XParent.ID}
+	}
+	for _, reference := range resource.ResourceReferences {
+		record.References = append(record.References, ResourceLocator{Type: reference.Type, ID: reference.ID})
+	}
+	if resource.Status.IsOneOf(ResourceStati.Downloaded, ResourceStati.PostProcessed) {
+		if relPath, err := filepath.Rel(me.folder, resource.GetFile()); err == nil {
+			record.File = filepath.ToSlash(relPath)
+		}
+		record.Checksum = checksum(resource.GetFile())
+	}
+	return record
+}
+
+// blockNames prevents the names handed out by the previous run from getting used for other resources.
+// Child resources are getting named by the module of their parent.
+func (me *Manifest) blockNames(module *Module, childParentGroups map[ResourceType]ResourceType) {
+	if me == nil || me.previous == nil {
+		return
+	}
+	for resourceType, record := range me.previous.Modules {
+		nameType := resourceType
+		if parentType, found := childParentGroups[resourceType]; found {
+			nameType = parentType
+		}
+		if nameType != module.Type {
+			continue
+		}
+		for _, resource := range record.Resources {
+			if len(resource.UniqueName) > 0 {
+				module.namer.BlockName(resource.UniqueName)
+			}
+		}
+	}
+}
+
+func (me *Manifest) lookup(resourceType ResourceType, id string) *ResourceManifest {
+	if me == nil || me.previous == nil {
+		return nil
+	}
+	module, found := me.previous.Modules[resourceType]
+	if !found {
+		return nil
+	}
+	return module.Resources[id]
+}
+
+// UniqueName returns the name the previous run has chosen for the given resource,
+// which keeps file names and references stable across resumed exports
+func (me *Manifest) UniqueName(resourceType ResourceType, id string) string {
+	if record := me.lookup(resourceType, id); record != nil {
+		return record.UniqueName
+	}
+	return ""
+}
+
+// Discover populates the given module with the resources discovered by the previous run.
+// That's only possible if the previous run has processed all resources of that module.
+func (me *Manifest) Discover(module *Module) bool {
+	if me == nil || me.previous == nil {
+		return false
+	}
+	record, found := me.previous.Modules[module.Type]
+	if !found || !record.Complete || record.Status == ModuleStati.Erronous {
+		return false
+	}
+	for id, resourceRecord := range record.Resources {
+		if IsIgnoredResource(module.Type, id) {
+			continue
+		}
+		resource := module.Resource(id).SetName(resourceRecord.Name)
+		resource.LegacyID = resourceRecord.LegacyID
+		resource.ParentID = resourceRecord.ParentID
+	}
+	logging.Debug.Info.Printf("[DISCOVER] [%s] %d items restored from %s.", module.Type, len(record.Resources), ManifestFileName)
+	return true
+}
+
+// Restore takes over the outcome of the previous run for the given resource.
+// That's only possible if the file written by the previous run hasn't been modified since then.
+// Resources that had been written into bundles are always downloaded again.
+func (me *Manifest) Restore(resource *Resource) bool {
+	record := me.lookup(resource.Type, resource.ID)
+	if record == nil || record.Bundled || len(record.Checksum) == 0 {
+		return false
+	}
+	if !record.Status.IsOneOf(ResourceStati.Downloaded, ResourceStati.PostProcessed) {
+		return false
+	}
+
+	resource.SetName(record.Name)
+	if resource.UniqueName != record.UniqueName {
+		return false
+	}
+	if relPath, err := filepath.Rel(me.folder, resource.GetFile()); err != nil || filepath.ToSlash(relPath) != record.File {
+		return false
+	}
+	if checksum(resource.GetFile()) != record.Checksum {
+		return false
+	}
+
+	resource.LegacyID = record.LegacyID
+	resource.Flawed = record.Flawed
+	resource.RequiresAttention = record.RequiresAttention
+	resource.BundleFilePath = ""
+	resource.SplitId = 0
+	resource.Status = record.Status
+
+	if record.Status == ResourceStati.PostProcessed {
+		// the file already refers to these resources, hence they need to be part of the export again
+		env := resource.Module.Environment
+		for _, reference := range record.References {
+			referenced := env.Module(reference.Type).Resource(reference.ID)
+			if err := referenced.Download(); err != nil {
+				logging.Debug.Warn.Printf("[RESUME] [%s] [%s] Unable to restore reference %s.%s: %s", resource.Type, resource.ID, reference.Type, reference.ID, err.Error())
+			}
+			resource.ResourceReferences = append(resource.ResourceReferences, referenced)
+			if record.Parent != nil && record.Parent.Type == reference.Type && record.Parent.ID == reference.ID {
+				resource.XParent = referenced
+			}
+		}
+	}
+
+	service := resource.Module.Service
+	if service == nil {
+		service = resource.Module.GetDescriptor().Service(resource.Module.Environment.Credentials)
+	}
+	idOnly, _, _ := settings.SplitID(resource.ID)
+	address.AddToComplete(address.AddressComplete{
+		AddressOriginal: address.AddressOriginal{
+			TerraformSchemaID: service.SchemaID(),
+			OriginalID:        idOnly,
+		},
+		UniqueName:  resource.UniqueName,
+		Type:        string(resource.Type),
+		TrimmedType: resource.Type.Trim(),
+	})
+	SetOptimizedRegexResource(resource)
+	me.Record(resource)
+	return true
+}
+
+func checksum(file string) string {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return ""
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,124 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+const manifestTestURL = "https://abc12345.live.dynatrace.com"
+
+const manifestTestID = "vu9U3hXa3q0AAAABABhidWlsdGluOmFsZXJ0aW5nLnByb2ZpbGUABnRlbmFudAAGdGVuYW50ACRhYmM"
+
+func newManifestEnvironment(t *testing.T, folder string, url string, resume bool) (*export.Environment, error) {
+	t.Helper()
+	env := &export.Environment{
+		OutputFolder: folder,
+		Credentials:  &settings.Credentials{URL: url},
+		Modules:      map[export.ResourceType]*export.Module{},
+		Flags:        export.Flags{Resume: resume},
+	}
+	return env, env.InitManifest()
+}
+
+func TestManifestResume(t *testing.T) {
+	folder := t.TempDir()
+
+	env, err := newManifestEnvironment(t, folder, manifestTestURL, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resource := env.Module(export.ResourceTypes.Alerting).Resource(manifestTestID).SetName("Default")
+	if err := env.Module(export.ResourceTypes.Alerting).MkdirAll(false); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(resource.GetFile(), []byte(`resource "dynatrace_alerting" "Default" {}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	resource.Status = export.ResourceStati.Downloaded
+	resource.RequiresAttention = true
+	env.Manifest.Record(resource)
+	if err := env.Manifest.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest, err := export.LoadManifest(folder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	record := manifest.Modules[export.ResourceTypes.Alerting].Resources[manifestTestID]
+	if record == nil {
+		t.Fatal("resource has not been recorded")
+	}
+	if record.Status != export.ResourceStati.Downloaded || !record.RequiresAttention || record.UniqueName != "Default" {
+		t.Errorf("unexpected record %+v", record)
+	}
+	if record.File != "modules/alerting/Default.alerting.tf" {
+		t.Errorf("unexpected file '%s'", record.File)
+	}
+
+	resumed, err := newManifestEnvironment(t, folder, manifestTestURL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := resumed.Module(export.ResourceTypes.Alerting).Resource(manifestTestID)
+	if !resumed.Manifest.Restore(restored) {
+		t.Fatal("resource has not been restored")
+	}
+	if restored.Status != export.ResourceStati.Downloaded || restored.UniqueName != "Default" || !restored.RequiresAttention {
+		t.Errorf("unexpected restored resource %+v", restored)
+	}
+	// the name of the restored resource must not get handed out twice
+	other := resumed.Module(export.ResourceTypes.Alerting).Resource("other").SetName("Default")
+	if other.UniqueName == "Default" {
+		t.Error("name of restored resource has been reused")
+	}
+
+	// modified files are getting downloaded again
+	if err := os.WriteFile(resource.GetFile(), []byte(`resource "dynatrace_alerting" "Default" { name = "x" }`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modified, err := newManifestEnvironment(t, folder, manifestTestURL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if modified.Manifest.Restore(modified.Module(export.ResourceTypes.Alerting).Resource(manifestTestID)) {
+		t.Error("modified resource has been restored")
+	}
+}
+
+func TestManifestResumeOtherEnvironment(t *testing.T) {
+	folder := t.TempDir()
+	env, err := newManifestEnvironment(t, folder, manifestTestURL, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := env.Manifest.Finish(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newManifestEnvironment(t, folder, "https://other.live.dynatrace.com", true); err == nil {
+		t.Error("resuming the export of a different environment should fail")
+	}
+	if _, err := newManifestEnvironment(t, t.TempDir(), manifestTestURL, true); err != nil {
+		t.Errorf("resuming without manifest should start from scratch: %s", err.Error())
+	}
+}
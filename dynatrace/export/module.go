@@ -31,6 +31,8 @@ import (
 	"sync"
 
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document/convert"
+	documents "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document/settings"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/shutdown"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
@@ -63,7 +65,7 @@ func (me *Module) GetDescriptor() *ResourceDescriptor {
 	me.DescriptorLock.Lock()
 	defer me.DescriptorLock.Unlock()
 	if me.PrivDescriptor == nil {
-		if descriptor, found := AllResources[me.Type]; found {
+		if descriptor, found := me.Environment.Descriptor(me.Type); found {
 			me.PrivDescriptor = &descriptor
 		} else {
 			panic(fmt.Sprintf("Tried to resolve a Resource Descriptor for resource type `%s` - that key doesn't exist in AllResource. Please contact Dynatrace.", me.Type))
@@ -537,9 +539,7 @@ func (me *Module) WriteDataSourcesFile(logToScreen bool) (err error) {
 		buf.Write([]byte("\n" + ds))
 	}
 	for _, dataSource := range me.SortedDataSources() {
-		dataSourceName := dataSource.Name
 		dataSourceID := dataSource.ID
-		dd, _ := json.Marshal(dataSourceName)
 		if dataSource.Type == string(DataSourceKindTenant) {
 			if _, err = buf.WriteString(`
 			data "dynatrace_tenant" "tenant" {
@@ -565,9 +565,8 @@ func (me *Module) WriteDataSourcesFile(logToScreen bool) (err error) {
 		} else {
 			if _, err = buf.WriteString(fmt.Sprintf(`
 				data "dynatrace_entity" "%s" {
-					type = "%s"
-					name = %s
-				}`, dataSourceID, dataSource.Type, string(dd))); err != nil {
+					%s
+				}`, dataSourceID, dataSource.entityLookup())); err != nil {
 				return err
 			}
 		}
@@ -622,9 +621,8 @@ func (me *Module) ProvideDataSources(except map[string]*DataSource) (dsm map[str
 		}
 		dd, _ := json.Marshal(dataSourceName)
 		dsm["dynatrace_entity."+dataSource.Type+"."+string(dd)] = fmt.Sprintf(`data "dynatrace_entity" "%s" {
-			type = "%s"
-			name = %s
-		}`, dataSourceID, dataSource.Type, string(dd))
+			%s
+		}`, dataSourceID, dataSource.entityLookup())
 	}
 	return dsm, nil
 }
@@ -847,6 +845,7 @@ func (me *Module) Download(multiThreaded bool, keys ...string) (err error) {
 	if err != nil {
 		return err
 	}
+	me.Environment.Manifest.RecordModule(me, !shutdown.System.Stopped())
 
 	return nil
 }
@@ -959,7 +958,9 @@ func (me *Module) downloadResources(resourcesToDownload []*Resource, multiThread
 	}
 
 	processItem := func(resource *Resource) error {
-		if err := resource.Download(); err != nil {
+		err := resource.Download()
+		me.Environment.Manifest.Record(resource)
+		if err != nil {
 			return err
 		}
 		mutex.Lock()
@@ -1216,7 +1217,7 @@ func (me *Module) getResourcesPerBundle(resourcesCount int, maxThreads int) bund
 }
 
 func (me *Module) IsBundleImpossible() bool {
-	resourceDefinition := AllResources[me.Type]
+	resourceDefinition, _ := me.Environment.Descriptor(me.Type)
 
 	if ULTRA_PARALLEL {
 		// pass
@@ -1224,6 +1225,10 @@ func (me *Module) IsBundleImpossible() bool {
 		return true
 	}
 
+	if !me.Environment.Flags.Format.IsHCL() {
+		return true
+	}
+
 	if me.Environment.HasDependenciesTo[me.Type] {
 		return true
 	}
@@ -1270,6 +1275,18 @@ func (me *Module) Discover() error {
 
 	if me.Service == nil {
 		me.Service = descriptor.Service(me.Environment.Credentials)
+		if me.Type == ResourceTypes.Documents && me.Environment.Flags.ConvertDashboards {
+			me.Service = &settings.GenericCRUDService[*documents.Document]{Service: convert.Service(me.Environment.Credentials)}
+		}
+		if me.Type == ResourceTypes.IAMUser && !me.Environment.Flags.IAMMemberships.IsUser() {
+			me.Service = newUsersWithoutGroups(me.Environment.Credentials)
+		}
+	}
+
+	if me.Environment.Manifest.Discover(me) {
+		me.Status = ModuleStati.Discovered
+		SetOptimizedRegexModule(me)
+		return nil
 	}
 
 	var err error
@@ -1280,17 +1297,20 @@ func (me *Module) Discover() error {
 			logging.Debug.Info.Printf("[DISCOVER] [%s] Module will not get exported. Token is missing required scope.", me.Type)
 			me.Status = ModuleStati.Erronous
 			me.Error = err
+			me.Environment.Manifest.RecordModule(me, false)
 			return nil
 		}
 		if strings.Contains(err.Error(), "No schema with topic identifier") {
 			logging.Debug.Info.Printf("[DISCOVER] [%s] Module will not get exported. The schema doesn't exist on that environment.", me.Type)
 			me.Status = ModuleStati.Erronous
 			me.Error = err
+			me.Environment.Manifest.RecordModule(me, false)
 			return nil
 		}
 		return err
 	}
 	stubs = stubs.Sort()
+	filtered := 0
 	for _, stub := range stubs {
 		if stub.Name == "" {
 			panic(me.Type)
@@ -1299,6 +1319,12 @@ func (me *Module) Discover() error {
 			fmt.Printf("Ignoring Resource - Type: %s - ID: %s\n", me.Type, stub.ID)
 			continue
 		}
+		if matches, err := me.Environment.Filter.Matches(me, stub); err != nil {
+			return err
+		} else if !matches {
+			filtered++
+			continue
+		}
 		res := me.Resource(stub.ID).SetName(stub.Name)
 		if stub.LegacyID != nil {
 			res.LegacyID = *stub.LegacyID
@@ -1313,6 +1339,9 @@ func (me *Module) Discover() error {
 	SetOptimizedRegexModule(me)
 
 	logging.Debug.Info.Printf("[DISCOVER] [%s] %d items found.", me.Type, len(stubs))
+	if filtered > 0 {
+		logging.Debug.Info.Printf("[DISCOVER] [%s] %d items don't match the filter.", me.Type, filtered)
+	}
 	return nil
 }
 
@@ -0,0 +1,550 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
+	"gopkg.in/yaml.v3"
+)
+
+type OutputFormat string
+
+var OutputFormats = struct {
+	HCL    OutputFormat
+	JSON   OutputFormat
+	Monaco OutputFormat
+}{
+	"hcl",
+	"json",
+	"monaco",
+}
+
+// IsHCL returns true unless the export has been asked to produce JSON payloads instead of Terraform configuration
+func (me OutputFormat) IsHCL() bool {
+	return me == "" || me == OutputFormats.HCL
+}
+
+func (me OutputFormat) Validate() error {
+	switch me {
+	case "", OutputFormats.HCL, OutputFormats.JSON, OutputFormats.Monaco:
+		return nil
+	}
+	return fmt.Errorf("unsupported value `%s` for -format. supported are `hcl`, `json` and `monaco`", me)
+}
+
+func (me OutputFormat) Extension() string {
+	if me.IsHCL() {
+		return "tf"
+	}
+	return "json"
+}
+
+// PayloadsManifestFileName is the name of the file listing all exported payloads in case of `-format=json`
+const PayloadsManifestFileName = "resources.json"
+
+// MonacoManifestFileName is the name of the monaco manifest in case of `-format=monaco`
+const MonacoManifestFileName = "manifest.yaml"
+
+// MonacoProjectPath is the folder (relative to the target folder) containing the monaco project
+const MonacoProjectPath = "modules"
+
+// PayloadReference is a reference from one exported payload to another resource,
+// resolved by the same `Dependency` implementations the Terraform export relies on
+type PayloadReference struct {
+	// Parameter is the name of the parameter representing the reference in a monaco template
+	Parameter  string       `json:"parameter"`
+	Type       ResourceType `json:"type"`
+	ID         string       `json:"id"`
+	UniqueName string       `json:"unique_name"`
+	// Property is the attribute of the referenced resource, i.e. `id`, `name` or `legacy_id`
+	Property string `json:"property"`
+	// Value is the value of that property as it's contained in the payload
+	Value string `json:"value"`
+}
+
+// writePayload writes the configuration of a resource as JSON instead of HCL.
+// For monaco only the value of a setting is of interest, scope and position are part of the config YAML.
+func (me *Resource) writePayload(v settings.Settings, w *os.File) error {
+	data, err := settings.ToJSON(v)
+	if err != nil {
+		return err
+	}
+	me.Scope = settings.GetScope(v)
+	if me.Module.Environment.Flags.Format == OutputFormats.Monaco {
+		if data, err = stripStorageAttributes(v, data); err != nil {
+			return err
+		}
+	}
+	var buf bytes.Buffer
+	if err = json.Indent(&buf, data, "", "  "); err != nil {
+		return err
+	}
+	buf.WriteString("\n")
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// stripStorageAttributes removes the properties `settings.ToJSON` adds on top of the actual value (e.g. `scope` or `insertAfter`)
+func stripStorageAttributes(v settings.Settings, data []byte) ([]byte, error) {
+	plain, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	plainMap := map[string]json.RawMessage{}
+	stored := map[string]json.RawMessage{}
+	if json.Unmarshal(plain, &plainMap) != nil || json.Unmarshal(data, &stored) != nil {
+		return data, nil
+	}
+	for key := range stored {
+		if _, found := plainMap[key]; !found {
+			delete(stored, key)
+		}
+	}
+	return json.Marshal(stored)
+}
+
+// postProcessPayload resolves the references within the JSON payload of a resource.
+// The dependencies produce Terraform references, which are getting translated into
+// monaco parameters afterwards. Data sources (e.g. entities) aren't configuration, hence
+// IDs referring to them remain untouched.
+func (me *Resource) postProcessPayload(dependencies []Dependency, nonPostProcessedResources []*Resource) error {
+	env := me.Module.Environment
+	data, err := me.ReadFile()
+	if err != nil {
+		return err
+	}
+	payload := string(data)
+	replaced := payload
+
+	for _, dependency := range dependencies {
+		resourceType := dependency.ResourceType()
+		if len(resourceType) == 0 {
+			continue
+		}
+		module := env.Module(resourceType)
+		if module.Status == ModuleStati.Erronous {
+			continue
+		}
+		if !module.Status.IsOneOf(ModuleStati.Downloaded, ModuleStati.Discovered, ModuleStati.Erronous) {
+			if err = module.Discover(); err != nil {
+				return err
+			}
+		}
+		var foundItems []any
+		if replaced, foundItems = dependency.Replace(env, replaced, me.Type, me.ID, nonPostProcessedResources); len(foundItems) == 0 {
+			continue
+		}
+		for _, item := range foundItems {
+			if typedItem, ok := item.(*Resource); ok {
+				if err = typedItem.Download(); err != nil {
+					return err
+				}
+				if dependency.IsParent() {
+					me.XParent = typedItem
+				}
+				me.ResourceReferences = append(me.ResourceReferences, typedItem)
+			}
+		}
+	}
+
+	var template string
+	template, me.PayloadReferences = toPayloadReferences(replaced, me.ResourceReferences)
+	if env.Flags.Format != OutputFormats.Monaco || template == payload {
+		return nil
+	}
+	return os.WriteFile(me.GetFile(), []byte(template), 0664)
+}
+
+var invalidParameterChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// toPayloadReferences replaces the Terraform references the dependencies have produced with monaco
+// parameters (`{{.parameter}}`). References to resources exported as data sources are reverted,
+// because they're not part of the monaco project.
+func toPayloadReferences(s string, references []*Resource) (string, []*PayloadReference) {
+	payloadReferences := []*PayloadReference{}
+	seen := map[string]bool{}
+	for _, reference := range references {
+		pattern := fmt.Sprintf(`\$\{(?:var\.|data\.)?(?:%s|%s)(?:\.|_)%s(?:\.value)?\.(id|name|legacy_id)\}`,
+			regexp.QuoteMeta(string(reference.Type)), regexp.QuoteMeta(reference.Type.AsDataSource()), regexp.QuoteMeta(reference.UniqueName))
+		s = regexp.MustCompile(pattern).ReplaceAllStringFunc(s, func(match string) string {
+			property := match[strings.LastIndex(match, ".")+1 : len(match)-1]
+			value := reference.ID
+			switch property {
+			case "name":
+				value = reference.Name
+			case "legacy_id":
+				value = reference.LegacyID
+			}
+			if reference.IsReferencedAsDataSource() {
+				return value
+			}
+			parameter := invalidParameterChars.ReplaceAllString(fmt.Sprintf("%s__%s__%s", reference.Type.Trim(), reference.UniqueName, property), "_")
+			if !seen[parameter] {
+				seen[parameter] = true
+				payloadReferences = append(payloadReferences, &PayloadReference{
+					Parameter:  parameter,
+					Type:       reference.Type,
+					ID:         reference.ID,
+					UniqueName: reference.UniqueName,
+					Property:   property,
+					Value:      value,
+				})
+			}
+			return "{{." + parameter + "}}"
+		})
+	}
+	sort.Slice(payloadReferences, func(i, j int) bool { return payloadReferences[i].Parameter < payloadReferences[j].Parameter })
+	return s, payloadReferences
+}
+
+type payloadManifestEntry struct {
+	Type              ResourceType        `json:"type"`
+	ID                string              `json:"id"`
+	LegacyID          string              `json:"legacy_id,omitempty"`
+	Name              string              `json:"name"`
+	UniqueName        string              `json:"unique_name"`
+	SchemaID          string              `json:"schema_id"`
+	Scope             string              `json:"scope,omitempty"`
+	File              string              `json:"file"`
+	Flawed            bool                `json:"flawed,omitempty"`
+	RequiresAttention bool                `json:"requires_attention,omitempty"`
+	References        []*PayloadReference `json:"references,omitempty"`
+}
+
+// getPayloadModules returns the modules containing exported payloads, sorted by resource type
+func (me *Environment) getPayloadModules() []*Module {
+	modules := []*Module{}
+	for _, module := range me.Modules {
+		if module.IsReferencedAsDataSource() {
+			module.PurgeFolder()
+			continue
+		}
+		if len(module.GetPostProcessedResources()) == 0 {
+			module.PurgeFolder()
+			continue
+		}
+		modules = append(modules, module)
+	}
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Type < modules[j].Type })
+	return modules
+}
+
+func (me *Module) getPayloadResources() []*Resource {
+	resources := []*Resource{}
+	for _, resource := range me.GetPostProcessedResources() {
+		if resource.IsReferencedAsDataSource() {
+			continue
+		}
+		resources = append(resources, resource)
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].UniqueName < resources[j].UniqueName })
+	return resources
+}
+
+// WritePayloadManifests writes the files describing the exported JSON payloads:
+// `resources.json` for `-format=json`, the monaco manifest and a `config.yaml` per resource type for `-format=monaco`
+func (me *Environment) WritePayloadManifests() error {
+	modules := me.getPayloadModules()
+	if me.Flags.Format == OutputFormats.Monaco {
+		return me.writeMonacoProject(modules)
+	}
+
+	fmt.Println("Writing " + PayloadsManifestFileName)
+	entries := []*payloadManifestEntry{}
+	for _, module := range modules {
+		schemaID := module.GetDescriptor().Service(me.Credentials).SchemaID()
+		for _, resource := range module.getPayloadResources() {
+			file, err := filepath.Rel(me.OutputFolder, resource.GetFile())
+			if err != nil {
+				return err
+			}
+			entries = append(entries, &payloadManifestEntry{
+				Type:              resource.Type,
+				ID:                resource.ID,
+				LegacyID:          resource.LegacyID,
+				Name:              resource.Name,
+				UniqueName:        resource.UniqueName,
+				SchemaID:          schemaID,
+				Scope:             resource.Scope,
+				File:              filepath.ToSlash(file),
+				Flawed:            resource.Flawed,
+				RequiresAttention: resource.RequiresAttention,
+				References:        resource.PayloadReferences,
+			})
+		}
+	}
+	data, err := json.MarshalIndent(struct {
+		EnvironmentURL string                  `json:"environment_url"`
+		Resources      []*payloadManifestEntry `json:"resources"`
+	}{me.Credentials.URL, entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(me.OutputFolder, os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(path.Join(me.OutputFolder, PayloadsManifestFileName), data, 0664)
+}
+
+type monacoManifest struct {
+	ManifestVersion   string                   `yaml:"manifestVersion"`
+	Projects          []monacoProject          `yaml:"projects"`
+	EnvironmentGroups []monacoEnvironmentGroup `yaml:"environmentGroups"`
+}
+
+type monacoProject struct {
+	Name string `yaml:"name"`
+	Path string `yaml:"path,omitempty"`
+}
+
+type monacoEnvironmentGroup struct {
+	Name         string              `yaml:"name"`
+	Environments []monacoEnvironment `yaml:"environments"`
+}
+
+type monacoEnvironment struct {
+	Name string `yaml:"name"`
+	URL  struct {
+		Value string `yaml:"value"`
+	} `yaml:"url"`
+	Auth struct {
+		Token struct {
+			Name string `yaml:"name"`
+		} `yaml:"token"`
+	} `yaml:"auth"`
+}
+
+type monacoConfigs struct {
+	Configs []monacoConfig `yaml:"configs"`
+}
+
+type monacoConfig struct {
+	ID     string             `yaml:"id"`
+	Config monacoConfigDetail `yaml:"config"`
+	Type   map[string]any     `yaml:"type"`
+}
+
+type monacoConfigDetail struct {
+	Name       string         `yaml:"name"`
+	Template   string         `yaml:"template"`
+	Skip       bool           `yaml:"skip"`
+	Parameters map[string]any `yaml:"parameters,omitempty"`
+}
+
+type monacoReference struct {
+	Type       string `yaml:"type"`
+	ConfigType string `yaml:"configType"`
+	ConfigID   string `yaml:"configId"`
+	Property   string `yaml:"property"`
+}
+
+type monacoValue struct {
+	Type  string `yaml:"type"`
+	Value string `yaml:"value"`
+}
+
+// monacoAPIs maps the schema IDs the provider uses for resource types not based on the Settings 2.0 API
+// to the IDs monaco uses for the corresponding classic configuration APIs.
+// Resource types not listed here don't have a counterpart in monaco.
+var monacoAPIs = map[string]string{
+	"v1:config:alerting":                          "alerting-profile",
+	"v1:config:anomaly-detection:disk-events":     "anomaly-detection-disks",
+	"v1:config:anomaly-detection:metric-events":   "anomaly-detection-metrics",
+	"v1:config:applications:detection":            "app-detection-rule",
+	"v1:config:applications:mobile":               "application-mobile",
+	"v1:config:applications:web":                  "application-web",
+	"v1:config:auto-tags":                         "auto-tag",
+	"v1:config:calculated-metrics-mobile":         "calculated-metrics-mobile",
+	"v1:config:calculated-metrics-service":        "calculated-metrics-service",
+	"v1:config:calculated-metrics-synthetic":      "calculated-metrics-synthetic",
+	"v1:config:calculated-metrics-web":            "calculated-metrics-web",
+	"v1:config:conditional-naming:hosts":          "conditional-naming-host",
+	"v1:config:conditional-naming:process-groups": "conditional-naming-processgroup",
+	"v1:config:conditional-naming:services":       "conditional-naming-service",
+	"v1:config:credentials:aws":                   "aws-credentials",
+	"v1:config:credentials:azure":                 "azure-credentials",
+	"v1:config:credentials:kubernetes":            "kubernetes-credentials",
+	"v1:config:dashboards":                        "dashboard",
+	"v1:config:json-dashboards":                   "dashboard",
+	"v1:config:maintenance-windows":               "maintenance-window",
+	"v1:config:management-zones":                  "management-zone",
+	"v1:config:notifications":                     "notification",
+	"v1:config:reports":                           "report",
+	"v1:config:request-attributes":                "request-attributes",
+	"v1:config:service:request-naming":            "request-naming-service",
+	"v1:synthetic:locations":                      "synthetic-location",
+	"v1:synthetic:monitors:browser":               "synthetic-monitor",
+	"v1:synthetic:monitors:http":                  "synthetic-monitor",
+	"v2:environment:credentials":                  "credential-vault",
+}
+
+func isSettingsSchema(schemaID string) bool {
+	return strings.HasPrefix(schemaID, "builtin:") || strings.HasPrefix(schemaID, "app:")
+}
+
+// monacoConfigType returns the type monaco identifies configs of the given schema with,
+// i.e. the schema ID for Settings 2.0 and the ID of the API for classic configuration APIs.
+// It returns false if there is no counterpart in monaco.
+func monacoConfigType(schemaID string) (string, bool) {
+	if isSettingsSchema(schemaID) {
+		return schemaID, true
+	}
+	api, found := monacoAPIs[schemaID]
+	return api, found
+}
+
+// monacoType produces the `type` section of a monaco config.
+// Settings 2.0 schemas are supported natively, all other resource types need a counterpart in `monacoAPIs`.
+func monacoType(schemaID string, scope string) (map[string]any, bool) {
+	if isSettingsSchema(schemaID) {
+		if len(scope) == 0 {
+			scope = "environment"
+		}
+		return map[string]any{"settings": map[string]string{"schema": schemaID, "scope": scope}}, true
+	}
+	if api, found := monacoAPIs[schemaID]; found {
+		return map[string]any{"api": api}, true
+	}
+	return nil, false
+}
+
+func (me *Environment) writeMonacoProject(modules []*Module) error {
+	fmt.Println("Writing " + MonacoManifestFileName)
+	schemaIDs := map[ResourceType]string{}
+	schemaID := func(resourceType ResourceType) string {
+		if id, found := schemaIDs[resourceType]; found {
+			return id
+		}
+		schemaIDs[resourceType] = me.Module(resourceType).GetDescriptor().Service(me.Credentials).SchemaID()
+		return schemaIDs[resourceType]
+	}
+
+	for _, module := range modules {
+		configs := monacoConfigs{Configs: []monacoConfig{}}
+		for _, resource := range module.getPayloadResources() {
+			if resource.Type == ResourceTypes.GenericSetting {
+				if err := resource.unwrapGenericSetting(); err != nil {
+					return err
+				}
+			}
+			resourceSchemaID := schemaID(resource.Type)
+			if len(resource.SchemaID) > 0 {
+				resourceSchemaID = resource.SchemaID
+			}
+			configType, supported := monacoType(resourceSchemaID, resource.Scope)
+			if !supported {
+				fmt.Printf("- [MONACO] %s - %s isn't supported by monaco and therefore not part of the project\n", resource.Type, resource.UniqueName)
+				logging.Debug.Warn.Printf("[MONACO] [%s] [%s] no counterpart in monaco for schema `%s`", resource.Type, resource.ID, resourceSchemaID)
+				os.Remove(resource.GetFile())
+				continue
+			}
+			config := monacoConfig{
+				ID: resource.UniqueName,
+				Config: monacoConfigDetail{
+					Name:     resource.Name,
+					Template: path.Base(resource.GetFile()),
+				},
+				Type: configType,
+			}
+			for _, reference := range resource.PayloadReferences {
+				if config.Config.Parameters == nil {
+					config.Config.Parameters = map[string]any{}
+				}
+				// resources monaco doesn't know about can't get referenced, their values are getting used as they are
+				referencedType, supported := monacoConfigType(schemaID(reference.Type))
+				if !supported {
+					config.Config.Parameters[reference.Parameter] = monacoValue{Type: "value", Value: reference.Value}
+					continue
+				}
+				config.Config.Parameters[reference.Parameter] = monacoReference{
+					Type:       "reference",
+					ConfigType: referencedType,
+					ConfigID:   reference.UniqueName,
+					Property:   reference.Property,
+				}
+			}
+			configs.Configs = append(configs.Configs, config)
+		}
+		if len(configs.Configs) == 0 {
+			module.PurgeFolder()
+			continue
+		}
+		if err := writeYAML(module.GetFile("config.yaml"), configs); err != nil {
+			return err
+		}
+	}
+
+	environment := monacoEnvironment{Name: me.TenantID()}
+	environment.URL.Value = me.Credentials.URL
+	environment.Auth.Token.Name = "DYNATRACE_API_TOKEN"
+	return writeYAML(path.Join(me.OutputFolder, MonacoManifestFileName), monacoManifest{
+		ManifestVersion:   "1.0",
+		Projects:          []monacoProject{{Name: "dynatrace", Path: MonacoProjectPath}},
+		EnvironmentGroups: []monacoEnvironmentGroup{{Name: "default", Environments: []monacoEnvironment{environment}}},
+	})
+}
+
+// unwrapGenericSetting replaces the payload of a generic setting with its value, because the schema is part of the monaco config.
+// That's only possible after post-processing, because dependency rules refer to the schema and the location within the stored payload.
+func (me *Resource) unwrapGenericSetting() error {
+	data, err := me.ReadFile()
+	if err != nil {
+		return err
+	}
+	var stored struct {
+		SchemaID string `json:"schemaId"`
+		Value    string `json:"value"`
+	}
+	if err = json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+	me.SchemaID = stored.SchemaID
+	var buf bytes.Buffer
+	if err = json.Indent(&buf, []byte(stored.Value), "", "  "); err != nil {
+		return err
+	}
+	buf.WriteString("\n")
+	return os.WriteFile(me.GetFile(), buf.Bytes(), 0664)
+}
+
+func writeYAML(file string, v any) error {
+	if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(file, buf.Bytes(), 0664)
+}
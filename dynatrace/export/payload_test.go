@@ -0,0 +1,216 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+)
+
+func createSettingsObject(t *testing.T, server *mockserver.Server, schemaID string, value string) string {
+	t.Helper()
+	request, _ := http.NewRequest(http.MethodPost, server.URL+"/api/v2/settings/objects", strings.NewReader(`[{"schemaId":"`+schemaID+`","scope":"environment","value":`+value+`}]`))
+	request.Header.Set("Authorization", "Api-Token "+mockserver.Token)
+	request.Header.Set("Content-Type", "application/json")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	data, _ := io.ReadAll(response.Body)
+	var result []struct {
+		ObjectID string `json:"objectId"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil || len(result) != 1 {
+		t.Fatalf("unable to create settings object: %s", string(data))
+	}
+	return result[0].ObjectID
+}
+
+func exportPayloads(t *testing.T, format export.OutputFormat) string {
+	t.Helper()
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+
+	createSettingsObject(t, server, "builtin:management-zones", `{"name":"team-a","rules":[]}`)
+	mzStubs, err := export.AllResources[export.ResourceTypes.ManagementZoneV2].Service(server.Credentials()).List(context.Background())
+	if err != nil || len(mzStubs) != 1 {
+		t.Fatalf("unable to list management zones: %v", err)
+	}
+	createSettingsObject(t, server, "builtin:alerting.profile", `{"name":"team-a alerts","managementZone":"`+*mzStubs[0].LegacyID+`","severityRules":[]}`)
+
+	folder := t.TempDir()
+	env := &export.Environment{
+		OutputFolder: folder,
+		Credentials:  server.Credentials(),
+		Modules:      map[export.ResourceType]*export.Module{},
+		Flags:        export.Flags{FollowReferences: true, Format: format},
+		ResArgs: map[string][]string{
+			string(export.ResourceTypes.Alerting):         nil,
+			string(export.ResourceTypes.ManagementZoneV2): nil,
+		},
+	}
+	if err := env.Export(); err != nil {
+		t.Fatal(err)
+	}
+	return folder
+}
+
+func TestExportFormatJSON(t *testing.T) {
+	folder := exportPayloads(t, export.OutputFormats.JSON)
+
+	data, err := os.ReadFile(filepath.Join(folder, export.PayloadsManifestFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest struct {
+		Resources []struct {
+			Type       export.ResourceType        `json:"type"`
+			Name       string                     `json:"name"`
+			SchemaID   string                     `json:"schema_id"`
+			File       string                     `json:"file"`
+			References []*export.PayloadReference `json:"references"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Resources) != 2 {
+		t.Fatalf("expected 2 resources, got %s", string(data))
+	}
+	alerting := manifest.Resources[0]
+	if alerting.Type != export.ResourceTypes.Alerting || alerting.SchemaID != "builtin:alerting.profile" {
+		t.Fatalf("unexpected resource %+v", alerting)
+	}
+	if len(alerting.References) != 1 || alerting.References[0].Type != export.ResourceTypes.ManagementZoneV2 || alerting.References[0].Property != "legacy_id" {
+		t.Fatalf("expected a reference to the management zone, got %s", string(data))
+	}
+	payload, err := os.ReadFile(filepath.Join(folder, alerting.File))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the raw payload keeps the ID of the management zone
+	if !strings.Contains(string(payload), `"managementZone": "`+alerting.References[0].Value+`"`) {
+		t.Errorf("unexpected payload %s", string(payload))
+	}
+	if _, err := os.Stat(filepath.Join(folder, "main.tf")); !os.IsNotExist(err) {
+		t.Error("no Terraform configuration expected")
+	}
+}
+
+func TestExportFormatMonaco(t *testing.T) {
+	folder := exportPayloads(t, export.OutputFormats.Monaco)
+
+	manifest, err := os.ReadFile(filepath.Join(folder, export.MonacoManifestFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(manifest), "path: "+export.MonacoProjectPath) {
+		t.Errorf("unexpected manifest %s", string(manifest))
+	}
+	config, err := os.ReadFile(filepath.Join(folder, export.MonacoProjectPath, "alerting", "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"schema: builtin:alerting.profile",
+		"scope: environment",
+		"type: reference",
+		"configType: builtin:management-zones",
+		"configId: team-a",
+		"property: legacy_id",
+	} {
+		if !strings.Contains(string(config), expected) {
+			t.Errorf("expected `%s` within config.yaml:\n%s", expected, string(config))
+		}
+	}
+	template, err := os.ReadFile(filepath.Join(folder, export.MonacoProjectPath, "alerting", "team-a_alerts.alerting.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(template), `"managementZone": "{{.management_zone_v2__team_a__legacy_id}}"`) {
+		t.Errorf("unexpected template %s", string(template))
+	}
+}
+
+func createConfig(t *testing.T, server *mockserver.Server, path string, payload string) {
+	t.Helper()
+	request, _ := http.NewRequest(http.MethodPost, server.URL+path, strings.NewReader(payload))
+	request.Header.Set("Authorization", "Api-Token "+mockserver.Token)
+	request.Header.Set("Content-Type", "application/json")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		data, _ := io.ReadAll(response.Body)
+		t.Fatalf("unable to create %s: %s", path, string(data))
+	}
+}
+
+func TestExportFormatMonacoClassicAPIs(t *testing.T) {
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+
+	createConfig(t, server, "/api/config/v1/managementZones", `{"name":"team-b","rules":[]}`)
+	createConfig(t, server, "/api/config/v1/service/requestNaming", `{"enabled":true,"namingPattern":"team-b","conditions":[]}`)
+
+	folder := t.TempDir()
+	env := &export.Environment{
+		OutputFolder: folder,
+		Credentials:  server.Credentials(),
+		Modules:      map[export.ResourceType]*export.Module{},
+		Flags:        export.Flags{Format: export.OutputFormats.Monaco},
+		ResArgs: map[string][]string{
+			string(export.ResourceTypes.ManagementZone): nil,
+			string(export.ResourceTypes.RequestNamings): nil,
+		},
+	}
+	if err := env.Export(); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := os.ReadFile(filepath.Join(folder, export.MonacoProjectPath, "management_zone", "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// classic configuration APIs are identified by the IDs monaco uses for them
+	if !strings.Contains(string(config), "api: management-zone") {
+		t.Errorf("expected the monaco API `management-zone` within config.yaml:\n%s", string(config))
+	}
+	// the order of request namings has no counterpart in monaco
+	if _, err := os.Stat(filepath.Join(folder, export.MonacoProjectPath, "request_namings")); !os.IsNotExist(err) {
+		t.Error("expected no monaco configs for the order of request namings")
+	}
+}
@@ -0,0 +1,227 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	entitysettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/v2/entity/settings"
+)
+
+// PromotionExportedVarsFileName is the name of the file containing the values of the exported environment in case of `-promote`
+const PromotionExportedVarsFileName = "exported.tfvars"
+
+// promote registers the variable replacing an environment specific value in case of `-promote` and returns a reference to it.
+// Names derived from display names may clash, a different value is then getting registered with the name suffixed with `suffix`
+func (me *Variables) promote(resourceType ResourceType, name string, description string, value string, suffix string) string {
+	return "${var." + me.use(resourceType, &Variable{Name: name, Description: description, Value: value}, suffix) + "}"
+}
+
+// valueSuffix distinguishes variables for values without an ID, whose names derived from the value clash
+func valueSuffix(value string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(value)))[:8]
+}
+
+// promotionDependencies are getting applied to every resource in case of `-promote`, in addition to the dependencies of its descriptor.
+// The environment URL needs to get replaced before the tenant ID, because it contains the tenant ID
+var promotionDependencies = []Dependency{
+	&promotiondep{},
+	Dependencies.Tenant,
+	Dependencies.Service,
+	Dependencies.ServiceMethod,
+	Dependencies.HostGroup,
+	Dependencies.Host,
+	Dependencies.Disk,
+	Dependencies.ProcessGroup,
+	Dependencies.ProcessGroupInstance,
+	Dependencies.ApplicationMethod,
+	Dependencies.DeviceApplicationMethod,
+	Dependencies.K8sCluster,
+	Dependencies.CloudApplication,
+	Dependencies.CloudApplicationNamespace,
+	Dependencies.EnvironmentActiveGate,
+}
+
+// withPromotionDependencies appends the dependencies required for `-promote`, unless they're already contained
+func withPromotionDependencies(dependencies []Dependency) []Dependency {
+	result := append([]Dependency{}, dependencies...)
+	for _, promotionDependency := range promotionDependencies {
+		contained := false
+		for _, dependency := range dependencies {
+			if dependency == promotionDependency {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			result = append(result, promotionDependency)
+		}
+	}
+	return result
+}
+
+var credentialsVaultRegex = regexp.MustCompile(`CREDENTIALS_VAULT-[A-Z0-9]{16}`)
+
+// promotionAttributes are the attributes and JSON properties containing environment specific names, mapped to the prefix of the variable replacing their value
+var promotionAttributes = []struct {
+	regex       *regexp.Regexp
+	prefix      string
+	description string
+}{
+	{regexp.MustCompile(`(?m)^(\s*active_gate_group\s*=\s*)"([^"$]+)"`), "activegate_group", "ActiveGate group"},
+	{regexp.MustCompile(`("activeGateGroup"\s*:\s*)"([^"$]+)"`), "activegate_group", "ActiveGate group"},
+	{regexp.MustCompile(`(?m)^(\s*network_zone\s*=\s*)"([^"$]+)"`), "network_zone", "Network zone"},
+	{regexp.MustCompile(`("networkZone"\s*:\s*)"([^"$]+)"`), "network_zone", "Network zone"},
+}
+
+// promotiondep replaces environment specific values with variables in case of `-promote`:
+// the URL of the environment, the IDs of credentials not being part of the export, ActiveGate groups and network zones
+type promotiondep struct {
+}
+
+func (me *promotiondep) IsParent() bool {
+	return false
+}
+
+func (me *promotiondep) ResourceType() ResourceType {
+	return ""
+}
+
+func (me *promotiondep) DataSourceType() DataSourceType {
+	return ""
+}
+
+func (me *promotiondep) Replace(environment *Environment, s string, replacingIn ResourceType, resourceId string, nonPostProcessedResources []*Resource) (string, []any) {
+	if !environment.Flags.IsPromotion() {
+		return s, []any{}
+	}
+	variables := environment.GetVariables()
+	moduleType := environment.moduleTypeOf(replacingIn)
+	original := s
+
+	urls := []struct{ name, description, url string }{
+		{"environment_url", "The URL of the Dynatrace environment", environment.Credentials.URL},
+		{"platform_url", "The URL of the Dynatrace platform environment", environment.Credentials.Automation.EnvironmentURL},
+	}
+	for _, url := range urls {
+		value := strings.TrimSuffix(url.url, "/")
+		if len(value) == 0 || (url.name != "environment_url" && value == strings.TrimSuffix(environment.Credentials.URL, "/")) {
+			continue
+		}
+		if strings.Contains(s, value) {
+			s = strings.ReplaceAll(s, value, variables.promote(moduleType, url.name, url.description, value, ""))
+		}
+	}
+
+	s = credentialsVaultRegex.ReplaceAllStringFunc(s, func(id string) string {
+		name := strings.ToLower(id)
+		if credentials, found := environment.Module(ResourceTypes.Credentials).Resources[id]; found && len(credentials.Name) > 0 {
+			name = "credentials_" + toTerraformName(credentials.Name)
+		}
+		return variables.promote(moduleType, name, fmt.Sprintf("The ID of the credentials `%s`", id), id, strings.ToLower(strings.TrimPrefix(id, "CREDENTIALS_VAULT-")))
+	})
+
+	for _, attribute := range promotionAttributes {
+		s = attribute.regex.ReplaceAllStringFunc(s, func(match string) string {
+			groups := attribute.regex.FindStringSubmatch(match)
+			name := attribute.prefix + "_" + toTerraformName(groups[2])
+			return groups[1] + `"` + variables.promote(moduleType, name, fmt.Sprintf("%s `%s`", attribute.description, groups[2]), groups[2], valueSuffix(groups[2])) + `"`
+		})
+	}
+
+	if s == original {
+		return s, []any{}
+	}
+	return s, []any{true}
+}
+
+// promotionSelector produces an entity selector for looking up the entity via the tag with the key configured via `-promote-tag`.
+// The result is empty if the entity doesn't carry such a tag
+func (me *Environment) promotionSelector(entity *entitysettings.Entity) string {
+	if !me.Flags.IsPromotion() || len(me.Flags.PromoteTag) == 0 || entity.Type == nil {
+		return ""
+	}
+	for _, tag := range entity.Tags {
+		if tag == nil || tag.Key != me.Flags.PromoteTag {
+			continue
+		}
+		representation := tag.Key
+		if tag.Value != nil {
+			representation = representation + ":" + *tag.Value
+		}
+		if tag.Context != "" && tag.Context != "CONTEXTLESS" {
+			representation = "[" + string(tag.Context) + "]" + representation
+		}
+		representation = strings.ReplaceAll(strings.ReplaceAll(representation, "~", "~~"), `"`, `~"`)
+		return fmt.Sprintf(`type(%s),tag("%s")`, *entity.Type, representation)
+	}
+	return ""
+}
+
+// writePromotionVarFiles writes the values of the exported environment into `exported.tfvars` and a skeleton `<environment>.tfvars`
+// per environment specified in case of `-promote`. Sensitive variables are not part of these files
+func (me *Environment) writePromotionVarFiles(all []*Variable) error {
+	if !me.Flags.IsPromotion() {
+		return nil
+	}
+	variables := []*Variable{}
+	for _, variable := range all {
+		if !variable.Sensitive {
+			variables = append(variables, variable)
+		}
+	}
+
+	tfvars := func(environment string) []byte {
+		buf := new(bytes.Buffer)
+		w := bufio.NewWriter(buf)
+		if len(environment) == 0 {
+			fmt.Fprintf(w, "# Values of the exported environment %s\n\n", me.Credentials.URL)
+		} else {
+			fmt.Fprintf(w, "# Values of the environment `%s`\n\n", environment)
+		}
+		for _, variable := range variables {
+			value, _ := json.Marshal(variable.Value)
+			if len(environment) == 0 {
+				fmt.Fprintf(w, "%s = %s\n", variable.Name, string(value))
+			} else {
+				fmt.Fprintf(w, "# %s, exported: %s\n%s = \"\"\n\n", variable.Description, string(value), variable.Name)
+			}
+		}
+		w.Flush()
+		return buf.Bytes()
+	}
+	fmt.Println("Writing " + PromotionExportedVarsFileName)
+	if err := os.WriteFile(path.Join(me.OutputFolder, PromotionExportedVarsFileName), tfvars(""), 0664); err != nil {
+		return err
+	}
+	for _, environment := range me.Flags.Promote {
+		fmt.Println("Writing " + environment + ".tfvars")
+		if err := os.WriteFile(path.Join(me.OutputFolder, environment+".tfvars"), tfvars(environment), 0664); err != nil {
+			return err
+		}
+	}
+	return nil
+}
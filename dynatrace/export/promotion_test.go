@@ -0,0 +1,184 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+)
+
+const promotionSchema = `{
+	"schemaId": "app:my.app:connection",
+	"version": "1.0.0",
+	"properties": {
+		"endpoint": {"type": "text", "nullable": false},
+		"activeGateGroup": {"type": "text", "nullable": false},
+		"networkZone": {"type": "text", "nullable": false},
+		"credentials": {"type": "text", "nullable": false}
+	}
+}`
+
+func TestExportPromotion(t *testing.T) {
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+	if err := server.AddSchema([]byte(promotionSchema)); err != nil {
+		t.Fatal(err)
+	}
+	createSettingsObject(t, server, "app:my.app:connection", `{"endpoint":"`+server.URL+`/api/v2/metrics","activeGateGroup":"ag-prod","networkZone":"zone-a","credentials":"CREDENTIALS_VAULT-ABCDEF0000000000"}`)
+
+	folder := t.TempDir()
+	env := &export.Environment{
+		OutputFolder: folder,
+		Credentials:  server.Credentials(),
+		Modules:      map[export.ResourceType]*export.Module{},
+		Flags:        export.Flags{FollowReferences: true, SkipTerraformInit: true, Promote: []string{"dev", "prod"}},
+		ResArgs: map[string][]string{
+			string(export.ResourceTypes.GenericSetting): nil,
+		},
+	}
+	if err := env.Export(); err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(elem ...string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(append([]string{folder}, elem...)...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	config := readSettingOfSchema(t, folder, "app:my.app:connection")
+	for _, expected := range []string{
+		`"${var.environment_url}/api/v2/metrics"`,
+		`"${var.activegate_group_ag-prod}"`,
+		`"${var.network_zone_zone-a}"`,
+		`"${var.credentials_vault-abcdef0000000000}"`,
+	} {
+		if !strings.Contains(config, expected) {
+			t.Errorf("expected `%s` within:\n%s", expected, config)
+		}
+	}
+	if strings.Contains(config, server.URL) || strings.Contains(config, "ag-prod\"") {
+		t.Errorf("environment specific values remain within:\n%s", config)
+	}
+
+	moduleVariables := read("modules", "generic_setting", "___inputs___.tf")
+	main := read("main.tf")
+	variables := read(export.VariablesFileName)
+	for _, name := range []string{"environment_url", "activegate_group_ag-prod", "network_zone_zone-a", "credentials_vault-abcdef0000000000"} {
+		if !strings.Contains(moduleVariables, `variable "`+name+`"`) {
+			t.Errorf("expected variable `%s` to be declared within the module:\n%s", name, moduleVariables)
+		}
+		if !strings.Contains(variables, `variable "`+name+`"`) {
+			t.Errorf("expected variable `%s` to be declared:\n%s", name, variables)
+		}
+		if !strings.Contains(main, name+" = var."+name) {
+			t.Errorf("expected variable `%s` to be passed to the module:\n%s", name, main)
+		}
+	}
+
+	exported := read(export.PromotionExportedVarsFileName)
+	if !strings.Contains(exported, `environment_url = "`+server.URL+`"`) || !strings.Contains(exported, `activegate_group_ag-prod = "ag-prod"`) {
+		t.Errorf("unexpected %s:\n%s", export.PromotionExportedVarsFileName, exported)
+	}
+	for _, environment := range []string{"dev", "prod"} {
+		skeleton := read(environment + ".tfvars")
+		if !strings.Contains(skeleton, `network_zone_zone-a = ""`) || !strings.Contains(skeleton, "Network zone `zone-a`") {
+			t.Errorf("unexpected %s.tfvars:\n%s", environment, skeleton)
+		}
+	}
+}
+
+func TestExportPromotionClashingNames(t *testing.T) {
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+	if err := server.AddSchema([]byte(promotionSchema)); err != nil {
+		t.Fatal(err)
+	}
+	// both network zones result in the variable name `network_zone_zone_b`
+	for _, networkZone := range []string{"zone.b", "zone_b"} {
+		createSettingsObject(t, server, "app:my.app:connection", `{"endpoint":"https://example.com","activeGateGroup":"ag-prod","networkZone":"`+networkZone+`","credentials":"CREDENTIALS_VAULT-ABCDEF0000000000"}`)
+	}
+
+	folder := t.TempDir()
+	env := &export.Environment{
+		OutputFolder: folder,
+		Credentials:  server.Credentials(),
+		Modules:      map[export.ResourceType]*export.Module{},
+		Flags:        export.Flags{FollowReferences: true, SkipTerraformInit: true, Promote: []string{"prod"}},
+		ResArgs: map[string][]string{
+			string(export.ResourceTypes.GenericSetting): nil,
+		},
+	}
+	if err := env.Export(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(folder, export.PromotionExportedVarsFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := map[string]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if name, value, found := strings.Cut(line, " = "); found && strings.HasPrefix(name, "network_zone_zone_b") {
+			values[value] = name
+		}
+	}
+	if len(values) != 2 || len(values[`"zone.b"`]) == 0 || len(values[`"zone_b"`]) == 0 || values[`"zone.b"`] == values[`"zone_b"`] {
+		t.Errorf("expected a variable of its own per network zone, got %s:\n%s", export.PromotionExportedVarsFileName, string(data))
+	}
+	// values being equal share the variable
+	if count := strings.Count(string(data), "activegate_group_ag-prod = "); count != 1 {
+		t.Errorf("expected the ActiveGate group to be promoted once, got %d times:\n%s", count, string(data))
+	}
+}
+
+// readSettingOfSchema returns the exported configuration of the (only) setting of the given schema
+func readSettingOfSchema(t *testing.T, folder string, schemaID string) string {
+	t.Helper()
+	var result string
+	filepath.Walk(folder, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(path, ".tf") {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if strings.Contains(string(data), schemaID) && strings.Contains(string(data), "dynatrace_generic_setting") {
+			result = string(data)
+		}
+		return nil
+	})
+	if len(result) == 0 {
+		t.Fatalf("no configuration found for a setting of schema `%s`", schemaID)
+	}
+	return result
+}
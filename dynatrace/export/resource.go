@@ -33,6 +33,7 @@ import (
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/shutdown"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
 
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hclgen"
 )
 
@@ -51,12 +52,16 @@ type Resource struct {
 	DataSourceReferences            []*DataSource
 	OutputFileAbs                   string
 	Flawed                          bool
+	RequiresAttention               bool
 	XParent                         *Resource
 	ParentID                        *string
 	SplitId                         int
 	BundleFilePath                  string
 	ExtractedIdsPerDependencyModule map[string]map[string]bool
 	ResourceMutex                   *sync.Mutex
+	Scope                           string
+	SchemaID                        string // only set if the schema differs from resource to resource, like for generic settings
+	PayloadReferences               []*PayloadReference
 }
 
 func (me *Resource) GetReferringResources() []*Resource {
@@ -103,6 +108,9 @@ func (me *Resource) SetName(name string) *Resource {
 		me.UniqueName = parentUniqueName
 	} else {
 		prevUniqueName := me.Module.Environment.PrevStateMapCommon.GetPrevUniqueName(me)
+		if prevUniqueName == "" {
+			prevUniqueName = me.Module.Environment.Manifest.UniqueName(me.Type, me.ID)
+		}
 		if prevUniqueName == "" {
 			terraformName := toTerraformName(name)
 			me.UniqueName = nameModule.namer.Name(terraformName)
@@ -191,7 +199,7 @@ func (me *Resource) ReadFile() ([]byte, error) {
 const MAX_PATH_LENGTH_FILENAME_SHORTER = 240
 
 func (me *Resource) GetFileName() string {
-	filename := fileSystemName(fmt.Sprintf("%s.%s.tf", strings.TrimSpace(me.UniqueName), me.Type.Trim()))
+	filename := fileSystemName(fmt.Sprintf("%s.%s.%s", strings.TrimSpace(me.UniqueName), me.Type.Trim(), me.Module.Environment.Flags.Format.Extension()))
 
 	if SHORTER_NAMES {
 		filename = me.getShorterFileName(filename)
@@ -207,7 +215,7 @@ func (me *Resource) getShorterFileName(filename string) string {
 	}
 
 	if (len(folderPath) + len(filename)) > MAX_PATH_LENGTH_FILENAME_SHORTER {
-		filename = fileSystemName(fmt.Sprintf("%s.%s.tf", GetHashName(strings.TrimSpace(me.UniqueName)), me.Type.Trim()))
+		filename = fileSystemName(fmt.Sprintf("%s.%s.%s", GetHashName(strings.TrimSpace(me.UniqueName)), me.Type.Trim(), me.Module.Environment.Flags.Format.Extension()))
 	}
 	return filename
 }
@@ -255,6 +263,10 @@ func (me *Resource) Download() error {
 		}
 	}
 
+	if me.Module.Status != ModuleStati.Erronous && me.Module.Environment.Manifest.Restore(me) {
+		return nil
+	}
+
 	var service = me.Module.Service
 
 	settngs := me.Module.GetDescriptor().NewSettings()
@@ -321,7 +333,19 @@ func (me *Resource) Download() error {
 		Type:        string(me.Type),
 		TrimmedType: me.Type.Trim(),
 	})
+	var marshaler hcl.Marshaler = settngs
+	var original hcl.Properties
+	if me.Module.Environment.Flags.SecretVars {
+		if original, err = marshalSecrets(settngs); err != nil {
+			return err
+		}
+	}
 	comments := settings.FillDemoValues(settngs)
+	if me.Module.Environment.Flags.SecretVars {
+		if marshaler, comments, err = me.replaceSecrets(settngs, original, comments); err != nil {
+			return err
+		}
+	}
 	comments = append(comments, settings.Validate(settngs)...)
 
 	if len(comments) > 0 {
@@ -379,11 +403,16 @@ func (me *Resource) Download() error {
 		defer outputFile.Close()
 	}
 
-	if err = hclgen.ExportResource(settngs, outputFile, string(me.Type), me.UniqueName, finalComments...); err != nil {
+	if me.Module.Environment.Flags.Format.IsHCL() {
+		if err = hclgen.ExportResource(marshaler, outputFile, string(me.Type), me.UniqueName, finalComments...); err != nil {
+			return err
+		}
+	} else if err = me.writePayload(settngs, outputFile); err != nil {
 		return err
 	}
 
 	if !me.Flawed && me.Status != ResourceStati.Erronous && len(comments) > 0 {
+		me.RequiresAttention = true
 		orig, _ := filepath.Abs(me.GetFile())
 		att, _ := filepath.Abs(me.GetAttentionFile())
 		absdir, _ := filepath.Abs(path.Dir(me.GetAttentionFile()))
@@ -435,10 +464,18 @@ func (me *Resource) PostProcess(nonPostProcessedResources []*Resource) error {
 		}
 	}
 
+	if me.Module.Environment.Flags.IsPromotion() && !me.IsReferencedAsDataSource() {
+		dependecyList = withPromotionDependencies(dependecyList)
+	}
+
 	if len(dependecyList) == 0 {
 		return nil
 	}
 
+	if !me.Module.Environment.Flags.Format.IsHCL() {
+		return me.postProcessPayload(dependecyList, nonPostProcessedResources)
+	}
+
 	var data []byte
 	var foundItemsInFileContents []any
 	if data, err = me.ReadFile(); err != nil {
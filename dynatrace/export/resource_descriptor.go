@@ -23,6 +23,9 @@ import (
 	"strings"
 
 	openpipeline "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline"
+	openpipelineendpoints "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/endpoints"
+	openpipelinepipelines "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/pipelines"
+	openpipelinerouting "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/openpipeline/routing"
 
 	dbfeatureflags "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/app/dynatrace/database/featureflags"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/app/dynatrace/devobs/debugger/gitonprem"
@@ -275,6 +278,8 @@ import (
 	directshares "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/directshares"
 	documents "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/bindings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/groupmember"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/groupmembers"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/groups"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/permissions"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam/policies"
@@ -291,6 +296,7 @@ import (
 	locations "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/v1/config/synthetic/locations/private"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/v2/activegatetokens"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/v2/customdevice"
+	extensionpackages "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/v2/extensions/packages"
 	active_version "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/v2/hub/extension/active_version"
 	extension_config "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/v2/hub/extension/config"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/v2/slo"
@@ -352,7 +358,7 @@ import (
 func NewResourceDescriptor[T settings.Settings](fn func(credentials *settings.Credentials) settings.CRUDService[T], dependencies ...Dependency) ResourceDescriptor {
 	return ResourceDescriptor{
 		Service: func(credentials *settings.Credentials) settings.CRUDService[settings.Settings] {
-			return &settings.GenericCRUDService[T]{Service: cache.CRUD(fn(credentials))}
+			return &settings.GenericCRUDService[T]{Service: cache.CRUD(cache.Persistent(fn(credentials), credentials.URL))}
 		},
 		protoType:    newSettings(fn),
 		Dependencies: dependencies,
@@ -362,7 +368,7 @@ func NewResourceDescriptor[T settings.Settings](fn func(credentials *settings.Cr
 func NewResourceDescriptorWithFolderOverride[T settings.Settings](fn func(credentials *settings.Credentials) settings.CRUDService[T], folderName string, dependencies ...Dependency) ResourceDescriptor {
 	return ResourceDescriptor{
 		Service: func(credentials *settings.Credentials) settings.CRUDService[settings.Settings] {
-			return &settings.GenericCRUDService[T]{Service: cache.CRUD(fn(credentials))}
+			return &settings.GenericCRUDService[T]{Service: cache.CRUD(cache.Persistent(fn(credentials), credentials.URL))}
 		},
 		protoType:    newSettings(fn),
 		Dependencies: dependencies,
@@ -373,7 +379,7 @@ func NewResourceDescriptorWithFolderOverride[T settings.Settings](fn func(creden
 func NewChildResourceDescriptor[T settings.Settings](fn func(credentials *settings.Credentials) settings.CRUDService[T], parent ResourceType, dependencies ...Dependency) ResourceDescriptor {
 	return ResourceDescriptor{
 		Service: func(credentials *settings.Credentials) settings.CRUDService[settings.Settings] {
-			return &settings.GenericCRUDService[T]{Service: cache.CRUD(fn(credentials))}
+			return &settings.GenericCRUDService[T]{Service: cache.CRUD(cache.Persistent(fn(credentials), credentials.URL))}
 		},
 		protoType:    newSettings(fn),
 		Dependencies: dependencies,
@@ -546,6 +552,36 @@ var AllResources = map[ResourceType]ResourceDescriptor{
 		openpipeline.BusinessEventsService, Dependencies.ID(ResourceTypes.PlatformBucket)),
 	ResourceTypes.OpenPipelineSDLCEvents: NewResourceDescriptor(
 		openpipeline.SDLCEventsService, Dependencies.ID(ResourceTypes.PlatformBucket)),
+	ResourceTypes.OpenPipelineLogsPipeline: NewResourceDescriptor(
+		openpipelinepipelines.LogsService, Dependencies.ID(ResourceTypes.PlatformBucket)),
+	ResourceTypes.OpenPipelineLogsRouting: NewResourceDescriptor(
+		openpipelinerouting.LogsService, Dependencies.QuotedID(ResourceTypes.OpenPipelineLogsPipeline)),
+	ResourceTypes.OpenPipelineLogsEndpoint: NewResourceDescriptor(
+		openpipelineendpoints.LogsService, Dependencies.QuotedID(ResourceTypes.OpenPipelineLogsPipeline)),
+	ResourceTypes.OpenPipelineEventsPipeline: NewResourceDescriptor(
+		openpipelinepipelines.EventsService, Dependencies.ID(ResourceTypes.PlatformBucket)),
+	ResourceTypes.OpenPipelineEventsRouting: NewResourceDescriptor(
+		openpipelinerouting.EventsService, Dependencies.QuotedID(ResourceTypes.OpenPipelineEventsPipeline)),
+	ResourceTypes.OpenPipelineEventsEndpoint: NewResourceDescriptor(
+		openpipelineendpoints.EventsService, Dependencies.QuotedID(ResourceTypes.OpenPipelineEventsPipeline), Dependencies.ID(ResourceTypes.PlatformBucket)),
+	ResourceTypes.OpenPipelineSecurityEventsPipeline: NewResourceDescriptor(
+		openpipelinepipelines.SecurityEventsService, Dependencies.ID(ResourceTypes.PlatformBucket)),
+	ResourceTypes.OpenPipelineSecurityEventsRouting: NewResourceDescriptor(
+		openpipelinerouting.SecurityEventsService, Dependencies.QuotedID(ResourceTypes.OpenPipelineSecurityEventsPipeline)),
+	ResourceTypes.OpenPipelineSecurityEventsEndpoint: NewResourceDescriptor(
+		openpipelineendpoints.SecurityEventsService, Dependencies.QuotedID(ResourceTypes.OpenPipelineSecurityEventsPipeline), Dependencies.ID(ResourceTypes.PlatformBucket)),
+	ResourceTypes.OpenPipelineBusinessEventsPipeline: NewResourceDescriptor(
+		openpipelinepipelines.BusinessEventsService, Dependencies.ID(ResourceTypes.PlatformBucket)),
+	ResourceTypes.OpenPipelineBusinessEventsRouting: NewResourceDescriptor(
+		openpipelinerouting.BusinessEventsService, Dependencies.QuotedID(ResourceTypes.OpenPipelineBusinessEventsPipeline)),
+	ResourceTypes.OpenPipelineBusinessEventsEndpoint: NewResourceDescriptor(
+		openpipelineendpoints.BusinessEventsService, Dependencies.QuotedID(ResourceTypes.OpenPipelineBusinessEventsPipeline), Dependencies.ID(ResourceTypes.PlatformBucket)),
+	ResourceTypes.OpenPipelineSDLCEventsPipeline: NewResourceDescriptor(
+		openpipelinepipelines.SDLCEventsService, Dependencies.ID(ResourceTypes.PlatformBucket)),
+	ResourceTypes.OpenPipelineSDLCEventsRouting: NewResourceDescriptor(
+		openpipelinerouting.SDLCEventsService, Dependencies.QuotedID(ResourceTypes.OpenPipelineSDLCEventsPipeline)),
+	ResourceTypes.OpenPipelineSDLCEventsEndpoint: NewResourceDescriptor(
+		openpipelineendpoints.SDLCEventsService, Dependencies.QuotedID(ResourceTypes.OpenPipelineSDLCEventsPipeline), Dependencies.ID(ResourceTypes.PlatformBucket)),
 	ResourceTypes.JSONDashboard: NewChildResourceDescriptor(
 		jsondashboards.Service,
 		ResourceTypes.JSONDashboardBase,
@@ -753,6 +789,16 @@ var AllResources = map[ResourceType]ResourceDescriptor{
 		Dependencies.ID(ResourceTypes.IAMPermission),
 		Dependencies.Tenant,
 	),
+	ResourceTypes.IAMGroupMembers: NewResourceDescriptor(
+		groupmembers.Service,
+		Dependencies.ID(ResourceTypes.IAMGroup),
+		Dependencies.ID(ResourceTypes.IAMUser),
+	),
+	ResourceTypes.IAMGroupMember: NewResourceDescriptor(
+		groupmember.Service,
+		Dependencies.ID(ResourceTypes.IAMGroup),
+		Dependencies.ID(ResourceTypes.IAMUser),
+	),
 	ResourceTypes.IAMPermission:     NewResourceDescriptor(permissions.Service),
 	ResourceTypes.IAMPolicy:         NewResourceDescriptor(policies.Service),
 	ResourceTypes.IAMPolicyBindings: NewResourceDescriptor(bindings.Service),
@@ -1331,6 +1377,7 @@ var AllResources = map[ResourceType]ResourceDescriptor{
 	ResourceTypes.ManagedNetworkZones:       NewResourceDescriptor(managednetworkzones.Service),
 	ResourceTypes.HubExtensionConfig:        NewResourceDescriptor(extension_config.Service),
 	ResourceTypes.HubActiveExtensionVersion: NewResourceDescriptor(active_version.Service),
+	ResourceTypes.ExtensionPackage:          NewResourceDescriptor(extensionpackages.Service),
 	ResourceTypes.DatabaseAppFeatureFlags:   NewResourceDescriptor(dbfeatureflags.Service),
 	ResourceTypes.InfraOpsAppFeatureFlags:   NewResourceDescriptor(infraopsfeatureflags.Service),
 	ResourceTypes.EBPFServiceDiscovery:      NewResourceDescriptor(ebpf.Service),
@@ -1441,6 +1488,8 @@ var excludeListedResourceGroups = []ResourceExclusionGroup{
 		Exclusions: []ResourceExclusion{
 			{ResourceTypes.IAMUser, ""},
 			{ResourceTypes.IAMGroup, ""},
+			{ResourceTypes.IAMGroupMembers, ""},
+			{ResourceTypes.IAMGroupMember, ""},
 			{ResourceTypes.IAMPermission, ""},
 			{ResourceTypes.IAMPolicy, ""},
 			{ResourceTypes.IAMPolicyBindings, ""},
@@ -1506,6 +1555,12 @@ var excludeListedResourceGroups = []ResourceExclusionGroup{
 			{ResourceTypes.AppSecAttackAllowlist, ""},
 		},
 	},
+	{
+		Reason: "Requires local extension archives",
+		Exclusions: []ResourceExclusion{
+			{ResourceTypes.ExtensionPackage, "The uploaded archives can't be downloaded"},
+		},
+	},
 	{
 		Reason: "Requires the app from Dynatrace Hub",
 		Exclusions: []ResourceExclusion{
@@ -1514,6 +1569,26 @@ var excludeListedResourceGroups = []ResourceExclusionGroup{
 			{ResourceTypes.SlackForWorkflows, ""},
 		},
 	},
+	{
+		Reason: "Fine-grained alternatives to the OpenPipeline configuration resources",
+		Exclusions: []ResourceExclusion{
+			{ResourceTypes.OpenPipelineLogsPipeline, "Manages parts of dynatrace_openpipeline_logs, which is exported instead"},
+			{ResourceTypes.OpenPipelineLogsRouting, "Manages parts of dynatrace_openpipeline_logs, which is exported instead"},
+			{ResourceTypes.OpenPipelineLogsEndpoint, "Manages parts of dynatrace_openpipeline_logs, which is exported instead"},
+			{ResourceTypes.OpenPipelineEventsPipeline, "Manages parts of dynatrace_openpipeline_events, which is exported instead"},
+			{ResourceTypes.OpenPipelineEventsRouting, "Manages parts of dynatrace_openpipeline_events, which is exported instead"},
+			{ResourceTypes.OpenPipelineEventsEndpoint, "Manages parts of dynatrace_openpipeline_events, which is exported instead"},
+			{ResourceTypes.OpenPipelineSecurityEventsPipeline, "Manages parts of dynatrace_openpipeline_security_events, which is exported instead"},
+			{ResourceTypes.OpenPipelineSecurityEventsRouting, "Manages parts of dynatrace_openpipeline_security_events, which is exported instead"},
+			{ResourceTypes.OpenPipelineSecurityEventsEndpoint, "Manages parts of dynatrace_openpipeline_security_events, which is exported instead"},
+			{ResourceTypes.OpenPipelineBusinessEventsPipeline, "Manages parts of dynatrace_openpipeline_business_events, which is exported instead"},
+			{ResourceTypes.OpenPipelineBusinessEventsRouting, "Manages parts of dynatrace_openpipeline_business_events, which is exported instead"},
+			{ResourceTypes.OpenPipelineBusinessEventsEndpoint, "Manages parts of dynatrace_openpipeline_business_events, which is exported instead"},
+			{ResourceTypes.OpenPipelineSDLCEventsPipeline, "Manages parts of dynatrace_openpipeline_sdlc_events, which is exported instead"},
+			{ResourceTypes.OpenPipelineSDLCEventsRouting, "Manages parts of dynatrace_openpipeline_sdlc_events, which is exported instead"},
+			{ResourceTypes.OpenPipelineSDLCEventsEndpoint, "Manages parts of dynatrace_openpipeline_sdlc_events, which is exported instead"},
+		},
+	},
 	{
 		Reason: "Generic resource against any Setting 2.0 schema",
 		Exclusions: []ResourceExclusion{
@@ -1522,6 +1597,16 @@ var excludeListedResourceGroups = []ResourceExclusionGroup{
 	},
 }
 
+// openPipelineParts lists the fine-grained resources managing parts of an OpenPipeline configuration.
+// They are never getting exported together with the configuration resource, which overwrites the whole configuration
+var openPipelineParts = map[ResourceType][]ResourceType{
+	ResourceTypes.OpenPipelineLogs:           {ResourceTypes.OpenPipelineLogsPipeline, ResourceTypes.OpenPipelineLogsRouting, ResourceTypes.OpenPipelineLogsEndpoint},
+	ResourceTypes.OpenPipelineEvents:         {ResourceTypes.OpenPipelineEventsPipeline, ResourceTypes.OpenPipelineEventsRouting, ResourceTypes.OpenPipelineEventsEndpoint},
+	ResourceTypes.OpenPipelineSecurityEvents: {ResourceTypes.OpenPipelineSecurityEventsPipeline, ResourceTypes.OpenPipelineSecurityEventsRouting, ResourceTypes.OpenPipelineSecurityEventsEndpoint},
+	ResourceTypes.OpenPipelineBusinessEvents: {ResourceTypes.OpenPipelineBusinessEventsPipeline, ResourceTypes.OpenPipelineBusinessEventsRouting, ResourceTypes.OpenPipelineBusinessEventsEndpoint},
+	ResourceTypes.OpenPipelineSDLCEvents:     {ResourceTypes.OpenPipelineSDLCEventsPipeline, ResourceTypes.OpenPipelineSDLCEventsRouting, ResourceTypes.OpenPipelineSDLCEventsEndpoint},
+}
+
 var excludeListedResources = genExcludeListedResourceGroups()
 
 func genExcludeListedResourceGroups() []ResourceType {
@@ -0,0 +1,390 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/crypto/scrypt"
+)
+
+// SecretsVarsFileName is the name of the file containing the values of the sensitive variables in case of `-secret-vars`.
+// Terraform loads it automatically
+const SecretsVarsFileName = "secrets.auto.tfvars.json"
+
+// SecretsEncryptedFileName is the name of the file written instead of `secrets.auto.tfvars.json` if `DYNATRACE_SECRETS_KEY` is set
+const SecretsEncryptedFileName = SecretsVarsFileName + ".enc"
+
+// SecretsKeyEnvVar is the environment variable containing the passphrase to encrypt `secrets.auto.tfvars.json` with.
+// The key used for AES-256-GCM is derived from it via scrypt, using a random salt stored along with the encrypted secrets
+const SecretsKeyEnvVar = "DYNATRACE_SECRETS_KEY"
+
+// secretsMarshaler hands over the properties of settings whose sensitive attributes have been replaced with variables
+type secretsMarshaler struct {
+	properties hcl.Properties
+	schema     map[string]*schema.Schema
+}
+
+func (me *secretsMarshaler) MarshalHCL(properties hcl.Properties) error {
+	for key, value := range me.properties {
+		properties[key] = value
+	}
+	return nil
+}
+
+func (me *secretsMarshaler) Schema() map[string]*schema.Schema {
+	return me.schema
+}
+
+// marshalSecrets produces the properties of the given settings in case of `-secret-vars`, before `FillDemoValues` is getting invoked
+func marshalSecrets(settngs settings.Settings) (hcl.Properties, error) {
+	properties := hcl.Properties{}
+	if err := settngs.MarshalHCL(properties); err != nil {
+		return nil, err
+	}
+	return detach(properties).(hcl.Properties), nil
+}
+
+// replaceSecrets replaces the sensitive attributes of the given settings and any values marked as `${state.secret_value}` with
+// variables named after the address of the resource in case of `-secret-vars`.
+// `original` contains the properties before `FillDemoValues` has been invoked. In case the demo values filled in are all
+// getting replaced with variables, the comments produced by `FillDemoValues` are not relevant anymore and are getting dropped
+func (me *Resource) replaceSecrets(settngs settings.Settings, original hcl.Properties, comments []string) (hcl.Marshaler, []string, error) {
+	properties, err := marshalSecrets(settngs)
+	if err != nil {
+		return nil, nil, err
+	}
+	replacer := &secretsReplacer{
+		variables:  me.Module.Environment.GetVariables(),
+		moduleType: me.Module.Environment.moduleTypeOf(me.Type),
+		address:    fmt.Sprintf("dynatrace_%s.%s", me.Type.Trim(), me.UniqueName),
+	}
+	replacer.replace(properties, original, settngs.Schema(), me.Type.Trim()+"_"+me.UniqueName, "")
+	if reflect.DeepEqual(properties, original) {
+		comments = []string{}
+	}
+	return &secretsMarshaler{properties: properties, schema: settngs.Schema()}, comments, nil
+}
+
+type secretsReplacer struct {
+	variables  *Variables
+	moduleType ResourceType
+	address    string
+}
+
+// replace walks the properties along the schema. Replaced values are getting written into both, `properties` and `original`
+func (me *secretsReplacer) replace(properties map[string]any, original map[string]any, sch map[string]*schema.Schema, name string, breadCrumbs string) {
+	keys := []string{}
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	for key, attribute := range sch {
+		if _, found := properties[key]; !found && attribute.Sensitive && attribute.Required {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := properties[key]
+		var originalValue any
+		if original != nil {
+			originalValue = original[key]
+		}
+		attribute := sch[key]
+		if attribute != nil && attribute.Sensitive {
+			if value == nil && !attribute.Required {
+				continue
+			}
+			if value == nil {
+				value = originalValue
+			}
+			reference := me.use(name+"_"+key, breadCrumbs+key, value, originalValue)
+			properties[key] = reference
+			if original != nil {
+				original[key] = reference
+			}
+			continue
+		}
+		switch typedValue := value.(type) {
+		case string:
+			if isSecretMarker(typedValue) {
+				reference := me.use(name+"_"+key, breadCrumbs+key, typedValue, originalValue)
+				properties[key] = reference
+				if original != nil {
+					original[key] = reference
+				}
+			}
+		case []string:
+			originalValues, _ := originalValue.([]string)
+			for idx, elem := range typedValue {
+				if !isSecretMarker(elem) {
+					continue
+				}
+				var originalElem any
+				if idx < len(originalValues) {
+					originalElem = originalValues[idx]
+				}
+				reference := me.use(fmt.Sprintf("%s_%s_%d", name, key, idx+1), fmt.Sprintf("%s%s.%d", breadCrumbs, key, idx), elem, originalElem)
+				typedValue[idx] = reference
+				if idx < len(originalValues) {
+					originalValues[idx] = reference
+				}
+			}
+		case []any:
+			var elemSchema map[string]*schema.Schema
+			if attribute != nil {
+				if resource, ok := attribute.Elem.(*schema.Resource); ok {
+					elemSchema = resource.Schema
+				}
+			}
+			originalElems, _ := originalValue.([]any)
+			for idx, elem := range typedValue {
+				elemProperties, ok := asMap(elem)
+				if !ok {
+					continue
+				}
+				var originalProperties map[string]any
+				if idx < len(originalElems) {
+					originalProperties, _ = asMap(originalElems[idx])
+				}
+				elemName := name + "_" + key
+				if len(typedValue) > 1 {
+					elemName = fmt.Sprintf("%s_%d", elemName, idx+1)
+				}
+				me.replace(elemProperties, originalProperties, elemSchema, elemName, fmt.Sprintf("%s%s.%d.", breadCrumbs, key, idx))
+			}
+		}
+	}
+}
+
+// use registers a sensitive variable for the given value and returns the reference to it.
+// The value within `secrets.auto.tfvars.json` is the value received from the environment, unless it has been masked
+func (me *secretsReplacer) use(name string, attribute string, value any, originalValue any) string {
+	variable := &Variable{
+		Name:        name,
+		Description: fmt.Sprintf("The value of `%s` of `%s`", attribute, me.address),
+		Type:        "string",
+		Value:       secretValue(originalValue),
+		Sensitive:   true,
+	}
+	switch value.(type) {
+	case []string, hcl.StringSet, []any:
+		variable.Type = "list(string)"
+		if variable.Value == "" {
+			variable.Value = []string{}
+		}
+	case bool:
+		variable.Type = "bool"
+	case int, int32, int64, float32, float64:
+		variable.Type = "number"
+	}
+	// the names are derived from the unique names of the resources, no suffix needed
+	me.variables.use(me.moduleType, variable, "")
+	return "HCL-UNQUOTE-var." + name
+}
+
+// secretValue returns the given value unless it is masked or a placeholder, in which case the value is empty
+func secretValue(value any) any {
+	switch typedValue := value.(type) {
+	case nil:
+		return ""
+	case string:
+		if len(strings.Trim(typedValue, "#*")) == 0 || isSecretMarker(typedValue) {
+			return ""
+		}
+	case []string:
+		values := []string{}
+		for _, elem := range typedValue {
+			if v, ok := secretValue(elem).(string); ok && len(v) > 0 {
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			return ""
+		}
+		return values
+	}
+	return value
+}
+
+func isSecretMarker(s string) bool {
+	return strings.Contains(s, "${state.secret_value")
+}
+
+func asMap(v any) (map[string]any, bool) {
+	switch typed := v.(type) {
+	case hcl.Properties:
+		return typed, true
+	case map[string]any:
+		return typed, true
+	}
+	return nil, false
+}
+
+// detach copies the given properties, in order for them not to share slices or maps with the settings they have been produced by
+func detach(v any) any {
+	switch typed := v.(type) {
+	case hcl.Properties:
+		result := hcl.Properties{}
+		for key, value := range typed {
+			result[key] = detach(value)
+		}
+		return result
+	case map[string]any:
+		result := map[string]any{}
+		for key, value := range typed {
+			result[key] = detach(value)
+		}
+		return result
+	case []any:
+		result := []any{}
+		for _, value := range typed {
+			result = append(result, detach(value))
+		}
+		return result
+	case []string:
+		return append([]string{}, typed...)
+	case hcl.StringSet:
+		return append(hcl.StringSet{}, typed...)
+	}
+	return v
+}
+
+// writeSecretsVarFile writes the values of the sensitive variables into `secrets.auto.tfvars.json` in case of `-secret-vars`.
+// If `DYNATRACE_SECRETS_KEY` is set, the file is getting encrypted and written as `secrets.auto.tfvars.json.enc` instead
+func (me *Environment) writeSecretsVarFile(variables []*Variable) error {
+	if !me.Flags.SecretVars {
+		return nil
+	}
+	values := map[string]any{}
+	for _, variable := range variables {
+		if variable.Sensitive {
+			values[variable.Name] = variable.Value
+		}
+	}
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return err
+	}
+	key := os.Getenv(SecretsKeyEnvVar)
+	if len(key) == 0 {
+		fmt.Println("Writing " + SecretsVarsFileName)
+		return os.WriteFile(path.Join(me.OutputFolder, SecretsVarsFileName), data, 0600)
+	}
+	fmt.Println("Writing " + SecretsEncryptedFileName)
+	if data, err = encryptSecrets(key, data); err != nil {
+		return err
+	}
+	return os.WriteFile(path.Join(me.OutputFolder, SecretsEncryptedFileName), data, 0600)
+}
+
+// DecryptSecrets decrypts `secrets.auto.tfvars.json.enc` within the given folder with the key contained in `DYNATRACE_SECRETS_KEY`
+// and writes the result as `secrets.auto.tfvars.json` into the same folder
+func DecryptSecrets(folder string) error {
+	key := os.Getenv(SecretsKeyEnvVar)
+	if len(key) == 0 {
+		return fmt.Errorf("the environment variable %s has not been set", SecretsKeyEnvVar)
+	}
+	data, err := os.ReadFile(path.Join(folder, SecretsEncryptedFileName))
+	if err != nil {
+		return err
+	}
+	if data, err = decryptSecrets(key, data); err != nil {
+		return err
+	}
+	fmt.Println("Writing " + path.Join(folder, SecretsVarsFileName))
+	return os.WriteFile(path.Join(folder, SecretsVarsFileName), data, 0600)
+}
+
+// scrypt parameters recommended for interactive logins as of 2017
+const (
+	secretsSaltSize = 16
+	secretsScryptN  = 32768
+	secretsScryptR  = 8
+	secretsScryptP  = 1
+)
+
+// secretsCipher derives the AES-256 key from the passphrase and the salt via scrypt
+func secretsCipher(key string, salt []byte) (cipher.AEAD, error) {
+	derived, err := scrypt.Key([]byte(key), salt, secretsScryptN, secretsScryptR, secretsScryptP, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(derived)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptSecrets produces the base64 encoded random salt, followed by the nonce and the ciphertext
+func encryptSecrets(key string, data []byte) ([]byte, error) {
+	salt := make([]byte, secretsSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	gcm, err := secretsCipher(key, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return []byte(base64.StdEncoding.EncodeToString(append(salt, gcm.Seal(nonce, nonce, data, nil)...))), nil
+}
+
+func decryptSecrets(key string, data []byte) ([]byte, error) {
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < secretsSaltSize {
+		return nil, errors.New("the encrypted secrets are corrupt")
+	}
+	gcm, err := secretsCipher(key, sealed[:secretsSaltSize])
+	if err != nil {
+		return nil, err
+	}
+	sealed = sealed[secretsSaltSize:]
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("the encrypted secrets are corrupt")
+	}
+	plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("the encrypted secrets could not get decrypted with the key contained in %s", SecretsKeyEnvVar)
+	}
+	return plain, nil
+}
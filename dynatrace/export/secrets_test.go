@@ -0,0 +1,141 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+)
+
+const notificationsSchema = `{
+	"schemaId": "builtin:problem.notifications",
+	"version": "1.0.0",
+	"properties": {
+		"enabled": {"type": "boolean", "nullable": false},
+		"displayName": {"type": "text", "nullable": false},
+		"type": {"type": "text", "nullable": false},
+		"alertingProfile": {"type": "text", "nullable": false},
+		"opsGenieNotification": {"type": "object", "nullable": true}
+	}
+}`
+
+func exportSecrets(t *testing.T, key string) string {
+	t.Helper()
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+	if err := server.AddSchema([]byte(notificationsSchema)); err != nil {
+		t.Fatal(err)
+	}
+	createSettingsObject(t, server, "builtin:problem.notifications", `{"enabled":true,"displayName":"On Call","type":"OPS_GENIE","alertingProfile":"b1f379d9-98b4-4efe-be38-0289609c9295","opsGenieNotification":{"apiKey":"********","domain":"api.opsgenie.com","message":"{ProblemTitle}"}}`)
+
+	t.Setenv(export.SecretsKeyEnvVar, key)
+	folder := t.TempDir()
+	env := &export.Environment{
+		OutputFolder: folder,
+		Credentials:  server.Credentials(),
+		Modules:      map[export.ResourceType]*export.Module{},
+		Flags:        export.Flags{SkipTerraformInit: true, SecretVars: true},
+		ResArgs: map[string][]string{
+			string(export.ResourceTypes.OpsGenieNotification): nil,
+		},
+	}
+	if err := env.Export(); err != nil {
+		t.Fatal(err)
+	}
+	return folder
+}
+
+func TestExportSecretVars(t *testing.T) {
+	folder := exportSecrets(t, "")
+	read := func(elem ...string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(append([]string{folder}, elem...)...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	name := "ops_genie_notification_On_Call_api_key"
+	config := read("modules", "ops_genie_notification", "On_Call.ops_genie_notification.tf")
+	if !strings.Contains(config, "api_key = var."+name) {
+		t.Errorf("expected `api_key` to refer to `var.%s`:\n%s", name, config)
+	}
+	if strings.Contains(config, "ATTENTION") || strings.Contains(config, "####") {
+		t.Errorf("expected no demo values and attention comments:\n%s", config)
+	}
+	if _, err := os.Stat(filepath.Join(folder, ".requires_attention")); err == nil {
+		t.Error("expected no resources requiring attention")
+	}
+
+	for _, file := range []string{export.VariablesFileName, filepath.Join("modules", "ops_genie_notification", "___inputs___.tf")} {
+		if declarations := read(file); !strings.Contains(declarations, `variable "`+name+`"`) || !strings.Contains(declarations, "sensitive   = true") {
+			t.Errorf("expected sensitive variable `%s` within %s:\n%s", name, file, declarations)
+		}
+	}
+	if main := read("main.tf"); !strings.Contains(main, name+" = var."+name) {
+		t.Errorf("expected variable `%s` to be passed to the module:\n%s", name, main)
+	}
+
+	values := map[string]any{}
+	if err := json.Unmarshal([]byte(read(export.SecretsVarsFileName)), &values); err != nil {
+		t.Fatal(err)
+	}
+	if value, found := values[name]; !found || value != "" {
+		t.Errorf("expected an empty value for the masked `%s`, got %v", name, values)
+	}
+}
+
+func TestExportSecretVarsEncrypted(t *testing.T) {
+	folder := exportSecrets(t, "top-secret")
+	if _, err := os.Stat(filepath.Join(folder, export.SecretsVarsFileName)); err == nil {
+		t.Fatalf("expected %s not to get written unencrypted", export.SecretsVarsFileName)
+	}
+	encrypted, err := os.ReadFile(filepath.Join(folder, export.SecretsEncryptedFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(encrypted), "api_key") {
+		t.Errorf("expected %s to be encrypted", export.SecretsEncryptedFileName)
+	}
+
+	t.Setenv(export.SecretsKeyEnvVar, "wrong")
+	if err := export.DecryptSecrets(folder); err == nil {
+		t.Error("expected decrypting with the wrong key to fail")
+	}
+	t.Setenv(export.SecretsKeyEnvVar, "top-secret")
+	if err := export.DecryptSecrets(folder); err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := os.ReadFile(filepath.Join(folder, export.SecretsVarsFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(decrypted), `"ops_genie_notification_On_Call_api_key"`) {
+		t.Errorf("unexpected %s:\n%s", export.SecretsVarsFileName, string(decrypted))
+	}
+}
@@ -0,0 +1,189 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// VariablesFileName is the name of the file declaring the variables in the target folder in case of `-promote` or `-secret-vars`
+const VariablesFileName = "variables.tf"
+
+// moduleInputsFileName is the name of the file declaring the variables introduced via `-promote` or `-secret-vars` a module requires.
+// `___variables___.tf` is already taken by the references to other modules
+const moduleInputsFileName = "___inputs___.tf"
+
+// Variable is a value, which has been replaced with a Terraform variable during the export
+type Variable struct {
+	Name        string
+	Description string
+	// Type is the type of the variable. Defaults to `string`
+	Type string
+	// Value is the value within the exported environment
+	Value any
+	// Sensitive variables are declared with `sensitive = true`
+	Sensitive bool
+}
+
+// Variables keeps track of the variables introduced during the export and of the modules using them
+type Variables struct {
+	mu        sync.Mutex
+	variables map[string]*Variable
+	usages    map[ResourceType]map[string]bool
+}
+
+func (me *Environment) GetVariables() *Variables {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	if me.Variables == nil {
+		me.Variables = &Variables{variables: map[string]*Variable{}, usages: map[ResourceType]map[string]bool{}}
+	}
+	return me.Variables
+}
+
+// moduleTypeOf returns the resource type of the module resources of the given type are getting written into.
+// Child resources are getting written into the module of their parent
+func (me *Environment) moduleTypeOf(resourceType ResourceType) ResourceType {
+	if parentType, found := me.ChildParentGroups[resourceType]; found && !me.ChildResourceOverride {
+		return parentType
+	}
+	return resourceType
+}
+
+// use registers the variable as being used by the module of the given resource type and returns the name it has been registered with.
+// If a variable with the same name but a different value has already been registered, the name gets suffixed with `_<suffix>`.
+// Without a suffix, or if the suffixed name is taken as well, the variable registered first remains
+func (me *Variables) use(resourceType ResourceType, variable *Variable, suffix string) string {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	if existing, found := me.variables[variable.Name]; found && len(suffix) > 0 && !reflect.DeepEqual(existing.Value, variable.Value) {
+		variable.Name = variable.Name + "_" + suffix
+	}
+	if _, found := me.variables[variable.Name]; !found {
+		me.variables[variable.Name] = variable
+	}
+	if _, found := me.usages[resourceType]; !found {
+		me.usages[resourceType] = map[string]bool{}
+	}
+	me.usages[resourceType][variable.Name] = true
+	return variable.Name
+}
+
+// List returns the variables introduced so far, sorted by name
+func (me *Variables) List() []*Variable {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	variables := []*Variable{}
+	for _, variable := range me.variables {
+		variables = append(variables, variable)
+	}
+	sort.Slice(variables, func(i, j int) bool { return variables[i].Name < variables[j].Name })
+	return variables
+}
+
+// usedBy returns the names of the variables used by the module of the given resource type, sorted by name
+func (me *Variables) usedBy(resourceType ResourceType) []string {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	names := []string{}
+	for name := range me.usages[resourceType] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// writeVariableArguments passes the variables used by the module of the given resource type on to the module
+func (me *Environment) writeVariableArguments(mainFile *os.File, resourceType ResourceType) {
+	if me.Variables == nil {
+		return
+	}
+	for _, name := range me.Variables.usedBy(resourceType) {
+		mainFile.WriteString(fmt.Sprintf("  %s = var.%s\n", name, name))
+	}
+}
+
+// WriteInputVariablesFiles declares the variables introduced during the export within the target folder and the modules using them.
+// Afterwards the files containing their values get written (`-promote` and `-secret-vars`)
+func (me *Environment) WriteInputVariablesFiles() error {
+	if me.Variables == nil {
+		return nil
+	}
+	fmt.Println("Writing " + VariablesFileName)
+	variables := me.Variables.List()
+
+	declarations := func(names []string) []byte {
+		buf := new(bytes.Buffer)
+		w := bufio.NewWriter(buf)
+		for _, variable := range variables {
+			if names != nil && !slices.Contains(names, variable.Name) {
+				continue
+			}
+			description, _ := json.Marshal(variable.Description)
+			variableType := variable.Type
+			if len(variableType) == 0 {
+				variableType = "string"
+			}
+			fmt.Fprintf(w, "variable \"%s\" {\n  type        = %s\n  description = %s\n", variable.Name, variableType, string(description))
+			if variable.Sensitive {
+				fmt.Fprintf(w, "  sensitive   = true\n")
+			}
+			fmt.Fprintf(w, "}\n\n")
+		}
+		w.Flush()
+		return buf.Bytes()
+	}
+
+	if err := os.MkdirAll(me.OutputFolder, os.ModePerm); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path.Join(me.OutputFolder, VariablesFileName), declarations(nil), 0664); err != nil {
+		return err
+	}
+	if !me.Flags.Flat {
+		for _, module := range me.Modules {
+			names := me.Variables.usedBy(module.Type)
+			if len(names) == 0 {
+				continue
+			}
+			folders := []string{module.GetFolder()}
+			for folder := range module.SplitPathModuleNameMap {
+				folders = append(folders, folder)
+			}
+			for _, folder := range folders {
+				if err := os.WriteFile(path.Join(folder, moduleInputsFileName), declarations(names), 0664); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if err := me.writePromotionVarFiles(variables); err != nil {
+		return err
+	}
+	return me.writeSecretsVarFile(variables)
+}
@@ -68,7 +68,7 @@ func (me *defaultClient) Delete(ctx context.Context, url string, expectedStatusC
 }
 
 func (me *defaultClient) Upload(ctx context.Context, url string, reader io.ReadCloser, fileName string, expectedStatusCodes ...int) Request {
-	req := &request{id: uuid.NewString(), ctx: ctx, client: me, url: url, method: "POST", upload: reader, fileName: fileName, headers: map[string]string{"Content-Type": "application/json"}}
+	req := &request{id: uuid.NewString(), ctx: ctx, client: me, url: url, method: "POST", upload: reader, fileName: fileName}
 	if len(expectedStatusCodes) > 0 {
 		req.expect = statuscodes(expectedStatusCodes)
 	}
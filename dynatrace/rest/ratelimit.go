@@ -0,0 +1,576 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package rest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimitFamily identifies a group of Dynatrace APIs sharing the same token bucket
+type RateLimitFamily string
+
+const (
+	// RateLimitFamilyClassicConfig covers the Configuration API (v1) and the Environment API (v2), except Settings 2.0
+	RateLimitFamilyClassicConfig = RateLimitFamily("classic_config")
+	// RateLimitFamilySettings covers the Settings 2.0 API
+	RateLimitFamilySettings = RateLimitFamily("settings")
+	// RateLimitFamilyIAM covers the Account Management API
+	RateLimitFamilyIAM = RateLimitFamily("iam")
+	// RateLimitFamilyPlatform covers the platform APIs (automation, buckets, OpenPipeline, ...), except documents
+	RateLimitFamilyPlatform = RateLimitFamily("platform")
+	// RateLimitFamilyDocuments covers the Documents API
+	RateLimitFamilyDocuments = RateLimitFamily("documents")
+)
+
+var RateLimitFamilies = []RateLimitFamily{
+	RateLimitFamilyClassicConfig,
+	RateLimitFamilySettings,
+	RateLimitFamilyIAM,
+	RateLimitFamilyPlatform,
+	RateLimitFamilyDocuments,
+}
+
+// accountManagementHosts are the hosts of the Account Management API (see `settings.ProdIAMEndpointURL` and siblings)
+var accountManagementHosts = []string{
+	"api.dynatrace.com",
+	"api-hardening.internal.dynatracelabs.com",
+	"api-dev.internal.dynatracelabs.com",
+}
+
+// RateLimitFamilyOf determines the API family the given URL belongs to.
+// OAuth token endpoints don't belong to any family and are not getting limited
+func RateLimitFamilyOf(u *url.URL) RateLimitFamily {
+	if u == nil {
+		return ""
+	}
+	path := u.Path
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case strings.Contains(path, "/oauth2/token") || strings.HasPrefix(host, "sso."):
+		return ""
+	case strings.HasPrefix(path, "/iam/") || slices.Contains(accountManagementHosts, host):
+		return RateLimitFamilyIAM
+	case strings.Contains(path, "api/v2/settings"):
+		return RateLimitFamilySettings
+	case strings.HasPrefix(path, "/platform/document"):
+		return RateLimitFamilyDocuments
+	case strings.HasPrefix(path, "/platform/"):
+		return RateLimitFamilyPlatform
+	}
+	return RateLimitFamilyClassicConfig
+}
+
+// RateLimit configures the token bucket of an API family
+type RateLimit struct {
+	// Rate is the number of requests per second. Zero means unlimited
+	Rate float64
+	// Burst is the number of requests that may be sent without delay after being idle
+	Burst int
+}
+
+// RateLimits configures the limiter shared by all HTTP clients of the provider
+type RateLimits struct {
+	// MaxConcurrency caps the number of requests in flight across all API families
+	MaxConcurrency int
+	// Adaptive signals that the rate of an API family gets lowered to the limit reported by the
+	// `X-RateLimit-Limit` header until `X-RateLimit-Reset` and that requests are held back once `X-RateLimit-Remaining` reaches zero
+	Adaptive bool
+	// Families holds the rate limits per API family. Families not contained are not limited
+	Families map[RateLimitFamily]RateLimit
+}
+
+const DefaultMaxConcurrency = 20
+const HighLimitMaxConcurrency = 50
+
+const DefaultIAMRateLimiterInterval = 1000 * time.Millisecond
+const MaxIAMRateLimiterInterval = 5000 * time.Millisecond
+
+// DefaultRateLimits returns the RateLimits used unless configured otherwise. Only the Account Management API is
+// limited to one request per second by default, the other families adapt to the limits reported by the server.
+// The environment variables `DYNATRACE_MAX_HTTP_WORKERS`, `DYNATRACE_HTTP_RATE_LIMIT_ADAPTIVE`,
+// `DYNATRACE_HTTP_RATE_LIMIT_<FAMILY>` (requests per second), `DYNATRACE_IAM_RATE_LIMITER_RATE` (milliseconds between
+// two requests) and `DYNATRACE_DISABLE_IAM_RATE_LIMITER` are taken into account
+func DefaultRateLimits() RateLimits {
+	limits := RateLimits{
+		MaxConcurrency: resolveMaxConcurrency(),
+		Adaptive:       strings.TrimSpace(os.Getenv("DYNATRACE_HTTP_RATE_LIMIT_ADAPTIVE")) != "false",
+		Families:       map[RateLimitFamily]RateLimit{},
+	}
+	if interval := resolveIAMRateLimiterInterval(); interval > 0 {
+		limits.Families[RateLimitFamilyIAM] = RateLimit{Rate: float64(time.Second) / float64(interval), Burst: 1}
+	}
+	for _, family := range RateLimitFamilies {
+		value := strings.TrimSpace(os.Getenv("DYNATRACE_HTTP_RATE_LIMIT_" + strings.ToUpper(string(family))))
+		if len(value) == 0 {
+			continue
+		}
+		if rate, err := strconv.ParseFloat(value, 64); err == nil && rate >= 0 {
+			limits.Families[family] = RateLimit{Rate: rate, Burst: int(math.Max(1, math.Ceil(rate)))}
+		}
+	}
+	return limits
+}
+
+func resolveMaxConcurrency() int {
+	value := strings.TrimSpace(os.Getenv("DYNATRACE_MAX_HTTP_WORKERS"))
+	if len(value) == 0 {
+		return DefaultMaxConcurrency
+	}
+	maxConcurrency, err := strconv.Atoi(value)
+	if err != nil {
+		return DefaultMaxConcurrency
+	}
+	return min(max(maxConcurrency, 1), HighLimitMaxConcurrency)
+}
+
+func resolveIAMRateLimiterInterval() time.Duration {
+	if strings.TrimSpace(os.Getenv("DYNATRACE_DISABLE_IAM_RATE_LIMITER")) == "true" {
+		return 0
+	}
+	value := strings.TrimSpace(os.Getenv("DYNATRACE_IAM_RATE_LIMITER_RATE"))
+	if len(value) == 0 {
+		return DefaultIAMRateLimiterInterval
+	}
+	millis, err := strconv.ParseInt(value, 10, 0)
+	if err != nil {
+		return DefaultIAMRateLimiterInterval
+	}
+	return min(time.Duration(millis)*time.Millisecond, MaxIAMRateLimiterInterval)
+}
+
+// RateLimitStats are the metrics collected per API family
+type RateLimitStats struct {
+	// Requests is the number of requests sent
+	Requests int64
+	// Waits is the number of requests that had to wait for a token or a free slot
+	Waits int64
+	// WaitTime is the total time requests have been waiting
+	WaitTime time.Duration
+	// Throttles is the number of responses with status code 429
+	Throttles int64
+}
+
+type rateLimitCounters struct {
+	requests  atomic.Int64
+	waits     atomic.Int64
+	waitTime  atomic.Int64
+	throttles atomic.Int64
+}
+
+// tokenBucket holds back requests of a single API family
+type tokenBucket struct {
+	mu          sync.Mutex
+	configured  RateLimit
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	throttles   int
+	// adaptedUntil is the time the rate adapted to the `X-RateLimit-Limit` header falls back to the configured one.
+	// The header refers to the endpoint of the response only, other endpoints of the family shouldn't be held back for longer than necessary
+	adaptedUntil time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	burst := math.Max(1, float64(limit.Burst))
+	return &tokenBucket{configured: limit, rate: limit.Rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// configure applies a new limit. Tokens already consumed and pauses caused by a 429 are kept
+func (me *tokenBucket) configure(limit RateLimit) {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	now := time.Now()
+	if me.rate > 0 {
+		me.tokens = math.Min(me.burst, me.tokens+now.Sub(me.last).Seconds()*me.rate)
+	}
+	me.last = now
+	me.configured = limit
+	me.restore()
+}
+
+// restore falls back to the configured limit
+func (me *tokenBucket) restore() {
+	me.rate = me.configured.Rate
+	me.burst = math.Max(1, float64(me.configured.Burst))
+	me.tokens = math.Min(me.tokens, me.burst)
+	me.adaptedUntil = time.Time{}
+}
+
+// reserve takes a token if one is available. Otherwise it returns how long to wait before trying again
+func (me *tokenBucket) reserve(now time.Time) time.Duration {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	if now.Before(me.pausedUntil) {
+		return me.pausedUntil.Sub(now)
+	}
+	if !me.adaptedUntil.IsZero() && !now.Before(me.adaptedUntil) {
+		if me.rate > 0 {
+			me.tokens = math.Min(me.burst, me.tokens+now.Sub(me.last).Seconds()*me.rate)
+		}
+		me.restore()
+	}
+	if me.rate <= 0 {
+		return 0
+	}
+	me.tokens = math.Min(me.burst, me.tokens+now.Sub(me.last).Seconds()*me.rate)
+	me.last = now
+	if me.tokens >= 1 {
+		me.tokens--
+		return 0
+	}
+	return time.Duration((1 - me.tokens) / me.rate * float64(time.Second))
+}
+
+func (me *tokenBucket) pause(until time.Time) {
+	if until.After(me.pausedUntil) {
+		me.pausedUntil = until
+	}
+}
+
+// observe adapts the bucket to the `X-RateLimit-*` headers of the given response.
+// It returns the time requests are held back in case of a 429
+func (me *tokenBucket) observe(response *http.Response, adaptive bool, now time.Time) time.Duration {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	limit, _ := strconv.ParseFloat(response.Header.Get("X-RateLimit-Limit"), 64)
+	reset, hasReset := resetTime(response)
+
+	if response.StatusCode == http.StatusTooManyRequests {
+		me.throttles++
+		// without a reset time the wait time doubles with every consecutive 429
+		wait := MinWaitTime << min(me.throttles-1, 8)
+		if hasReset {
+			wait = reset.Sub(now)
+		}
+		wait = min(max(wait, MinWaitTime), MaxWaitTime)
+		me.pause(now.Add(wait))
+		if adaptive && limit > 0 {
+			me.adapt(limit, now.Add(wait), now)
+		}
+		return wait
+	}
+	me.throttles = 0
+	if !adaptive {
+		return 0
+	}
+	if limit > 0 && (!hasReset || reset.After(now)) {
+		// without a reset time the limit refers to the current minute
+		until := now.Add(time.Minute)
+		if hasReset {
+			until = reset
+		}
+		me.adapt(limit, until, now)
+	}
+	if remaining := response.Header.Get("X-RateLimit-Remaining"); remaining == "0" && hasReset && reset.After(now) {
+		me.pause(now.Add(min(reset.Sub(now), MaxWaitTime)))
+	}
+	return 0
+}
+
+// adapt lowers the rate to the given limit (requests per minute) reported by the server until the given time,
+// but never raises it above the configured rate. Families without a configured limit may send the requests
+// of a second (at least two) without delay
+func (me *tokenBucket) adapt(limit float64, until time.Time, now time.Time) {
+	rate := limit / 60
+	if me.configured.Rate > 0 && me.configured.Rate < rate {
+		rate = me.configured.Rate
+	}
+	if me.configured.Rate <= 0 {
+		me.burst = math.Max(2, math.Ceil(rate))
+	}
+	if me.rate <= 0 {
+		// the bucket hasn't been limited until now, start over with a full burst
+		me.tokens = me.burst
+		me.last = now
+	}
+	me.rate = rate
+	if until.After(me.adaptedUntil) {
+		me.adaptedUntil = until
+	}
+}
+
+// resetTime parses the header `X-RateLimit-Reset`, containing the time in microseconds
+func resetTime(response *http.Response) (time.Time, bool) {
+	value := response.Header.Get("X-RateLimit-Reset")
+	if len(value) == 0 {
+		return time.Time{}, false
+	}
+	micros, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return MicrosecondsToUnixTime(micros), true
+}
+
+// slots caps the number of requests in flight. Unlike a semaphore of fixed size it can get resized
+// while requests are waiting or in flight
+type slots struct {
+	mu      sync.Mutex
+	size    int
+	used    int
+	waiting []chan struct{}
+}
+
+func (me *slots) acquire(ctx context.Context) error {
+	me.mu.Lock()
+	if me.used < me.size && len(me.waiting) == 0 {
+		me.used++
+		me.mu.Unlock()
+		return nil
+	}
+	ready := make(chan struct{})
+	me.waiting = append(me.waiting, ready)
+	me.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		me.mu.Lock()
+		defer me.mu.Unlock()
+		if idx := slices.Index(me.waiting, ready); idx >= 0 {
+			me.waiting = slices.Delete(me.waiting, idx, idx+1)
+		} else {
+			// the slot has been granted in the meantime
+			me.used--
+			me.grant()
+		}
+		return ctx.Err()
+	}
+}
+
+func (me *slots) release() {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	me.used--
+	me.grant()
+}
+
+// resize changes the number of slots. Requests in flight keep their slots
+func (me *slots) resize(size int) {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	me.size = size
+	me.grant()
+}
+
+func (me *slots) grant() {
+	for me.used < me.size && len(me.waiting) > 0 {
+		me.used++
+		close(me.waiting[0])
+		me.waiting = me.waiting[1:]
+	}
+}
+
+type rateLimiter struct {
+	mu       sync.RWMutex
+	limits   RateLimits
+	slots    *slots
+	buckets  map[RateLimitFamily]*tokenBucket
+	counters map[RateLimitFamily]*rateLimitCounters
+}
+
+func newRateLimiter(limits RateLimits) *rateLimiter {
+	limiter := &rateLimiter{
+		slots:    new(slots),
+		buckets:  map[RateLimitFamily]*tokenBucket{},
+		counters: map[RateLimitFamily]*rateLimitCounters{},
+	}
+	for _, family := range RateLimitFamilies {
+		limiter.buckets[family] = newTokenBucket(limits.Families[family])
+		limiter.counters[family] = new(rateLimitCounters)
+	}
+	limiter.configure(limits)
+	return limiter
+}
+
+// configure updates the buckets and the slots in place, requests already waiting are subject to the new limits
+func (me *rateLimiter) configure(limits RateLimits) {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	limits.MaxConcurrency = min(max(limits.MaxConcurrency, 1), HighLimitMaxConcurrency)
+	families := map[RateLimitFamily]RateLimit{}
+	for _, family := range RateLimitFamilies {
+		limit := limits.Families[family]
+		if limit.Rate < 0 {
+			limit.Rate = 0
+		}
+		families[family] = limit
+		me.buckets[family].configure(limit)
+	}
+	limits.Families = families
+	me.limits = limits
+	me.slots.resize(limits.MaxConcurrency)
+}
+
+// acquire blocks until a request of the given family may be sent.
+// The returned function needs to be called once the response has been received.
+// Requests waiting for a token of their family don't occupy a slot, which keeps other families going
+func (me *rateLimiter) acquire(ctx context.Context, family RateLimitFamily) (func(), error) {
+	bucket, counters := me.buckets[family], me.counters[family]
+
+	start := time.Now()
+	for {
+		wait := bucket.reserve(time.Now())
+		if wait <= 0 {
+			break
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if err := me.slots.acquire(ctx); err != nil {
+		return nil, err
+	}
+	counters.requests.Add(1)
+	// waiting for less than a millisecond is not worth mentioning
+	if waited := time.Since(start); waited >= time.Millisecond {
+		counters.waits.Add(1)
+		counters.waitTime.Add(int64(waited))
+	}
+	return me.slots.release, nil
+}
+
+func (me *rateLimiter) observe(ctx context.Context, family RateLimitFamily, response *http.Response) {
+	me.mu.RLock()
+	adaptive := me.limits.Adaptive
+	me.mu.RUnlock()
+	bucket, counters := me.buckets[family], me.counters[family]
+
+	if wait := bucket.observe(response, adaptive, time.Now()); wait > 0 {
+		counters.throttles.Add(1)
+		logger.Printf(ctx, "[RATE LIMIT] %s: rate limit reached, holding back requests for %s", family, wait.Round(time.Millisecond))
+	}
+}
+
+var limiter = newRateLimiter(DefaultRateLimits())
+
+// SetRateLimits replaces the RateLimits used by all HTTP clients of the provider.
+// Collected metrics are kept
+func SetRateLimits(limits RateLimits) {
+	limiter.configure(limits)
+}
+
+// GetRateLimits returns the RateLimits currently in use
+func GetRateLimits() RateLimits {
+	limiter.mu.RLock()
+	defer limiter.mu.RUnlock()
+	limits := limiter.limits
+	limits.Families = map[RateLimitFamily]RateLimit{}
+	for family, limit := range limiter.limits.Families {
+		limits.Families[family] = limit
+	}
+	return limits
+}
+
+// RateLimitMetrics returns the metrics collected per API family since the start of the process
+func RateLimitMetrics() map[RateLimitFamily]RateLimitStats {
+	metrics := map[RateLimitFamily]RateLimitStats{}
+	for family, counters := range limiter.counters {
+		metrics[family] = RateLimitStats{
+			Requests:  counters.requests.Load(),
+			Waits:     counters.waits.Load(),
+			WaitTime:  time.Duration(counters.waitTime.Load()),
+			Throttles: counters.throttles.Load(),
+		}
+	}
+	return metrics
+}
+
+// LogRateLimitMetrics writes the metrics of every API family that has been accessed into the debug log
+func LogRateLimitMetrics(ctx context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	metrics := RateLimitMetrics()
+	families := []string{}
+	for family, stats := range metrics {
+		if stats.Requests > 0 || stats.Throttles > 0 {
+			families = append(families, string(family))
+		}
+	}
+	sort.Strings(families)
+	for _, family := range families {
+		stats := metrics[RateLimitFamily(family)]
+		logger.Printf(ctx, "[RATE LIMIT] %s: %d requests, %d waits (%s), %d throttles", family, stats.Requests, stats.Waits, stats.WaitTime.Round(time.Millisecond), stats.Throttles)
+	}
+}
+
+type rateLimitedContextKey struct{}
+
+// RateLimitedTransport subjects requests to the rate limits of their API family before passing them on.
+// Nested RateLimitedTransports (e.g. an OAuth client based on `http.DefaultClient`) don't limit a request twice
+type RateLimitedTransport struct {
+	// Base is the RoundTripper actually sending the requests. If nil, the transport of `http.DefaultClient`
+	// or `http.DefaultTransport` is used
+	Base http.RoundTripper
+}
+
+// NewRateLimitedTransport wraps the given RoundTripper, unless it is already a RateLimitedTransport
+func NewRateLimitedTransport(base http.RoundTripper) http.RoundTripper {
+	if transport, ok := base.(*RateLimitedTransport); ok {
+		return transport
+	}
+	return &RateLimitedTransport{Base: base}
+}
+
+func (me *RateLimitedTransport) base() http.RoundTripper {
+	if me.Base != nil {
+		return me.Base
+	}
+	if http.DefaultClient.Transport != nil && http.DefaultClient.Transport != me {
+		return http.DefaultClient.Transport
+	}
+	return http.DefaultTransport
+}
+
+func (me *RateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	family := RateLimitFamilyOf(req.URL)
+	ctx := req.Context()
+	if len(family) == 0 || ctx.Value(rateLimitedContextKey{}) != nil {
+		return me.base().RoundTrip(req)
+	}
+	release, err := limiter.acquire(ctx, family)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", req.Method, req.URL.String(), err)
+	}
+	defer release()
+	response, err := me.base().RoundTrip(req.WithContext(context.WithValue(ctx, rateLimitedContextKey{}, family)))
+	if response != nil {
+		limiter.observe(ctx, family, response)
+	}
+	return response, err
+}
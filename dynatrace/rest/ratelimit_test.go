@@ -0,0 +1,265 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package rest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+)
+
+// withRateLimits applies the given rate limits for the duration of the test
+func withRateLimits(t *testing.T, families map[rest.RateLimitFamily]rest.RateLimit, adaptive bool) {
+	t.Helper()
+	previous := rest.GetRateLimits()
+	t.Cleanup(func() { rest.SetRateLimits(previous) })
+	rest.SetRateLimits(rest.RateLimits{MaxConcurrency: 5, Adaptive: adaptive, Families: families})
+}
+
+func rateLimitServer(header http.Header, status int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for key, values := range header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(status)
+	}))
+}
+
+func send(t *testing.T, ctx context.Context, client *http.Client, u string) error {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	return nil
+}
+
+func TestRateLimitFamilyOf(t *testing.T) {
+	for rawURL, expected := range map[string]rest.RateLimitFamily{
+		"https://abc.live.dynatrace.com/api/config/v1/alertingProfiles":                       rest.RateLimitFamilyClassicConfig,
+		"https://abc.live.dynatrace.com/api/v2/slo":                                           rest.RateLimitFamilyClassicConfig,
+		"https://abc.live.dynatrace.com/api/v2/settings/objects":                              rest.RateLimitFamilySettings,
+		"https://abc.apps.dynatrace.com/platform/classic/environment-api/v2/settings/objects": rest.RateLimitFamilySettings,
+		"https://api.dynatrace.com/iam/v1/accounts/123/groups":                                rest.RateLimitFamilyIAM,
+		"https://api-hardening.internal.dynatracelabs.com/iam/v1/accounts/123/groups":         rest.RateLimitFamilyIAM,
+		"https://api.dynatrace.com/env/v2/accounts/123/environments":                          rest.RateLimitFamilyIAM,
+		"https://abc.live.dynatrace.com/env/v1/something":                                     rest.RateLimitFamilyClassicConfig,
+		"https://api-gateway.example.com/api/v2/slo":                                          rest.RateLimitFamilyClassicConfig,
+		"https://abc.apps.dynatrace.com/platform/automation/v1/workflows":                     rest.RateLimitFamilyPlatform,
+		"https://abc.apps.dynatrace.com/platform/document/v1/documents":                       rest.RateLimitFamilyDocuments,
+		"https://sso.dynatrace.com/sso/oauth2/token":                                          "",
+	} {
+		u, _ := url.Parse(rawURL)
+		if actual := rest.RateLimitFamilyOf(u); actual != expected {
+			t.Errorf("%s: expected family `%s`, got `%s`", rawURL, expected, actual)
+		}
+	}
+}
+
+func TestRateLimitedTransport(t *testing.T) {
+	withRateLimits(t, map[rest.RateLimitFamily]rest.RateLimit{rest.RateLimitFamilyClassicConfig: {Rate: 20, Burst: 1}}, false)
+	server := rateLimitServer(nil, http.StatusOK)
+	defer server.Close()
+
+	before := rest.RateLimitMetrics()[rest.RateLimitFamilyClassicConfig]
+	client := &http.Client{Transport: rest.NewRateLimitedTransport(http.DefaultTransport)}
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := send(t, context.Background(), client, server.URL+"/api/config/v1/managementZones"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the first request consumes the only token, the others need to wait 50ms each
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("5 requests at 20 requests/s expected to take at least 200ms, took %s", elapsed)
+	}
+	after := rest.RateLimitMetrics()[rest.RateLimitFamilyClassicConfig]
+	if requests := after.Requests - before.Requests; requests != 5 {
+		t.Errorf("expected 5 requests, got %d", requests)
+	}
+	if waits := after.Waits - before.Waits; waits < 3 {
+		t.Errorf("expected at least 3 requests to wait, got %d", waits)
+	}
+}
+
+func TestRateLimitedTransportNested(t *testing.T) {
+	withRateLimits(t, map[rest.RateLimitFamily]rest.RateLimit{}, false)
+	server := rateLimitServer(nil, http.StatusOK)
+	defer server.Close()
+
+	before := rest.RateLimitMetrics()[rest.RateLimitFamilySettings]
+	client := &http.Client{Transport: rest.NewRateLimitedTransport(&rest.RateLimitedTransport{Base: http.DefaultTransport})}
+	if err := send(t, context.Background(), client, server.URL+"/api/v2/settings/objects"); err != nil {
+		t.Fatal(err)
+	}
+	if requests := rest.RateLimitMetrics()[rest.RateLimitFamilySettings].Requests - before.Requests; requests != 1 {
+		t.Errorf("nested transports expected to count the request once, got %d", requests)
+	}
+}
+
+func TestRateLimitedTransportAdaptive(t *testing.T) {
+	withRateLimits(t, map[rest.RateLimitFamily]rest.RateLimit{}, true)
+	// 600 requests per minute lower the (unlimited) rate to 10 requests per second with a burst of 10 requests after the first one
+	server := rateLimitServer(http.Header{"X-Ratelimit-Limit": []string{"600"}}, http.StatusOK)
+	defer server.Close()
+
+	client := &http.Client{Transport: rest.NewRateLimitedTransport(http.DefaultTransport)}
+	start := time.Now()
+	for i := 0; i < 13; i++ {
+		if err := send(t, context.Background(), client, server.URL+"/platform/automation/v1/workflows"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("expected the rate to adapt to the `X-RateLimit-Limit` header, 13 requests took %s", elapsed)
+	}
+}
+
+func TestRateLimitedTransportAdaptiveRecovers(t *testing.T) {
+	withRateLimits(t, map[rest.RateLimitFamily]rest.RateLimit{}, true)
+	// 60 requests per minute lower the (unlimited) rate to 1 request per second with a burst of 2 requests
+	reset := time.Now().Add(200 * time.Millisecond)
+	server := rateLimitServer(http.Header{"X-Ratelimit-Limit": []string{"60"}, "X-Ratelimit-Reset": []string{strconv.FormatInt(reset.UnixMicro(), 10)}}, http.StatusOK)
+	defer server.Close()
+
+	client := &http.Client{Transport: rest.NewRateLimitedTransport(http.DefaultTransport)}
+	sendWithin := func(timeout time.Duration) error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		return send(t, ctx, client, server.URL+"/api/v2/settings/objects")
+	}
+	// the first request isn't limited yet, the next ones make use of the burst
+	for i := 0; i < 3; i++ {
+		if err := sendWithin(50 * time.Millisecond); err != nil {
+			t.Fatalf("expected the burst not to be held back, got %v", err)
+		}
+	}
+	if err := sendWithin(50 * time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the request to be held back, got %v", err)
+	}
+	// the limit reported by the server applies until it resets
+	time.Sleep(time.Until(reset))
+	for i := 0; i < 3; i++ {
+		if err := sendWithin(50 * time.Millisecond); err != nil {
+			t.Errorf("expected the rate to recover after the reset time, got %v", err)
+		}
+	}
+}
+
+func TestRateLimitedTransportThrottle(t *testing.T) {
+	withRateLimits(t, map[rest.RateLimitFamily]rest.RateLimit{}, true)
+	reset := time.Now().Add(time.Minute).UnixMicro()
+	server := rateLimitServer(http.Header{"X-Ratelimit-Limit": []string{"6000"}, "X-Ratelimit-Reset": []string{strconv.FormatInt(reset, 10)}}, http.StatusTooManyRequests)
+	defer server.Close()
+
+	before := rest.RateLimitMetrics()[rest.RateLimitFamilyDocuments]
+	client := &http.Client{Transport: rest.NewRateLimitedTransport(http.DefaultTransport)}
+	if err := send(t, context.Background(), client, server.URL+"/platform/document/v1/documents"); err != nil {
+		t.Fatal(err)
+	}
+	// further requests of the same family are held back until the limit resets, even after reconfiguring the limits
+	rest.SetRateLimits(rest.GetRateLimits())
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := send(t, ctx, client, server.URL+"/platform/document/v1/documents"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the request to be held back, got %v", err)
+	}
+	// other families are not affected
+	if err := send(t, context.Background(), client, server.URL+"/platform/automation/v1/workflows"); err != nil {
+		t.Errorf("expected requests of other families not to be held back, got %v", err)
+	}
+	if throttles := rest.RateLimitMetrics()[rest.RateLimitFamilyDocuments].Throttles - before.Throttles; throttles != 1 {
+		t.Errorf("expected 1 throttle, got %d", throttles)
+	}
+}
+
+func TestRateLimitedTransportWaitsWithoutSlot(t *testing.T) {
+	previous := rest.GetRateLimits()
+	t.Cleanup(func() { rest.SetRateLimits(previous) })
+	rest.SetRateLimits(rest.RateLimits{MaxConcurrency: 1, Families: map[rest.RateLimitFamily]rest.RateLimit{rest.RateLimitFamilySettings: {Rate: 0.1, Burst: 1}}})
+	server := rateLimitServer(nil, http.StatusOK)
+	defer server.Close()
+
+	client := &http.Client{Transport: rest.NewRateLimitedTransport(http.DefaultTransport)}
+	if err := send(t, context.Background(), client, server.URL+"/api/v2/settings/objects"); err != nil {
+		t.Fatal(err)
+	}
+	// the next request of the family `settings` waits for a token without occupying the only slot
+	waiting := make(chan error)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+		waiting <- send(t, ctx, client, server.URL+"/api/v2/settings/objects")
+	}()
+	time.Sleep(20 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := send(t, ctx, client, server.URL+"/api/config/v1/managementZones"); err != nil {
+		t.Errorf("expected requests of other families not to be blocked by a request waiting for a token, got %v", err)
+	}
+	if err := <-waiting; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the request to be held back, got %v", err)
+	}
+}
+
+func TestClientResendsOn429(t *testing.T) {
+	withRateLimits(t, map[rest.RateLimitFamily]rest.RateLimit{}, true)
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("X-RateLimit-Limit", "100")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().UnixMicro(), 10))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"value":"ok"}`))
+	}))
+	defer server.Close()
+
+	var result struct {
+		Value string `json:"value"`
+	}
+	start := time.Now()
+	if err := rest.DefaultClient(server.URL, "token").Get(context.Background(), "/api/v2/something", 200).Finish(&result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Value != "ok" {
+		t.Errorf("unexpected result %v", result)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 calls, got %d", calls.Load())
+	}
+	// the rate limiter holds back the second attempt
+	if elapsed := time.Since(start); elapsed < rest.MinWaitTime {
+		t.Errorf("expected the request to be resent after at least %s, took %s", rest.MinWaitTime, elapsed)
+	}
+}
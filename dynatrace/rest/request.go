@@ -36,7 +36,6 @@ import (
 	"time"
 
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/shutdown"
-	"golang.org/x/sync/semaphore"
 )
 
 const MinWaitTime = 5 * time.Second
@@ -251,8 +250,12 @@ func (me *request) Raw() ([]byte, error) {
 	} else {
 		httpClient.Transport = http.DefaultTransport
 	}
-	response, err := me.execute(me.ctx, func() (*http.Response, error) {
-		if res, err = httpClient.Do(req); err != nil {
+	httpClient.Transport = NewRateLimitedTransport(httpClient.Transport)
+	response, err := me.execute(me.ctx, func(ctx context.Context) (*http.Response, error) {
+		if err := Rewind(req); err != nil {
+			return nil, err
+		}
+		if res, err = httpClient.Do(req.WithContext(ctx)); err != nil {
 			return nil, err
 		}
 		return res, nil
@@ -266,7 +269,9 @@ func (me *request) Raw() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	if data, err = io.ReadAll(res.Body); err != nil {
+	data, err = io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
 		return nil, err
 	}
 	if os.Getenv("DYNATRACE_HTTP_RESPONSE") == "true" {
@@ -324,45 +329,22 @@ func (me *request) OnResponse(onResponse func(resp *http.Response)) Request {
 	return me
 }
 
-const defaultMaxWorkers = 20
-const highLimitMaxWorkers = 50
-
-var maxWorkers = resolveMaxWorkers()
-
-func resolveMaxWorkers() int64 {
-	sMaxWorkers := os.Getenv("DYNATRACE_MAX_HTTP_WORKERS")
-	if len(sMaxWorkers) == 0 {
-		return defaultMaxWorkers
-	}
-	mw, err := strconv.Atoi(sMaxWorkers)
-	if err != nil {
-		return defaultMaxWorkers
-	}
-	if mw > highLimitMaxWorkers {
-		return highLimitMaxWorkers
-	}
-	if mw < 1 {
-		return 1
-	}
-	return int64(mw)
-}
-
-var sem = semaphore.NewWeighted(maxWorkers)
-
-func (s *request) execute(ctx context.Context, callback func() (*http.Response, error)) (*http.Response, error) {
+func (s *request) execute(ctx context.Context, callback func(ctx context.Context) (*http.Response, error)) (*http.Response, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	err := sem.Acquire(ctx, 1)
-	if err != nil {
-		return nil, err
-	}
-	defer sem.Release(1)
 	if shutdown.System.Stopped() {
 		return nil, nil
 	}
 
-	response, err := callback()
+	// transient failures (e.g. a 502 from a load balancer) are retried according to the configured policy
+	// requests hitting the rate limit are handled below
+	policy := GetRetryPolicy()
+	send := func() (*http.Response, error) {
+		return policy.Retry(ctx, s.method, s.url, callback)
+	}
+
+	response, err := send()
 	if err != nil {
 		return nil, err
 	}
@@ -370,30 +352,20 @@ func (s *request) execute(ctx context.Context, callback func() (*http.Response,
 	maxIterationCount := 500
 	currentIteration := 0
 
+	// the rate limiter holds back further requests of the same API family until the limit resets
+	// resending the request therefore doesn't require sleeping here
 	for response.StatusCode == http.StatusTooManyRequests && currentIteration < maxIterationCount {
-
-		if limit, humanReadableTimestamp, timeInMicroseconds, err := s.extractRateLimitHeaders(response); err == nil {
+		if limit, humanReadableTimestamp, _, err := s.extractRateLimitHeaders(response); err == nil {
 			logger.Printf(ctx, "Rate limit of %s requests/min reached (iteration: %d)", limit, currentIteration+1)
 			logger.Printf(ctx, "Attempting to sleep until %s", humanReadableTimestamp)
-
-			now := Now()                                            // client time
-			resetTime := MicrosecondsToUnixTime(timeInMicroseconds) // server time
-			// mixing server and client time here - sanity check necessary
-			sleepDuration := min(max(resetTime.Sub(now), MinWaitTime), MaxWaitTime)
-
-			time.Sleep(sleepDuration)
-
-			currentIteration++
-			if response, err = callback(); err != nil {
-				return nil, err
-			}
 		} else {
-			// fallback if there are no response headers available
-			time.Sleep(30 * time.Second)
-			currentIteration++
-			if response, err = callback(); err != nil {
-				return nil, err
-			}
+			logger.Printf(ctx, "Rate limit reached (iteration: %d)", currentIteration+1)
+		}
+		response.Body.Close()
+
+		currentIteration++
+		if response, err = send(); err != nil {
+			return nil, err
 		}
 	}
 
@@ -419,21 +391,6 @@ func (s *request) extractRateLimitHeaders(response *http.Response) (limit string
 	return limit, humanReadableResetTimestamp, resetTimeInMicroseconds, nil
 }
 
-func min(a, b time.Duration) time.Duration {
-	if a.Nanoseconds() < b.Nanoseconds() {
-		return a
-	}
-
-	return b
-}
-
-func max(a, b time.Duration) time.Duration {
-	if a.Nanoseconds() < b.Nanoseconds() {
-		return b
-	}
-	return a
-}
-
 func Now() time.Time {
 	nowInLocalTimeZone := time.Now()
 	location, _ := time.LoadLocation("UTC")
@@ -0,0 +1,342 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Error classes a RetryPolicy can get configured to retry on
+const (
+	ErrorClassTimeout           = "timeout"
+	ErrorClassConnectionReset   = "connection_reset"
+	ErrorClassConnectionRefused = "connection_refused"
+	ErrorClassEOF               = "eof"
+)
+
+var ErrorClasses = []string{ErrorClassTimeout, ErrorClassConnectionReset, ErrorClassConnectionRefused, ErrorClassEOF}
+
+const DefaultRetryMaxAttempts = 5
+const DefaultRetryMinBackoff = 1 * time.Second
+const DefaultRetryMaxBackoff = 30 * time.Second
+
+var DefaultRetryStatusCodes = []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// RetryPolicy defines how requests that failed because of transient problems
+// (a 502 from a load balancer, a connection reset, ...) are getting retried.
+// HTTP 429 isn't covered here. Requests hitting the rate limit are handled
+// separately based on the `X-RateLimit-*` headers of the response.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent, including the first attempt
+	MaxAttempts int
+	// MinBackoff is the wait time before the first retry. It doubles with every further attempt
+	MinBackoff time.Duration
+	// MaxBackoff caps the wait time between two attempts
+	MaxBackoff time.Duration
+	// Timeout is the deadline for a request including all of its retries. Every attempt is bound to
+	// that deadline via its context, so also an attempt that hangs gets aborted. Zero means no deadline
+	Timeout time.Duration
+	// StatusCodes are the HTTP status codes considered to be transient
+	StatusCodes []int
+	// ErrorClasses are the classes of transport errors considered to be transient (see `ErrorClasses`)
+	ErrorClasses []string
+	// NonIdempotent signals that also requests with methods like POST and PATCH are getting retried
+	NonIdempotent bool
+	// NonIdempotentStatusCodes are the HTTP status codes known to get returned before the request
+	// has reached the service. Requests with methods like POST and PATCH are retried on them regardless of NonIdempotent
+	NonIdempotentStatusCodes []int
+}
+
+// DefaultRetryPolicy returns the RetryPolicy used unless configured otherwise, taking
+// the environment variables `DYNATRACE_HTTP_RETRY_*` into account
+func DefaultRetryPolicy() RetryPolicy {
+	policy := RetryPolicy{
+		MaxAttempts:  DefaultRetryMaxAttempts,
+		MinBackoff:   DefaultRetryMinBackoff,
+		MaxBackoff:   DefaultRetryMaxBackoff,
+		StatusCodes:  DefaultRetryStatusCodes,
+		ErrorClasses: ErrorClasses,
+	}
+	if value := strings.TrimSpace(os.Getenv("DYNATRACE_HTTP_RETRY_MAX_ATTEMPTS")); len(value) > 0 {
+		if maxAttempts, err := strconv.Atoi(value); err == nil && maxAttempts > 0 {
+			policy.MaxAttempts = maxAttempts
+		}
+	}
+	if value := strings.TrimSpace(os.Getenv("DYNATRACE_HTTP_RETRY_MAX_BACKOFF")); len(value) > 0 {
+		if maxBackoff, err := time.ParseDuration(value); err == nil && maxBackoff > 0 {
+			policy.MaxBackoff = maxBackoff
+		}
+	}
+	if value := strings.TrimSpace(os.Getenv("DYNATRACE_HTTP_RETRY_TIMEOUT")); len(value) > 0 {
+		if timeout, err := time.ParseDuration(value); err == nil && timeout >= 0 {
+			policy.Timeout = timeout
+		}
+	}
+	if value := strings.TrimSpace(os.Getenv("DYNATRACE_HTTP_RETRY_ON")); len(value) > 0 {
+		if statusCodes, errorClasses, err := ParseRetryOn(strings.Split(value, ",")); err == nil {
+			policy.StatusCodes = statusCodes
+			policy.ErrorClasses = errorClasses
+		}
+	}
+	policy.NonIdempotent = strings.TrimSpace(os.Getenv("DYNATRACE_HTTP_RETRY_NON_IDEMPOTENT")) == "true"
+	return policy
+}
+
+// ParseRetryOn splits the given entries into HTTP status codes and error classes.
+// Status codes need to be within the range of 500-599
+func ParseRetryOn(entries []string) (statusCodes []int, errorClasses []string, err error) {
+	statusCodes = []int{}
+	errorClasses = []string{}
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if len(entry) == 0 {
+			continue
+		}
+		if statusCode, aerr := strconv.Atoi(entry); aerr == nil {
+			if statusCode < 500 || statusCode > 599 {
+				return nil, nil, fmt.Errorf("`%s` is not a status code for server errors (500-599)", entry)
+			}
+			statusCodes = append(statusCodes, statusCode)
+			continue
+		}
+		known := false
+		for _, errorClass := range ErrorClasses {
+			if errorClass == entry {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, nil, fmt.Errorf("`%s` is neither a status code nor one of the error classes %s", entry, strings.Join(ErrorClasses, ", "))
+		}
+		errorClasses = append(errorClasses, entry)
+	}
+	return statusCodes, errorClasses, nil
+}
+
+var retryPolicy = DefaultRetryPolicy()
+var retryPolicyMutex sync.RWMutex
+
+// SetRetryPolicy replaces the RetryPolicy used by all HTTP clients of the provider
+func SetRetryPolicy(policy RetryPolicy) {
+	retryPolicyMutex.Lock()
+	defer retryPolicyMutex.Unlock()
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	if policy.MinBackoff <= 0 {
+		policy.MinBackoff = DefaultRetryMinBackoff
+	}
+	if policy.MaxBackoff < policy.MinBackoff {
+		policy.MaxBackoff = policy.MinBackoff
+	}
+	retryPolicy = policy
+}
+
+// GetRetryPolicy returns the RetryPolicy currently in use
+func GetRetryPolicy() RetryPolicy {
+	retryPolicyMutex.RLock()
+	defer retryPolicyMutex.RUnlock()
+	return retryPolicy
+}
+
+// IsIdempotent tells whether requests with the given method can safely be sent more than once
+func IsIdempotent(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// ErrorClass determines the error class (see `ErrorClasses`) of an error returned by an `http.Client`.
+// An empty string is returned for errors which aren't considered to be transient
+func ErrorClass(err error) string {
+	if err == nil {
+		return ""
+	}
+	if errors.Is(err, syscall.ECONNRESET) {
+		return ErrorClassConnectionReset
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return ErrorClassConnectionRefused
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return ErrorClassEOF
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrorClassTimeout
+	}
+	return ""
+}
+
+// ShouldRetry decides whether a request with the given method, which resulted in
+// the given status code or error, is eligible for another attempt
+func (me RetryPolicy) ShouldRetry(method string, statusCode int, err error) bool {
+	if !me.NonIdempotent && !IsIdempotent(method) {
+		if err != nil {
+			return false
+		}
+		for _, sc := range me.NonIdempotentStatusCodes {
+			if sc == statusCode {
+				return true
+			}
+		}
+		return false
+	}
+	if err != nil {
+		errorClass := ErrorClass(err)
+		if len(errorClass) == 0 {
+			return false
+		}
+		for _, ec := range me.ErrorClasses {
+			if ec == errorClass {
+				return true
+			}
+		}
+		return false
+	}
+	for _, sc := range me.StatusCodes {
+		if sc == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// Backoff calculates the time to wait before the given attempt (starting with 1 for the first retry).
+// The wait time grows exponentially, is capped by MaxBackoff and is randomized ("full jitter")
+// in order to avoid a thundering herd after an outage
+func (me RetryPolicy) Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	backoff := me.MinBackoff
+	for i := 1; i < attempt && backoff < me.MaxBackoff; i++ {
+		backoff = backoff * 2
+	}
+	if backoff > me.MaxBackoff {
+		backoff = me.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	// at least half of the calculated backoff, plus a random amount of the other half
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// Retry invokes the given callback until it either succeeds, the error or status code
+// isn't considered to be transient, the maximum number of attempts is reached or
+// the deadline of the policy would be exceeded.
+// The callback is expected to send its request with the context it gets passed, which
+// carries the deadline of the policy.
+// The caller is responsible for closing the body of the returned response.
+// Responses that are going to be discarded because of a retry are closed here.
+func (me RetryPolicy) Retry(ctx context.Context, method string, url string, callback func(ctx context.Context) (*http.Response, error)) (*http.Response, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var deadline time.Time
+	if me.Timeout > 0 {
+		deadline = time.Now().Add(me.Timeout)
+	}
+	attempt := 1
+	for {
+		response, err := me.attempt(ctx, deadline, callback)
+		statusCode := 0
+		if response != nil {
+			statusCode = response.StatusCode
+		}
+		if attempt >= me.MaxAttempts || !me.ShouldRetry(method, statusCode, err) {
+			return response, err
+		}
+		wait := me.Backoff(attempt)
+		if !deadline.IsZero() && time.Now().Add(wait).After(deadline) {
+			return response, err
+		}
+		reason := strconv.Itoa(statusCode)
+		if err != nil {
+			reason = err.Error()
+		}
+		logger.Printf(ctx, "%s %s failed (%s). Retrying in %s (attempt %d of %d)", method, url, reason, wait, attempt+1, me.MaxAttempts)
+		if response != nil && response.Body != nil {
+			io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		attempt++
+	}
+}
+
+// attempt invokes the callback with a context bound to the given deadline (if there is one).
+// The context stays valid until the body of the returned response gets closed
+func (me RetryPolicy) attempt(ctx context.Context, deadline time.Time, callback func(ctx context.Context) (*http.Response, error)) (*http.Response, error) {
+	if deadline.IsZero() {
+		return callback(ctx)
+	}
+	attemptCtx, cancel := context.WithDeadline(ctx, deadline)
+	response, err := callback(attemptCtx)
+	if err != nil || response == nil || response.Body == nil {
+		cancel()
+		return response, err
+	}
+	response.Body = &cancelOnClose{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+// cancelOnClose releases the context of an attempt once the body of its response has been closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (me *cancelOnClose) Close() error {
+	defer me.cancel()
+	return me.ReadCloser.Close()
+}
+
+// Rewind prepares a request for being sent once more by restoring its body
+func Rewind(req *http.Request) error {
+	if req == nil || req.Body == nil || req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
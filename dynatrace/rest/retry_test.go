@@ -0,0 +1,214 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+)
+
+func testPolicy() rest.RetryPolicy {
+	return rest.RetryPolicy{
+		MaxAttempts:  3,
+		MinBackoff:   time.Millisecond,
+		MaxBackoff:   4 * time.Millisecond,
+		StatusCodes:  rest.DefaultRetryStatusCodes,
+		ErrorClasses: rest.ErrorClasses,
+	}
+}
+
+func flakyServer(failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"value":"ok"}`))
+	}))
+	return server, calls
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	policy := testPolicy()
+	if !policy.ShouldRetry(http.MethodGet, http.StatusBadGateway, nil) {
+		t.Error("GET with 502 expected to be retried")
+	}
+	if policy.ShouldRetry(http.MethodPost, http.StatusBadGateway, nil) {
+		t.Error("POST expected not to be retried by default")
+	}
+	if policy.ShouldRetry(http.MethodGet, http.StatusInternalServerError, nil) {
+		t.Error("500 is not configured to be retried")
+	}
+	policy.NonIdempotentStatusCodes = []int{http.StatusGatewayTimeout}
+	if !policy.ShouldRetry(http.MethodPost, http.StatusGatewayTimeout, nil) {
+		t.Error("POST with 504 expected to be retried if configured for non idempotent requests")
+	}
+	if policy.ShouldRetry(http.MethodPost, http.StatusServiceUnavailable, nil) {
+		t.Error("POST with 503 expected not to be retried if only 504 is configured for non idempotent requests")
+	}
+	policy.NonIdempotent = true
+	if !policy.ShouldRetry(http.MethodPost, http.StatusServiceUnavailable, nil) {
+		t.Error("POST with 503 expected to be retried if non idempotent requests are allowed")
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := rest.RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	for attempt := 1; attempt < 10; attempt++ {
+		backoff := policy.Backoff(attempt)
+		if backoff > policy.MaxBackoff {
+			t.Errorf("attempt %d: backoff %s exceeds maximum of %s", attempt, backoff, policy.MaxBackoff)
+		}
+		if backoff < policy.MinBackoff/2 {
+			t.Errorf("attempt %d: backoff %s is below half of the minimum of %s", attempt, backoff, policy.MinBackoff)
+		}
+	}
+}
+
+func TestParseRetryOn(t *testing.T) {
+	statusCodes, errorClasses, err := rest.ParseRetryOn([]string{"503", " timeout ", "EOF"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statusCodes) != 1 || statusCodes[0] != 503 {
+		t.Errorf("unexpected status codes %v", statusCodes)
+	}
+	if len(errorClasses) != 2 {
+		t.Errorf("unexpected error classes %v", errorClasses)
+	}
+	if _, _, err := rest.ParseRetryOn([]string{"404"}); err == nil {
+		t.Error("404 expected to be rejected")
+	}
+	if _, _, err := rest.ParseRetryOn([]string{"dns"}); err == nil {
+		t.Error("unknown error class expected to be rejected")
+	}
+}
+
+func TestClientRetriesTransientFailures(t *testing.T) {
+	defer rest.SetRetryPolicy(rest.GetRetryPolicy())
+	rest.SetRetryPolicy(testPolicy())
+
+	server, calls := flakyServer(2, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	var result struct {
+		Value string `json:"value"`
+	}
+	client := rest.DefaultClient(server.URL, "token")
+	if err := client.Get(context.Background(), "/api/v2/something", 200).Finish(&result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Value != "ok" {
+		t.Errorf("unexpected result %v", result)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestClientGivesUpAfterMaxAttempts(t *testing.T) {
+	defer rest.SetRetryPolicy(rest.GetRetryPolicy())
+	rest.SetRetryPolicy(testPolicy())
+
+	server, calls := flakyServer(10, http.StatusBadGateway)
+	defer server.Close()
+
+	client := rest.DefaultClient(server.URL, "token")
+	if err := client.Get(context.Background(), "/api/v2/something", 200).Finish(); err == nil {
+		t.Error("expected the request to fail")
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestClientDoesNotRetryPost(t *testing.T) {
+	defer rest.SetRetryPolicy(rest.GetRetryPolicy())
+	rest.SetRetryPolicy(testPolicy())
+
+	server, calls := flakyServer(1, http.StatusBadGateway)
+	defer server.Close()
+
+	client := rest.DefaultClient(server.URL, "token")
+	if err := client.Post(context.Background(), "/api/v2/something", map[string]string{"name": "value"}, 200).Finish(); err == nil {
+		t.Error("expected the request to fail")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 call, got %d", calls.Load())
+	}
+}
+
+func TestClientRetriesPutWithPayload(t *testing.T) {
+	defer rest.SetRetryPolicy(rest.GetRetryPolicy())
+	rest.SetRetryPolicy(testPolicy())
+
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength <= 0 {
+			t.Errorf("attempt %d: request body missing", calls.Load()+1)
+		}
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := rest.DefaultClient(server.URL, "token")
+	if err := client.Put(context.Background(), "/api/v2/something", map[string]string{"name": "value"}, 204).Finish(); err != nil {
+		t.Fatal(err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 calls, got %d", calls.Load())
+	}
+}
+
+func TestClientAbortsAttemptsAtTimeout(t *testing.T) {
+	defer rest.SetRetryPolicy(rest.GetRetryPolicy())
+	policy := testPolicy()
+	policy.Timeout = 200 * time.Millisecond
+	rest.SetRetryPolicy(policy)
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	start := time.Now()
+	client := rest.DefaultClient(server.URL, "token")
+	if err := client.Get(context.Background(), "/api/v2/something", 200).Finish(); err == nil {
+		t.Error("expected the request to fail")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the hanging attempt to get aborted after the timeout, took %s", elapsed)
+	}
+}
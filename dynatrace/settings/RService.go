@@ -47,6 +47,11 @@ type ContextKey string
 
 const ContextKeyStateConfig = ContextKey("state-config")
 
+// ContextKeyValidateID carries the ID of an already existing object when
+// validating settings. Services supporting it validate the settings as an
+// update of that object instead of as a newly created one
+const ContextKeyValidateID = ContextKey("validate-id")
+
 type CRUDService[T Settings] interface {
 	List(ctx context.Context) (api.Stubs, error)
 	Get(ctx context.Context, id string, v T) error
@@ -65,6 +70,20 @@ type Validator[T Settings] interface {
 	Validate(ctx context.Context, v T) error
 }
 
+// PlanValidator is implemented by services whose Validate function doesn't
+// have any side effects on the remote side and is therefore safe to get
+// invoked while Terraform is calculating a plan
+type PlanValidator interface {
+	SupportsPlanValidation() bool
+}
+
+// RevisionLister is implemented by services which are able to tell the current
+// revision of all their configurations without having to download them.
+// The revision of a configuration is expected to change whenever it gets modified
+type RevisionLister interface {
+	ListRevisions(ctx context.Context) (map[string]string, error)
+}
+
 func NewSettings[T Settings](service RService[T]) T {
 	var proto T
 	return reflect.New(reflect.ValueOf(proto).Type().Elem()).Interface().(T)
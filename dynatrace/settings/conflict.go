@@ -0,0 +1,124 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ConflictPolicy defines how updates deal with objects that have been modified
+// on the remote side since Terraform has read them the last time
+type ConflictPolicy string
+
+var ConflictPolicies = struct {
+	Fail      ConflictPolicy
+	Overwrite ConflictPolicy
+}{
+	Fail:      ConflictPolicy("fail"),
+	Overwrite: ConflictPolicy("overwrite"),
+}
+
+// ConflictError signals that an update has been refused, because the object has been modified
+// on the remote side since Terraform has read it the last time
+type ConflictError struct {
+	SchemaID string   // the kind of object, e.g. `document:documents`
+	ID       string   // the ID of the object
+	Known    string   // the version or modification timestamp known to Terraform
+	Remote   string   // the current version or modification timestamp on the remote side
+	Changes  []string // the remote changes since Terraform has read the object the last time
+}
+
+func (me *ConflictError) Error() string {
+	return fmt.Sprintf("%s `%s` has been modified outside of Terraform (known version: %s, current version: %s)", me.SchemaID, me.ID, me.Known, me.Remote)
+}
+
+// Detail produces a human readable description of the remote changes and how to resolve the conflict
+func (me *ConflictError) Detail() string {
+	var sb strings.Builder
+	if len(me.Changes) > 0 {
+		sb.WriteString("Remote changes since the last refresh:\n\n")
+		for _, change := range me.Changes {
+			sb.WriteString("  ")
+			sb.WriteString(change)
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("Run `terraform apply -refresh-only` and incorporate the remote changes into your configuration, or set `on_conflict = \"overwrite\"` within the provider configuration in order to discard them.")
+	return sb.String()
+}
+
+// JSONChanges compares two JSON documents and returns one line per property which has been
+// added (`+`), removed (`-`) or modified (`~`), addressed by its path below `root`.
+// If one of the documents is not valid JSON, the comparison falls back to the documents as a whole
+func JSONChanges(root string, before []byte, after []byte) []string {
+	var vBefore, vAfter any
+	if json.Unmarshal(before, &vBefore) != nil || json.Unmarshal(after, &vAfter) != nil {
+		if string(before) == string(after) {
+			return []string{}
+		}
+		return []string{fmt.Sprintf("~ %s: %q => %q", root, string(before), string(after))}
+	}
+	mBefore := map[string]string{}
+	flattenJSON(root, vBefore, mBefore)
+	mAfter := map[string]string{}
+	flattenJSON(root, vAfter, mAfter)
+
+	changes := []string{}
+	for path, value := range mBefore {
+		if afterValue, found := mAfter[path]; !found {
+			changes = append(changes, fmt.Sprintf("- %s: %s", path, value))
+		} else if afterValue != value {
+			changes = append(changes, fmt.Sprintf("~ %s: %s => %s", path, value, afterValue))
+		}
+	}
+	for path, value := range mAfter {
+		if _, found := mBefore[path]; !found {
+			changes = append(changes, fmt.Sprintf("+ %s: %s", path, value))
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i][2:] < changes[j][2:]
+	})
+	return changes
+}
+
+func flattenJSON(path string, v any, m map[string]string) {
+	join := func(key string) string {
+		if len(path) == 0 {
+			return key
+		}
+		return path + "." + key
+	}
+	switch tv := v.(type) {
+	case map[string]any:
+		for key, elem := range tv {
+			flattenJSON(join(key), elem, m)
+		}
+	case []any:
+		for idx, elem := range tv {
+			flattenJSON(join(fmt.Sprintf("%d", idx)), elem, m)
+		}
+	default:
+		data, _ := json.Marshal(tv)
+		m[path] = string(data)
+	}
+}
@@ -47,4 +47,5 @@ type Credentials struct {
 		URL   string
 		Token string
 	}
+	OnConflict ConflictPolicy // how services supporting optimistic concurrency deal with concurrent modifications. Unless `ConflictPolicies.Overwrite` they fail
 }
@@ -34,6 +34,13 @@ func (me *GenericCRUDService[T]) NoCache() bool {
 	return false
 }
 
+func (me *GenericCRUDService[T]) SupportsPlanValidation() bool {
+	if pv, ok := me.Service.(PlanValidator); ok {
+		return pv.SupportsPlanValidation()
+	}
+	return false
+}
+
 func (me *GenericCRUDService[T]) List(ctx context.Context) (api.Stubs, error) {
 	return me.Service.List(ctx)
 }
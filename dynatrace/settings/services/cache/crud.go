@@ -218,6 +218,16 @@ func (me *crudService[T]) Update(ctx context.Context, id string, v T) error {
 	return me.tarFolder.Delete(id)
 }
 
+func (me *crudService[T]) SupportsPlanValidation() bool {
+	if mode == ModeOffline {
+		return false
+	}
+	if pv, ok := me.service.(settings.PlanValidator); ok {
+		return pv.SupportsPlanValidation()
+	}
+	return false
+}
+
 func (me *crudService[T]) Validate(ctx context.Context, v T) error {
 	me.mu.Lock()
 	defer me.mu.Unlock()
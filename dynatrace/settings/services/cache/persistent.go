@@ -0,0 +1,284 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+// ENV_VAR_PERSISTENT_CACHE_FOLDER enables the persistent cache. Unlike the cache maintained by `CRUD`
+// its contents survive the termination of the process and are getting re-used by subsequent runs
+const ENV_VAR_PERSISTENT_CACHE_FOLDER = "DYNATRACE_PERSISTENT_CACHE_FOLDER"
+
+// revisionsMaxAge defines for how long the revisions listed for a schema are trusted.
+// Afterwards they're getting listed again on the next request
+const revisionsMaxAge = time.Minute
+
+type persistentRecord struct {
+	ID       string          `json:"id"`
+	Name     string          `json:"name"`
+	Revision string          `json:"revision"`
+	Value    json.RawMessage `json:"value"`
+}
+
+type revisions struct {
+	mu        sync.Mutex
+	fetched   time.Time
+	revisions map[string]string
+}
+
+var persistentRevisions = map[string]*revisions{}
+var persistentRevisionsMutex sync.Mutex
+
+func getRevisions(folder string) *revisions {
+	persistentRevisionsMutex.Lock()
+	defer persistentRevisionsMutex.Unlock()
+	if revs, found := persistentRevisions[folder]; found {
+		return revs
+	}
+	revs := &revisions{}
+	persistentRevisions[folder] = revs
+	return revs
+}
+
+type persistentService[T settings.Settings] struct {
+	service   settings.CRUDService[T]
+	lister    settings.RevisionLister
+	folder    string
+	revisions *revisions
+}
+
+// Persistent decorates the given service with a cache that is stored on disk and shared by subsequent runs.
+// The cache is keyed by the given environment URL and the schema ID of the service.
+// A cached configuration is only getting used as long as its revision reported by the service hasn't
+// changed, i.e. only configurations modified in the meantime are getting downloaded again.
+// It is disabled unless the environment variable `DYNATRACE_PERSISTENT_CACHE_FOLDER` is set.
+// Services which aren't able to list the revisions of their configurations are returned as they are
+func Persistent[T settings.Settings](service settings.CRUDService[T], environmentURL string) settings.CRUDService[T] {
+	persistentCacheFolder := os.Getenv(ENV_VAR_PERSISTENT_CACHE_FOLDER)
+	if len(persistentCacheFolder) == 0 {
+		return service
+	}
+	// when running on local HTTP Cache the responses are already coming from disk
+	if len(os.Getenv("DYNATRACE_MIGRATION_CACHE_FOLDER")) > 0 {
+		return service
+	}
+	if ncs, ok := service.(settings.NoCacheService); ok && ncs.NoCache() {
+		return service
+	}
+	lister, ok := service.(settings.RevisionLister)
+	if !ok {
+		return service
+	}
+	folder := path.Join(persistentCacheFolder, tenantFolderName(environmentURL), strings.ReplaceAll(service.SchemaID(), ":", "."))
+	return &persistentService[T]{
+		service:   service,
+		lister:    lister,
+		folder:    folder,
+		revisions: getRevisions(folder),
+	}
+}
+
+// PersistentFolder returns the folder within the persistent cache dedicated to the given environment and purpose.
+// It returns an empty string if the persistent cache is disabled
+func PersistentFolder(environmentURL string, name string) string {
+	persistentCacheFolder := os.Getenv(ENV_VAR_PERSISTENT_CACHE_FOLDER)
+	if len(persistentCacheFolder) == 0 {
+		return ""
+	}
+	return path.Join(persistentCacheFolder, tenantFolderName(environmentURL), name)
+}
+
+var unsafeFolderChars = regexp.MustCompile(`[^a-zA-Z0-9.\-]+`)
+
+func tenantFolderName(environmentURL string) string {
+	environmentURL = strings.TrimSuffix(strings.TrimSpace(environmentURL), "/")
+	if u, err := url.Parse(environmentURL); err == nil && len(u.Host) > 0 {
+		return unsafeFolderChars.ReplaceAllString(u.Host+u.Path, "_")
+	}
+	return unsafeFolderChars.ReplaceAllString(environmentURL, "_")
+}
+
+func (me *persistentService[T]) fileName(id string) string {
+	hash := sha256.Sum256([]byte(id))
+	return path.Join(me.folder, hex.EncodeToString(hash[:])+".json")
+}
+
+// revision returns the current revision of the configuration with the given ID.
+// The revisions of all configurations are getting listed at most once per `revisionsMaxAge`
+func (me *persistentService[T]) revision(ctx context.Context, id string) (string, bool, error) {
+	me.revisions.mu.Lock()
+	defer me.revisions.mu.Unlock()
+
+	if me.revisions.revisions == nil || time.Since(me.revisions.fetched) > revisionsMaxAge {
+		revisions, err := me.lister.ListRevisions(ctx)
+		if err != nil {
+			return "", false, err
+		}
+		me.revisions.revisions = revisions
+		me.revisions.fetched = time.Now()
+	}
+	revision, found := me.revisions.revisions[id]
+	return revision, found, nil
+}
+
+// invalidate ensures that the revisions are getting listed again,
+// because modifying a configuration may also affect the revisions of others (ordering)
+func (me *persistentService[T]) invalidate(id string) {
+	me.revisions.mu.Lock()
+	me.revisions.revisions = nil
+	me.revisions.mu.Unlock()
+
+	if len(id) > 0 {
+		os.Remove(me.fileName(id))
+	}
+}
+
+func (me *persistentService[T]) load(id string, revision string, v T) bool {
+	data, err := os.ReadFile(me.fileName(id))
+	if err != nil {
+		return false
+	}
+	var record persistentRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return false
+	}
+	if record.ID != id || record.Revision != revision {
+		return false
+	}
+	if err := settings.FromJSON(record.Value, v); err != nil {
+		return false
+	}
+	if legacyIDAware, ok := me.service.(settings.LegacyIDAware); ok {
+		settings.SetLegacyID(id, legacyIDAware.LegacyID(), v)
+	}
+	return true
+}
+
+func (me *persistentService[T]) store(id string, revision string, v T) error {
+	value, err := settings.ToJSON(v)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(persistentRecord{ID: id, Name: settings.Name(v, id), Revision: revision, Value: value})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(me.folder, os.ModePerm); err != nil {
+		return err
+	}
+	// other processes may be using the same cache concurrently
+	// writing into a temporary file first ensures they never read half written records
+	file, err := os.CreateTemp(me.folder, ".record-*")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	return os.Rename(file.Name(), me.fileName(id))
+}
+
+func (me *persistentService[T]) Get(ctx context.Context, id string, v T) error {
+	revision, found, err := me.revision(ctx, id)
+	if err != nil || !found {
+		// without a revision the cache can't tell whether a record is stale
+		// if the configuration doesn't exist anymore the service reports that
+		if !found && err == nil {
+			os.Remove(me.fileName(id))
+		}
+		return me.service.Get(ctx, id, v)
+	}
+	if me.load(id, revision, v) {
+		return nil
+	}
+	if err := me.service.Get(ctx, id, v); err != nil {
+		return err
+	}
+	// the cache is an optimization only, failing to store a record doesn't make the request fail
+	me.store(id, revision, v)
+	return nil
+}
+
+func (me *persistentService[T]) List(ctx context.Context) (api.Stubs, error) {
+	return me.service.List(ctx)
+}
+
+func (me *persistentService[T]) Create(ctx context.Context, v T) (*api.Stub, error) {
+	stub, err := me.service.Create(ctx, v)
+	if stub != nil {
+		me.invalidate(stub.ID)
+	} else {
+		me.invalidate("")
+	}
+	return stub, err
+}
+
+func (me *persistentService[T]) Update(ctx context.Context, id string, v T) error {
+	defer me.invalidate(id)
+	return me.service.Update(ctx, id, v)
+}
+
+func (me *persistentService[T]) Delete(ctx context.Context, id string) error {
+	defer me.invalidate(id)
+	return me.service.Delete(ctx, id)
+}
+
+func (me *persistentService[T]) SupportsPlanValidation() bool {
+	if pv, ok := me.service.(settings.PlanValidator); ok {
+		return pv.SupportsPlanValidation()
+	}
+	return false
+}
+
+func (me *persistentService[T]) Validate(ctx context.Context, v T) error {
+	if validator, ok := me.service.(settings.Validator[T]); ok {
+		return validator.Validate(ctx, v)
+	}
+	return nil
+}
+
+func (me *persistentService[T]) LegacyID() func(id string) string {
+	if legacyIDAware, ok := me.service.(settings.LegacyIDAware); ok {
+		return legacyIDAware.LegacyID()
+	}
+	return nil
+}
+
+func (me *persistentService[T]) SchemaID() string {
+	return me.service.SchemaID()
+}
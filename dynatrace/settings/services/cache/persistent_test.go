@@ -0,0 +1,158 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/cache"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/assert"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type record struct {
+	Name string `json:"name"`
+}
+
+func (me *record) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{"name": {Type: schema.TypeString, Required: true}}
+}
+
+func (me *record) MarshalHCL(properties hcl.Properties) error {
+	return properties.Encode("name", me.Name)
+}
+
+func (me *record) UnmarshalHCL(decoder hcl.Decoder) error {
+	return decoder.Decode("name", &me.Name)
+}
+
+// revisionService keeps its records in memory and counts how often they are getting downloaded
+type revisionService struct {
+	records   map[string]*record
+	revisions map[string]string
+	gets      int
+	lists     int
+}
+
+func (me *revisionService) ListRevisions(ctx context.Context) (map[string]string, error) {
+	me.lists++
+	result := map[string]string{}
+	for id, revision := range me.revisions {
+		result[id] = revision
+	}
+	return result, nil
+}
+
+func (me *revisionService) List(ctx context.Context) (api.Stubs, error) {
+	stubs := api.Stubs{}
+	for id, record := range me.records {
+		stubs = append(stubs, &api.Stub{ID: id, Name: record.Name})
+	}
+	return stubs, nil
+}
+
+func (me *revisionService) Get(ctx context.Context, id string, v *record) error {
+	me.gets++
+	stored, found := me.records[id]
+	if !found {
+		return fmt.Errorf("%s not found", id)
+	}
+	*v = *stored
+	return nil
+}
+
+func (me *revisionService) Create(ctx context.Context, v *record) (*api.Stub, error) {
+	id := fmt.Sprintf("id-%d", len(me.records)+1)
+	me.records[id] = &record{Name: v.Name}
+	me.revisions[id] = "1"
+	return &api.Stub{ID: id, Name: v.Name}, nil
+}
+
+func (me *revisionService) Update(ctx context.Context, id string, v *record) error {
+	me.records[id] = &record{Name: v.Name}
+	me.revisions[id] = me.revisions[id] + "+"
+	return nil
+}
+
+func (me *revisionService) Delete(ctx context.Context, id string) error {
+	delete(me.records, id)
+	delete(me.revisions, id)
+	return nil
+}
+
+func (me *revisionService) SchemaID() string {
+	return "builtin:test.persistent"
+}
+
+func TestPersistentCache(t *testing.T) {
+	t.Setenv(cache.ENV_VAR_PERSISTENT_CACHE_FOLDER, t.TempDir())
+	assert := assert.New(t)
+
+	remote := &revisionService{
+		records:   map[string]*record{"a": {Name: "a"}, "b": {Name: "b"}},
+		revisions: map[string]string{"a": "1", "b": "1"},
+	}
+	// every run of the provider creates a new service
+	service := cache.Persistent(remote, "https://abc123.live.dynatrace.com/")
+
+	var v record
+	for _, id := range []string{"a", "b", "a"} {
+		if err := service.Get(context.Background(), id, &v); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equals(id, v.Name)
+	}
+	assert.Equals(2, remote.gets)
+	assert.Equals(1, remote.lists)
+
+	// a subsequent run only downloads what has changed in the meantime
+	remote.records["b"] = &record{Name: "b2"}
+	remote.revisions["b"] = "2"
+	service = cache.Persistent(remote, "https://abc123.live.dynatrace.com")
+	if err := service.Update(context.Background(), "a", &record{Name: "a2"}); err != nil {
+		t.Fatal(err)
+	}
+	remote.gets = 0
+	for id, name := range map[string]string{"a": "a2", "b": "b2"} {
+		if err := service.Get(context.Background(), id, &v); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equals(name, v.Name)
+	}
+	assert.Equals(2, remote.gets)
+
+	remote.gets = 0
+	for _, id := range []string{"a", "b"} {
+		if err := service.Get(context.Background(), id, &v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assert.Equals(0, remote.gets)
+
+	// deleted records aren't getting served from the cache
+	if err := service.Delete(context.Background(), "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Get(context.Background(), "b", &v); err == nil {
+		t.Error("expected deleted record to be reported as not found")
+	}
+}
@@ -64,6 +64,13 @@ func (me *FilterService[T]) Validate(ctx context.Context, v T) error {
 	return nil
 }
 
+func (me *FilterService[T]) SupportsPlanValidation() bool {
+	if pv, ok := me.Service.(settings.PlanValidator); ok {
+		return pv.SupportsPlanValidation()
+	}
+	return false
+}
+
 func (me *FilterService[T]) Get(ctx context.Context, id string, v T) error {
 	return me.Service.Get(ctx, id, v)
 }
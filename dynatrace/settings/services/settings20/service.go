@@ -20,6 +20,7 @@ package settings20
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"reflect"
@@ -278,6 +279,54 @@ func (me *service[T]) listIDs(ctx context.Context) ([]string, error) {
 	return ids, nil
 }
 
+// ListRevisions lists the current revision of all settings objects of the schema.
+// The revision is based on the modification time and the schema version of a settings object.
+// For schemas supporting ordering it additionally reflects the neighbours of a settings object,
+// because `insert_after` changes whenever another settings object gets moved around
+func (me *service[T]) ListRevisions(ctx context.Context) (map[string]string, error) {
+	proto := settings.NewSettings[T](me)
+	ordered := !DISABLE_ORDERING_SUPPORT && (settings.HasInsertAfter(proto) || settings.HasInsertBefore(proto))
+
+	items := []*SettingsObjectListItem{}
+	var nextPageKey *string
+	for {
+		var sol SettingsObjectList
+		var urlStr string
+		if nextPageKey != nil {
+			urlStr = fmt.Sprintf("/api/v2/settings/objects?nextPageKey=%s", url.QueryEscape(*nextPageKey))
+		} else {
+			urlStr = fmt.Sprintf("/api/v2/settings/objects?schemaIds=%s&fields=%s&pageSize=500", url.QueryEscape(me.SchemaID()), url.QueryEscape("objectId,modificationInfo,schemaVersion"))
+		}
+		if err := me.client.Get(ctx, urlStr, 200).Finish(&sol); err != nil {
+			return nil, err
+		}
+		if shutdown.System.Stopped() {
+			return nil, errors.New("shutdown in progress")
+		}
+		items = append(items, sol.Items...)
+		if nextPageKey = sol.NextPageKey; nextPageKey == nil {
+			break
+		}
+	}
+
+	revisions := map[string]string{}
+	for idx, item := range items {
+		revision := fmt.Sprintf("%d/%s", item.ModificationInfo.LastModifiedTime, item.SchemaVersion)
+		if ordered {
+			prevID, nextID := "", ""
+			if idx > 0 {
+				prevID = items[idx-1].ObjectID
+			}
+			if idx < len(items)-1 {
+				nextID = items[idx+1].ObjectID
+			}
+			revision = revision + "/" + prevID + "/" + nextID
+		}
+		revisions[item.ObjectID] = revision
+	}
+	return revisions, nil
+}
+
 func (me *service[T]) List(ctx context.Context) (api.Stubs, error) {
 	var err error
 
@@ -296,7 +345,7 @@ func (me *service[T]) List(ctx context.Context) (api.Stubs, error) {
 		if nextPageKey != nil {
 			urlStr = fmt.Sprintf("/api/v2/settings/objects?nextPageKey=%s", url.QueryEscape(*nextPageKey))
 		} else {
-			urlStr = fmt.Sprintf("/api/v2/settings/objects?schemaIds=%s&fields=%s&pageSize=100", url.QueryEscape(me.SchemaID()), url.QueryEscape("objectId,value,scope,schemaVersion"))
+			urlStr = fmt.Sprintf("/api/v2/settings/objects?schemaIds=%s&fields=%s&pageSize=100", url.QueryEscape(me.SchemaID()), url.QueryEscape("objectId,value,scope,schemaVersion,modificationInfo"))
 		}
 		req := me.client.Get(ctx, urlStr, 200)
 		if err = req.Finish(&sol); err != nil {
@@ -335,6 +384,10 @@ func (me *service[T]) List(ctx context.Context) (api.Stubs, error) {
 					itemName = settings.Name(newItem, item.ObjectID)
 				}
 				stub := &api.Stub{ID: item.ObjectID, Name: itemName, Value: newItem, LegacyID: settings.GetLegacyID(newItem)}
+				if item.ModificationInfo.LastModifiedTime > 0 {
+					lastModified := time.UnixMilli(item.ModificationInfo.LastModifiedTime)
+					stub.LastModified = &lastModified
+				}
 				if len(itemName) > 0 {
 					stubs = append(stubs, stub)
 				}
@@ -347,8 +400,31 @@ func (me *service[T]) List(ctx context.Context) (api.Stubs, error) {
 	return stubs, nil
 }
 
-func (me *service[T]) Validate(v T) error {
-	return nil // Settings 2.0 doesn't offer validation
+// Validate sends the given settings to the Settings 2.0 API with `validateOnly=true`.
+// Nothing gets persisted on the remote side, but any constraint violations are reported
+// the same way as they would be for an actual create or update.
+// If the context contains an object ID (`settings.ContextKeyValidateID`) the settings are
+// validated as an update of that object, otherwise as a newly created object.
+func (me *service[T]) Validate(ctx context.Context, v T) error {
+	query := "validateOnly=true"
+	if !me.skipRepairInput() {
+		query = query + "&repairInput=true"
+	}
+	if id, ok := ctx.Value(settings.ContextKeyValidateID).(string); ok && len(id) > 0 {
+		sou := SettingsObjectUpdate{Value: v, SchemaVersion: me.schemaVersion}
+		return me.client.Put(ctx, fmt.Sprintf("/api/v2/settings/objects/%s?%s", url.PathEscape(id), query), &sou, 200, 204).Finish()
+	}
+	soc := SettingsObjectCreate{
+		SchemaID:      me.schemaID,
+		SchemaVersion: me.schemaVersion,
+		Scope:         settings.GetScope(v),
+		Value:         v,
+	}
+	return me.client.Post(ctx, "/api/v2/settings/objects?"+query, []SettingsObjectCreate{soc}, 200, 204).Finish()
+}
+
+func (me *service[T]) SupportsPlanValidation() bool {
+	return true
 }
 
 func (me *service[T]) Create(ctx context.Context, v T) (*api.Stub, error) {
@@ -0,0 +1,131 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package settings20_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/settings20"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type testSettings struct {
+	Name string `json:"name"`
+}
+
+func (me *testSettings) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{"name": {Type: schema.TypeString, Required: true}}
+}
+
+func (me *testSettings) MarshalHCL(properties hcl.Properties) error {
+	return properties.Encode("name", me.Name)
+}
+
+func (me *testSettings) UnmarshalHCL(decoder hcl.Decoder) error {
+	return decoder.Decode("name", &me.Name)
+}
+
+type recordedRequest struct {
+	Method       string
+	Path         string
+	ValidateOnly string
+	Body         any
+}
+
+func newServer(t *testing.T, status int, response string) (*httptest.Server, *[]recordedRequest) {
+	t.Helper()
+	requests := []recordedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body any
+		json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, recordedRequest{Method: r.Method, Path: r.URL.EscapedPath(), ValidateOnly: r.URL.Query().Get("validateOnly"), Body: body})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestValidate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("create", func(t *testing.T) {
+		server, requests := newServer(t, 200, `[{"code":200,"objectId":""}]`)
+		service := settings20.Service[*testSettings](&settings.Credentials{URL: server.URL, Token: "token"}, "builtin:test", "1.0")
+		if err := service.(settings.Validator[*testSettings]).Validate(ctx, &testSettings{Name: "a"}); err != nil {
+			t.Fatalf("expected no error, got %s", err.Error())
+		}
+		if len(*requests) != 1 {
+			t.Fatalf("expected exactly one request, got %d", len(*requests))
+		}
+		request := (*requests)[0]
+		if request.Method != http.MethodPost || request.Path != "/api/v2/settings/objects" || request.ValidateOnly != "true" {
+			t.Errorf("expected a validateOnly POST to the settings objects, got %s %s (validateOnly=%s)", request.Method, request.Path, request.ValidateOnly)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		server, requests := newServer(t, 200, `{"code":200}`)
+		service := settings20.Service[*testSettings](&settings.Credentials{URL: server.URL, Token: "token"}, "builtin:test", "1.0")
+		validateCtx := context.WithValue(ctx, settings.ContextKeyValidateID, "vu9U3hXa3q0AAAABABRidWlsdGluOnRlc3Q")
+		if err := service.(settings.Validator[*testSettings]).Validate(validateCtx, &testSettings{Name: "a"}); err != nil {
+			t.Fatalf("expected no error, got %s", err.Error())
+		}
+		if len(*requests) != 1 {
+			t.Fatalf("expected exactly one request, got %d", len(*requests))
+		}
+		request := (*requests)[0]
+		if request.Method != http.MethodPut || request.Path != "/api/v2/settings/objects/vu9U3hXa3q0AAAABABRidWlsdGluOnRlc3Q" || request.ValidateOnly != "true" {
+			t.Errorf("expected a validateOnly PUT to the settings object, got %s %s (validateOnly=%s)", request.Method, request.Path, request.ValidateOnly)
+		}
+	})
+
+	t.Run("violations", func(t *testing.T) {
+		server, _ := newServer(t, 400, `[{"code":400,"error":{"code":400,"message":"Validation failed","constraintViolations":[{"path":"name","message":"Name must be unique","parameterLocation":"PAYLOAD_BODY"}]}}]`)
+		service := settings20.Service[*testSettings](&settings.Credentials{URL: server.URL, Token: "token"}, "builtin:test", "1.0")
+		err := service.(settings.Validator[*testSettings]).Validate(ctx, &testSettings{Name: "a"})
+		if err == nil {
+			t.Fatal("expected the constraint violations to get reported")
+		}
+		violations := rest.ConstraintViolations(err)
+		if len(violations) != 1 || violations[0].Message != "Name must be unique" {
+			t.Errorf("expected the constraint violation `Name must be unique`, got %v", err)
+		}
+	})
+
+	t.Run("transport failure", func(t *testing.T) {
+		server, _ := newServer(t, 200, `{}`)
+		server.Close()
+		service := settings20.Service[*testSettings](&settings.Credentials{URL: server.URL, Token: "token"}, "builtin:test", "1.0")
+		err := service.(settings.Validator[*testSettings]).Validate(ctx, &testSettings{Name: "a"})
+		if err == nil {
+			t.Fatal("expected the transport failure to get reported")
+		}
+		if len(rest.ConstraintViolations(err)) > 0 {
+			t.Errorf("expected no constraint violations for a transport failure, got %v", err)
+		}
+	})
+}
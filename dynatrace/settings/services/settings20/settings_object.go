@@ -40,7 +40,8 @@ type SettingsObjectListItem struct {
 	SchemaVersion    string `json:"schemaVersion"`
 	SchemaID         string `json:"schemaId"`
 	ModificationInfo struct {
-		Deleteable bool `json:"deletable"`
+		Deleteable       bool  `json:"deletable"`
+		LastModifiedTime int64 `json:"lastModifiedTime"`
 	} `json:"modificationInfo"`
 	Value json.RawMessage `json:"value"`
 }
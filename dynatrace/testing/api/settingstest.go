@@ -19,6 +19,7 @@ package api
 
 import (
 	"context"
+	"crypto/sha256"
 	"io/fs"
 	"os"
 	"path"
@@ -28,7 +29,9 @@ import (
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/assert"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/testbase"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -68,7 +71,11 @@ func (st SettingsTest[V]) Run(createService func(*settings.Credentials) settings
 	st.T.Helper()
 	envURL := os.Getenv("DYNATRACE_ENV_URL")
 	apiToken := os.Getenv("DYNATRACE_API_TOKEN")
-	if envURL == "" || apiToken == "" {
+	credentials := &settings.Credentials{URL: envURL, Token: apiToken}
+	server := mockserver.Start(st.T)
+	if server != nil {
+		credentials = server.Credentials(mockserver.UpstreamCredentials())
+	} else if envURL == "" || apiToken == "" {
 		st.T.Skip("Environment Variables DYNATRACE_ENV_URL and DYNATRACE_API_TOKEN must be specified")
 		return
 	}
@@ -80,9 +87,13 @@ func (st SettingsTest[V]) Run(createService func(*settings.Credentials) settings
 
 			folder := path.Join("testdata", entry.Name())
 
-			service := createService(&settings.Credentials{URL: envURL, Token: apiToken})
+			service := createService(credentials)
 
 			randomize := uuid.NewString()
+			if server != nil {
+				// recorded interactions can only be replayed if the payloads are identical
+				randomize = uuid.NewSHA1(uuid.NameSpaceOID, []byte(st.T.Name()+"/"+entry.Name())).String()
+			}
 
 			var entries []fs.DirEntry
 			if entries, err = os.ReadDir(folder); err != nil {
@@ -107,7 +118,7 @@ func (st SettingsTest[V]) Run(createService func(*settings.Credentials) settings
 				st.T.Run(entry.Name(), func(t *testing.T) {
 					t.Helper()
 					assert := assert.New(t)
-					service := createService(&settings.Credentials{URL: envURL, Token: apiToken})
+					service := createService(credentials)
 
 					var err error
 					var stub *api.Stub
@@ -207,10 +218,16 @@ func TestAcc(t *testing.T, opts ...TestAccOptions) {
 		options = opts[0]
 	}
 	t.Helper()
-	if v := os.Getenv("TF_ACC"); v == "" {
+	// without TF_ACC the configurations get applied in-process against the mock server
+	offline := len(os.Getenv("TF_ACC")) == 0
+	if offline && !mockserver.Enabled() {
 		t.Skip("TF_ACC has not been set for acceptance tests")
 		return
 	}
+	server := mockserver.Start(t)
+	if server != nil {
+		server.Setenv(t)
+	}
 	if v := os.Getenv("DYNATRACE_ENV_URL"); v == "" {
 		t.Skip("DYNATRACE_ENV_URL has not been set for acceptance tests")
 		return
@@ -251,8 +268,16 @@ func TestAcc(t *testing.T, opts ...TestAccOptions) {
 					}
 					config := string(content)
 					name := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+					if server != nil {
+						// recorded interactions can only be replayed if the configuration is identical
+						name = deterministicName(t.Name(), 10)
+					}
 					config = strings.ReplaceAll(config, "#name#", name)
 					config = strings.ReplaceAll(config, "${randomize}", name)
+					if offline {
+						testbase.ApplyOffline(t, config, options.ExpectNonEmptyPlan)
+						return
+					}
 					provider := provider.Provider()
 					testCase := &resource.TestCase{
 						ProviderFactories: map[string]func() (*schema.Provider, error){
@@ -268,3 +293,13 @@ func TestAcc(t *testing.T, opts ...TestAccOptions) {
 		}
 	}
 }
+
+// deterministicName produces a name consisting of lowercase letters, which stays the same for every run of a test
+func deterministicName(seed string, length int) string {
+	hash := sha256.Sum256([]byte(seed))
+	name := make([]byte, length)
+	for idx := range name {
+		name[idx] = 'a' + hash[idx%len(hash)]%26
+	}
+	return string(name)
+}
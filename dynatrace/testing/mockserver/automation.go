@@ -0,0 +1,145 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package mockserver
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+const automationPath = "/platform/automation/v1"
+
+// automationStore emulates workflows, business calendars and scheduling rules of the Automation API
+type automationStore struct {
+	kinds   map[string]*collection
+	counter int
+}
+
+func newAutomationStore() *automationStore {
+	return &automationStore{kinds: map[string]*collection{}}
+}
+
+func (me *automationStore) serve(server *Server, w http.ResponseWriter, r *http.Request) {
+	segments := pathSegments(r, automationPath)
+	if len(segments) == 0 {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	c, found := me.kinds[segments[0]]
+	if !found {
+		c = &collection{order: []string{}, records: map[string]map[string]any{}}
+		me.kinds[segments[0]] = c
+	}
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		query := r.URL.Query()
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, err := strconv.Atoi(query.Get("limit"))
+		if err != nil || limit < 1 {
+			limit = len(c.order)
+		}
+		results := []map[string]any{}
+		for idx := offset; idx < len(c.order) && idx < offset+limit; idx++ {
+			results = append(results, c.records[c.order[idx]])
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"count": len(c.order), "results": results})
+	case len(segments) == 1 && r.Method == http.MethodPost:
+		var record map[string]any
+		if err := readJSON(r, &record); err != nil || record == nil {
+			writeError(w, http.StatusBadRequest, "Invalid payload")
+			return
+		}
+		me.counter++
+		id := uuid.NewSHA1(uuid.NameSpaceOID, []byte(segments[0]+"/"+strconv.Itoa(me.counter))).String()
+		record["id"] = id
+		me.stamp(server, record, true)
+		c.records[id] = record
+		c.order = append(c.order, id)
+		writeJSON(w, http.StatusCreated, record)
+	case len(segments) == 2 && r.Method == http.MethodGet:
+		if record, found := c.records[segments[1]]; found {
+			writeJSON(w, http.StatusOK, record)
+		} else {
+			writeError(w, http.StatusNotFound, "No "+segments[0]+" matches the given query.")
+		}
+	case len(segments) == 2 && (r.Method == http.MethodPut || r.Method == http.MethodPatch):
+		existing, found := c.records[segments[1]]
+		if !found {
+			writeError(w, http.StatusNotFound, "No "+segments[0]+" matches the given query.")
+			return
+		}
+		var record map[string]any
+		if err := readJSON(r, &record); err != nil || record == nil {
+			writeError(w, http.StatusBadRequest, "Invalid payload")
+			return
+		}
+		if r.Method == http.MethodPatch {
+			for k, v := range record {
+				existing[k] = v
+			}
+			record = existing
+		}
+		record["id"] = segments[1]
+		for _, key := range []string{"version", "owner", "ownerType", "modificationInfo"} {
+			// an explicitly specified owner is getting honored
+			if key == "owner" && record[key] != nil {
+				continue
+			}
+			record[key] = existing[key]
+		}
+		me.stamp(server, record, false)
+		c.records[segments[1]] = record
+		writeJSON(w, http.StatusOK, record)
+	case len(segments) == 2 && r.Method == http.MethodDelete:
+		if _, found := c.records[segments[1]]; !found {
+			writeError(w, http.StatusNotFound, "No "+segments[0]+" matches the given query.")
+			return
+		}
+		c.remove(segments[1])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// stamp maintains the read-only properties the Automation API is adding to every record
+func (me *automationStore) stamp(server *Server, record map[string]any, created bool) {
+	now := timestamp(server.now())
+	if created {
+		record["version"] = 0
+		if record["owner"] == nil {
+			record["owner"] = mockUser
+		}
+		record["ownerType"] = "USER"
+		record["modificationInfo"] = map[string]any{"createdBy": mockUser, "createdTime": now}
+	}
+	if version, ok := record["version"].(float64); ok {
+		record["version"] = int(version) + 1
+	} else if version, ok := record["version"].(int); ok {
+		record["version"] = version + 1
+	}
+	modificationInfo, _ := record["modificationInfo"].(map[string]any)
+	if modificationInfo == nil {
+		modificationInfo = map[string]any{"createdBy": mockUser, "createdTime": now}
+	}
+	modificationInfo["lastModifiedBy"] = mockUser
+	modificationInfo["lastModifiedTime"] = now
+	record["modificationInfo"] = modificationInfo
+}
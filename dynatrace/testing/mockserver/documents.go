@@ -0,0 +1,411 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package mockserver
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const documentsPath = "/platform/document/v1/documents"
+const trashPath = "/platform/document/v1/trash/documents"
+
+const defaultDocumentsPageSize = 20
+
+type document struct {
+	ID         string
+	ExternalID string
+	Name       string
+	Type       string
+	IsPrivate  bool
+	Version    int
+	Content    []byte
+	Created    int64
+	Modified   int64
+	Snapshots  []*snapshot
+}
+
+// snapshot is a previous state of a document. The Document API keeps one whenever a document gets modified
+type snapshot struct {
+	Version         int
+	DocumentVersion int
+	Name            string
+	IsPrivate       bool
+	Content         []byte
+	Created         int64
+}
+
+func (me *snapshot) metadata() map[string]any {
+	return map[string]any{
+		"snapshotVersion": me.Version,
+		"documentVersion": me.DocumentVersion,
+		"description":     "",
+		"modificationInfo": map[string]any{
+			"createdBy":   mockUser,
+			"createdTime": timestamp(me.Created),
+		},
+	}
+}
+
+// snapshot preserves the current state of the document
+func (me *document) snapshot(server *Server) {
+	me.Snapshots = append(me.Snapshots, &snapshot{
+		Version:         len(me.Snapshots) + 1,
+		DocumentVersion: me.Version,
+		Name:            me.Name,
+		IsPrivate:       me.IsPrivate,
+		Content:         me.Content,
+		Created:         server.now(),
+	})
+}
+
+func timestamp(millis int64) string {
+	return time.UnixMilli(millis).UTC().Format(time.RFC3339Nano)
+}
+
+func (me *document) metadata() map[string]any {
+	return map[string]any{
+		"id":         me.ID,
+		"externalId": me.ExternalID,
+		"name":       me.Name,
+		"type":       me.Type,
+		"version":    me.Version,
+		"owner":      mockUser,
+		"actor":      mockUser,
+		"isPrivate":  me.IsPrivate,
+		"modificationInfo": map[string]any{
+			"createdBy":        mockUser,
+			"createdTime":      timestamp(me.Created),
+			"lastModifiedBy":   mockUser,
+			"lastModifiedTime": timestamp(me.Modified),
+		},
+	}
+}
+
+// mockUser is the user all documents are owned by
+const mockUser = "00000000-0000-0000-0000-000000000000"
+
+// documentStore emulates the Document API including its trash bin
+type documentStore struct {
+	documents []*document
+	trash     []*document
+	counter   int
+}
+
+func newDocumentStore() *documentStore {
+	return &documentStore{documents: []*document{}, trash: []*document{}}
+}
+
+func find(documents []*document, id string) int {
+	for idx, document := range documents {
+		if document.ID == id {
+			return idx
+		}
+	}
+	return -1
+}
+
+func (me *documentStore) serve(server *Server, w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, trashPath) {
+		me.serveTrash(w, r)
+		return
+	}
+	segments := pathSegments(r, documentsPath)
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		me.list(w, r)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		me.create(server, w, r)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		me.get(w, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodPatch:
+		me.patch(server, w, r, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		me.delete(w, r, segments[0])
+	case len(segments) == 2 && segments[1] == "snapshots" && r.Method == http.MethodGet:
+		me.listSnapshots(w, segments[0])
+	case len(segments) == 3 && segments[1] == "snapshots" && strings.HasSuffix(segments[2], ":restore") && r.Method == http.MethodPost:
+		me.restoreSnapshot(server, w, r, segments[0], strings.TrimSuffix(segments[2], ":restore"))
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func notFound(w http.ResponseWriter, id string) {
+	writeError(w, http.StatusNotFound, "Document "+id+" not found")
+}
+
+func (me *documentStore) create(server *Server, w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	content, err := formFile(r, "content")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(r.FormValue("name")) == 0 || len(r.FormValue("type")) == 0 {
+		writeError(w, http.StatusBadRequest, "name and type are required")
+		return
+	}
+	me.counter++
+	created := server.now()
+	document := &document{
+		ID:         uuid.NewSHA1(uuid.NameSpaceOID, []byte("document/"+strconv.Itoa(me.counter))).String(),
+		ExternalID: r.FormValue("externalId"),
+		Name:       r.FormValue("name"),
+		Type:       r.FormValue("type"),
+		IsPrivate:  r.FormValue("isPrivate") != "false",
+		Version:    1,
+		Content:    content,
+		Created:    created,
+		Modified:   created,
+	}
+	me.documents = append(me.documents, document)
+	writeJSON(w, http.StatusCreated, document.metadata())
+}
+
+func formFile(r *http.Request, name string) ([]byte, error) {
+	file, _, err := r.FormFile(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+func (me *documentStore) get(w http.ResponseWriter, id string) {
+	idx := find(me.documents, id)
+	if idx == -1 {
+		notFound(w, id)
+		return
+	}
+	document := me.documents[idx]
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	metadata, _ := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Disposition": {`form-data; name="metadata"`},
+		"Content-Type":        {"application/json"},
+	})
+	writeTo(metadata, document.metadata())
+	content, _ := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Disposition": {`form-data; name="content"; filename="` + document.Name + `"`},
+		"Content-Type":        {"application/json"},
+	})
+	content.Write(document.Content)
+	writer.Close()
+
+	w.Header().Set("Content-Type", "multipart/form-data; boundary="+writer.Boundary())
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+}
+
+func (me *documentStore) checkVersion(w http.ResponseWriter, r *http.Request, document *document) bool {
+	version, err := strconv.Atoi(r.URL.Query().Get("optimistic-locking-version"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "optimistic-locking-version is required")
+		return false
+	}
+	if version != document.Version {
+		writeError(w, http.StatusConflict, "Document "+document.ID+" has been modified in the meantime. Current version is "+strconv.Itoa(document.Version))
+		return false
+	}
+	return true
+}
+
+func (me *documentStore) patch(server *Server, w http.ResponseWriter, r *http.Request, id string) {
+	idx := find(me.documents, id)
+	if idx == -1 {
+		notFound(w, id)
+		return
+	}
+	document := me.documents[idx]
+	if !me.checkVersion(w, r, document) {
+		return
+	}
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	document.snapshot(server)
+	if content, err := formFile(r, "content"); err == nil {
+		document.Content = content
+	}
+	if name := r.FormValue("name"); len(name) > 0 {
+		document.Name = name
+	}
+	if documentType := r.FormValue("type"); len(documentType) > 0 {
+		document.Type = documentType
+	}
+	if isPrivate := r.FormValue("isPrivate"); len(isPrivate) > 0 {
+		document.IsPrivate = isPrivate != "false"
+	}
+	document.Version++
+	document.Modified = server.now()
+	writeJSON(w, http.StatusOK, map[string]any{"documentMetadata": document.metadata()})
+}
+
+func (me *documentStore) listSnapshots(w http.ResponseWriter, id string) {
+	idx := find(me.documents, id)
+	if idx == -1 {
+		notFound(w, id)
+		return
+	}
+	snapshots := []map[string]any{}
+	for _, snapshot := range me.documents[idx].Snapshots {
+		snapshots = append(snapshots, snapshot.metadata())
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"totalCount": len(snapshots), "snapshots": snapshots, "nextPageKey": nil})
+}
+
+func (me *documentStore) restoreSnapshot(server *Server, w http.ResponseWriter, r *http.Request, id string, snapshotVersion string) {
+	idx := find(me.documents, id)
+	if idx == -1 {
+		notFound(w, id)
+		return
+	}
+	document := me.documents[idx]
+	if !me.checkVersion(w, r, document) {
+		return
+	}
+	version, _ := strconv.Atoi(snapshotVersion)
+	if version < 1 || version > len(document.Snapshots) {
+		writeError(w, http.StatusNotFound, "Snapshot "+snapshotVersion+" of document "+id+" not found")
+		return
+	}
+	restored := document.Snapshots[version-1]
+	document.snapshot(server)
+	document.Name = restored.Name
+	document.IsPrivate = restored.IsPrivate
+	document.Content = restored.Content
+	document.Version++
+	document.Modified = server.now()
+	writeJSON(w, http.StatusOK, map[string]any{"documentMetadata": document.metadata()})
+}
+
+func (me *documentStore) delete(w http.ResponseWriter, r *http.Request, id string) {
+	idx := find(me.documents, id)
+	if idx == -1 {
+		notFound(w, id)
+		return
+	}
+	document := me.documents[idx]
+	if !me.checkVersion(w, r, document) {
+		return
+	}
+	me.documents = append(me.documents[:idx], me.documents[idx+1:]...)
+	me.trash = append(me.trash, document)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serveTrash handles restoring documents from and removing documents from the trash bin
+func (me *documentStore) serveTrash(w http.ResponseWriter, r *http.Request) {
+	segments := pathSegments(r, trashPath)
+	if len(segments) == 0 {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	idx := find(me.trash, segments[0])
+	if idx == -1 {
+		notFound(w, segments[0])
+		return
+	}
+	document := me.trash[idx]
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, document.metadata())
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		me.trash = append(me.trash[:idx], me.trash[idx+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	case len(segments) == 2 && segments[1] == "restore" && r.Method == http.MethodPost:
+		me.trash = append(me.trash[:idx], me.trash[idx+1:]...)
+		me.documents = append(me.documents, document)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// filterCondition matches a single condition like `name == 'foo'` within the `filter` parameter
+var filterCondition = regexp.MustCompile(`^\s*(\w+)\s*(==|!=)\s*'([^']*)'\s*$`)
+
+// matches supports filters consisting of (in)equality conditions combined with `and`
+func (me *document) matches(filter string) bool {
+	if len(strings.TrimSpace(filter)) == 0 {
+		return true
+	}
+	for _, condition := range strings.Split(filter, " and ") {
+		match := filterCondition.FindStringSubmatch(condition)
+		if match == nil {
+			continue
+		}
+		var actual string
+		switch match[1] {
+		case "id":
+			actual = me.ID
+		case "name":
+			actual = me.Name
+		case "type":
+			actual = me.Type
+		case "externalId":
+			actual = me.ExternalID
+		default:
+			continue
+		}
+		if (actual == match[3]) != (match[2] == "==") {
+			return false
+		}
+	}
+	return true
+}
+
+func (me *documentStore) list(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	pageSize := defaultDocumentsPageSize
+	if s := query.Get("page-size"); len(s) > 0 {
+		pageSize, _ = strconv.Atoi(s)
+	}
+	offset, _ := strconv.Atoi(query.Get("page-key"))
+	matching := []*document{}
+	for _, document := range me.documents {
+		if document.matches(query.Get("filter")) {
+			matching = append(matching, document)
+		}
+	}
+	documents := []map[string]any{}
+	for idx := offset; idx < len(matching) && idx < offset+pageSize; idx++ {
+		documents = append(documents, matching[idx].metadata())
+	}
+	// `nextPageKey` needs to be present on the last page as well, with value `null`
+	response := map[string]any{"totalCount": len(matching), "documents": documents, "nextPageKey": nil}
+	if offset+pageSize < len(matching) {
+		response["nextPageKey"] = strconv.Itoa(offset + pageSize)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
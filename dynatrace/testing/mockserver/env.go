@@ -0,0 +1,114 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package mockserver
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+// ENV_VAR_MOCK_SERVER controls whether tests are running against the mock server instead of a real environment.
+//   - `true` emulates the environment, unless a recording for the test exists, which is getting replayed then
+//   - `record` forwards all requests to the environment configured via `DYNATRACE_ENV_URL` and records them
+const ENV_VAR_MOCK_SERVER = "DYNATRACE_MOCK_SERVER"
+
+const ModeRecord = "record"
+
+// Enabled returns true if tests are supposed to run against the mock server
+func Enabled() bool {
+	mode := strings.TrimSpace(os.Getenv(ENV_VAR_MOCK_SERVER))
+	return mode == "true" || mode == ModeRecord
+}
+
+// Recording returns true if tests are supposed to record their interactions with a real environment
+func Recording() bool {
+	return strings.TrimSpace(os.Getenv(ENV_VAR_MOCK_SERVER)) == ModeRecord
+}
+
+// RecordingFile is the file the interactions of the given test are getting recorded to.
+// It resides in the `testdata` folder of the package, but outside of the folders containing test cases.
+func RecordingFile(t testing.TB) string {
+	return filepath.Join("testdata", strings.ReplaceAll(t.Name(), "/", "_")+".recording.json")
+}
+
+func getenv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); len(value) > 0 {
+			return value
+		}
+	}
+	return ""
+}
+
+// UpstreamCredentials are the credentials for the real environment configured via environment variables
+func UpstreamCredentials() *settings.Credentials {
+	credentials := &settings.Credentials{
+		URL:   getenv("DYNATRACE_ENV_URL", "DT_ENV_URL"),
+		Token: getenv("DYNATRACE_API_TOKEN", "DT_API_TOKEN"),
+	}
+	credentials.Automation.ClientID = getenv("AUTOMATION_CLIENT_ID", "DT_CLIENT_ID", "DYNATRACE_CLIENT_ID")
+	credentials.Automation.ClientSecret = getenv("AUTOMATION_CLIENT_SECRET", "DT_CLIENT_SECRET", "DYNATRACE_CLIENT_SECRET")
+	credentials.Automation.TokenURL = getenv("AUTOMATION_TOKEN_URL", "DT_AUTOMATION_TOKEN_URL", "DYNATRACE_AUTOMATION_TOKEN_URL")
+	credentials.Automation.EnvironmentURL = getenv("AUTOMATION_ENVIRONMENT_URL", "DT_AUTOMATION_ENVIRONMENT_URL", "DYNATRACE_AUTOMATION_ENVIRONMENT_URL")
+	return credentials
+}
+
+// Start launches a mock server for the given test if `DYNATRACE_MOCK_SERVER` asks for it.
+// It returns nil if the test is supposed to run against a real environment.
+// The server is getting shut down (and the recording stored) once the test has finished.
+func Start(t testing.TB) *Server {
+	t.Helper()
+	if !Enabled() {
+		return nil
+	}
+	options := Options{Recording: RecordingFile(t)}
+	if Recording() {
+		upstream := UpstreamCredentials()
+		if len(upstream.URL) == 0 || len(upstream.Token) == 0 {
+			t.Fatalf("%s=%s requires DYNATRACE_ENV_URL and DYNATRACE_API_TOKEN to be specified", ENV_VAR_MOCK_SERVER, ModeRecord)
+		}
+		options.Upstream = upstream.URL
+		options.PlatformUpstream = upstream.Automation.EnvironmentURL
+	}
+	server, err := New(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := server.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	return server
+}
+
+// Setenv points the provider configuration to the server for the duration of the given test
+func (me *Server) Setenv(t testing.TB) {
+	t.Helper()
+	credentials := me.Credentials(UpstreamCredentials())
+	t.Setenv("DYNATRACE_ENV_URL", credentials.URL)
+	t.Setenv("DYNATRACE_API_TOKEN", credentials.Token)
+	t.Setenv("AUTOMATION_CLIENT_ID", credentials.Automation.ClientID)
+	t.Setenv("AUTOMATION_CLIENT_SECRET", credentials.Automation.ClientSecret)
+	t.Setenv("AUTOMATION_TOKEN_URL", credentials.Automation.TokenURL)
+	t.Setenv("AUTOMATION_ENVIRONMENT_URL", credentials.Automation.EnvironmentURL)
+}
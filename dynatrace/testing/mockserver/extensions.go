@@ -0,0 +1,280 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package mockserver
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+const extensionsPath = "/api/v2/extensions"
+
+// extension holds the uploaded versions of an extension, its environment configuration and monitoring configurations
+type extension struct {
+	Name                     string
+	Versions                 []string
+	ActiveVersion            string
+	MonitoringConfigurations []*monitoringConfiguration
+}
+
+type monitoringConfiguration struct {
+	ObjectID string
+	Scope    string
+	Value    map[string]any
+}
+
+// extensionStore emulates the Extensions 2.0 API. Archives are expected to contain `extension.yaml`,
+// either directly or within `extension.zip`. Signatures are not getting verified
+type extensionStore struct {
+	extensions map[string]*extension
+	counter    int
+}
+
+func newExtensionStore() *extensionStore {
+	return &extensionStore{extensions: map[string]*extension{}}
+}
+
+func (me *extensionStore) serve(w http.ResponseWriter, r *http.Request) {
+	segments := pathSegments(r, extensionsPath)
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		me.upload(w, r)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		me.list(w, segments[0])
+	case len(segments) == 2 && segments[1] == "environmentConfiguration":
+		me.serveEnvironmentConfiguration(w, r, segments[0])
+	case len(segments) == 2 && segments[1] == "monitoringConfigurations":
+		me.serveMonitoringConfigurations(w, r, segments[0])
+	case len(segments) == 2 && r.Method == http.MethodGet:
+		me.get(w, segments[0], segments[1])
+	case len(segments) == 2 && r.Method == http.MethodDelete:
+		me.delete(w, segments[0], segments[1])
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (me *extensionStore) upload(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	archive, err := formFile(r, "file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var manifest struct {
+		Name    string `yaml:"name"`
+		Version string `yaml:"version"`
+	}
+	data, err := extensionManifest(archive)
+	if err == nil {
+		err = yaml.Unmarshal(data, &manifest)
+	}
+	if err != nil || len(manifest.Name) == 0 || len(manifest.Version) == 0 {
+		writeError(w, http.StatusBadRequest, "Extension archive is invalid")
+		return
+	}
+	ext, found := me.extensions[manifest.Name]
+	if !found {
+		ext = &extension{Name: manifest.Name}
+		me.extensions[manifest.Name] = ext
+	}
+	if contains(ext.Versions, manifest.Version) {
+		writeError(w, http.StatusBadRequest, "Extension "+manifest.Name+" in version "+manifest.Version+" already exists")
+		return
+	}
+	ext.Versions = append(ext.Versions, manifest.Version)
+	writeJSON(w, http.StatusOK, map[string]any{"extensionName": manifest.Name, "version": manifest.Version})
+}
+
+func extensionManifest(archive []byte) ([]byte, error) {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range reader.File {
+		if file.Name == "extension.yaml" {
+			return readZipFile(file)
+		}
+	}
+	for _, file := range reader.File {
+		if file.Name == "extension.zip" {
+			inner, err := readZipFile(file)
+			if err != nil {
+				return nil, err
+			}
+			return extensionManifest(inner)
+		}
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+func (me *extensionStore) find(w http.ResponseWriter, name string) *extension {
+	ext, found := me.extensions[name]
+	if !found || len(ext.Versions) == 0 {
+		writeError(w, http.StatusNotFound, "Extension "+name+" not found")
+		return nil
+	}
+	return ext
+}
+
+func (me *extensionStore) list(w http.ResponseWriter, name string) {
+	ext := me.find(w, name)
+	if ext == nil {
+		return
+	}
+	versions := []map[string]any{}
+	for _, version := range ext.Versions {
+		versions = append(versions, map[string]any{"extensionName": name, "version": version})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"extensions": versions, "totalCount": len(versions)})
+}
+
+func (me *extensionStore) get(w http.ResponseWriter, name string, version string) {
+	ext := me.find(w, name)
+	if ext == nil {
+		return
+	}
+	if !contains(ext.Versions, version) {
+		writeError(w, http.StatusNotFound, "Extension "+name+" in version "+version+" not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"extensionName": name, "version": version})
+}
+
+func (me *extensionStore) delete(w http.ResponseWriter, name string, version string) {
+	ext := me.find(w, name)
+	if ext == nil {
+		return
+	}
+	if !contains(ext.Versions, version) {
+		writeError(w, http.StatusNotFound, "Extension "+name+" in version "+version+" not found")
+		return
+	}
+	if ext.ActiveVersion == version {
+		writeError(w, http.StatusBadRequest, "Cannot delete the active version "+version+" of extension "+name)
+		return
+	}
+	for _, configuration := range ext.MonitoringConfigurations {
+		if configuration.Value["version"] == version {
+			writeError(w, http.StatusBadRequest, "Extension "+name+" in version "+version+" is in use by monitoring configurations")
+			return
+		}
+	}
+	versions := []string{}
+	for _, v := range ext.Versions {
+		if v != version {
+			versions = append(versions, v)
+		}
+	}
+	ext.Versions = versions
+	writeJSON(w, http.StatusOK, map[string]any{"extensionName": name, "version": version})
+}
+
+func (me *extensionStore) serveEnvironmentConfiguration(w http.ResponseWriter, r *http.Request, name string) {
+	ext := me.find(w, name)
+	if ext == nil {
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		if len(ext.ActiveVersion) == 0 {
+			writeError(w, http.StatusNotFound, "Environment configuration of extension "+name+" not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"version": ext.ActiveVersion})
+	case http.MethodPut, http.MethodPost:
+		var payload struct {
+			Version string `json:"version"`
+		}
+		if err := readJSON(r, &payload); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if !contains(ext.Versions, payload.Version) {
+			writeError(w, http.StatusNotFound, "Extension "+name+" in version "+payload.Version+" not found")
+			return
+		}
+		ext.ActiveVersion = payload.Version
+		writeJSON(w, http.StatusOK, map[string]any{"version": ext.ActiveVersion})
+	case http.MethodDelete:
+		version := ext.ActiveVersion
+		ext.ActiveVersion = ""
+		writeJSON(w, http.StatusOK, map[string]any{"version": version})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (me *extensionStore) serveMonitoringConfigurations(w http.ResponseWriter, r *http.Request, name string) {
+	ext := me.find(w, name)
+	if ext == nil {
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		version := r.URL.Query().Get("version")
+		items := []map[string]any{}
+		for _, configuration := range ext.MonitoringConfigurations {
+			if len(version) == 0 || configuration.Value["version"] == version {
+				items = append(items, map[string]any{"objectId": configuration.ObjectID, "scope": configuration.Scope, "value": configuration.Value})
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"items": items, "totalCount": len(items)})
+	case http.MethodPost:
+		var payload []struct {
+			Scope string         `json:"scope"`
+			Value map[string]any `json:"value"`
+		}
+		if err := readJSON(r, &payload); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		results := []map[string]any{}
+		for _, item := range payload {
+			me.counter++
+			configuration := &monitoringConfiguration{
+				ObjectID: uuid.NewSHA1(uuid.NameSpaceOID, []byte("monitoringConfiguration/"+strconv.Itoa(me.counter))).String(),
+				Scope:    item.Scope,
+				Value:    item.Value,
+			}
+			ext.MonitoringConfigurations = append(ext.MonitoringConfigurations, configuration)
+			results = append(results, map[string]any{"objectId": configuration.ObjectID, "code": http.StatusOK})
+		}
+		writeJSON(w, http.StatusOK, results)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
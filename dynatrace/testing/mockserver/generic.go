@@ -0,0 +1,171 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package mockserver
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// collection holds the records created by POSTing to the same URL
+type collection struct {
+	order   []string
+	records map[string]map[string]any
+}
+
+func (me *collection) remove(id string) {
+	delete(me.records, id)
+	for idx, candidate := range me.order {
+		if candidate == id {
+			me.order = append(me.order[:idx], me.order[idx+1:]...)
+			return
+		}
+	}
+}
+
+// genericStore emulates the REST endpoints following the conventions of the Configuration API v1.
+// POSTing to a URL creates a record within the collection identified by that URL,
+// while `<collection>/<id>` addresses a single record.
+type genericStore struct {
+	collections map[string]*collection
+	counter     int
+}
+
+func newGenericStore() *genericStore {
+	return &genericStore{collections: map[string]*collection{}}
+}
+
+func (me *genericStore) collection(path string) *collection {
+	if c, found := me.collections[path]; found {
+		return c
+	}
+	c := &collection{order: []string{}, records: map[string]map[string]any{}}
+	me.collections[path] = c
+	return c
+}
+
+func recordName(id string, record map[string]any) string {
+	for _, key := range []string{"name", "displayName", "title", "key"} {
+		if s, ok := record[key].(string); ok && len(s) > 0 {
+			return s
+		}
+	}
+	if metadata, ok := record["dashboardMetadata"].(map[string]any); ok {
+		if s, ok := metadata["name"].(string); ok {
+			return s
+		}
+	}
+	return id
+}
+
+// listKeys contains the collections whose list responses don't deliver the records as `values`
+var listKeys = map[string]string{
+	"/api/config/v1/dashboards": "dashboards",
+}
+
+func (me *genericStore) serve(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	parent, id := path, ""
+	if idx := strings.LastIndex(path, "/"); idx > 0 {
+		parent, id = path[:idx], path[idx+1:]
+	}
+
+	if id == "validator" && (r.Method == http.MethodPost || r.Method == http.MethodPut) {
+		var record map[string]any
+		if err := readJSON(r, &record); err != nil {
+			writeError(w, http.StatusBadRequest, "Could not map JSON at '' near line 1 column 1")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	c, isItem := me.collections[parent]
+
+	switch r.Method {
+	case http.MethodGet:
+		if isItem {
+			if record, found := c.records[id]; found {
+				writeJSON(w, http.StatusOK, record)
+			} else {
+				writeError(w, http.StatusNotFound, "Configuration with id "+id+" not found")
+			}
+			return
+		}
+		values := []map[string]any{}
+		if c, found := me.collections[path]; found {
+			for _, id := range c.order {
+				value := map[string]any{"id": id, "name": recordName(id, c.records[id])}
+				if metadata, ok := c.records[id]["dashboardMetadata"].(map[string]any); ok && metadata["owner"] != nil {
+					value["owner"] = metadata["owner"]
+				}
+				values = append(values, value)
+			}
+		}
+		listKey := "values"
+		if key, found := listKeys[path]; found {
+			listKey = key
+		}
+		writeJSON(w, http.StatusOK, map[string]any{listKey: values, "totalCount": len(values)})
+	case http.MethodPost:
+		var record map[string]any
+		if err := readJSON(r, &record); err != nil || record == nil {
+			writeError(w, http.StatusBadRequest, "Could not map JSON at '' near line 1 column 1")
+			return
+		}
+		me.counter++
+		id := uuid.NewSHA1(uuid.NameSpaceOID, []byte("generic/"+strconv.Itoa(me.counter))).String()
+		record["id"] = id
+		c := me.collection(path)
+		c.records[id] = record
+		c.order = append(c.order, id)
+		writeJSON(w, http.StatusCreated, map[string]any{"id": id, "name": recordName(id, record)})
+	case http.MethodPut:
+		var record map[string]any
+		if err := readJSON(r, &record); err != nil || record == nil {
+			writeError(w, http.StatusBadRequest, "Could not map JSON at '' near line 1 column 1")
+			return
+		}
+		record["id"] = id
+		c := me.collection(parent)
+		if _, found := c.records[id]; found {
+			c.records[id] = record
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		c.records[id] = record
+		c.order = append(c.order, id)
+		writeJSON(w, http.StatusCreated, map[string]any{"id": id, "name": recordName(id, record)})
+	case http.MethodDelete:
+		if !isItem {
+			writeError(w, http.StatusNotFound, "Configuration with id "+id+" not found")
+			return
+		}
+		if _, found := c.records[id]; !found {
+			writeError(w, http.StatusNotFound, "Configuration with id "+id+" not found")
+			return
+		}
+		c.remove(id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
@@ -0,0 +1,293 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package mockserver
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// iamPath is the prefix of the emulated Account Management API. The account ID within the path is ignored
+const iamPath = "/iam/v1/accounts/"
+
+// AccountID is the ID of the emulated account
+const AccountID = "urn:dtaccount:mockserver"
+
+type iamGroup struct {
+	UUID                     string   `json:"uuid"`
+	Name                     string   `json:"name"`
+	Description              string   `json:"description,omitempty"`
+	FederatedAttributeValues []string `json:"federatedAttributeValues,omitempty"`
+	Permissions              []any    `json:"permissions,omitempty"`
+}
+
+type iamUser struct {
+	UID    string
+	Email  string
+	Groups map[string]bool
+}
+
+// iamStore emulates users, groups and group memberships of the Account Management API
+type iamStore struct {
+	users  map[string]*iamUser
+	groups map[string]*iamGroup
+}
+
+func newIAMStore() *iamStore {
+	return &iamStore{users: map[string]*iamUser{}, groups: map[string]*iamGroup{}}
+}
+
+func (me *iamStore) serve(w http.ResponseWriter, r *http.Request) {
+	segments := pathSegments(r, iamPath)
+	if len(segments) < 2 {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	// the first segment is the account ID
+	switch segments[1] {
+	case "users":
+		me.serveUsers(w, r, segments[2:])
+	case "groups":
+		me.serveGroups(w, r, segments[2:])
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (me *iamStore) serveUsers(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		items := []map[string]any{}
+		for _, user := range me.sortedUsers() {
+			items = append(items, map[string]any{"uid": user.UID, "email": user.Email})
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"count": len(items), "items": items})
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		var payload struct {
+			Email string `json:"email"`
+		}
+		if err := readJSON(r, &payload); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if _, found := me.users[strings.ToLower(payload.Email)]; found {
+			writeError(w, http.StatusBadRequest, "User already exists")
+			return
+		}
+		me.users[strings.ToLower(payload.Email)] = &iamUser{UID: uuid.NewString(), Email: payload.Email, Groups: map[string]bool{}}
+		writeJSON(w, http.StatusCreated, map[string]any{"email": payload.Email})
+	case len(segments) >= 1:
+		user, found := me.users[strings.ToLower(segments[0])]
+		if !found {
+			writeError(w, http.StatusNotFound, "User "+segments[0]+" not found")
+			return
+		}
+		if len(segments) == 1 {
+			me.serveUser(w, r, user)
+		} else if len(segments) == 2 && segments[1] == "groups" {
+			me.serveUserGroups(w, r, user)
+		} else {
+			writeError(w, http.StatusNotFound, "Not found")
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (me *iamStore) serveUser(w http.ResponseWriter, r *http.Request, user *iamUser) {
+	switch r.Method {
+	case http.MethodGet:
+		groups := []map[string]any{}
+		for _, group := range me.sortedGroups() {
+			if user.Groups[group.UUID] {
+				groups = append(groups, map[string]any{"uuid": group.UUID, "groupName": group.Name})
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"uid": user.UID, "email": user.Email, "groups": groups})
+	case http.MethodDelete:
+		delete(me.users, strings.ToLower(user.Email))
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (me *iamStore) serveUserGroups(w http.ResponseWriter, r *http.Request, user *iamUser) {
+	switch r.Method {
+	case http.MethodPut, http.MethodPost:
+		var groupIDs []string
+		if err := readJSON(r, &groupIDs); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		for _, groupID := range groupIDs {
+			if _, found := me.groups[groupID]; !found {
+				writeError(w, http.StatusBadRequest, "Group "+groupID+" not found")
+				return
+			}
+		}
+		if r.Method == http.MethodPut {
+			user.Groups = map[string]bool{}
+		}
+		for _, groupID := range groupIDs {
+			user.Groups[groupID] = true
+		}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		for _, groupID := range r.URL.Query()["group-uuid"] {
+			delete(user.Groups, groupID)
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (me *iamStore) serveGroups(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]any{"count": len(me.groups), "items": me.sortedGroups()})
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		var payload []*iamGroup
+		if err := readJSON(r, &payload); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		for _, group := range payload {
+			group.UUID = uuid.NewString()
+			me.groups[group.UUID] = group
+		}
+		writeJSON(w, http.StatusCreated, payload)
+	case len(segments) >= 1:
+		group, found := me.groups[segments[0]]
+		if !found {
+			writeError(w, http.StatusNotFound, "Group "+segments[0]+" not found")
+			return
+		}
+		switch {
+		case len(segments) == 1:
+			me.serveGroup(w, r, group)
+		case len(segments) == 2 && segments[1] == "users":
+			me.serveGroupUsers(w, r, group)
+		case len(segments) == 2 && segments[1] == "permissions":
+			me.serveGroupPermissions(w, r, group)
+		default:
+			writeError(w, http.StatusNotFound, "Not found")
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (me *iamStore) serveGroup(w http.ResponseWriter, r *http.Request, group *iamGroup) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, group)
+	case http.MethodPut:
+		var payload iamGroup
+		if err := readJSON(r, &payload); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		group.Name = payload.Name
+		group.Description = payload.Description
+		group.FederatedAttributeValues = payload.FederatedAttributeValues
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		delete(me.groups, group.UUID)
+		for _, user := range me.users {
+			delete(user.Groups, group.UUID)
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (me *iamStore) serveGroupUsers(w http.ResponseWriter, r *http.Request, group *iamGroup) {
+	switch r.Method {
+	case http.MethodGet:
+		items := []map[string]any{}
+		for _, user := range me.sortedUsers() {
+			if user.Groups[group.UUID] {
+				items = append(items, map[string]any{"uid": user.UID, "email": user.Email})
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"count": len(items), "items": items})
+	case http.MethodPost:
+		var emails []string
+		if err := readJSON(r, &emails); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		for _, email := range emails {
+			if _, found := me.users[strings.ToLower(email)]; !found {
+				writeError(w, http.StatusNotFound, "User "+email+" not found")
+				return
+			}
+		}
+		for _, email := range emails {
+			me.users[strings.ToLower(email)].Groups[group.UUID] = true
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (me *iamStore) serveGroupPermissions(w http.ResponseWriter, r *http.Request, group *iamGroup) {
+	switch r.Method {
+	case http.MethodGet:
+		permissions := group.Permissions
+		if permissions == nil {
+			permissions = []any{}
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"uuid": group.UUID, "name": group.Name, "permissions": permissions})
+	case http.MethodPut:
+		var permissions []any
+		if err := readJSON(r, &permissions); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		group.Permissions = permissions
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (me *iamStore) sortedUsers() []*iamUser {
+	users := []*iamUser{}
+	for _, user := range me.users {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Email < users[j].Email })
+	return users
+}
+
+func (me *iamStore) sortedGroups() []*iamGroup {
+	groups := []*iamGroup{}
+	for _, group := range me.groups {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
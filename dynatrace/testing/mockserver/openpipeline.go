@@ -0,0 +1,85 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package mockserver
+
+import (
+	"net/http"
+	"strconv"
+)
+
+const openPipelinePath = "/platform/openpipeline/v1/configurations"
+
+// openPipelineStore emulates the OpenPipeline configurations. There is exactly one configuration per kind
+// (logs, events, ...), which initially contains neither ingest sources nor pipelines nor routing entries.
+// Updates are only accepted if they are based on the current version of the configuration.
+type openPipelineStore struct {
+	configurations map[string]map[string]any
+}
+
+func newOpenPipelineStore() *openPipelineStore {
+	return &openPipelineStore{configurations: map[string]map[string]any{}}
+}
+
+func (me *openPipelineStore) configuration(kind string) map[string]any {
+	if configuration, found := me.configurations[kind]; found {
+		return configuration
+	}
+	configuration := map[string]any{
+		"id":             kind,
+		"editable":       true,
+		"version":        "1",
+		"customBasePath": "/platform/ingest/custom/" + kind,
+		"endpoints":      []any{},
+		"pipelines":      []any{},
+		"routing":        map[string]any{"editable": true, "entries": []any{}},
+	}
+	me.configurations[kind] = configuration
+	return configuration
+}
+
+func (me *openPipelineStore) serve(w http.ResponseWriter, r *http.Request) {
+	segments := pathSegments(r, openPipelinePath)
+	if len(segments) != 1 {
+		writeError(w, http.StatusNotFound, "Configuration not found")
+		return
+	}
+	kind := segments[0]
+	configuration := me.configuration(kind)
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, configuration)
+	case http.MethodPut:
+		var update map[string]any
+		if err := readJSON(r, &update); err != nil || update == nil {
+			writeError(w, http.StatusBadRequest, "Invalid configuration")
+			return
+		}
+		if update["version"] != configuration["version"] {
+			writeError(w, http.StatusConflict, "The configuration has been modified in the meantime")
+			return
+		}
+		version, _ := strconv.Atoi(configuration["version"].(string))
+		update["id"] = kind
+		update["version"] = strconv.Itoa(version + 1)
+		me.configurations[kind] = update
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
@@ -0,0 +1,185 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package mockserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// environmentPlaceholder replaces the URL of the recorded environment within recorded responses
+const environmentPlaceholder = "${environment}"
+
+// interaction is a single recorded request and the response to it
+type interaction struct {
+	Method      string `json:"method"`
+	URI         string `json:"uri"`
+	Status      int    `json:"status"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        string `json:"body,omitempty"`
+
+	replayed bool
+}
+
+// recorder either forwards requests to a real environment and records the interactions
+// or replays previously recorded interactions.
+type recorder struct {
+	mu           sync.Mutex
+	file         string
+	upstreams    []string
+	proxy        *httputil.ReverseProxy
+	platform     *httputil.ReverseProxy
+	interactions []*interaction
+}
+
+// newRecorder returns nil if there is neither an upstream to record from nor a recording to replay
+func newRecorder(options Options) (*recorder, error) {
+	recorder := &recorder{file: options.Recording, interactions: []*interaction{}}
+	if len(options.Upstream) == 0 {
+		if len(options.Recording) == 0 {
+			return nil, nil
+		}
+		data, err := os.ReadFile(options.Recording)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &recorder.interactions); err != nil {
+			return nil, fmt.Errorf("invalid recording '%s': %s", options.Recording, err.Error())
+		}
+		return recorder, nil
+	}
+	var err error
+	if recorder.proxy, err = recorder.reverseProxy(options.Upstream); err != nil {
+		return nil, err
+	}
+	if recorder.platform, err = recorder.reverseProxy(options.PlatformUpstream); err != nil {
+		return nil, err
+	}
+	recorder.upstreams = []string{strings.TrimSuffix(options.Upstream, "/"), strings.TrimSuffix(options.PlatformUpstream, "/")}
+	return recorder, nil
+}
+
+func (me *recorder) reverseProxy(upstream string) (*httputil.ReverseProxy, error) {
+	target, err := url.Parse(upstream)
+	if err != nil {
+		return nil, err
+	}
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	director := proxy.Director
+	proxy.Director = func(r *http.Request) {
+		director(r)
+		r.Host = target.Host
+	}
+	proxy.ModifyResponse = me.record
+	return proxy, nil
+}
+
+func (me *recorder) recording() bool {
+	return me.proxy != nil
+}
+
+func (me *recorder) serve(w http.ResponseWriter, r *http.Request) {
+	if me.recording() {
+		if strings.HasPrefix(r.URL.Path, "/platform/") {
+			me.platform.ServeHTTP(w, r)
+		} else {
+			me.proxy.ServeHTTP(w, r)
+		}
+		return
+	}
+	if r.URL.Path == TokenPath {
+		writeJSON(w, http.StatusOK, map[string]any{"access_token": "mockserver", "token_type": "Bearer", "expires_in": 3600})
+		return
+	}
+	me.replay(w, r)
+}
+
+func (me *recorder) record(response *http.Response) error {
+	data, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(data))
+
+	body := string(data)
+	for _, upstream := range me.upstreams {
+		body = strings.ReplaceAll(body, upstream, environmentPlaceholder)
+	}
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	me.interactions = append(me.interactions, &interaction{
+		Method:      response.Request.Method,
+		URI:         response.Request.URL.RequestURI(),
+		Status:      response.StatusCode,
+		ContentType: response.Header.Get("Content-Type"),
+		Body:        body,
+	})
+	return nil
+}
+
+// replay responds with the first not yet replayed interaction matching method and URI of the request
+func (me *recorder) replay(w http.ResponseWriter, r *http.Request) {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	for _, interaction := range me.interactions {
+		if interaction.replayed || interaction.Method != r.Method || interaction.URI != r.URL.RequestURI() {
+			continue
+		}
+		interaction.replayed = true
+		if len(interaction.ContentType) > 0 {
+			w.Header().Set("Content-Type", interaction.ContentType)
+		}
+		w.WriteHeader(interaction.Status)
+		io.WriteString(w, strings.ReplaceAll(interaction.Body, environmentPlaceholder, "http://"+r.Host))
+		return
+	}
+	writeError(w, http.StatusNotImplemented, fmt.Sprintf("mockserver: no recorded interaction for %s %s left in '%s'", r.Method, r.URL.RequestURI(), me.file))
+}
+
+// save stores the recorded interactions.
+// Nothing is getting stored when replaying or if no file has been configured.
+func (me *recorder) save() error {
+	if !me.recording() || len(me.file) == 0 {
+		return nil
+	}
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	data, err := json.MarshalIndent(me.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(me.file), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(me.file, data, 0o644)
+}
@@ -0,0 +1,251 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+// Package mockserver provides an in-process fake of a Dynatrace environment.
+//
+// It emulates the parts of the REST API the provider is talking to the most
+// (Settings 2.0, the Configuration API v1 and compatible endpoints, documents, automation, extensions,
+// OpenPipeline configurations and users and groups of the Account Management API)
+// well enough for the CRUD tests in `dynatrace/testing/api` and `testbase` to run without a live tenant.
+// Optionally it records the traffic against a real environment and replays it later on.
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+// Token is the API Token the mock server hands out via `Credentials`.
+// The mock server doesn't validate tokens. It only requires requests to be authenticated at all.
+const Token = "dt0c01.MOCKSERVER.TOKEN"
+
+// TokenPath is the path of the emulated OAuth token endpoint
+const TokenPath = "/sso/oauth2/token"
+
+// epoch is the initial value of the logical clock used for `lastModifiedTime` and similar properties.
+// A logical clock keeps responses deterministic, which is required for recordings to be comparable.
+const epoch = int64(1700000000000)
+
+// Options configure how a Server is handling requests
+type Options struct {
+	// Upstream is the URL of a real environment.
+	// If set, all requests are getting forwarded to it instead of being emulated.
+	Upstream string
+	// PlatformUpstream is the URL requests for `/platform/...` are getting forwarded to.
+	// Defaults to Upstream.
+	PlatformUpstream string
+	// Recording is the file interactions are getting stored into (if Upstream is set)
+	// or replayed from (if Upstream is not set and the file exists)
+	Recording string
+}
+
+// Server is a fake Dynatrace environment running on a local port
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	clock      int64
+	options    Options
+	settings   *settingsStore
+	generic    *genericStore
+	documents  *documentStore
+	automation *automationStore
+	extensions *extensionStore
+	iam        *iamStore
+	pipelines  *openPipelineStore
+	recorder   *recorder
+}
+
+// New starts a new Server.
+// Without any options all requests are getting emulated.
+func New(options ...Options) (*Server, error) {
+	server := &Server{
+		clock:      epoch,
+		settings:   newSettingsStore(),
+		generic:    newGenericStore(),
+		documents:  newDocumentStore(),
+		automation: newAutomationStore(),
+		extensions: newExtensionStore(),
+		iam:        newIAMStore(),
+		pipelines:  newOpenPipelineStore(),
+	}
+	if len(options) > 0 {
+		server.options = options[0]
+	}
+	if len(server.options.PlatformUpstream) == 0 {
+		server.options.PlatformUpstream = server.options.Upstream
+	}
+	if len(server.options.Upstream) > 0 || len(server.options.Recording) > 0 {
+		var err error
+		if server.recorder, err = newRecorder(server.options); err != nil {
+			return nil, err
+		}
+	}
+	server.Server = httptest.NewServer(server)
+	return server, nil
+}
+
+// Close shuts down the server and - if traffic got recorded - stores the recording
+func (me *Server) Close() error {
+	me.Server.Close()
+	if me.recorder != nil {
+		return me.recorder.save()
+	}
+	return nil
+}
+
+// Credentials produces credentials for the services of this provider pointing to this server.
+// When forwarding to a real environment, API Token and OAuth credentials are taken from `upstream`.
+func (me *Server) Credentials(upstream ...*settings.Credentials) *settings.Credentials {
+	credentials := &settings.Credentials{URL: me.URL, Token: Token}
+	credentials.Automation.ClientID = "mockserver"
+	credentials.Automation.ClientSecret = "mockserver"
+	credentials.Automation.TokenURL = me.URL + TokenPath
+	credentials.Automation.EnvironmentURL = me.URL
+	credentials.IAM.ClientID = "mockserver"
+	credentials.IAM.AccountID = AccountID
+	credentials.IAM.ClientSecret = "mockserver"
+	credentials.IAM.TokenURL = me.URL + TokenPath
+	credentials.IAM.EndpointURL = me.URL
+	if len(upstream) > 0 && upstream[0] != nil && len(me.options.Upstream) > 0 {
+		credentials.Token = upstream[0].Token
+		credentials.Automation.ClientID = upstream[0].Automation.ClientID
+		credentials.Automation.ClientSecret = upstream[0].Automation.ClientSecret
+		credentials.Automation.TokenURL = upstream[0].Automation.TokenURL
+		credentials.IAM = upstream[0].IAM
+	}
+	return credentials
+}
+
+// AddSchema registers the definition of a Settings 2.0 schema, which is from then on returned by
+// `/api/v2/settings/schemas/{schemaId}` instead of a definition without any properties.
+// Registering several versions of a schema is possible. The last one registered is the latest one.
+func (me *Server) AddSchema(definition []byte) error {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	return me.settings.addSchema(definition)
+}
+
+// now advances the logical clock
+func (me *Server) now() int64 {
+	me.clock++
+	return me.clock
+}
+
+func (me *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if me.recorder != nil {
+		me.recorder.serve(w, r)
+		return
+	}
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	if r.URL.Path == TokenPath {
+		writeJSON(w, http.StatusOK, map[string]any{"access_token": "mockserver", "token_type": "Bearer", "expires_in": 3600})
+		return
+	}
+	if len(r.Header.Get("Authorization")) == 0 {
+		writeError(w, http.StatusUnauthorized, "Missing authorization parameter.")
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, settingsSchemasPath):
+		me.settings.serveSchemas(w, r)
+	case strings.HasPrefix(r.URL.Path, settingsObjectsPath):
+		me.settings.serve(me, w, r)
+	case strings.HasPrefix(r.URL.Path, documentsPath), strings.HasPrefix(r.URL.Path, trashPath):
+		me.documents.serve(me, w, r)
+	case strings.HasPrefix(r.URL.Path, automationPath):
+		me.automation.serve(me, w, r)
+	case strings.HasPrefix(r.URL.Path, extensionsPath):
+		me.extensions.serve(w, r)
+	case strings.HasPrefix(r.URL.Path, iamPath):
+		me.iam.serve(w, r)
+	case strings.HasPrefix(r.URL.Path, openPipelinePath):
+		me.pipelines.serve(w, r)
+	case strings.HasPrefix(r.URL.Path, "/api/"), strings.HasPrefix(r.URL.Path, "/platform/"):
+		me.generic.serve(w, r)
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("mockserver: no handler for %s %s", r.Method, r.URL.Path))
+	}
+}
+
+// pathSegments splits the escaped path of the request into unescaped segments.
+// IDs of settings objects may contain a `/`, which is only preserved within the escaped path.
+func pathSegments(r *http.Request, prefix string) []string {
+	escaped := strings.Trim(strings.TrimPrefix(r.URL.EscapedPath(), prefix), "/")
+	if len(escaped) == 0 {
+		return []string{}
+	}
+	segments := strings.Split(escaped, "/")
+	for idx, segment := range segments {
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			segments[idx] = unescaped
+		}
+	}
+	return segments
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+type errorEnvelope struct {
+	Error errorBody `json:"error"`
+}
+
+type errorBody struct {
+	Code                 int                   `json:"code"`
+	Message              string                `json:"message"`
+	ConstraintViolations []constraintViolation `json:"constraintViolations,omitempty"`
+}
+
+type constraintViolation struct {
+	Path              string `json:"path"`
+	Message           string `json:"message"`
+	ParameterLocation string `json:"parameterLocation"`
+}
+
+func writeError(w http.ResponseWriter, status int, message string, violations ...constraintViolation) {
+	writeJSON(w, status, errorEnvelope{Error: errorBody{Code: status, Message: message, ConstraintViolations: violations}})
+}
+
+func readJSON(r *http.Request, v any) error {
+	defer r.Body.Close()
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+func writeTo(w io.Writer, v any) {
+	json.NewEncoder(w).Encode(v)
+}
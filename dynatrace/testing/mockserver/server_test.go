@@ -0,0 +1,328 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package mockserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/automation/business_calendars"
+	calendars "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/automation/business_calendars/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/alerting/profile"
+	profiles "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/alerting/profile/settings"
+	documentservice "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document"
+	documents "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/v1/config/naming/hosts"
+	namingrules "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/v1/config/naming/hosts/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+)
+
+func start(t *testing.T, options ...mockserver.Options) *mockserver.Server {
+	t.Helper()
+	server, err := mockserver.New(options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+	return server
+}
+
+// TestSettingsTest runs the regular CRUD test harness against the emulated environment
+func TestSettingsTest(t *testing.T) {
+	t.Setenv(mockserver.ENV_VAR_MOCK_SERVER, "true")
+	api.TestService(t, profile.Service)
+}
+
+func TestSettings20(t *testing.T) {
+	ctx := context.Background()
+	server := start(t)
+	service := profile.Service(server.Credentials())
+
+	stub, err := service.Create(ctx, &profiles.Profile{Name: "first", SeverityRules: profiles.SeverityRules{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var objectID settings.ObjectID
+	objectID.ID = stub.ID
+	if err := objectID.Decode(); err != nil || objectID.SchemaID != profile.SchemaID || objectID.Scope.ID != "environment" {
+		t.Errorf("object ID '%s' is not decodable (%v): %v", stub.ID, err, objectID)
+	}
+
+	var remote profiles.Profile
+	if err := service.Get(ctx, stub.ID, &remote); err != nil {
+		t.Fatal(err)
+	}
+	if remote.Name != "first" {
+		t.Errorf("expected name 'first', got '%s'", remote.Name)
+	}
+	if err := service.Update(ctx, stub.ID, &profiles.Profile{Name: "updated", SeverityRules: profiles.SeverityRules{}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Get(ctx, stub.ID, &remote); err != nil || remote.Name != "updated" {
+		t.Errorf("expected name 'updated', got '%s' (%v)", remote.Name, err)
+	}
+	if stubs, err := service.List(ctx); err != nil || len(stubs) != 1 {
+		t.Errorf("expected 1 alerting profile, got %d (%v)", len(stubs), err)
+	}
+	if err := service.Delete(ctx, stub.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Get(ctx, stub.ID, &remote); !rest.Is404(err) {
+		t.Errorf("expected 404, got %v", err)
+	}
+}
+
+type listResponse struct {
+	Items []struct {
+		ObjectID      string `json:"objectId"`
+		SchemaVersion string `json:"schemaVersion"`
+	} `json:"items"`
+	NextPageKey string `json:"nextPageKey"`
+}
+
+func request(t *testing.T, server *mockserver.Server, method string, path string, payload string, v any) int {
+	t.Helper()
+	req, _ := http.NewRequest(method, server.URL+path, strings.NewReader(payload))
+	req.Header.Set("Authorization", "Api-Token "+mockserver.Token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if v != nil {
+		json.NewDecoder(resp.Body).Decode(v)
+	}
+	return resp.StatusCode
+}
+
+func create(t *testing.T, server *mockserver.Server, payload string) string {
+	t.Helper()
+	var results []struct {
+		ObjectID string `json:"objectId"`
+	}
+	if status := request(t, server, http.MethodPost, "/api/v2/settings/objects", payload, &results); status != http.StatusOK {
+		t.Fatalf("create failed with status %d", status)
+	}
+	return results[0].ObjectID
+}
+
+func list(t *testing.T, server *mockserver.Server, query string) []string {
+	t.Helper()
+	ids := []string{}
+	for path := "/api/v2/settings/objects?" + query; ; {
+		var response listResponse
+		if status := request(t, server, http.MethodGet, path, "", &response); status != http.StatusOK {
+			t.Fatalf("list failed with status %d", status)
+		}
+		for _, item := range response.Items {
+			ids = append(ids, item.ObjectID)
+		}
+		if len(response.NextPageKey) == 0 {
+			return ids
+		}
+		path = "/api/v2/settings/objects?nextPageKey=" + url.QueryEscape(response.NextPageKey)
+	}
+}
+
+func TestSettings20Ordering(t *testing.T) {
+	server := start(t)
+
+	a := create(t, server, `[{"schemaId":"builtin:ordered","scope":"environment","value":{"name":"a"}}]`)
+	b := create(t, server, `[{"schemaId":"builtin:ordered","scope":"environment","value":{"name":"b"}}]`)
+	first := create(t, server, `[{"schemaId":"builtin:ordered","scope":"environment","value":{"name":"first"},"insertAfter":""}]`)
+	afterA := create(t, server, `[{"schemaId":"builtin:ordered","scope":"environment","value":{"name":"after-a"},"insertAfter":"`+a+`"}]`)
+	create(t, server, `[{"schemaId":"builtin:ordered","scope":"HOST-1234","value":{"name":"other scope"}}]`)
+	create(t, server, `[{"schemaId":"builtin:other","value":{"name":"other schema"}}]`)
+
+	expected := []string{first, a, afterA, b}
+	actual := list(t, server, "schemaIds=builtin:ordered&scopes=environment&pageSize=1")
+	if strings.Join(actual, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected order %v, got %v", expected, actual)
+	}
+
+	// moving `b` to the front
+	if status := request(t, server, http.MethodPut, "/api/v2/settings/objects/"+url.PathEscape(b), `{"value":{"name":"b"},"insertAfter":""}`, nil); status != http.StatusOK {
+		t.Fatalf("update failed with status %d", status)
+	}
+	expected = []string{b, first, a, afterA}
+	actual = list(t, server, "schemaIds=builtin:ordered&scopes=environment")
+	if strings.Join(actual, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected order %v, got %v", expected, actual)
+	}
+
+	if status := request(t, server, http.MethodPost, "/api/v2/settings/objects", `[{"schemaId":"builtin:ordered","value":{"name":"c"},"insertAfter":"unknown"}]`, nil); status != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an invalid insertAfter, got %d", status)
+	}
+}
+
+func TestSettings20ValidateOnly(t *testing.T) {
+	server := start(t)
+	if status := request(t, server, http.MethodPost, "/api/v2/settings/objects?validateOnly=true", `[{"schemaId":"builtin:validated","schemaVersion":"1.2.3","value":{"name":"a"}}]`, nil); status != http.StatusOK {
+		t.Errorf("expected status 200, got %d", status)
+	}
+	if ids := list(t, server, "schemaIds=builtin:validated"); len(ids) != 0 {
+		t.Errorf("validation must not create objects, found %v", ids)
+	}
+	if status := request(t, server, http.MethodPost, "/api/v2/settings/objects?validateOnly=true", `[{"schemaId":"builtin:validated","value":"a"}]`, nil); status != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", status)
+	}
+
+	id := create(t, server, `[{"schemaId":"builtin:validated","schemaVersion":"1.2.3","value":{"name":"a"}}]`)
+	var object struct {
+		SchemaVersion string `json:"schemaVersion"`
+	}
+	request(t, server, http.MethodGet, "/api/v2/settings/objects/"+url.PathEscape(id), "", &object)
+	if object.SchemaVersion != "1.2.3" {
+		t.Errorf("expected schema version 1.2.3, got '%s'", object.SchemaVersion)
+	}
+}
+
+func TestConfigV1(t *testing.T) {
+	ctx := context.Background()
+	server := start(t)
+	service := hosts.Service(server.Credentials())
+
+	rule := &namingrules.NamingRule{Name: "mock", Enabled: true, Format: "{Host:DetectedName}"}
+	if err := service.(settings.Validator[*namingrules.NamingRule]).Validate(ctx, rule); err != nil {
+		t.Fatal(err)
+	}
+	stub, err := service.Create(ctx, rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule.Name = "updated"
+	if err := service.Update(ctx, stub.ID, rule); err != nil {
+		t.Fatal(err)
+	}
+	var remote namingrules.NamingRule
+	if err := service.Get(ctx, stub.ID, &remote); err != nil || remote.Name != "updated" {
+		t.Errorf("expected name 'updated', got '%s' (%v)", remote.Name, err)
+	}
+	if stubs, err := service.List(ctx); err != nil || len(stubs) != 1 || stubs[0].Name != "updated" {
+		t.Errorf("expected a single naming rule named 'updated', got %v (%v)", stubs, err)
+	}
+	if err := service.Delete(ctx, stub.ID); err != nil {
+		t.Fatal(err)
+	}
+	if stubs, err := service.List(ctx); err != nil || len(stubs) != 0 {
+		t.Errorf("expected no naming rules, got %v (%v)", stubs, err)
+	}
+}
+
+func TestDocuments(t *testing.T) {
+	ctx := context.Background()
+	server := start(t)
+	service := documentservice.Service(server.Credentials())
+
+	stub, err := service.Create(ctx, &documents.Document{Name: "mock", Type: "dashboard", Content: `{"tiles":{}}`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Update(ctx, stub.ID, &documents.Document{Name: "updated", Type: "dashboard", Content: `{"tiles":{"0":{}}}`}); err != nil {
+		t.Fatal(err)
+	}
+	var remote documents.Document
+	if err := service.Get(ctx, stub.ID, &remote); err != nil {
+		t.Fatal(err)
+	}
+	if remote.Name != "updated" || remote.Content != `{"tiles":{"0":{}}}` || remote.Version != 4 {
+		t.Errorf("unexpected document %v", remote)
+	}
+	if stubs, err := service.List(ctx); err != nil || len(stubs) != 1 {
+		t.Errorf("expected 1 document, got %v (%v)", stubs, err)
+	}
+	if err := service.Delete(ctx, stub.ID); err != nil {
+		t.Fatal(err)
+	}
+	if stubs, err := service.List(ctx); err != nil || len(stubs) != 0 {
+		t.Errorf("expected no documents, got %v (%v)", stubs, err)
+	}
+}
+
+func TestAutomation(t *testing.T) {
+	ctx := context.Background()
+	server := start(t)
+	service := business_calendars.Service(server.Credentials())
+
+	stub, err := service.Create(ctx, &calendars.Settings{Title: "mock", WeekDays: []int{1, 2, 3, 4, 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Update(ctx, stub.ID, &calendars.Settings{Title: "updated", WeekDays: []int{1, 2}}); err != nil {
+		t.Fatal(err)
+	}
+	var remote calendars.Settings
+	if err := service.Get(ctx, stub.ID, &remote); err != nil || remote.Title != "updated" || len(remote.WeekDays) != 2 {
+		t.Errorf("unexpected business calendar %v (%v)", remote, err)
+	}
+	if stubs, err := service.List(ctx); err != nil || len(stubs) != 1 {
+		t.Errorf("expected 1 business calendar, got %v (%v)", stubs, err)
+	}
+	if err := service.Delete(ctx, stub.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Get(ctx, stub.ID, &remote); err == nil {
+		t.Error("expected the business calendar to be gone")
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	recording := filepath.Join(t.TempDir(), "recording.json")
+	environment := start(t)
+
+	exercise := func(credentials *settings.Credentials) string {
+		service := profile.Service(credentials)
+		stub, err := service.Create(ctx, &profiles.Profile{Name: "recorded", SeverityRules: profiles.SeverityRules{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var remote profiles.Profile
+		if err := service.Get(ctx, stub.ID, &remote); err != nil {
+			t.Fatal(err)
+		}
+		return stub.ID + "/" + remote.Name
+	}
+
+	recorder, err := mockserver.New(mockserver.Options{Upstream: environment.URL, Recording: recording})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorded := exercise(recorder.Credentials(environment.Credentials()))
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayer := start(t, mockserver.Options{Recording: recording})
+	if replayed := exercise(replayer.Credentials()); replayed != recorded {
+		t.Errorf("expected '%s' to be replayed, got '%s'", recorded, replayed)
+	}
+	// every recorded interaction gets replayed only once
+	if _, err := profile.Service(replayer.Credentials()).Create(ctx, &profiles.Profile{Name: "recorded", SeverityRules: profiles.SeverityRules{}}); err == nil {
+		t.Error("expected replaying to fail once the recording is exhausted")
+	}
+}
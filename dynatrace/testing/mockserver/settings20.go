@@ -0,0 +1,466 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package mockserver
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const settingsObjectsPath = "/api/v2/settings/objects"
+const settingsSchemasPath = "/api/v2/settings/schemas"
+
+const defaultSchemaVersion = "1.0"
+const defaultPageSize = 100
+const maxPageSize = 500
+
+// objectIDPrefix are the leading bytes of a Settings 2.0 object ID.
+// IDs produced by the mock server can get decoded by `settings.ObjectID` just like real ones.
+var objectIDPrefix = []byte{0xbe, 0xef, 0x54, 0xde, 0x15, 0xda, 0xde, 0xad, 0x00, 0x00, 0x00, 0x01}
+
+type settingsObject struct {
+	ObjectID      string
+	SchemaID      string
+	SchemaVersion string
+	Scope         string
+	Value         json.RawMessage
+	Created       int64
+	Modified      int64
+}
+
+func (me *settingsObject) modificationInfo() map[string]any {
+	return map[string]any{
+		"deletable":        true,
+		"modifiable":       true,
+		"movable":          true,
+		"createdBy":        "mockserver",
+		"createdTime":      me.Created,
+		"lastModifiedBy":   "mockserver",
+		"lastModifiedTime": me.Modified,
+	}
+}
+
+func (me *settingsObject) summary() string {
+	var value map[string]any
+	if err := json.Unmarshal(me.Value, &value); err == nil {
+		for _, key := range []string{"name", "displayName", "key", "title"} {
+			if s, ok := value[key].(string); ok && len(s) > 0 {
+				return s
+			}
+		}
+	}
+	return me.ObjectID
+}
+
+func (me *settingsObject) fields(fields []string) map[string]any {
+	m := map[string]any{"objectId": me.ObjectID}
+	for _, field := range fields {
+		switch field {
+		case "summary":
+			m["summary"] = me.summary()
+		case "scope":
+			m["scope"] = me.Scope
+		case "schemaId":
+			m["schemaId"] = me.SchemaID
+		case "schemaVersion":
+			m["schemaVersion"] = me.SchemaVersion
+		case "modificationInfo":
+			m["modificationInfo"] = me.modificationInfo()
+		case "value":
+			m["value"] = me.Value
+		case "created":
+			m["created"] = me.Created
+		case "modified":
+			m["modified"] = me.Modified
+		case "author":
+			m["author"] = "mockserver"
+		}
+	}
+	return m
+}
+
+// settingsStore keeps all Settings 2.0 objects in a single slice.
+// The order of objects sharing schema and scope within that slice is the order reported when listing them.
+type settingsStore struct {
+	objects     []*settingsObject
+	versions    map[string]string
+	definitions map[string]map[string]json.RawMessage
+	counter     int
+}
+
+func newSettingsStore() *settingsStore {
+	return &settingsStore{objects: []*settingsObject{}, versions: map[string]string{}, definitions: map[string]map[string]json.RawMessage{}}
+}
+
+func (me *settingsStore) newObjectID(schemaID string, scope string) string {
+	me.counter++
+	scopeClass := scope
+	if idx := strings.Index(scope, "-"); idx > 0 {
+		scopeClass = scope[:idx]
+	}
+	buf := bytes.NewBuffer(append([]byte{}, objectIDPrefix...))
+	for _, s := range []string{schemaID, scopeClass, scope, uuid.NewSHA1(uuid.NameSpaceOID, []byte(strconv.Itoa(me.counter))).String()} {
+		binary.Write(buf, binary.BigEndian, uint16(len(s)))
+		buf.WriteString(s)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func (me *settingsStore) index(objectID string) int {
+	for idx, object := range me.objects {
+		if object.ObjectID == objectID {
+			return idx
+		}
+	}
+	return -1
+}
+
+// place inserts the object into the store.
+// If `insertAfter` is nil, the object becomes the last one of its schema and scope.
+// If `insertAfter` is empty, it becomes the first one.
+func (me *settingsStore) place(object *settingsObject, insertAfter *string) error {
+	if insertAfter == nil {
+		me.objects = append(me.objects, object)
+		return nil
+	}
+	position := len(me.objects)
+	if len(*insertAfter) == 0 {
+		for idx, candidate := range me.objects {
+			if candidate.SchemaID == object.SchemaID && candidate.Scope == object.Scope {
+				position = idx
+				break
+			}
+		}
+	} else {
+		idx := me.index(*insertAfter)
+		if idx == -1 || me.objects[idx].SchemaID != object.SchemaID || me.objects[idx].Scope != object.Scope {
+			return errors.New("Setting value cannot be inserted to the specified position")
+		}
+		position = idx + 1
+	}
+	me.objects = append(me.objects[:position], append([]*settingsObject{object}, me.objects[position:]...)...)
+	return nil
+}
+
+func (me *settingsStore) remove(objectID string) *settingsObject {
+	idx := me.index(objectID)
+	if idx == -1 {
+		return nil
+	}
+	object := me.objects[idx]
+	me.objects = append(me.objects[:idx], me.objects[idx+1:]...)
+	return object
+}
+
+func (me *settingsStore) schemaVersion(schemaID string, schemaVersion string) string {
+	if len(schemaVersion) > 0 {
+		me.versions[schemaID] = schemaVersion
+		return schemaVersion
+	}
+	if version, found := me.versions[schemaID]; found {
+		return version
+	}
+	return defaultSchemaVersion
+}
+
+func (me *settingsStore) serveSchemas(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	segments := pathSegments(r, settingsSchemasPath)
+	if len(segments) == 0 {
+		schemaIDs := []string{}
+		for schemaID := range me.versions {
+			schemaIDs = append(schemaIDs, schemaID)
+		}
+		sort.Strings(schemaIDs)
+		items := []map[string]any{}
+		for _, schemaID := range schemaIDs {
+			items = append(items, map[string]any{"schemaId": schemaID, "displayName": schemaID, "latestSchemaVersion": me.versions[schemaID]})
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"items": items, "totalCount": len(items)})
+		return
+	}
+	schemaID := segments[0]
+	if definitions, found := me.definitions[schemaID]; found {
+		schemaVersion := r.URL.Query().Get("schemaVersion")
+		if len(schemaVersion) == 0 {
+			schemaVersion = me.versions[schemaID]
+		}
+		definition, found := definitions[schemaVersion]
+		if !found {
+			writeError(w, http.StatusNotFound, "Schema "+schemaID+" with version "+schemaVersion+" not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, definition)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"schemaId": schemaID, "displayName": schemaID, "version": me.schemaVersion(schemaID, "")})
+}
+
+// addSchema registers the definition of a schema. The most recently added version of a schema is its latest version
+func (me *settingsStore) addSchema(definition json.RawMessage) error {
+	var header struct {
+		SchemaID string `json:"schemaId"`
+		Version  string `json:"version"`
+	}
+	if err := json.Unmarshal(definition, &header); err != nil {
+		return err
+	}
+	if len(header.SchemaID) == 0 || len(header.Version) == 0 {
+		return errors.New("the definition of a schema requires `schemaId` and `version`")
+	}
+	if _, found := me.definitions[header.SchemaID]; !found {
+		me.definitions[header.SchemaID] = map[string]json.RawMessage{}
+	}
+	me.definitions[header.SchemaID][header.Version] = definition
+	me.versions[header.SchemaID] = header.Version
+	return nil
+}
+
+type settingsCreate struct {
+	SchemaID      string          `json:"schemaId"`
+	SchemaVersion string          `json:"schemaVersion"`
+	Scope         string          `json:"scope"`
+	Value         json.RawMessage `json:"value"`
+	InsertAfter   *string         `json:"insertAfter"`
+}
+
+type settingsUpdate struct {
+	SchemaVersion string          `json:"schemaVersion"`
+	Value         json.RawMessage `json:"value"`
+	InsertAfter   *string         `json:"insertAfter"`
+}
+
+type settingsResult struct {
+	Code     int        `json:"code"`
+	ObjectID string     `json:"objectId,omitempty"`
+	Error    *errorBody `json:"error,omitempty"`
+}
+
+func (me *settingsStore) serve(server *Server, w http.ResponseWriter, r *http.Request) {
+	segments := pathSegments(r, settingsObjectsPath)
+	validateOnly := r.URL.Query().Get("validateOnly") == "true"
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		me.list(w, r)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		me.create(server, w, r, validateOnly)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		if idx := me.index(segments[0]); idx == -1 {
+			writeError(w, http.StatusNotFound, "Settings not found")
+		} else {
+			object := me.objects[idx]
+			writeJSON(w, http.StatusOK, object.fields([]string{"schemaId", "schemaVersion", "scope", "modificationInfo", "value", "created", "modified", "author"}))
+		}
+	case len(segments) == 1 && r.Method == http.MethodPut:
+		me.update(server, w, r, segments[0], validateOnly)
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		if me.remove(segments[0]) == nil {
+			writeError(w, http.StatusNotFound, "Settings not found")
+		} else {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func validateValue(value json.RawMessage) *errorBody {
+	var m map[string]any
+	if err := json.Unmarshal(value, &m); err != nil || m == nil {
+		return &errorBody{Code: http.StatusBadRequest, Message: "Validation failed", ConstraintViolations: []constraintViolation{{Path: "value", Message: "value must be a JSON object", ParameterLocation: "PAYLOAD_BODY"}}}
+	}
+	return nil
+}
+
+func (me *settingsStore) create(server *Server, w http.ResponseWriter, r *http.Request, validateOnly bool) {
+	var payload []settingsCreate
+	if err := readJSON(r, &payload); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	results := []settingsResult{}
+	status := http.StatusOK
+	for _, item := range payload {
+		result := settingsResult{Code: http.StatusOK}
+		if len(item.SchemaID) == 0 {
+			result.Error = &errorBody{Code: http.StatusBadRequest, Message: "Schema ID must not be empty"}
+		} else {
+			result.Error = validateValue(item.Value)
+		}
+		if result.Error == nil && item.InsertAfter != nil && len(*item.InsertAfter) > 0 {
+			if idx := me.index(*item.InsertAfter); idx == -1 {
+				result.Error = &errorBody{Code: http.StatusBadRequest, Message: "Validation failed", ConstraintViolations: []constraintViolation{{Path: "insertAfter", Message: "Setting value cannot be inserted to the specified position", ParameterLocation: "PAYLOAD_BODY"}}}
+			}
+		}
+		if result.Error == nil && !validateOnly {
+			scope := item.Scope
+			if len(scope) == 0 {
+				scope = "environment"
+			}
+			created := server.now()
+			object := &settingsObject{
+				ObjectID:      me.newObjectID(item.SchemaID, scope),
+				SchemaID:      item.SchemaID,
+				SchemaVersion: me.schemaVersion(item.SchemaID, item.SchemaVersion),
+				Scope:         scope,
+				Value:         item.Value,
+				Created:       created,
+				Modified:      created,
+			}
+			if err := me.place(object, item.InsertAfter); err != nil {
+				result.Error = &errorBody{Code: http.StatusBadRequest, Message: err.Error()}
+			} else {
+				result.ObjectID = object.ObjectID
+			}
+		}
+		if result.Error != nil {
+			result.Code = result.Error.Code
+			status = result.Error.Code
+		}
+		results = append(results, result)
+	}
+	writeJSON(w, status, results)
+}
+
+func (me *settingsStore) update(server *Server, w http.ResponseWriter, r *http.Request, objectID string, validateOnly bool) {
+	idx := me.index(objectID)
+	if idx == -1 {
+		writeError(w, http.StatusNotFound, "Settings not found")
+		return
+	}
+	var payload settingsUpdate
+	if err := readJSON(r, &payload); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if e := validateValue(payload.Value); e != nil {
+		writeError(w, e.Code, e.Message, e.ConstraintViolations...)
+		return
+	}
+	object := me.objects[idx]
+	if payload.InsertAfter != nil && (*payload.InsertAfter == objectID || (len(*payload.InsertAfter) > 0 && me.index(*payload.InsertAfter) == -1)) {
+		writeError(w, http.StatusBadRequest, "Validation failed", constraintViolation{Path: "insertAfter", Message: "Setting value cannot be inserted to the specified position", ParameterLocation: "PAYLOAD_BODY"})
+		return
+	}
+	if validateOnly {
+		writeJSON(w, http.StatusOK, settingsResult{Code: http.StatusOK, ObjectID: objectID})
+		return
+	}
+	object.Value = payload.Value
+	object.SchemaVersion = me.schemaVersion(object.SchemaID, payload.SchemaVersion)
+	object.Modified = server.now()
+	if payload.InsertAfter != nil {
+		me.remove(objectID)
+		me.place(object, payload.InsertAfter)
+	}
+	writeJSON(w, http.StatusOK, settingsResult{Code: http.StatusOK, ObjectID: objectID})
+}
+
+// pageKey carries the parameters of a list request into the follow-up requests for further pages
+type pageKey struct {
+	SchemaIDs []string `json:"schemaIds"`
+	Scopes    []string `json:"scopes"`
+	Fields    []string `json:"fields"`
+	PageSize  int      `json:"pageSize"`
+	Offset    int      `json:"offset"`
+}
+
+func splitParam(s string) []string {
+	result := []string{}
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); len(part) > 0 {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (me *settingsStore) list(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var key pageKey
+	if nextPageKey := query.Get("nextPageKey"); len(nextPageKey) > 0 {
+		data, err := base64.RawURLEncoding.DecodeString(nextPageKey)
+		if err == nil {
+			err = json.Unmarshal(data, &key)
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid next page key")
+			return
+		}
+	} else {
+		key = pageKey{
+			SchemaIDs: splitParam(query.Get("schemaIds")),
+			Scopes:    splitParam(query.Get("scopes")),
+			Fields:    splitParam(query.Get("fields")),
+			PageSize:  defaultPageSize,
+		}
+		if len(key.Fields) == 0 {
+			key.Fields = []string{"objectId", "value"}
+		}
+		if pageSize := query.Get("pageSize"); len(pageSize) > 0 {
+			var err error
+			if key.PageSize, err = strconv.Atoi(pageSize); err != nil || key.PageSize < 1 || key.PageSize > maxPageSize {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("pageSize must be between 1 and %d", maxPageSize))
+				return
+			}
+		}
+	}
+	matching := []*settingsObject{}
+	for _, object := range me.objects {
+		if len(key.SchemaIDs) > 0 && !contains(key.SchemaIDs, object.SchemaID) {
+			continue
+		}
+		if len(key.Scopes) > 0 && !contains(key.Scopes, object.Scope) {
+			continue
+		}
+		matching = append(matching, object)
+	}
+	items := []map[string]any{}
+	for idx := key.Offset; idx < len(matching) && idx < key.Offset+key.PageSize; idx++ {
+		items = append(items, matching[idx].fields(key.Fields))
+	}
+	response := map[string]any{"items": items, "totalCount": len(matching), "pageSize": key.PageSize}
+	if key.Offset+key.PageSize < len(matching) {
+		key.Offset += key.PageSize
+		data, _ := json.Marshal(key)
+		response["nextPageKey"] = base64.RawURLEncoding.EncodeToString(data)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
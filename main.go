@@ -18,11 +18,13 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
 
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/config"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -47,4 +49,5 @@ func main() {
 			return provider.Provider()
 		},
 	})
+	rest.LogRateLimitMetrics(context.Background())
 }
@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 )
@@ -64,5 +65,7 @@ func NewOAuthClient(ctx context.Context, oauthConfig OauthCredentials) *http.Cli
 		Scopes:       oauthConfig.Scopes,
 	}
 
-	return config.Client(ctx)
+	client := config.Client(ctx)
+	client.Transport = rest.NewRateLimitedTransport(client.Transport)
+	return client
 }
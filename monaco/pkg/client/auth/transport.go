@@ -18,6 +18,8 @@ package auth
 
 import (
 	"net/http"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
 )
 
 // TokenAuthTransport should be used to enable a client
@@ -29,7 +31,7 @@ type TokenAuthTransport struct {
 
 // NewTokenAuthTransport creates a new http transport to be used for token authorization
 func NewTokenAuthTransport(token string) *TokenAuthTransport {
-	t := &TokenAuthTransport{RoundTripper: http.DefaultTransport, header: http.Header{}}
+	t := &TokenAuthTransport{RoundTripper: rest.NewRateLimitedTransport(http.DefaultTransport), header: http.Header{}}
 	t.setHeader("Authorization", "Api-Token "+token)
 	return t
 }
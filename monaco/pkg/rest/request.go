@@ -155,7 +155,13 @@ func executeRequest(ctx context.Context, client *http.Client, request *http.Requ
 	request.Header.Set("User-Agent", "Dynatrace Terraform Provider")
 
 	response, err := executeWithRateLimiter(func() (Response, error) {
-		resp, err := client.Do(request)
+		// transient failures (e.g. a 503 from an ActiveGate) are retried according to the configured policy
+		resp, err := rest.GetRetryPolicy().Retry(ctx, request.Method, request.URL.String(), func(ctx context.Context) (*http.Response, error) {
+			if err := rest.Rewind(request); err != nil {
+				return nil, err
+			}
+			return client.Do(request.WithContext(ctx))
+		})
 		if err != nil {
 			log.Printf("[DEBUG] HTTP Request failed with Error: " + err.Error())
 			return Response{}, err
@@ -20,10 +20,13 @@ package config
 import (
 	"context"
 	"fmt"
+	"math"
 	"os"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -121,6 +124,7 @@ func Credentials(m any, CredentialValidation int) (*settings.Credentials, error)
 			URL:   conf.ClusterAPIV2URL,
 			Token: conf.ClusterAPIToken,
 		},
+		OnConflict: conf.OnConflict,
 	}, nil
 }
 
@@ -135,6 +139,8 @@ type ProviderConfiguration struct {
 	APIToken          string
 	IAM               IAM
 	Automation        Automation
+	ValidateOnPlan    bool
+	OnConflict        settings.ConflictPolicy
 }
 
 type Getter interface {
@@ -205,6 +211,27 @@ func ProviderConfigureGeneric(ctx context.Context, d Getter) (any, diag.Diagnost
 		oauth_endpoint_url = settings.DevIAMEndpointURL
 	}
 
+	validateOnPlan := getBool(d, "validate_on_plan")
+
+	var diags diag.Diagnostics
+
+	if retryPolicy, err := httpRetryPolicy(d); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	} else if retryPolicy != nil {
+		rest.SetRetryPolicy(*retryPolicy)
+	}
+
+	if rateLimits, err := httpRateLimits(d); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	} else if rateLimits != nil {
+		rest.SetRateLimits(*rateLimits)
+	}
+
+	onConflict := settings.ConflictPolicies.Fail
+	if value := getString(d, "on_conflict"); len(value) > 0 {
+		onConflict = settings.ConflictPolicy(value)
+	}
+
 	iam_client_id := getString(d, "iam_client_id")
 	iam_account_id := getString(d, "iam_account_id")
 	iam_client_secret := getString(d, "iam_client_secret")
@@ -230,8 +257,6 @@ func ProviderConfigureGeneric(ctx context.Context, d Getter) (any, diag.Diagnost
 	iam_account_id = streamlineOAuthCreds(iam_account_id, account_id)
 	iam_endpoint_url = streamlineOAuthCreds(iam_endpoint_url, oauth_endpoint_url)
 
-	var diags diag.Diagnostics
-
 	pc := &ProviderConfiguration{
 		EnvironmentURL:    dtEnvURL,
 		DTenvURL:          fullURL,
@@ -253,6 +278,8 @@ func ProviderConfigureGeneric(ctx context.Context, d Getter) (any, diag.Diagnost
 			TokenURL:       automation_token_url,
 			EnvironmentURL: automation_environment_url,
 		},
+		ValidateOnPlan: validateOnPlan,
+		OnConflict:     onConflict,
 	}
 	return pc, diags
 }
@@ -276,6 +303,118 @@ func getString(d Getter, key string) string {
 	return ""
 }
 
+// httpRetryPolicy evaluates the block `http_retry` of the provider configuration.
+// Settings not configured within that block fall back to `rest.DefaultRetryPolicy()`.
+// If the block isn't configured at all, `nil` is returned
+func httpRetryPolicy(d Getter) (*rest.RetryPolicy, error) {
+	blocks, ok := d.Get("http_retry").([]any)
+	if !ok || len(blocks) == 0 {
+		return nil, nil
+	}
+	block, ok := blocks[0].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	policy := rest.DefaultRetryPolicy()
+	if maxAttempts, ok := block["max_attempts"].(int); ok && maxAttempts > 0 {
+		policy.MaxAttempts = maxAttempts
+	}
+	for key, target := range map[string]*time.Duration{"min_backoff": &policy.MinBackoff, "max_backoff": &policy.MaxBackoff, "timeout": &policy.Timeout} {
+		if value, ok := block[key].(string); ok && len(value) > 0 {
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("http_retry.%s: `%s` is not a valid duration", key, value)
+			}
+			*target = duration
+		}
+	}
+	var retryOn []string
+	switch value := block["retry_on"].(type) {
+	case *schema.Set:
+		for _, elem := range value.List() {
+			retryOn = append(retryOn, elem.(string))
+		}
+	case []any:
+		for _, elem := range value {
+			retryOn = append(retryOn, elem.(string))
+		}
+	}
+	if len(retryOn) > 0 {
+		statusCodes, errorClasses, err := rest.ParseRetryOn(retryOn)
+		if err != nil {
+			return nil, fmt.Errorf("http_retry.retry_on: %s", err.Error())
+		}
+		policy.StatusCodes = statusCodes
+		policy.ErrorClasses = errorClasses
+	}
+	if nonIdempotent, ok := block["retry_non_idempotent"].(bool); ok {
+		policy.NonIdempotent = nonIdempotent
+	}
+	return &policy, nil
+}
+
+// httpRateLimits evaluates the block `http_rate_limit` of the provider configuration.
+// Settings not configured within that block fall back to `rest.DefaultRateLimits()`.
+// If the block isn't configured at all, `nil` is returned
+func httpRateLimits(d Getter) (*rest.RateLimits, error) {
+	blocks, ok := d.Get("http_rate_limit").([]any)
+	if !ok || len(blocks) == 0 {
+		return nil, nil
+	}
+	block, ok := blocks[0].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	limits := rest.DefaultRateLimits()
+	if maxConcurrency, ok := block["max_concurrency"].(int); ok && maxConcurrency != 0 {
+		if maxConcurrency < 1 || maxConcurrency > rest.HighLimitMaxConcurrency {
+			return nil, fmt.Errorf("http_rate_limit.max_concurrency: %d is not within the range of 1-%d", maxConcurrency, rest.HighLimitMaxConcurrency)
+		}
+		limits.MaxConcurrency = maxConcurrency
+	}
+	if adaptive, ok := block["adaptive"].(bool); ok {
+		limits.Adaptive = adaptive
+	}
+	families, _ := block["family"].([]any)
+	for _, elem := range families {
+		family, ok := elem.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := family["name"].(string)
+		known := false
+		for _, rateLimitFamily := range rest.RateLimitFamilies {
+			if string(rateLimitFamily) == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("http_rate_limit.family: `%s` is not a known API family", name)
+		}
+		rate, _ := family["rate"].(float64)
+		if rate < 0 {
+			return nil, fmt.Errorf("http_rate_limit.family.%s: the rate must not be negative", name)
+		}
+		burst, _ := family["burst"].(int)
+		if burst <= 0 {
+			burst = int(math.Max(1, math.Ceil(rate)))
+		}
+		limits.Families[rest.RateLimitFamily(name)] = rest.RateLimit{Rate: rate, Burst: burst}
+	}
+	return &limits, nil
+}
+
+func getBool(d Getter, key string) bool {
+	switch value := d.Get(key).(type) {
+	case bool:
+		return value
+	case string:
+		return strings.TrimSpace(value) == "true"
+	}
+	return false
+}
+
 type ConfigGetter struct {
 	Provider *schema.Provider
 }
@@ -20,7 +20,10 @@ package config_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/config"
 )
 
@@ -43,3 +46,119 @@ func TestProviderConfigure(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestProviderConfigureValidateOnPlan(t *testing.T) {
+	ctx := context.Background()
+	for _, value := range []any{true, "true"} {
+		d := mockResourceData{
+			"dt_env_url":       "https://something.live.dynatrace.com",
+			"dt_api_token":     "faketoken",
+			"validate_on_plan": value,
+		}
+		result, _ := config.ProviderConfigureGeneric(ctx, d)
+		if !result.(*config.ProviderConfiguration).ValidateOnPlan {
+			t.Errorf("validate_on_plan = %v expected to enable validation", value)
+		}
+	}
+	d := mockResourceData{
+		"dt_env_url":   "https://something.live.dynatrace.com",
+		"dt_api_token": "faketoken",
+	}
+	result, _ := config.ProviderConfigureGeneric(ctx, d)
+	if result.(*config.ProviderConfiguration).ValidateOnPlan {
+		t.Error("validation on plan is expected to be disabled by default")
+	}
+}
+
+func TestProviderConfigureHTTPRetry(t *testing.T) {
+	defer rest.SetRetryPolicy(rest.GetRetryPolicy())
+
+	ctx := context.Background()
+	d := mockResourceData{
+		"dt_env_url":   "https://something.live.dynatrace.com",
+		"dt_api_token": "faketoken",
+		"http_retry": []any{map[string]any{
+			"max_attempts": 7,
+			"max_backoff":  "2m",
+			"retry_on":     []any{"500", "timeout"},
+		}},
+	}
+	if _, diags := config.ProviderConfigureGeneric(ctx, d); diags.HasError() {
+		t.Fatal(diags)
+	}
+	policy := rest.GetRetryPolicy()
+	if policy.MaxAttempts != 7 {
+		t.Errorf("expected max_attempts 7, got %d", policy.MaxAttempts)
+	}
+	if policy.MaxBackoff != 2*time.Minute {
+		t.Errorf("expected max_backoff 2m, got %s", policy.MaxBackoff)
+	}
+	if len(policy.StatusCodes) != 1 || policy.StatusCodes[0] != 500 {
+		t.Errorf("unexpected status codes %v", policy.StatusCodes)
+	}
+
+	d["http_retry"] = []any{map[string]any{"retry_on": []any{"404"}}}
+	if _, diags := config.ProviderConfigureGeneric(ctx, d); !diags.HasError() {
+		t.Error("404 expected to be rejected as retryable status code")
+	}
+}
+
+func TestProviderConfigureHTTPRateLimit(t *testing.T) {
+	defer rest.SetRateLimits(rest.GetRateLimits())
+
+	ctx := context.Background()
+	d := mockResourceData{
+		"dt_env_url":   "https://something.live.dynatrace.com",
+		"dt_api_token": "faketoken",
+		"http_rate_limit": []any{map[string]any{
+			"max_concurrency": 4,
+			"adaptive":        false,
+			"family": []any{
+				map[string]any{"name": "settings", "rate": 2.5},
+				map[string]any{"name": "iam", "rate": 0.0},
+			},
+		}},
+	}
+	if _, diags := config.ProviderConfigureGeneric(ctx, d); diags.HasError() {
+		t.Fatal(diags)
+	}
+	limits := rest.GetRateLimits()
+	if limits.MaxConcurrency != 4 || limits.Adaptive {
+		t.Errorf("unexpected rate limits %+v", limits)
+	}
+	if limit := limits.Families[rest.RateLimitFamilySettings]; limit.Rate != 2.5 || limit.Burst != 3 {
+		t.Errorf("unexpected rate limit for settings %+v", limit)
+	}
+	if limit := limits.Families[rest.RateLimitFamilyIAM]; limit.Rate != 0 {
+		t.Errorf("expected iam not to be limited, got %+v", limit)
+	}
+
+	d["http_rate_limit"] = []any{map[string]any{"max_concurrency": 100}}
+	if _, diags := config.ProviderConfigureGeneric(ctx, d); !diags.HasError() {
+		t.Error("max_concurrency of 100 expected to be rejected")
+	}
+}
+
+func TestProviderConfigureOnConflict(t *testing.T) {
+	ctx := context.Background()
+	d := mockResourceData{
+		"dt_env_url":   "https://something.live.dynatrace.com",
+		"dt_api_token": "faketoken",
+	}
+	pc, _ := config.ProviderConfigureGeneric(ctx, d)
+	credentials, err := config.Credentials(pc, config.CredValNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policy := credentials.OnConflict; policy != settings.ConflictPolicies.Fail {
+		t.Errorf("expected conflicting updates to fail by default, got %v", policy)
+	}
+	d["on_conflict"] = "overwrite"
+	pc, _ = config.ProviderConfigureGeneric(ctx, d)
+	if credentials, err = config.Credentials(pc, config.CredValNone); err != nil {
+		t.Fatal(err)
+	}
+	if policy := credentials.OnConflict; policy != settings.ConflictPolicies.Overwrite {
+		t.Errorf("expected conflicting updates to overwrite, got %v", policy)
+	}
+}
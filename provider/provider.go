@@ -19,6 +19,7 @@ package provider
 
 import (
 	"context"
+	"time"
 
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/alerting"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/apitoken"
@@ -34,11 +35,14 @@ import (
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/credentials/vault"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/dashboard"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/deployment/lambdaagent"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/documents/converteddashboard"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/documents/document"
+	documentsnapshots "github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/documents/snapshots"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/entities"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/entity"
 	failure_detection_parameters "github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/failuredetection/parameters"
 	genericsettingsds "github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/generic/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/generic/settingsschema"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/host"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/hub/items"
 	ds_iam_groups "github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/iam/groups"
@@ -47,6 +51,7 @@ import (
 	metricsds "github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/metrics/calculated/service"
 	mgmzds "github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/mgmz"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/mobileapplication"
+	notificationpreview "github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/notifications/preview"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/process"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/processgroup"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/datasources/remoteenvironments"
@@ -69,6 +74,7 @@ import (
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/resources/backup"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/resources/bindings"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/resources/customtags"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/resources/documentsnapshotrestore"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/resources/environments"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/resources/generic"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/resources/goldenstate"
@@ -83,10 +89,21 @@ import (
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/resources/usergroups"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/resources/users"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+func validateDuration(v any, path cty.Path) diag.Diagnostics {
+	if s, ok := v.(string); ok && len(s) > 0 {
+		if _, err := time.ParseDuration(s); err != nil {
+			return diag.Errorf("`%s` is not a valid duration (e.g. `500ms`, `30s`, `2m`)", s)
+		}
+	}
+	return nil
+}
+
 // ResourceSpecification has no documentation
 type ResourceSpecification interface {
 	Resource() *schema.Resource
@@ -196,6 +213,110 @@ func Provider() *schema.Provider {
 				Description: "The URL of the Dynatrace Environment with Platform capabilities turned on (`https://#####.apps.dynatrace.com)`. This is optional configuration when `dt_env_url` already specifies a SaaS Environment like `https://#####.live.dynatrace.com` or `https://#####.apps.dynatrace.com`",
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"AUTOMATION_ENVIRONMENT_URL", "DT_AUTOMATION_ENVIRONMENT_URL", "DYNATRACE_AUTOMATION_ENVIRONMENT_URL", "DYNATRACE_AUTOMATION_ENV_URL", "DT_AUTOMATION_ENV_URL"}, nil),
 			},
+			"http_retry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Configures how HTTP requests failing because of transient problems (e.g. a `502`, `503` or `504` from an ActiveGate or load balancer, or a reset connection) get retried. Requests hitting the rate limit (HTTP `429`) are handled independently of this configuration",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_attempts": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "The maximum number of times a request is sent, including the first attempt. Default: `5`. Specify `1` to disable retries",
+						},
+						"min_backoff": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Description:      "The time to wait before the first retry, e.g. `500ms`. The wait time doubles with every further attempt and is randomized. Default: `1s`",
+							ValidateDiagFunc: validateDuration,
+						},
+						"max_backoff": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Description:      "The maximum time to wait between two attempts, e.g. `1m`. Default: `30s`",
+							ValidateDiagFunc: validateDuration,
+						},
+						"timeout": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Description:      "The deadline for a request including all of its retries, e.g. `5m`. An attempt still running at the deadline gets aborted and no further attempts are made once it would be exceeded. Default: no deadline",
+							ValidateDiagFunc: validateDuration,
+						},
+						"retry_on": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The HTTP status codes (`500`-`599`) and error classes (`timeout`, `connection_reset`, `connection_refused`, `eof`) considered to be transient. Default: `502`, `503`, `504` and all error classes",
+						},
+						"retry_non_idempotent": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "By default only requests with idempotent methods (`GET`, `PUT`, `DELETE`, ...) get retried. If `true` also `POST` and `PATCH` requests get retried, which may lead to duplicate objects",
+						},
+					},
+				},
+			},
+			"http_rate_limit": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Configures how many requests are sent to the Dynatrace APIs. Each family of APIs (`classic_config`, `settings`, `iam`, `platform`, `documents`) is limited by its own token bucket, all of them share the maximum number of concurrent requests",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_concurrency": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "The maximum number of requests in flight across all API families (`1`-`50`). Default: `20` or the value of the environment variable `DYNATRACE_MAX_HTTP_WORKERS`",
+						},
+						"adaptive": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "If `true` (default), the rate of an API family is lowered to the limit reported by the `X-RateLimit-Limit` header of responses, and requests are held back once `X-RateLimit-Remaining` reaches zero",
+						},
+						"family": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Overrides the rate limit of an API family. By default only `iam` is limited to one request per second, the other families adapt to the limits reported by the server",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:             schema.TypeString,
+										Required:         true,
+										Description:      "The API family. Possible values are `classic_config`, `settings`, `iam`, `platform` and `documents`",
+										ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"classic_config", "settings", "iam", "platform", "documents"}, false)),
+									},
+									"rate": {
+										Type:             schema.TypeFloat,
+										Required:         true,
+										Description:      "The number of requests per second, e.g. `0.5`. Specify `0` for no limit",
+										ValidateDiagFunc: validation.ToDiagFunc(validation.FloatAtLeast(0)),
+									},
+									"burst": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Description: "The number of requests that may be sent without delay after being idle. Default: `rate`, rounded up",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"on_conflict": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.MultiEnvDefaultFunc([]string{"DYNATRACE_ON_CONFLICT", "DT_ON_CONFLICT"}, "fail"),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"fail", "overwrite"}, false)),
+				Description:      "Defines how updates of documents (`dynatrace_document`) and workflows (`dynatrace_automation_workflow`) deal with modifications that happened outside of Terraform since the last refresh. With `fail` (default) the update is refused and the remote changes are reported. With `overwrite` the remote changes are discarded",
+			},
+			"validate_on_plan": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"DYNATRACE_VALIDATE_ON_PLAN", "DT_VALIDATE_ON_PLAN"}, false),
+				Description: "If `true`, resources backed by the Settings 2.0 API get validated by the Dynatrace Environment (`validateOnly=true`) while Terraform is calculating a plan. Constraint violations are then reported by `terraform plan` instead of failing during `terraform apply`. Validation is skipped for resources whose configuration refers to values that are not known yet",
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"dynatrace_alerting_profiles":            alerting.DataSource(),
@@ -244,6 +365,11 @@ func Provider() *schema.Provider {
 			"dynatrace_generic_setting":              genericsettingsds.DataSource(),
 			"dynatrace_api_tokens":                   apitoken.DataSourceMultiple(),
 			"dynatrace_api_token":                    apitoken.DataSource(),
+			"dynatrace_golden_state_report":          goldenstate.DataSource(),
+			"dynatrace_converted_dashboard":          converteddashboard.DataSource(),
+			"dynatrace_document_snapshots":           documentsnapshots.DataSource(),
+			"dynatrace_settings_schema":              settingsschema.DataSource(),
+			"dynatrace_notification_preview":         notificationpreview.DataSource(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"dynatrace_custom_service":                      resources.NewGeneric(export.ResourceTypes.CustomService).Resource(),
@@ -329,6 +455,8 @@ func Provider() *schema.Provider {
 			"dynatrace_network_zone":                        resources.NewGeneric(export.ResourceTypes.NetworkZone).Resource(),
 			"dynatrace_iam_user":                            resources.NewGeneric(export.ResourceTypes.IAMUser, resources.CredValIAM).Resource(),
 			"dynatrace_iam_group":                           resources.NewGeneric(export.ResourceTypes.IAMGroup, resources.CredValIAM).Resource(),
+			"dynatrace_iam_group_members":                   resources.NewGeneric(export.ResourceTypes.IAMGroupMembers, resources.CredValIAM).Resource(),
+			"dynatrace_iam_group_member":                    resources.NewGeneric(export.ResourceTypes.IAMGroupMember, resources.CredValIAM).Resource(),
 			"dynatrace_iam_permission":                      resources.NewGeneric(export.ResourceTypes.IAMPermission, resources.CredValIAM).Resource(),
 			"dynatrace_iam_policy":                          resources.NewGeneric(export.ResourceTypes.IAMPolicy, resources.CredValIAM).Resource(),
 			"dynatrace_iam_policy_bindings":                 resources.NewGeneric(export.ResourceTypes.IAMPolicyBindings, resources.CredValIAM).Resource(),
@@ -539,8 +667,10 @@ func Provider() *schema.Provider {
 			"dynatrace_managed_network_zones":               networkzones.Resource(),
 			"dynatrace_hub_extension_config":                resources.NewGeneric(export.ResourceTypes.HubExtensionConfig).Resource(),
 			"dynatrace_hub_extension_active_version":        resources.NewGeneric(export.ResourceTypes.HubActiveExtensionVersion).Resource(),
+			"dynatrace_extension_package":                   resources.NewGeneric(export.ResourceTypes.ExtensionPackage).Resource(),
 			"dynatrace_document":                            resources.NewGeneric(export.ResourceTypes.Documents).Resource(),
 			"dynatrace_direct_shares":                       resources.NewGeneric(export.ResourceTypes.DirectShares).Resource(),
+			"dynatrace_document_snapshot_restore":           documentsnapshotrestore.Resource(),
 			"dynatrace_db_app_feature_flags":                resources.NewGeneric(export.ResourceTypes.DatabaseAppFeatureFlags).Resource(),
 			"dynatrace_infraops_app_feature_flags":          resources.NewGeneric(export.ResourceTypes.InfraOpsAppFeatureFlags).Resource(),
 			"dynatrace_ebpf_service_discovery":              resources.NewGeneric(export.ResourceTypes.EBPFServiceDiscovery).Resource(),
@@ -575,6 +705,22 @@ func Provider() *schema.Provider {
 			"dynatrace_kubernetes_spm":                      resources.NewGeneric(export.ResourceTypes.KubernetesSPM).Resource(),
 			"dynatrace_log_agent_feature_flags":             resources.NewGeneric(export.ResourceTypes.LogAgentFeatureFlags).Resource(),
 			"dynatrace_problem_record_propagation_rules":    resources.NewGeneric(export.ResourceTypes.ProblemRecordPropagationRules).Resource(),
+
+			"dynatrace_openpipeline_logs_pipeline":                 resources.NewGeneric(export.ResourceTypes.OpenPipelineLogsPipeline).Resource(),
+			"dynatrace_openpipeline_logs_routing_entry":            resources.NewGeneric(export.ResourceTypes.OpenPipelineLogsRouting).Resource(),
+			"dynatrace_openpipeline_logs_endpoint":                 resources.NewGeneric(export.ResourceTypes.OpenPipelineLogsEndpoint).Resource(),
+			"dynatrace_openpipeline_events_pipeline":               resources.NewGeneric(export.ResourceTypes.OpenPipelineEventsPipeline).Resource(),
+			"dynatrace_openpipeline_events_routing_entry":          resources.NewGeneric(export.ResourceTypes.OpenPipelineEventsRouting).Resource(),
+			"dynatrace_openpipeline_events_endpoint":               resources.NewGeneric(export.ResourceTypes.OpenPipelineEventsEndpoint).Resource(),
+			"dynatrace_openpipeline_security_events_pipeline":      resources.NewGeneric(export.ResourceTypes.OpenPipelineSecurityEventsPipeline).Resource(),
+			"dynatrace_openpipeline_security_events_routing_entry": resources.NewGeneric(export.ResourceTypes.OpenPipelineSecurityEventsRouting).Resource(),
+			"dynatrace_openpipeline_security_events_endpoint":      resources.NewGeneric(export.ResourceTypes.OpenPipelineSecurityEventsEndpoint).Resource(),
+			"dynatrace_openpipeline_business_events_pipeline":      resources.NewGeneric(export.ResourceTypes.OpenPipelineBusinessEventsPipeline).Resource(),
+			"dynatrace_openpipeline_business_events_routing_entry": resources.NewGeneric(export.ResourceTypes.OpenPipelineBusinessEventsRouting).Resource(),
+			"dynatrace_openpipeline_business_events_endpoint":      resources.NewGeneric(export.ResourceTypes.OpenPipelineBusinessEventsEndpoint).Resource(),
+			"dynatrace_openpipeline_sdlc_events_pipeline":          resources.NewGeneric(export.ResourceTypes.OpenPipelineSDLCEventsPipeline).Resource(),
+			"dynatrace_openpipeline_sdlc_events_routing_entry":     resources.NewGeneric(export.ResourceTypes.OpenPipelineSDLCEventsRouting).Resource(),
+			"dynatrace_openpipeline_sdlc_events_endpoint":          resources.NewGeneric(export.ResourceTypes.OpenPipelineSDLCEventsEndpoint).Resource(),
 		},
 		ConfigureContextFunc: config.ProviderConfigure,
 	}
@@ -0,0 +1,116 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package documentsnapshotrestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	documents "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document"
+	documentsettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/config"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Resource produces the terraform resource definition for rolling back a document to one of its snapshots.
+// Creating the resource performs the restore. Destroying it leaves the document untouched.
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"document_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the document (dashboard or notebook) to roll back",
+			},
+			"snapshot_version": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The version of the snapshot to restore. The data source `dynatrace_document_snapshots` lists the available snapshots of a document",
+			},
+			"restored_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The version of the document right after the snapshot has been restored",
+			},
+		},
+		CreateContext: logging.Enable(Create),
+		ReadContext:   logging.Enable(Read),
+		DeleteContext: logging.Enable(Delete),
+	}
+}
+
+// Create restores the snapshot
+func Create(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	creds, err := config.Credentials(m, config.CredValAutomation)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	documentID := d.Get("document_id").(string)
+	snapshotVersion := d.Get("snapshot_version").(int)
+	version, err := documents.NewSnapshotService(creds).Restore(ctx, documentID, snapshotVersion)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%s#%d", documentID, snapshotVersion))
+	d.Set("restored_version", version)
+	return diag.Diagnostics{}
+}
+
+// Read only verifies that the document still exists.
+// Modifications of the document after the snapshot has been restored are not considered a change of this resource
+func Read(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	creds, err := config.Credentials(m, config.CredValAutomation)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	documentID, snapshotVersion, found := strings.Cut(d.Id(), "#")
+	if !found {
+		return diag.Errorf("`%s` is not a valid ID. Expected format: `<document-id>#<snapshot-version>`", d.Id())
+	}
+	var document documentsettings.Document
+	if err := documents.Service(creds).Get(ctx, documentID, &document); err != nil {
+		var apiErr api.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 404 {
+			d.SetId("")
+			return diag.Diagnostics{}
+		}
+		return diag.FromErr(err)
+	}
+	version, err := strconv.Atoi(snapshotVersion)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("document_id", documentID)
+	d.Set("snapshot_version", version)
+	return diag.Diagnostics{}
+}
+
+// Delete doesn't touch the document. It remains in the state it currently is in
+func Delete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	d.SetId("")
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,94 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package documentsnapshotrestore_test
+
+import (
+	"context"
+	"testing"
+
+	documentservice "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document"
+	documents "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/config"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/resources/documentsnapshotrestore"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	ctx := context.Background()
+	credentials := server.Credentials()
+	service := documentservice.Service(credentials)
+	stub, err := service.Create(ctx, &documents.Document{Name: "restore", Type: "notebook", Content: `{"sections":[{"id":"a","type":"markdown","markdown":"v1"}]}`, IsPrivate: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Update(ctx, stub.ID, &documents.Document{Name: "restore", Type: "notebook", Content: `{"sections":[{"id":"a","type":"markdown","markdown":"v2"}]}`, IsPrivate: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &config.ProviderConfiguration{
+		EnvironmentURL: server.URL,
+		APIToken:       mockserver.Token,
+		Automation: config.Automation{
+			ClientID:       credentials.Automation.ClientID,
+			ClientSecret:   credentials.Automation.ClientSecret,
+			TokenURL:       credentials.Automation.TokenURL,
+			EnvironmentURL: credentials.Automation.EnvironmentURL,
+		},
+	}
+	d := schema.TestResourceDataRaw(t, documentsnapshotrestore.Resource().Schema, map[string]any{
+		"document_id":      stub.ID,
+		"snapshot_version": 1,
+	})
+	if diags := documentsnapshotrestore.Create(ctx, d, m); diags.HasError() {
+		t.Fatal(diags)
+	}
+	var document documents.Document
+	if err := service.Get(ctx, stub.ID, &document); err != nil {
+		t.Fatal(err)
+	}
+	if document.Content != `{"sections":[{"id":"a","type":"markdown","markdown":"v1"}]}` {
+		t.Errorf("expected the content of the first snapshot to be restored, got %s", document.Content)
+	}
+	if restored := d.Get("restored_version").(int); restored != document.Version {
+		t.Errorf("expected restored version %d, got %d", document.Version, restored)
+	}
+
+	if diags := documentsnapshotrestore.Read(ctx, d, m); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(d.Id()) == 0 {
+		t.Fatal("expected the resource to remain in the state as long as the document exists")
+	}
+
+	if err := service.Delete(ctx, stub.ID); err != nil {
+		t.Fatal(err)
+	}
+	if diags := documentsnapshotrestore.Read(ctx, d, m); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(d.Id()) != 0 {
+		t.Errorf("expected the resource to be removed from the state after the document has been deleted, got ID `%s`", d.Id())
+	}
+}
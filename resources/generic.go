@@ -133,9 +133,7 @@ func (me *Generic) Resource() *schema.Resource {
 		if updateableAttrs > 0 {
 			resRes.UpdateContext = logging.Enable(me.Update)
 		}
-		if dc, ok := stngs.(DiffCustomizer); ok {
-			resRes.CustomizeDiff = dc.CustomizeDiff
-		}
+		resRes.CustomizeDiff = me.customizeDiff(stngs)
 		return resRes
 	}
 
@@ -149,10 +147,79 @@ func (me *Generic) Resource() *schema.Resource {
 	if updateableAttrs > 0 {
 		resRes.UpdateContext = logging.Enable(me.Update)
 	}
+	resRes.CustomizeDiff = me.customizeDiff(stngs)
+	return resRes
+}
+
+// customizeDiff combines the CustomizeDiff function of the given settings (if there is one)
+// with the validation performed by the Dynatrace Environment during `terraform plan`
+func (me *Generic) customizeDiff(stngs settings.Settings) schema.CustomizeDiffFunc {
 	if dc, ok := stngs.(DiffCustomizer); ok {
-		resRes.CustomizeDiff = dc.CustomizeDiff
+		return func(ctx context.Context, rd *schema.ResourceDiff, m any) error {
+			if err := dc.CustomizeDiff(ctx, rd, m); err != nil {
+				return err
+			}
+			return me.ValidateDiff(ctx, rd, m)
+		}
 	}
-	return resRes
+	return me.ValidateDiff
+}
+
+// ValidateDiff lets the Dynatrace Environment validate the planned settings without persisting them.
+// It only kicks in if the provider has been configured with `validate_on_plan = true` and the
+// service behind this resource is able to validate without any side effects (Settings 2.0).
+// Plans containing values that are not known yet (e.g. IDs of resources that still need to get created)
+// cannot get validated reliably and are therefore skipped.
+func (me *Generic) ValidateDiff(ctx context.Context, rd *schema.ResourceDiff, m any) error {
+	conf, ok := m.(*config.ProviderConfiguration)
+	if !ok || conf == nil || !conf.ValidateOnPlan {
+		return nil
+	}
+	if diags := me.validateCredentials(m); len(diags) > 0 {
+		return nil
+	}
+	if len(rd.Id()) > 0 && len(rd.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	if !rd.GetRawPlan().IsWhollyKnown() {
+		return nil
+	}
+	service := me.Service(m)
+	if pv, ok := service.(settings.PlanValidator); !ok || !pv.SupportsPlanValidation() {
+		return nil
+	}
+	validator, ok := service.(settings.Validator[settings.Settings])
+	if !ok {
+		return nil
+	}
+	sttngs := me.Settings()
+	if err := hcl.UnmarshalHCL(sttngs, hcl.DecoderFrom(rd)); err != nil {
+		// the configuration is not decodable yet - the actual create or update will report that
+		return nil
+	}
+	if settings.RefersToMissingID(sttngs) {
+		return nil
+	}
+	if len(rd.Id()) > 0 && !strings.HasSuffix(rd.Id(), "---flawed----") {
+		ctx = context.WithValue(ctx, settings.ContextKeyValidateID, rd.Id())
+	}
+	if err := validator.Validate(ctx, sttngs); err != nil {
+		if restError, ok := err.(rest.Error); ok {
+			return validationError(&restError)
+		}
+		if restError, ok := err.(*rest.Error); ok && restError != nil {
+			return validationError(restError)
+		}
+		return err
+	}
+	return nil
+}
+
+func validationError(restError *rest.Error) error {
+	if vm := restError.ViolationMessage(); len(vm) > 0 {
+		return errors.New(vm)
+	}
+	return errors.New(restError.Message)
 }
 
 func (me *Generic) createCredentials(m any) *settings.Credentials {
@@ -162,6 +229,7 @@ func (me *Generic) createCredentials(m any) *settings.Credentials {
 		URL:        conf.EnvironmentURL,
 		IAM:        conf.IAM,
 		Automation: conf.Automation,
+		OnConflict: conf.OnConflict,
 	}
 }
 
@@ -241,7 +309,7 @@ func (me *Generic) Create(ctx context.Context, d *schema.ResourceData, m any) di
 	// because of the current rate limitations of api.dynatrace.com we simply trust
 	// that the results on the remote side are correct
 	// and therefore avoid unnecessary GET calls
-	if me.Type == export.ResourceTypes.IAMGroup || me.Type == export.ResourceTypes.IAMPermission || me.Type == export.ResourceTypes.IAMPolicy || me.Type == export.ResourceTypes.IAMPolicyBindings || me.Type == export.ResourceTypes.IAMPolicyBindingsV2 || me.Type == export.ResourceTypes.IAMUser {
+	if me.Type == export.ResourceTypes.IAMGroup || me.Type == export.ResourceTypes.IAMPermission || me.Type == export.ResourceTypes.IAMPolicy || me.Type == export.ResourceTypes.IAMPolicyBindings || me.Type == export.ResourceTypes.IAMPolicyBindingsV2 || me.Type == export.ResourceTypes.IAMUser || me.Type == export.ResourceTypes.IAMGroupMembers || me.Type == export.ResourceTypes.IAMGroupMember {
 		return diag.Diagnostics{}
 	}
 	return me.Read(context.WithValue(ctx, settings.ContextKeyStateConfig, sttngs), d, m)
@@ -268,6 +336,9 @@ func (me *Generic) Update(ctx context.Context, d *schema.ResourceData, m any) di
 	}
 	err = service.Update(ctx, d.Id(), sttngs)
 	if err != nil {
+		if conflictError, ok := err.(*settings.ConflictError); ok {
+			return diag.Diagnostics{diag.Diagnostic{Severity: diag.Error, Summary: conflictError.Error(), Detail: conflictError.Detail()}}
+		}
 		if restWarning, ok := err.(rest.Warning); ok {
 			return diag.Diagnostics{diag.Diagnostic{Severity: diag.Warning, Summary: restWarning.Message}}
 		}
@@ -290,7 +361,7 @@ func (me *Generic) Update(ctx context.Context, d *schema.ResourceData, m any) di
 	// because of the current rate limitations of api.dynatrace.com we simply trust
 	// that the results on the remote side are correct
 	// and therefore avoid unnecessary GET calls
-	if me.Type == export.ResourceTypes.IAMGroup || me.Type == export.ResourceTypes.IAMPermission || me.Type == export.ResourceTypes.IAMPolicy || me.Type == export.ResourceTypes.IAMPolicyBindings || me.Type == export.ResourceTypes.IAMPolicyBindingsV2 || me.Type == export.ResourceTypes.IAMUser {
+	if me.Type == export.ResourceTypes.IAMGroup || me.Type == export.ResourceTypes.IAMPermission || me.Type == export.ResourceTypes.IAMPolicy || me.Type == export.ResourceTypes.IAMPolicyBindings || me.Type == export.ResourceTypes.IAMPolicyBindingsV2 || me.Type == export.ResourceTypes.IAMUser || me.Type == export.ResourceTypes.IAMGroupMembers || me.Type == export.ResourceTypes.IAMGroupMember {
 		return diag.Diagnostics{}
 	}
 	return me.Read(ctx, d, m)
@@ -19,10 +19,13 @@ package generic
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/generic"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/generic/schemas"
 	settings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/generic/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/config"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/confighcl"
@@ -39,10 +42,45 @@ func Resource() *schema.Resource {
 		UpdateContext: logging.Enable(Update),
 		ReadContext:   logging.Enable(Read),
 		DeleteContext: logging.Enable(Delete),
+		CustomizeDiff: logging.EnableCustomizeDiff(CustomizeDiff),
 		Importer:      &schema.ResourceImporter{StateContext: schema.ImportStatePassthroughContext},
 	}
 }
 
+// CustomizeDiff validates `value` and `scope` against the schema of the settings during `terraform plan`.
+// Values which are not known yet can't get validated. Neither can settings for which the schema is not accessible
+// with the configured credentials. These are left for the Dynatrace Environment to validate.
+func CustomizeDiff(ctx context.Context, rd *schema.ResourceDiff, m any) error {
+	if !rd.NewValueKnown("schema") || !rd.NewValueKnown("value") {
+		return nil
+	}
+	creds, err := config.Credentials(m, config.CredValDefault)
+	if err != nil {
+		return nil
+	}
+	schemaID := rd.Get("schema").(string)
+	sch, err := schemas.Fetch(ctx, creds, schemaID, "")
+	if err != nil {
+		if restErr, ok := err.(rest.Error); ok && restErr.Code == 404 {
+			return fmt.Errorf("the schema `%s` doesn't exist", schemaID)
+		}
+		return nil
+	}
+	// an unknown scope doesn't get validated
+	// settings get created for the scope `environment` unless configured otherwise
+	scope := ""
+	switch rawScope := rd.GetRawConfig().GetAttr("scope"); {
+	case !rawScope.IsKnown():
+	case !rawScope.IsNull():
+		scope = rawScope.AsString()
+	case len(rd.Id()) > 0:
+		scope = rd.Get("scope").(string)
+	default:
+		scope = "environment"
+	}
+	return sch.Validate(scope, rd.Get("value").(string))
+}
+
 func Create(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	var err error
 	creds, err := config.Credentials(m, config.CredValDefault)
@@ -119,7 +157,12 @@ func Read(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 		return diag.FromErr(err)
 	}
 	if len(stateConfig.Value) > 0 {
-		stateConfig.Merge(apiConfig)
+		// the schema tells which properties are secrets, which is more reliable than looking for masked values
+		if sch, err := schemas.Fetch(ctx, creds, apiConfig.SchemaID, apiConfig.SchemaVersion); err == nil && sch.Properties != nil {
+			stateConfig.MergeWith(apiConfig, sch)
+		} else {
+			stateConfig.Merge(apiConfig)
+		}
 		stateConfig.LocalStorage = stateConfig.Value
 		configToMarshal = stateConfig
 	} else {
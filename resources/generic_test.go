@@ -0,0 +1,192 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package resources_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/config"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/resources"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+type validatedSettings struct {
+	Name string `json:"name"`
+}
+
+func (me *validatedSettings) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+}
+
+func (me *validatedSettings) MarshalHCL(properties hcl.Properties) error {
+	return properties.Encode("name", me.Name)
+}
+
+func (me *validatedSettings) UnmarshalHCL(decoder hcl.Decoder) error {
+	return decoder.Decode("name", &me.Name)
+}
+
+// validatingService only supports validation, which fails with the configured error
+type validatingService struct {
+	err       error
+	validated []string
+}
+
+func (me *validatingService) List(ctx context.Context) (api.Stubs, error) {
+	return api.Stubs{}, nil
+}
+
+func (me *validatingService) Get(ctx context.Context, id string, v *validatedSettings) error {
+	return rest.Error{Code: 404, Message: "not found"}
+}
+
+func (me *validatingService) SchemaID() string {
+	return "test:validated"
+}
+
+func (me *validatingService) Create(ctx context.Context, v *validatedSettings) (*api.Stub, error) {
+	return nil, errors.New("not supported")
+}
+
+func (me *validatingService) Update(ctx context.Context, id string, v *validatedSettings) error {
+	return errors.New("not supported")
+}
+
+func (me *validatingService) Delete(ctx context.Context, id string) error {
+	return errors.New("not supported")
+}
+
+func (me *validatingService) SupportsPlanValidation() bool {
+	return true
+}
+
+func (me *validatingService) Validate(ctx context.Context, v *validatedSettings) error {
+	me.validated = append(me.validated, v.Name)
+	return me.err
+}
+
+func diffWith(t *testing.T, service *validatingService, validateOnPlan bool) error {
+	t.Helper()
+	descriptor := export.NewResourceDescriptor(func(credentials *settings.Credentials) settings.CRUDService[*validatedSettings] {
+		return service
+	})
+	generic := &resources.Generic{Type: "dynatrace_validated", Descriptor: descriptor}
+	conf := &config.ProviderConfiguration{
+		EnvironmentURL: "https://localhost",
+		APIToken:       "token",
+		ValidateOnPlan: validateOnPlan,
+	}
+	_, err := generic.Resource().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]any{"name": "planned"}), conf)
+	return err
+}
+
+func TestValidateDiff(t *testing.T) {
+	violations := []rest.ConstraintViolation{
+		{Path: "name", Message: "Name must be unique"},
+		{Path: "enabled", Message: "must not be null"},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		service := &validatingService{}
+		if err := diffWith(t, service, true); err != nil {
+			t.Fatalf("expected no error, got %s", err.Error())
+		}
+		if len(service.validated) != 1 || service.validated[0] != "planned" {
+			t.Errorf("expected the planned settings to get validated, got %v", service.validated)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		service := &validatingService{err: rest.Error{Code: 400, Message: "Validation failed"}}
+		if err := diffWith(t, service, false); err != nil {
+			t.Fatalf("expected no error, got %s", err.Error())
+		}
+		if len(service.validated) != 0 {
+			t.Errorf("expected no validation without `validate_on_plan`")
+		}
+	})
+
+	t.Run("violations", func(t *testing.T) {
+		service := &validatingService{err: rest.Error{Code: 400, Message: "Validation failed", ConstraintViolations: violations}}
+		err := diffWith(t, service, true)
+		if err == nil {
+			t.Fatal("expected the constraint violations to get reported")
+		}
+		if err.Error() != "Name must be unique\nenabled must not be null" {
+			t.Errorf("expected only the constraint violations to get reported, got %s", err.Error())
+		}
+	})
+
+	t.Run("pointer error", func(t *testing.T) {
+		service := &validatingService{err: &rest.Error{Code: 400, Message: "Validation failed", ConstraintViolations: violations}}
+		err := diffWith(t, service, true)
+		if err == nil {
+			t.Fatal("expected the constraint violations to get reported")
+		}
+		if err.Error() != "Name must be unique\nenabled must not be null" {
+			t.Errorf("expected only the constraint violations to get reported, got %s", err.Error())
+		}
+	})
+
+	t.Run("no violations", func(t *testing.T) {
+		service := &validatingService{err: &rest.Error{Code: 400, Message: "Unknown schema"}}
+		err := diffWith(t, service, true)
+		if err == nil || err.Error() != "Unknown schema" {
+			t.Errorf("expected the error message to get reported, got %v", err)
+		}
+	})
+
+	t.Run("transport failure", func(t *testing.T) {
+		service := &validatingService{err: errors.New("dial tcp: connection refused")}
+		err := diffWith(t, service, true)
+		if err == nil || !strings.Contains(err.Error(), "connection refused") {
+			t.Errorf("expected the transport failure to get reported, got %v", err)
+		}
+	})
+}
+
+func TestPlanValidationOfWrappedSettingsServices(t *testing.T) {
+	credentials := &settings.Credentials{URL: "https://abc.live.dynatrace.com", Token: "token"}
+	for _, resourceType := range []export.ResourceType{
+		export.ResourceTypes.AutoTagV2,
+		export.ResourceTypes.LogCustomSource,
+		export.ResourceTypes.DashboardsPresets,
+		export.ResourceTypes.OpenTelemetryMetrics,
+		export.ResourceTypes.ApplicationDetectionV2,
+		export.ResourceTypes.HostMonitoringMode,
+	} {
+		service := export.AllResources[resourceType].Service(credentials)
+		if pv, ok := service.(settings.PlanValidator); !ok || !pv.SupportsPlanValidation() {
+			t.Errorf("%s: expected the service to support validation during plan", resourceType)
+		}
+	}
+}
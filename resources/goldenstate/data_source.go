@@ -0,0 +1,135 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package goldenstate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	cfg "github.com/dynatrace-oss/terraform-provider-dynatrace/provider/config"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSource reports the settings not managed by Terraform without warning about them or deleting them.
+// In contrast to `dynatrace_golden_state` it is available without `DYNATRACE_GOLDEN_STATE_ENABLED`, because it doesn't modify anything.
+func DataSource() *schema.Resource {
+	schemaMap := map[string]*schema.Schema{
+		"resource_types": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "The resource types to report on. Omit this attribute in order to report on all resource types supported by `dynatrace_golden_state`",
+			Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice(supportedResourceTypes(), false)},
+		},
+		"ignore": ignoreSchema(),
+		"unmanaged": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The settings not managed by Terraform, grouped by resource type. Resource types without such settings are omitted",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"resource_type": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"objects": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"id": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"name": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"total": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The number of settings not managed by Terraform",
+		},
+	}
+	for resource := range serviceMap {
+		schemaMap[string(resource)] = &schema.Schema{
+			Type:        schema.TypeSet,
+			Description: fmt.Sprintf("The IDs of the resources of type `%s` managed by Terraform. These settings are not getting reported", resource),
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		}
+	}
+	return &schema.Resource{
+		ReadContext: logging.EnableDSCtx(DataSourceRead),
+		Schema:      schemaMap,
+	}
+}
+
+func DataSourceRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	creds, err := cfg.Credentials(m, cfg.CredValDefault)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rules, err := IgnoreRulesFrom(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resourceTypes := setToStrings(d.Get("resource_types"))
+	if len(resourceTypes) == 0 {
+		resourceTypes = supportedResourceTypes()
+	}
+	sort.Strings(resourceTypes)
+
+	unmanaged := []any{}
+	total := 0
+	for _, resourceType := range resourceTypes {
+		managedIDs := setToStrings(d.Get(resourceType))
+		_, stubs, err := Unmanaged(ctx, creds, export.ResourceType(resourceType), managedIDs, rules)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if len(stubs) == 0 {
+			continue
+		}
+		stubs = stubs.Sort()
+		objects := []any{}
+		for _, stub := range stubs {
+			objects = append(objects, map[string]any{"id": stub.ID, "name": stub.Name})
+		}
+		unmanaged = append(unmanaged, map[string]any{"resource_type": resourceType, "objects": objects})
+		total += len(stubs)
+	}
+	d.SetId("DYNATRACE_GOLDEN_STATE_REPORT")
+	if err := d.Set("unmanaged", unmanaged); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("total", total); err != nil {
+		return diag.FromErr(err)
+	}
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,117 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package goldenstate_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/alerting/profile"
+	profiles "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/alerting/profile/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/managementzones"
+	mzsettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/managementzones/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/config"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/resources/goldenstate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestGoldenStateReport(t *testing.T) {
+	ctx := context.Background()
+	server, err := mockserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	mzService := managementzones.Service(server.Credentials())
+	for _, name := range []string{"team-a", "team-b", "legacy"} {
+		if _, err := mzService.Create(ctx, &mzsettings.Settings{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mzStubs, err := mzService.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var teamA *string
+	for _, stub := range mzStubs {
+		if stub.Name == "team-a" {
+			teamA = stub.LegacyID
+		}
+	}
+	if teamA == nil {
+		t.Fatalf("expected management zone `team-a` with legacy ID, got %+v", mzStubs)
+	}
+
+	profileService := profile.Service(server.Credentials())
+	if _, err := profileService.Create(ctx, &profiles.Profile{Name: "scoped", ManagementZone: teamA, SeverityRules: profiles.SeverityRules{}}); err != nil {
+		t.Fatal(err)
+	}
+	shared, err := profileService.Create(ctx, &profiles.Profile{Name: "shared", SeverityRules: profiles.SeverityRules{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := profileService.Create(ctx, &profiles.Profile{Name: "known", SeverityRules: profiles.SeverityRules{}}); err != nil {
+		t.Fatal(err)
+	}
+	known, err := profileService.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	knownID := ""
+	for _, stub := range known {
+		if stub.Name == "known" {
+			knownID = stub.ID
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, goldenstate.DataSource().Schema, map[string]any{
+		"resource_types": []any{"dynatrace_management_zone_v2", "dynatrace_alerting"},
+		"ignore": []any{
+			map[string]any{"resource_types": []any{"dynatrace_management_zone_v2"}, "name_regexes": []any{"^team-"}},
+			map[string]any{"resource_types": []any{"dynatrace_alerting"}, "management_zones": []any{"team-a"}},
+		},
+		// the IDs of the resources managed by Terraform
+		"dynatrace_alerting": []any{"[ known ] " + knownID},
+	})
+	if diags := goldenstate.DataSourceRead(ctx, d, &config.ProviderConfiguration{EnvironmentURL: server.URL, APIToken: mockserver.Token}); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if total := d.Get("total").(int); total != 2 {
+		t.Errorf("expected 2 unmanaged settings, got %d", total)
+	}
+	expected := map[string]string{"dynatrace_alerting": shared.ID, "dynatrace_management_zone_v2": "legacy"}
+	unmanaged := d.Get("unmanaged").([]any)
+	if len(unmanaged) != len(expected) {
+		t.Fatalf("expected unmanaged settings for %d resource types, got %v", len(expected), unmanaged)
+	}
+	for _, untypedEntry := range unmanaged {
+		entry := untypedEntry.(map[string]any)
+		objects := entry["objects"].([]any)
+		if len(objects) != 1 {
+			t.Errorf("expected exactly one unmanaged `%s`, got %v", entry["resource_type"], objects)
+			continue
+		}
+		object := objects[0].(map[string]any)
+		if want := expected[entry["resource_type"].(string)]; object["id"] != want && object["name"] != want {
+			t.Errorf("expected unmanaged `%s` to be `%s`, got %v", entry["resource_type"], want, object)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package goldenstate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ignoreSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Rules for settings that should be treated as if they were managed by Terraform. A setting is getting ignored if it is matched by any of these rules.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"resource_types": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "The resource types this rule applies to. Omit this attribute if the rule should apply to all resource types",
+					Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice(supportedResourceTypes(), false)},
+				},
+				"ids": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "Settings with any of these IDs are getting ignored",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"name_regexes": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "Settings with a name matching any of these regular expressions are getting ignored. Settings without a name are not affected by this attribute",
+					Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsValidRegExp},
+				},
+				"management_zones": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "The names of management zones. Settings referring to any of these management zones (and the management zones themselves) are getting ignored. Settings that don't contain their configuration in the list response need to get fetched individually in order to evaluate this attribute",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+func supportedResourceTypes() []string {
+	resourceTypes := []string{}
+	for resourceType := range serviceMap {
+		resourceTypes = append(resourceTypes, string(resourceType))
+	}
+	sort.Strings(resourceTypes)
+	return resourceTypes
+}
+
+// IgnoreRule represents a block `ignore` of `dynatrace_golden_state` or `dynatrace_golden_state_report`
+type IgnoreRule struct {
+	ResourceTypes map[export.ResourceType]bool
+	IDs           map[string]bool
+	Filter        *export.ResourceFilter
+}
+
+type IgnoreRules []*IgnoreRule
+
+// IgnoreRulesFrom reads the blocks `ignore` from the given resource data
+func IgnoreRulesFrom(d *schema.ResourceData) (IgnoreRules, error) {
+	rules := IgnoreRules{}
+	untypedRules, ok := d.Get("ignore").([]any)
+	if !ok {
+		return rules, nil
+	}
+	for _, untypedRule := range untypedRules {
+		ruleMap, ok := untypedRule.(map[string]any)
+		if !ok {
+			continue
+		}
+		rule := &IgnoreRule{ResourceTypes: map[export.ResourceType]bool{}, IDs: map[string]bool{}}
+		for _, resourceType := range setToStrings(ruleMap["resource_types"]) {
+			rule.ResourceTypes[export.ResourceType(resourceType)] = true
+		}
+		for _, id := range setToStrings(ruleMap["ids"]) {
+			rule.IDs[parseID(id)] = true
+		}
+		args := export.FilterArgs{
+			NameRegexes:     setToStrings(ruleMap["name_regexes"]),
+			ManagementZones: setToStrings(ruleMap["management_zones"]),
+		}
+		// a setting is getting ignored if either its name or its management zone matches
+		args.MatchAny = len(args.NameRegexes) > 0 || len(args.ManagementZones) > 0
+		filter, err := export.NewResourceFilter(args)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block `ignore`: %s", err.Error())
+		}
+		rule.Filter = filter
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Ignores evaluates whether any of the rules matches the given setting.
+// The configuration of the setting is getting fetched via `service` only if a rule requires it.
+func (me IgnoreRules) Ignores(ctx context.Context, creds *settings.Credentials, key export.ResourceType, service BasicService, stub *api.Stub) (bool, error) {
+	for _, rule := range me {
+		if len(rule.ResourceTypes) > 0 && !rule.ResourceTypes[key] {
+			continue
+		}
+		if rule.IDs[stub.ID] {
+			return true, nil
+		}
+		if rule.Filter == nil {
+			continue
+		}
+		matches, err := rule.Filter.MatchesStub(creds, key, stub, func() (any, error) { return service.Get(ctx, stub.ID) })
+		if err != nil {
+			return false, err
+		}
+		if matches {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Unmanaged lists the settings of the given resource type that are neither among the given IDs nor ignored by any of the rules
+func Unmanaged(ctx context.Context, creds *settings.Credentials, key export.ResourceType, ids []string, rules IgnoreRules) (BasicService, api.Stubs, error) {
+	service := serviceMap[key](creds)
+
+	stubs, err := service.List(ctx)
+	if err != nil {
+		return service, nil, err
+	}
+	knownIDs := map[string]bool{}
+	for _, id := range ids {
+		knownIDs[parseID(id)] = true
+	}
+	unmanaged := api.Stubs{}
+	for _, stub := range stubs {
+		if knownIDs[stub.ID] {
+			continue
+		}
+		ignored, err := rules.Ignores(ctx, creds, key, service, stub)
+		if err != nil {
+			return service, nil, err
+		}
+		if !ignored {
+			unmanaged = append(unmanaged, stub)
+		}
+	}
+	return service, unmanaged, nil
+}
+
+// parseID extracts the ID out of values in the form `[ name ] id`, which is how the IDs are getting stored in the state
+func parseID(id string) string {
+	if matches := regexpNameId.FindStringSubmatch(id); len(matches) == 3 {
+		return matches[2]
+	}
+	return id
+}
+
+func setToStrings(v any) []string {
+	result := []string{}
+	set, ok := v.(*schema.Set)
+	if !ok {
+		return result
+	}
+	for _, elem := range set.List() {
+		if s, ok := elem.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -42,6 +42,7 @@ func Resource() *schema.Resource {
 			Description:  "Possible values are:\n* `DELETE` if you want resources to automatally get deleted`n* `WARN` if you want to get notified about resources that aren't managed by Terraform via a warning message from this resource`\nDefault is `WARN`.",
 			ValidateFunc: validation.StringInSlice([]string{"DELETE", "WARN"}, false),
 		},
+		"ignore": ignoreSchema(),
 	}
 	for resource := range serviceMap {
 		schemaMap[string(resource)] = &schema.Schema{
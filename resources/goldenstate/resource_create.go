@@ -51,8 +51,12 @@ func Update(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics
 func update(ctx context.Context, d *schema.ResourceData, m any, indent string) diag.Diagnostics {
 	allDiags := diag.Diagnostics{}
 	creds, _ := cfg.Credentials(m, cfg.CredValNone)
+	rules, err := IgnoreRulesFrom(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	for key := range serviceMap {
-		diags, err := CommonUpdate(ctx, d, key, creds, "  ")
+		diags, err := CommonUpdate(ctx, d, key, creds, rules, "  ")
 		if len(diags) > 0 {
 			details := ""
 			for _, diagElem := range diags {
@@ -67,7 +71,7 @@ func update(ctx context.Context, d *schema.ResourceData, m any, indent string) d
 	return append(allDiags, read(ctx, d, m, indent+"  ")...)
 }
 
-func CommonUpdate(ctx context.Context, d *schema.ResourceData, key export.ResourceType, creds *settings.Credentials, indent string) (diag.Diagnostics, error) {
+func CommonUpdate(ctx context.Context, d *schema.ResourceData, key export.ResourceType, creds *settings.Credentials, rules IgnoreRules, indent string) (diag.Diagnostics, error) {
 	mode := "QUIET"
 	utMode := d.Get("mode")
 	if tMode, ok := utMode.(string); ok {
@@ -86,37 +90,20 @@ func CommonUpdate(ctx context.Context, d *schema.ResourceData, key export.Resour
 	if !ok {
 		return diag.Diagnostics{}, nil
 	}
-	service := serviceMap[key](creds)
-
-	stubs, err := service.List(ctx)
-
+	service, stubs, err := Unmanaged(ctx, creds, key, setToStrings(untypedIDs), rules)
 	if err != nil {
 		return diag.Diagnostics{}, err
 	}
-	existingIDs := map[string]string{}
-	for _, stub := range stubs {
-		existingIDs[stub.ID] = stub.Name
-	}
-	if idSet, ok := untypedIDs.(*schema.Set); ok {
-		for _, untypedID := range idSet.List() {
-			if id, ok := untypedID.(string); ok {
-				if matches := regexpNameId.FindStringSubmatch(id); len(matches) == 3 {
-					id = matches[2]
-				}
-				delete(existingIDs, id)
-			}
-		}
-	}
 
 	diags := diag.Diagnostics{}
 
-	for id, name := range existingIDs {
+	for _, stub := range stubs {
 		switch mode {
 		case "QUIET":
 		case "WARN":
-			diags = append(diags, diag.Diagnostic{Summary: fmt.Sprintf("[ %-24s ] %s", trimName(name), id)})
+			diags = append(diags, diag.Diagnostic{Summary: fmt.Sprintf("[ %-24s ] %s", trimName(stub.Name), stub.ID)})
 		case "DELETE":
-			if err := service.Delete(ctx, id); err != nil {
+			if err := service.Delete(ctx, stub.ID); err != nil {
 				return diags, err
 			}
 		}
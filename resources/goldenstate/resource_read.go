@@ -41,12 +41,16 @@ func Read(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 func read(ctx context.Context, d *schema.ResourceData, m any, indent string) diag.Diagnostics {
 	// logging.File.Println(indent, "-- READ --")
 	creds, _ := cfg.Credentials(m, cfg.CredValNone)
+	rules, err := IgnoreRulesFrom(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	var wg sync.WaitGroup
 	wg.Add(len(serviceMap))
 	for key := range serviceMap {
 		go func() {
 			defer wg.Done()
-			CommonRead(ctx, d, creds, key, indent+"  ")
+			CommonRead(ctx, d, creds, key, rules, indent+"  ")
 		}()
 	}
 	wg.Wait()
@@ -86,12 +90,11 @@ func Set(d *schema.ResourceData, key string, ids []string) {
 	d.Set(key, ids)
 }
 
-func CommonRead(ctx context.Context, d *schema.ResourceData, creds *settings.Credentials, key export.ResourceType, indent string) error {
+func CommonRead(ctx context.Context, d *schema.ResourceData, creds *settings.Credentials, key export.ResourceType, rules IgnoreRules, indent string) error {
 	idMap := map[string]string{}
 
-	service := serviceMap[key](creds)
-
-	stubs, err := service.List(ctx)
+	// settings ignored by rules are treated as if they were managed by Terraform
+	_, stubs, err := Unmanaged(ctx, creds, key, nil, rules)
 
 	if err == nil {
 		for _, stub := range stubs {
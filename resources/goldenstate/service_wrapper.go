@@ -29,6 +29,7 @@ type ServiceFunc func(*settings.Credentials) BasicService
 
 type BasicService interface {
 	List(ctx context.Context) (api.Stubs, error)
+	Get(ctx context.Context, id string) (settings.Settings, error)
 	Delete(ctx context.Context, id string) error
 }
 
@@ -53,6 +54,14 @@ func (me *GenericService[T]) List(ctx context.Context) (api.Stubs, error) {
 	return me.Service.List(ctx)
 }
 
+func (me *GenericService[T]) Get(ctx context.Context, id string) (settings.Settings, error) {
+	v := settings.NewSettings(me.Service)
+	if err := me.Service.Get(ctx, id, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
 func (me *GenericService[T]) Delete(ctx context.Context, id string) error {
 	return me.Service.Delete(ctx, id)
 }
@@ -0,0 +1,402 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package testbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider"
+	hcty "github.com/hashicorp/go-cty/cty"
+	hctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// offlineFunctions are the functions configurations applied by `ApplyOffline` may use
+var offlineFunctions = map[string]function.Function{
+	"jsonencode": stdlib.JSONEncodeFunc,
+	"jsondecode": stdlib.JSONDecodeFunc,
+	"format":     stdlib.FormatFunc,
+	"join":       stdlib.JoinFunc,
+	"lower":      stdlib.LowerFunc,
+	"upper":      stdlib.UpperFunc,
+	"trimspace":  stdlib.TrimSpaceFunc,
+	"replace":    stdlib.ReplaceFunc,
+}
+
+// metaArguments are handled by Terraform itself and are therefore not part of the configuration of a resource
+var metaArguments = map[string]bool{"depends_on": true, "provider": true, "lifecycle": true}
+
+type offlineResource struct {
+	Type     string
+	Name     string
+	Body     *hclsyntax.Body
+	Resource *schema.Resource
+	State    *terraform.InstanceState
+}
+
+func (me *offlineResource) Address() string {
+	return me.Type + "." + me.Name
+}
+
+// ApplyOffline creates the resources of the given configuration in-process - without the Terraform CLI - and destroys them again afterwards.
+// After creating the resources they are getting refreshed and planned again, which is expected to produce no changes unless `expectNonEmptyPlan` is set.
+// The provider is configured via the environment variables only, therefore it's meant to be used together with the mock server.
+// Configurations the in-process apply doesn't support (data sources, variables, locals, modules, `count`, `for_each`, dynamic blocks and resources of other providers)
+// let the test fail, because skipping them would report tests as passed that never ran. They need to get covered with TF_ACC set instead.
+func ApplyOffline(t *testing.T, config string, expectNonEmptyPlan bool) {
+	t.Helper()
+	ctx := context.Background()
+
+	resources, reason := parseOffline(t, config)
+	if len(reason) > 0 {
+		t.Fatalf("configuration not supported without TF_ACC: %s", reason)
+	}
+
+	prov := provider.Provider()
+	if diags := prov.Configure(ctx, terraform.NewResourceConfigRaw(nil)); diags.HasError() {
+		t.Fatal(diagsError(diags))
+	}
+	meta := prov.Meta()
+
+	created := []*offlineResource{}
+	t.Cleanup(func() {
+		for idx := len(created) - 1; idx >= 0; idx-- {
+			res := created[idx]
+			if _, diags := res.Resource.Apply(ctx, res.State, &terraform.InstanceDiff{Destroy: true}, meta); diags.HasError() {
+				t.Errorf("destroying %s failed: %s", res.Address(), diagsError(diags))
+			}
+		}
+	})
+
+	configs := map[string]*terraform.ResourceConfig{}
+	for _, res := range resources {
+		cfg, val, err := res.config(created)
+		if err != nil {
+			t.Fatalf("%s: %s", res.Address(), err.Error())
+		}
+		if diags := res.Resource.Validate(cfg); diags.HasError() {
+			t.Fatalf("%s: %s", res.Address(), diagsError(diags))
+		}
+		configs[res.Address()] = cfg
+
+		planned := &terraform.InstanceState{RawConfig: val, RawPlan: val, RawState: hcty.NullVal(val.Type())}
+		diff, err := res.Resource.SimpleDiff(ctx, planned, cfg, meta)
+		if err != nil {
+			t.Fatalf("planning %s failed: %s", res.Address(), err.Error())
+		}
+		state, diags := res.Resource.Apply(ctx, planned, diff, meta)
+		if state != nil && len(state.ID) > 0 {
+			res.State = state
+			created = append(created, res)
+		}
+		if diags.HasError() {
+			t.Fatalf("creating %s failed: %s", res.Address(), diagsError(diags))
+		}
+		if res.State == nil {
+			t.Fatalf("%s was absent after it had been created", res.Address())
+		}
+		res.State.RawConfig = val
+	}
+
+	for _, res := range created {
+		if err := res.rawState(); err != nil {
+			t.Fatalf("%s: %s", res.Address(), err.Error())
+		}
+		state, diags := res.Resource.RefreshWithoutUpgrade(ctx, res.State, meta)
+		if diags.HasError() {
+			t.Fatalf("refreshing %s failed: %s", res.Address(), diagsError(diags))
+		}
+		if state == nil {
+			t.Fatalf("%s doesn't exist anymore after it has been created", res.Address())
+		}
+		state.RawConfig = res.State.RawConfig
+		res.State = state
+		if err := res.rawState(); err != nil {
+			t.Fatalf("%s: %s", res.Address(), err.Error())
+		}
+
+		diff, err := res.Resource.SimpleDiff(ctx, res.State, configs[res.Address()], meta)
+		if err != nil {
+			t.Fatalf("planning %s failed: %s", res.Address(), err.Error())
+		}
+		if !expectNonEmptyPlan && diff != nil && !diff.Empty() {
+			t.Errorf("after applying this step, the plan for %s was not empty:\n%s", res.Address(), describeDiff(diff))
+		}
+	}
+}
+
+// rawState provides the state to the resource the same way Terraform does during refresh and plan
+func (me *offlineResource) rawState() error {
+	state, err := me.State.AttrsAsObjectValue(me.Resource.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		return err
+	}
+	me.State.RawState = state
+	return nil
+}
+
+// config evaluates the configuration of the resource, given the resources that have been created so far
+func (me *offlineResource) config(created []*offlineResource) (*terraform.ResourceConfig, hcty.Value, error) {
+	ctx, err := evalContext(created)
+	if err != nil {
+		return nil, hcty.NilVal, err
+	}
+	m, diags := evalBody(me.Body, ctx, true)
+	if diags.HasErrors() {
+		return nil, hcty.NilVal, diags
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, hcty.NilVal, err
+	}
+	coreSchema := me.Resource.CoreConfigSchema()
+	val, err := hctyjson.Unmarshal(data, coreSchema.ImpliedType())
+	if err != nil {
+		return nil, hcty.NilVal, err
+	}
+	return terraform.NewResourceConfigShimmed(val, coreSchema), val, nil
+}
+
+// evalContext exposes the state of the resources created so far for references like `dynatrace_xyz.name.id`
+func evalContext(created []*offlineResource) (*hcl.EvalContext, error) {
+	types := map[string]map[string]cty.Value{}
+	for _, res := range created {
+		ty := res.Resource.CoreConfigSchema().ImpliedType()
+		state, err := res.State.AttrsAsObjectValue(ty)
+		if err != nil {
+			return nil, err
+		}
+		data, err := hctyjson.Marshal(state, ty)
+		if err != nil {
+			return nil, err
+		}
+		impliedType, err := ctyjson.ImpliedType(data)
+		if err != nil {
+			return nil, err
+		}
+		value, err := ctyjson.Unmarshal(data, impliedType)
+		if err != nil {
+			return nil, err
+		}
+		if _, found := types[res.Type]; !found {
+			types[res.Type] = map[string]cty.Value{}
+		}
+		types[res.Type][res.Name] = value
+	}
+	variables := map[string]cty.Value{}
+	for resourceType, resources := range types {
+		variables[resourceType] = cty.ObjectVal(resources)
+	}
+	return &hcl.EvalContext{Variables: variables, Functions: offlineFunctions}, nil
+}
+
+// evalBody converts the attributes and nested blocks of a resource into the structure `terraform.NewResourceConfigRaw` would expect
+func evalBody(body *hclsyntax.Body, ctx *hcl.EvalContext, top bool) (map[string]any, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	m := map[string]any{}
+	for name, attribute := range body.Attributes {
+		if top && metaArguments[name] {
+			continue
+		}
+		value, valueDiags := attribute.Expr.Value(ctx)
+		if diags = append(diags, valueDiags...); valueDiags.HasErrors() {
+			continue
+		}
+		if value.IsNull() {
+			continue
+		}
+		data, err := ctyjson.Marshal(value, value.Type())
+		if err != nil {
+			diags = append(diags, &hcl.Diagnostic{Severity: hcl.DiagError, Summary: err.Error(), Subject: attribute.Range().Ptr()})
+			continue
+		}
+		var v any
+		if err := json.Unmarshal(data, &v); err != nil {
+			diags = append(diags, &hcl.Diagnostic{Severity: hcl.DiagError, Summary: err.Error(), Subject: attribute.Range().Ptr()})
+			continue
+		}
+		m[name] = v
+	}
+	for _, block := range body.Blocks {
+		if top && metaArguments[block.Type] {
+			continue
+		}
+		nested, nestedDiags := evalBody(block.Body, ctx, false)
+		diags = append(diags, nestedDiags...)
+		list, _ := m[block.Type].([]any)
+		m[block.Type] = append(list, nested)
+	}
+	return m, diags
+}
+
+// parseOffline returns the resources of the configuration in the order they need to get created in.
+// If the configuration contains anything that can't get applied in-process, the reason is getting returned instead.
+func parseOffline(t *testing.T, config string) ([]*offlineResource, string) {
+	t.Helper()
+	file, diags := hclsyntax.ParseConfig([]byte(config), "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	resourcesMap := provider.Provider().ResourcesMap
+
+	resources := map[string]*offlineResource{}
+	order := []string{}
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		switch block.Type {
+		case "provider", "terraform", "output":
+			continue
+		case "resource":
+		default:
+			return nil, fmt.Sprintf("`%s` blocks", block.Type)
+		}
+		res := &offlineResource{Type: block.Labels[0], Name: block.Labels[1], Body: block.Body}
+		if res.Resource = resourcesMap[res.Type]; res.Resource == nil {
+			return nil, fmt.Sprintf("resource type `%s`", res.Type)
+		}
+		if reason := unsupported(block.Body); len(reason) > 0 {
+			return nil, reason
+		}
+		resources[res.Address()] = res
+		order = append(order, res.Address())
+	}
+
+	dependencies := map[string][]string{}
+	for _, address := range order {
+		for _, traversal := range variables(resources[address].Body) {
+			root := traversal.RootName()
+			if _, found := resourcesMap[root]; !found {
+				return nil, fmt.Sprintf("references to `%s`", root)
+			}
+			if len(traversal) < 2 {
+				return nil, fmt.Sprintf("references to `%s`", root)
+			}
+			attr, ok := traversal[1].(hcl.TraverseAttr)
+			if !ok {
+				return nil, fmt.Sprintf("references to `%s`", root)
+			}
+			dependency := root + "." + attr.Name
+			if _, found := resources[dependency]; !found {
+				return nil, fmt.Sprintf("references to the undeclared resource `%s`", dependency)
+			}
+			dependencies[address] = append(dependencies[address], dependency)
+		}
+	}
+
+	sorted := []*offlineResource{}
+	done := map[string]bool{}
+	for len(sorted) < len(order) {
+		progress := false
+		for _, address := range order {
+			if done[address] {
+				continue
+			}
+			ready := true
+			for _, dependency := range dependencies[address] {
+				ready = ready && done[dependency]
+			}
+			if ready {
+				done[address] = true
+				sorted = append(sorted, resources[address])
+				progress = true
+			}
+		}
+		if !progress {
+			return nil, "cyclic references between resources"
+		}
+	}
+	return sorted, ""
+}
+
+// unsupported returns a reason if the body of a resource uses constructs `ApplyOffline` isn't able to evaluate
+func unsupported(body *hclsyntax.Body) string {
+	for _, name := range []string{"count", "for_each"} {
+		if _, found := body.Attributes[name]; found {
+			return fmt.Sprintf("`%s`", name)
+		}
+	}
+	return unsupportedBlocks(body)
+}
+
+func unsupportedBlocks(body *hclsyntax.Body) string {
+	for _, block := range body.Blocks {
+		if block.Type == "dynamic" {
+			return "dynamic blocks"
+		}
+		if reason := unsupportedBlocks(block.Body); len(reason) > 0 {
+			return reason
+		}
+	}
+	return ""
+}
+
+func variables(body *hclsyntax.Body) []hcl.Traversal {
+	traversals := []hcl.Traversal{}
+	for _, attribute := range body.Attributes {
+		traversals = append(traversals, attribute.Expr.Variables()...)
+	}
+	for _, block := range body.Blocks {
+		// `ignore_changes` and the like refer to attributes, not to resources
+		if block.Type == "lifecycle" {
+			continue
+		}
+		traversals = append(traversals, variables(block.Body)...)
+	}
+	return traversals
+}
+
+func describeDiff(diff *terraform.InstanceDiff) string {
+	keys := []string{}
+	for key := range diff.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	lines := []string{}
+	for _, key := range keys {
+		attr := diff.Attributes[key]
+		lines = append(lines, fmt.Sprintf("  %s: %q => %q", key, attr.Old, attr.New))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func diagsError(diags diag.Diagnostics) string {
+	messages := []string{}
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+		if len(d.Detail) > 0 {
+			messages = append(messages, d.Summary+": "+d.Detail)
+		} else {
+			messages = append(messages, d.Summary)
+		}
+	}
+	return strings.Join(messages, "\n")
+}
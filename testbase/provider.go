@@ -22,6 +22,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -58,6 +59,10 @@ func init() {
 func TestAccPreCheck(t *testing.T) {
 	ctx := context.Background()
 
+	if server := mockserver.Start(t); server != nil {
+		server.Setenv(t)
+	}
+
 	if v := os.Getenv("DYNATRACE_ENV_URL"); v == "" {
 		t.Fatalf("[WARN] DYNATRACE_ENV_URL has not been set for acceptance tests")
 	}
@@ -17,7 +17,32 @@
 
 package testbase_test
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/mockserver"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/testbase"
+)
 
 func TestTestBase(t *testing.T) {
 }
+
+// the second profile is declared first, but refers to the first one and therefore needs to get created after it
+const offlineConfig = `
+resource "dynatrace_alerting" "second" {
+  name            = "${dynatrace_alerting.first.name}-second"
+  management_zone = ""
+}
+
+resource "dynatrace_alerting" "first" {
+  name            = "first"
+  management_zone = ""
+}
+`
+
+func TestApplyOffline(t *testing.T) {
+	t.Setenv(mockserver.ENV_VAR_MOCK_SERVER, "true")
+	mockserver.Start(t).Setenv(t)
+
+	testbase.ApplyOffline(t, offlineConfig, false)
+}
@@ -0,0 +1,91 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// diff writes a unified diff between the files currently on disk and the generated ones.
+// It returns the number of files which differ
+func diff(w io.Writer, root string, files map[string][]byte) (int, error) {
+	changed := 0
+	for _, name := range sortedKeys(files) {
+		rel, err := filepath.Rel(root, name)
+		if err != nil {
+			rel = name
+		}
+		rel = filepath.ToSlash(rel)
+
+		existing, err := os.ReadFile(name)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return changed, err
+		}
+		if string(existing) == string(files[name]) {
+			continue
+		}
+		from := "a/" + rel
+		if existing == nil {
+			from = "/dev/null"
+		}
+		text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(existing)),
+			B:        difflib.SplitLines(string(files[name])),
+			FromFile: from,
+			ToFile:   "b/" + rel,
+			Context:  3,
+		})
+		if err != nil {
+			return changed, err
+		}
+		if _, err := io.WriteString(w, text); err != nil {
+			return changed, err
+		}
+		changed++
+	}
+	return changed, nil
+}
+
+// obsolete lists the Go files within the `settings` folder of a package that wouldn't get generated anymore.
+// These are either types which got removed from the schema or code that was added manually
+func obsolete(dir string, files map[string][]byte) []string {
+	entries, err := os.ReadDir(filepath.Join(dir, "settings"))
+	if err != nil {
+		return nil
+	}
+	result := []string{}
+	for _, entry := range entries {
+		name := filepath.Join(dir, "settings", entry.Name())
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
+			continue
+		}
+		if _, found := files[name]; !found {
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,41 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+// Command settingsgen generates the package of a Settings 2.0 schema below `dynatrace/api/builtin`
+// out of the schema definition the Settings API returns for `/api/v2/settings/schemas/{id}`.
+//
+// It produces the `settings` package (one file per complex type, plus `enums.go`), `service.go` with the
+// service constructor based on `settings20.Service` and a copy of the schema definition in `schema.json`.
+// The test, the example configuration and the documentation template are only getting created if they don't exist yet,
+// because they are usually getting curated manually afterwards.
+//
+// New packages are getting generated by placing the schema definition into an empty folder:
+//
+//	curl -H "Authorization: Api-Token $DYNATRACE_API_TOKEN" $DYNATRACE_ENV_URL/api/v2/settings/schemas/builtin:grail.metrics.allow-list > schema.json
+//	go run github.com/dynatrace-oss/terraform-provider-dynatrace/tools/settingsgen -resource dynatrace_grail_metrics_allowlist -subcategory Platform
+//
+// With `-resource` the tool also registers the resource with the export (`dynatrace/export/enums.go`, `dynatrace/export/resource_descriptor.go`)
+// and the provider (`provider/provider.go`), unless these already know about it.
+//
+// Packages that should stay in sync with their schema can carry a directive
+//
+//	//go:generate go run github.com/dynatrace-oss/terraform-provider-dynatrace/tools/settingsgen
+//
+// After replacing `schema.json` with a newer version of the schema, `-diff` prints the changes a regeneration would introduce
+// without writing any files. This turns schema version bumps into reviewable regenerations and reveals code that has been
+// added to a package manually, which a regeneration would otherwise remove.
+package main
@@ -0,0 +1,260 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/generic/schemas"
+)
+
+// exampleFile produces `testdata/terraform/example_a.tf`.
+// Optional attributes are only getting configured if their preconditions are met by the values chosen for the other attributes
+func (me *generator) exampleFile(resource string) []byte {
+	var sb strings.Builder
+	sb.WriteString("resource \"" + resource + "\" \"#name#\" {\n")
+	def := me.settingsStruct()
+	attributes, blocks := me.exampleBody(def.fields, "  ")
+	if def.scope != nil {
+		scope := "environment"
+		if def.scope.required {
+			scope = exampleScope(me.schema.AllowedScopes)
+		}
+		attributes = append(attributes, [2]string{"scope", strconv.Quote(scope)})
+	}
+	writeAttributes(&sb, attributes, "  ")
+	sb.WriteString(blocks)
+	sb.WriteString("}")
+	return []byte(sb.String())
+}
+
+func (me *generator) exampleBody(fields []*field, indent string) ([][2]string, string) {
+	values := map[string]any{}
+	for _, f := range fields {
+		if f.property.Precondition == nil {
+			if v, ok := me.exampleValue(f); ok {
+				values[f.jsonName] = v
+			}
+		}
+	}
+	// preconditions may depend on properties which themselves depend on preconditions
+	for changed := true; changed; {
+		changed = false
+		for _, f := range fields {
+			if _, found := values[f.jsonName]; found || f.property.Precondition == nil {
+				continue
+			}
+			var pre precondition
+			if err := json.Unmarshal(f.property.Precondition, &pre); err != nil || !evaluate(&pre, values) {
+				continue
+			}
+			if v, ok := me.exampleValue(f); ok {
+				values[f.jsonName] = v
+				changed = true
+			}
+		}
+	}
+
+	attributes := [][2]string{}
+	var blocks strings.Builder
+	for _, f := range fields {
+		v, found := values[f.jsonName]
+		if !found {
+			continue
+		}
+		if len(f.wrapper) > 0 || (strings.HasPrefix(f.goType, "*") && !f.pointer) {
+			typeName := strings.TrimPrefix(f.goType, "*")
+			if len(f.wrapper) > 0 {
+				typeName = strings.TrimSuffix(f.wrapper, "s")
+			}
+			blocks.WriteString(indent + f.hcl + " {\n")
+			inner := indent + "  "
+			if len(f.wrapper) > 0 {
+				blocks.WriteString(inner + hclName(typeName) + " {\n")
+				inner = inner + "  "
+			}
+			innerAttributes, innerBlocks := me.exampleBody(me.fieldsOfType(typeName), inner)
+			writeAttributes(&blocks, innerAttributes, inner)
+			blocks.WriteString(innerBlocks)
+			if len(f.wrapper) > 0 {
+				blocks.WriteString(indent + "  }\n")
+			}
+			blocks.WriteString(indent + "}\n")
+			continue
+		}
+		attributes = append(attributes, [2]string{f.hcl, hclValue(v)})
+	}
+	return attributes, blocks.String()
+}
+
+// exampleValue picks the value of a property for the example configuration.
+// Nullable properties are only getting configured if the schema provides a default value for them
+func (me *generator) exampleValue(f *field) (any, bool) {
+	p := f.property
+	if p.Default != nil {
+		if s, ok := p.Default.(string); !ok || len(s) > 0 {
+			return p.Default, true
+		}
+	}
+	if p.Nullable || (p.Precondition == nil && !f.required) {
+		return nil, false
+	}
+	if len(f.wrapper) > 0 || strings.HasPrefix(f.goType, "*") && !f.pointer {
+		return struct{}{}, true
+	}
+	t := p.Type
+	if p.Items != nil {
+		t = p.Items.Type
+	}
+	var value any = "terraform"
+	if f.jsonName == "name" {
+		value = "#name#"
+	} else if values := me.schema.EnumValues(t); len(values) > 0 {
+		value = values[0]
+	} else {
+		switch t.Name {
+		case "boolean":
+			value = false
+		case "integer", "float":
+			value = float64(0)
+			if c := rangeConstraint(p); c != nil && c.Minimum != nil {
+				value = *c.Minimum
+			}
+		}
+	}
+	if strings.HasPrefix(f.goType, "[]") {
+		return []any{value}, true
+	}
+	return value, true
+}
+
+func (me *generator) fieldsOfType(typeName string) []*field {
+	for ref, name := range me.typeNames {
+		if name == typeName {
+			return me.fields(me.schema.Types[strings.TrimPrefix(ref, "#/types/")].Properties)
+		}
+	}
+	return nil
+}
+
+func rangeConstraint(p *schemas.Property) *schemas.Constraint {
+	for _, c := range p.Constraints {
+		if c.Type == "RANGE" {
+			return c
+		}
+	}
+	return nil
+}
+
+// evaluate decides whether the given values fulfill a precondition
+func evaluate(pre *precondition, values map[string]any) bool {
+	switch pre.Type {
+	case "EQUALS":
+		v, found := values[pre.Property]
+		return found && fmt.Sprint(v) == fmt.Sprint(pre.ExpectedValue)
+	case "IN":
+		v, found := values[pre.Property]
+		return found && slices.ContainsFunc(pre.ExpectedValues, func(expected any) bool { return fmt.Sprint(v) == fmt.Sprint(expected) })
+	case "NULL":
+		_, found := values[pre.Property]
+		return !found
+	case "NOT":
+		return pre.Precondition != nil && !evaluate(pre.Precondition, values)
+	case "AND":
+		for _, p := range pre.Preconditions {
+			if !evaluate(p, values) {
+				return false
+			}
+		}
+		return len(pre.Preconditions) > 0
+	case "OR":
+		for _, p := range pre.Preconditions {
+			if evaluate(p, values) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hclValue(v any) string {
+	switch tv := v.(type) {
+	case bool:
+		return strconv.FormatBool(tv)
+	case float64:
+		return strconv.FormatFloat(tv, 'f', -1, 64)
+	case []any:
+		elems := []string{}
+		for _, elem := range tv {
+			elems = append(elems, hclValue(elem))
+		}
+		return "[ " + strings.Join(elems, ", ") + " ]"
+	}
+	return strconv.Quote(fmt.Sprint(v))
+}
+
+// writeAttributes writes attributes with their equal signs aligned, the way `terraform fmt` does
+func writeAttributes(sb *strings.Builder, attributes [][2]string, indent string) {
+	sort.SliceStable(attributes, func(i, j int) bool { return attributes[i][0] < attributes[j][0] })
+	width := 0
+	for _, attribute := range attributes {
+		width = max(width, len(attribute[0]))
+	}
+	for _, attribute := range attributes {
+		sb.WriteString(indent + attribute[0] + strings.Repeat(" ", width-len(attribute[0])) + " = " + attribute[1] + "\n")
+	}
+}
+
+func exampleScope(allowedScopes []string) string {
+	if len(allowedScopes) == 0 {
+		return "environment"
+	}
+	return allowedScopes[0] + "-1234567890000000"
+}
+
+// templateFile produces the documentation template below `templates/resources`
+func (me *generator) templateFile(resource string, subcategory string, examplePath string) []byte {
+	title := strings.TrimSpace(me.schema.DisplayName)
+	if len(title) == 0 {
+		title = me.schema.SchemaID
+	}
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	sb.WriteString("layout: \"\"\n")
+	sb.WriteString("page_title: " + resource + " Resource - terraform-provider-dynatrace\"\n")
+	sb.WriteString("subcategory: " + strconv.Quote(subcategory) + "\n")
+	sb.WriteString("description: |-\n")
+	sb.WriteString("  The resource `" + resource + "` covers configuration for " + title + "\n")
+	sb.WriteString("---\n\n")
+	sb.WriteString("# " + resource + " (Resource)\n\n")
+	sb.WriteString("-> This resource requires the API token scopes **Read settings** (`settings.read`) and **Write settings** (`settings.write`)\n\n")
+	sb.WriteString("## Dynatrace Documentation\n\n")
+	sb.WriteString("- " + title + " - https://www.dynatrace.com/support/help/dynatrace-api/environment-api/settings (schemaId: `" + me.schema.SchemaID + "`)\n\n")
+	sb.WriteString("## Export Example Usage\n\n")
+	sb.WriteString("- `terraform-provider-dynatrace -export " + resource + "` downloads all existing " + strings.ToLower(title) + " configuration\n\n")
+	sb.WriteString("The full documentation of the export feature is available [here](https://dt-url.net/h203qmc).\n\n")
+	sb.WriteString("## Resource Example Usage\n\n")
+	sb.WriteString("{{ tffile " + strconv.Quote(examplePath) + " }}\n\n")
+	sb.WriteString("{{ .SchemaMarkdown | trimspace }}")
+	return []byte(sb.String())
+}
@@ -0,0 +1,685 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/generic/schemas"
+)
+
+const modulePath = "github.com/dynatrace-oss/terraform-provider-dynatrace"
+
+// generator produces the files of a package for a Settings 2.0 schema.
+// The generated code follows the conventions of the packages below `dynatrace/api/builtin`
+type generator struct {
+	schema      *schemas.Schema
+	importPath  string // import path of the package to generate
+	pkg         string // name of the package to generate
+	enumNames   map[string]string
+	typeNames   map[string]string
+	wrapperKind map[string]string // the kind of list (`schema.TypeList` or `schema.TypeSet`) the elements of a complex type are getting wrapped into
+}
+
+func newGenerator(schema *schemas.Schema, importPath string) *generator {
+	g := &generator{
+		schema:      schema,
+		importPath:  importPath,
+		pkg:         packageName(path.Base(importPath)),
+		enumNames:   map[string]string{},
+		typeNames:   map[string]string{},
+		wrapperKind: map[string]string{},
+	}
+	for name := range schema.Types {
+		g.typeNames["#/types/"+name] = goName(name)
+	}
+	for name := range schema.Enums {
+		enumName := goName(name)
+		for _, typeName := range g.typeNames {
+			if typeName == enumName {
+				enumName = enumName + "Enum"
+			}
+		}
+		g.enumNames["#/enums/"+name] = enumName
+	}
+	g.collectWrappers(schema.Properties)
+	for _, t := range schema.Types {
+		g.collectWrappers(t.Properties)
+	}
+	return g
+}
+
+func (me *generator) collectWrappers(properties map[string]*schemas.Property) {
+	for _, property := range properties {
+		if property.Items == nil {
+			continue
+		}
+		if _, found := me.typeNames[property.Items.Type.Ref]; found {
+			if _, found := me.wrapperKind[property.Items.Type.Ref]; !found {
+				me.wrapperKind[property.Items.Type.Ref] = listKind(property)
+			}
+		}
+	}
+}
+
+// Files produces the contents of all files of the package, keyed by their path relative to the package folder
+func (me *generator) Files() (map[string][]byte, error) {
+	files := map[string][]byte{}
+
+	data, err := me.settingsFile()
+	if err != nil {
+		return nil, err
+	}
+	files["settings/settings.go"] = data
+
+	for _, name := range sortedKeys(me.schema.Types) {
+		ref := "#/types/" + name
+		data, err := me.typeFile(ref, me.schema.Types[name])
+		if err != nil {
+			return nil, err
+		}
+		files["settings/"+hclName(me.typeNames[ref])+".go"] = data
+	}
+
+	if len(me.schema.Enums) > 0 {
+		if files["settings/enums.go"], err = me.enumsFile(); err != nil {
+			return nil, err
+		}
+	}
+
+	if files["service.go"], err = me.serviceFile(); err != nil {
+		return nil, err
+	}
+	if files["schema.json"], err = normalizeJSON(me.schema.JSON); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// field is a property of a schema or of a complex type, translated into a field of a Go struct and its HCL attribute
+type field struct {
+	property  *schemas.Property
+	jsonName  string
+	name      string
+	hcl       string
+	goType    string
+	pointer   bool
+	wrapper   string
+	enum      bool
+	secret    bool
+	multiline bool
+
+	schemaType     string
+	elem           string
+	required       bool
+	optionalReason string
+	minMaxItems    bool
+}
+
+func (me *generator) fields(properties map[string]*schemas.Property) []*field {
+	fields := []*field{}
+	for jsonName, property := range properties {
+		fields = append(fields, me.field(jsonName, property))
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].name < fields[j].name })
+	return fields
+}
+
+func (me *generator) field(jsonName string, property *schemas.Property) *field {
+	f := &field{property: property, jsonName: jsonName, name: goName(jsonName), hcl: hclName(jsonName)}
+
+	reasons := []string{}
+	if property.Nullable {
+		reasons = append(reasons, "nullable")
+	}
+	if property.Precondition != nil {
+		reasons = append(reasons, "precondition")
+	}
+
+	switch {
+	case property.Type.Name == "list" || property.Type.Name == "set":
+		items := property.Items
+		if items == nil {
+			items = &schemas.Property{Type: schemas.PropertyType{Name: "text"}}
+		}
+		if typeName, found := me.typeNames[items.Type.Ref]; found {
+			f.wrapper = plural(typeName)
+			f.goType = f.wrapper
+			f.schemaType = "schema.TypeList"
+			f.elem = "&schema.Resource{Schema: new(" + f.wrapper + ").Schema()}"
+			f.minMaxItems = true
+		} else {
+			elemType, elemSchemaType := me.primitive(items.Type)
+			f.goType = "[]" + elemType
+			f.schemaType = listKind(property)
+			f.elem = "&schema.Schema{Type: " + elemSchemaType + "}"
+			f.secret = items.Type.Name == "secret"
+		}
+		if property.MinObjects == nil || *property.MinObjects == 0 {
+			reasons = append(reasons, "minobjects == 0")
+		}
+	default:
+		if typeName, found := me.typeNames[property.Type.Ref]; found {
+			f.goType = "*" + typeName
+			f.schemaType = "schema.TypeList"
+			f.elem = "&schema.Resource{Schema: new(" + typeName + ").Schema()}"
+			f.minMaxItems = true
+			break
+		}
+		f.goType, f.schemaType = me.primitive(property.Type)
+		_, f.enum = me.enumNames[property.Type.Ref]
+		f.secret = property.Type.Name == "secret"
+		f.multiline = property.SubType == "multiline"
+		if len(reasons) > 0 {
+			f.pointer = true
+			f.goType = "*" + f.goType
+		}
+	}
+	f.required = len(reasons) == 0
+	f.optionalReason = strings.Join(reasons, " & ")
+	return f
+}
+
+// primitive maps the type of a property to the Go type of its field and the type of its HCL attribute
+func (me *generator) primitive(t schemas.PropertyType) (string, string) {
+	if enumName, found := me.enumNames[t.Ref]; found {
+		return enumName, "schema.TypeString"
+	}
+	switch t.Name {
+	case "boolean":
+		return "bool", "schema.TypeBool"
+	case "integer":
+		return "int", "schema.TypeInt"
+	case "float":
+		return "float64", "schema.TypeFloat"
+	}
+	return "string", "schema.TypeString"
+}
+
+func (me *field) omitEmpty() bool {
+	return me.pointer || strings.HasPrefix(me.goType, "*") || len(me.wrapper) > 0 || strings.HasPrefix(me.goType, "[]")
+}
+
+func (me *generator) description(property *schemas.Property, jsonName string) string {
+	t := property.Type
+	if property.Items != nil {
+		t = property.Items.Type
+	}
+	if values := me.schema.EnumValues(t); len(values) > 0 {
+		sort.Strings(values)
+		return "Possible Values: `" + strings.Join(values, "`, `") + "`"
+	}
+	if jsonName == "enabled" && property.Type.Name == "boolean" {
+		return "This setting is enabled (`true`) or disabled (`false`)"
+	}
+	description := strings.TrimSpace(property.Description)
+	if documentation := strings.TrimSpace(property.Documentation); len(documentation) > 0 {
+		if len(description) == 0 {
+			description = documentation
+		} else {
+			description = strings.TrimSuffix(description, ".") + ". " + documentation
+		}
+	}
+	if len(description) == 0 {
+		description = strings.TrimSpace(property.DisplayName)
+	}
+	if len(description) == 0 {
+		return "no documentation available"
+	}
+	return description
+}
+
+// structDef is either the `Settings` of the schema or one of its complex types
+type structDef struct {
+	name       string
+	fields     []*field
+	settings   bool
+	scope      *scopeDef
+	ordered    bool
+	nameMethod string
+}
+
+type scopeDef struct {
+	description string
+	required    bool
+}
+
+func (me *generator) settingsStruct() *structDef {
+	def := &structDef{name: "Settings", fields: me.fields(me.schema.Properties), settings: true, ordered: me.schema.Ordered}
+
+	scopes := []string{}
+	environment := false
+	for _, scope := range me.schema.AllowedScopes {
+		if scope == "environment" {
+			environment = true
+		} else {
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) > 0 {
+		def.scope = &scopeDef{description: "The scope of this setting (" + strings.Join(scopes, ", ") + ")"}
+		if environment {
+			def.scope.description = def.scope.description + ". Omit this property if you want to cover the whole environment."
+		} else {
+			def.scope.required = true
+		}
+	}
+
+	if _, found := me.schema.Properties["name"]; !found && !me.schema.MultiObject {
+		switch {
+		case def.scope != nil && def.scope.required:
+			def.nameMethod = "me.Scope"
+		case def.scope != nil:
+			def.nameMethod = "*me.Scope"
+		default:
+			def.nameMethod = `"environment"`
+		}
+	}
+	return def
+}
+
+func (me *generator) settingsFile() ([]byte, error) {
+	return me.render(me.settingsStruct(), "")
+}
+
+func (me *generator) typeFile(ref string, t *schemas.Type) ([]byte, error) {
+	def := &structDef{name: me.typeNames[ref], fields: me.fields(t.Properties)}
+	return me.render(def, me.wrapperKind[ref])
+}
+
+func (me *generator) render(def *structDef, wrapperKind string) ([]byte, error) {
+	var sb strings.Builder
+	imports := map[string]string{
+		modulePath + "/terraform/hcl":                                "",
+		"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema": "",
+	}
+
+	if len(wrapperKind) > 0 {
+		me.renderWrapper(&sb, def.name, wrapperKind)
+	}
+
+	secrets := false
+	for _, f := range def.fields {
+		secrets = secrets || f.secret
+	}
+	if secrets {
+		imports[modulePath+"/dynatrace/export/sensitive"] = ""
+	}
+
+	// type declaration
+	sb.WriteString("type " + def.name + " struct {\n")
+	for _, entry := range me.entries(def) {
+		sb.WriteString("\t" + entry.name + " " + entry.goType + " `" + entry.tag + "`")
+		if len(entry.comment) > 0 {
+			sb.WriteString(" // " + entry.comment)
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("}\n\n")
+
+	if len(def.nameMethod) > 0 {
+		sb.WriteString("func (me *" + def.name + ") Name() string {\n\treturn " + def.nameMethod + "\n}\n\n")
+	}
+
+	// schema
+	sb.WriteString("func (me *" + def.name + ") Schema() map[string]*schema.Schema {\n\treturn map[string]*schema.Schema{\n")
+	for _, entry := range me.entries(def) {
+		sb.WriteString(entry.schema)
+	}
+	sb.WriteString("\t}\n}\n\n")
+
+	// marshalling
+	sb.WriteString("func (me *" + def.name + ") MarshalHCL(properties hcl.Properties) error {\n")
+	if secrets {
+		sb.WriteString("\treturn properties.EncodeAll(sensitive.ConditionalIgnoreChangesMap(\n\t\tme.Schema(), map[string]any{\n")
+	} else {
+		sb.WriteString("\treturn properties.EncodeAll(map[string]any{\n")
+	}
+	for _, entry := range me.entries(def) {
+		value := "me." + entry.name
+		if entry.secret {
+			value = `"${state.secret_value}"`
+		}
+		sb.WriteString("\t\t" + strconv.Quote(entry.hcl) + ": " + value + ",\n")
+	}
+	if secrets {
+		sb.WriteString("\t\t},\n\t))\n}\n\n")
+	} else {
+		sb.WriteString("\t})\n}\n\n")
+	}
+
+	if preconditions := me.preconditions(def); len(preconditions) > 0 {
+		sb.WriteString("func (me *" + def.name + ") HandlePreconditions() error {\n")
+		for _, line := range preconditions {
+			sb.WriteString(line.code)
+			for imp := range line.imports {
+				imports[imp] = ""
+			}
+		}
+		sb.WriteString("\treturn nil\n}\n\n")
+	}
+
+	sb.WriteString("func (me *" + def.name + ") UnmarshalHCL(decoder hcl.Decoder) error {\n\treturn decoder.DecodeAll(map[string]any{\n")
+	for _, entry := range me.entries(def) {
+		sb.WriteString("\t\t" + strconv.Quote(entry.hcl) + ": &me." + entry.name + ",\n")
+	}
+	sb.WriteString("\t})\n}\n")
+
+	return me.finish(imports, sb.String())
+}
+
+func (me *generator) renderWrapper(sb *strings.Builder, typeName string, kind string) {
+	wrapper := plural(typeName)
+	key := hclName(typeName)
+	sb.WriteString("type " + wrapper + " []*" + typeName + "\n\n")
+	sb.WriteString("func (me *" + wrapper + ") Schema() map[string]*schema.Schema {\n\treturn map[string]*schema.Schema{\n")
+	sb.WriteString("\t\t" + strconv.Quote(key) + ": {\n")
+	sb.WriteString("\t\t\tType: " + kind + ",\n")
+	sb.WriteString("\t\t\tRequired: true,\n")
+	sb.WriteString("\t\t\tMinItems: 1,\n")
+	sb.WriteString("\t\t\tDescription: \"\",\n")
+	sb.WriteString("\t\t\tElem: &schema.Resource{Schema: new(" + typeName + ").Schema()},\n")
+	sb.WriteString("\t\t},\n\t}\n}\n\n")
+	sb.WriteString("func (me " + wrapper + ") MarshalHCL(properties hcl.Properties) error {\n\treturn properties.EncodeSlice(" + strconv.Quote(key) + ", me)\n}\n\n")
+	sb.WriteString("func (me *" + wrapper + ") UnmarshalHCL(decoder hcl.Decoder) error {\n\treturn decoder.DecodeSlice(" + strconv.Quote(key) + ", me)\n}\n\n")
+}
+
+// entry is a line of a struct declaration together with the corresponding HCL attribute
+type entry struct {
+	name    string
+	goType  string
+	tag     string
+	comment string
+	hcl     string
+	schema  string
+	secret  bool
+}
+
+func (me *generator) entries(def *structDef) []*entry {
+	entries := []*entry{}
+	for _, f := range def.fields {
+		tag := `json:"` + f.jsonName + `"`
+		if f.omitEmpty() {
+			tag = `json:"` + f.jsonName + `,omitempty"`
+		}
+		entries = append(entries, &entry{
+			name:    f.name,
+			goType:  f.goType,
+			tag:     tag,
+			comment: me.comment(f),
+			hcl:     f.hcl,
+			schema:  me.schemaEntry(f),
+			secret:  f.secret && !strings.HasPrefix(f.goType, "[]"),
+		})
+	}
+	if def.scope != nil {
+		var sb strings.Builder
+		sb.WriteString("\t\t\"scope\": {\n\t\t\tType: schema.TypeString,\n")
+		sb.WriteString("\t\t\tDescription: " + strconv.Quote(def.scope.description) + ",\n")
+		if def.scope.required {
+			sb.WriteString("\t\t\tRequired: true,\n")
+		} else {
+			sb.WriteString("\t\t\tOptional: true,\n\t\t\tDefault: \"environment\",\n")
+		}
+		sb.WriteString("\t\t\tForceNew: true,\n\t\t},\n")
+		goType := "*string"
+		if def.scope.required {
+			goType = "string"
+		}
+		entries = append(entries, &entry{name: "Scope", goType: goType, tag: `json:"-" scope:"scope"`, comment: def.scope.description, hcl: "scope", schema: sb.String()})
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+	}
+	if def.ordered {
+		entries = append(entries, &entry{name: "InsertAfter", goType: "string", tag: `json:"-"`, hcl: "insert_after", schema: insertAfterSchema})
+	}
+	return entries
+}
+
+const insertAfterSchema = `		"insert_after": {
+			Type:        schema.TypeString,
+			Description: "Because this resource allows for ordering you may specify the ID of the resource instance that comes before this instance regarding order. If not specified when creating the setting will be added to the end of the list. If not specified during update the order will remain untouched",
+			Optional:    true,
+			Computed:    true,
+		},
+`
+
+func (me *generator) comment(f *field) string {
+	if len(f.wrapper) > 0 && len(f.property.Description) == 0 && len(f.property.DisplayName) == 0 {
+		return ""
+	}
+	return strings.ReplaceAll(me.description(f.property, f.jsonName), "\n", `\n`)
+}
+
+func (me *generator) schemaEntry(f *field) string {
+	var sb strings.Builder
+	sb.WriteString("\t\t" + strconv.Quote(f.hcl) + ": {\n")
+	sb.WriteString("\t\t\tType: " + f.schemaType + ",\n")
+	sb.WriteString("\t\t\tDescription: " + strconv.Quote(me.description(f.property, f.jsonName)) + ",\n")
+	if f.required {
+		sb.WriteString("\t\t\tRequired: true,\n")
+	} else {
+		sb.WriteString("\t\t\tOptional: true, // " + f.optionalReason + "\n")
+	}
+	if len(f.elem) > 0 {
+		sb.WriteString("\t\t\tElem: " + f.elem + ",\n")
+	}
+	if f.minMaxItems {
+		sb.WriteString("\t\t\tMinItems: 1,\n\t\t\tMaxItems: 1,\n")
+	}
+	if f.secret {
+		sb.WriteString("\t\t\tSensitive: true,\n")
+	}
+	if f.multiline {
+		sb.WriteString("\t\t\tDiffSuppressFunc: hcl.SuppressEOT,\n")
+	}
+	sb.WriteString("\t\t},\n")
+	return sb.String()
+}
+
+func (me *generator) enumsFile() ([]byte, error) {
+	var sb strings.Builder
+	refs := sortedKeys(me.enumNames)
+	sort.Slice(refs, func(i, j int) bool { return me.enumNames[refs[i]] < me.enumNames[refs[j]] })
+	for _, ref := range refs {
+		enum := me.schema.Enums[strings.TrimPrefix(ref, "#/enums/")]
+		typeName := me.enumNames[ref]
+		type member struct{ name, value string }
+		members := []member{}
+		used := map[string]int{}
+		for _, item := range enum.Items {
+			name := enumMemberName(item.Value, item.DisplayName)
+			if used[name]++; used[name] > 1 {
+				name = name + strconv.Itoa(used[name])
+			}
+			members = append(members, member{name, item.Value})
+		}
+		sort.SliceStable(members, func(i, j int) bool { return members[i].name < members[j].name })
+
+		sb.WriteString("type " + typeName + " string\n\n")
+		sb.WriteString("var " + plural(typeName) + " = struct {\n")
+		for _, m := range members {
+			sb.WriteString("\t" + m.name + " " + typeName + "\n")
+		}
+		sb.WriteString("}{\n")
+		for _, m := range members {
+			sb.WriteString("\t" + strconv.Quote(m.value) + ",\n")
+		}
+		sb.WriteString("}\n\n")
+	}
+	return me.finish(nil, sb.String())
+}
+
+func (me *generator) serviceFile() ([]byte, error) {
+	var sb strings.Builder
+	sb.WriteString("const SchemaVersion = " + strconv.Quote(me.schema.Version) + "\n")
+	sb.WriteString("const SchemaID = " + strconv.Quote(me.schema.SchemaID) + "\n\n")
+	sb.WriteString("func Service(credentials *settings.Credentials) settings.CRUDService[*" + me.pkg + ".Settings] {\n")
+	sb.WriteString("\treturn settings20.Service[*" + me.pkg + ".Settings](credentials, SchemaID, SchemaVersion)\n}\n")
+	return me.finish(map[string]string{
+		me.importPath + "/settings":                            me.pkg,
+		modulePath + "/dynatrace/settings":                     "",
+		modulePath + "/dynatrace/settings/services/settings20": "",
+	}, sb.String())
+}
+
+// finish adds the package clause and the imports to the given declarations and formats the result
+func (me *generator) finish(imports map[string]string, body string) ([]byte, error) {
+	var sb strings.Builder
+	sb.WriteString("package " + me.pkg + "\n\n")
+	if len(imports) > 0 {
+		sb.WriteString("import (\n")
+		// the standard library comes first, separated from all other imports
+		groups := [2][]string{}
+		for _, imp := range sortedKeys(imports) {
+			if strings.Contains(strings.Split(imp, "/")[0], ".") {
+				groups[1] = append(groups[1], imp)
+			} else {
+				groups[0] = append(groups[0], imp)
+			}
+		}
+		for i, group := range groups {
+			if i > 0 && len(groups[0]) > 0 && len(group) > 0 {
+				sb.WriteString("\n")
+			}
+			for _, imp := range group {
+				sb.WriteString("\t")
+				if alias := imports[imp]; len(alias) > 0 {
+					sb.WriteString(alias + " ")
+				}
+				sb.WriteString(strconv.Quote(imp) + "\n")
+			}
+		}
+		sb.WriteString(")\n\n")
+	}
+	sb.WriteString(body)
+	formatted, err := format.Source([]byte(sb.String()))
+	if err != nil {
+		return nil, fmt.Errorf("generated code for schema `%s` is invalid: %w\n%s", me.schema.SchemaID, err, sb.String())
+	}
+	return formatted, nil
+}
+
+// normalizeJSON produces the JSON representation of a schema the way it is stored next to the generated packages
+func normalizeJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	var sb strings.Builder
+	encoder := json.NewEncoder(&sb)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "\t")
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return []byte(strings.TrimSuffix(sb.String(), "\n")), nil
+}
+
+func listKind(property *schemas.Property) string {
+	if property.Type.Name == "set" {
+		return "schema.TypeSet"
+	}
+	return "schema.TypeList"
+}
+
+// goName turns the name of a property, type or enum into an exported Go identifier, e.g. `allowRules` into `AllowRules`
+func goName(name string) string {
+	var sb strings.Builder
+	for _, part := range splitName(name) {
+		runes := []rune(part)
+		sb.WriteRune(unicode.ToUpper(runes[0]))
+		sb.WriteString(string(runes[1:]))
+	}
+	return identifier(sb.String())
+}
+
+// hclName turns the name of a property into the name of an HCL attribute, e.g. `allowRules` into `allow_rules`
+func hclName(name string) string {
+	var sb strings.Builder
+	for _, part := range splitName(name) {
+		runes := []rune(part)
+		for i, r := range runes {
+			if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				sb.WriteRune('_')
+			}
+			sb.WriteRune(unicode.ToLower(r))
+		}
+		sb.WriteRune('_')
+	}
+	return strings.TrimSuffix(sb.String(), "_")
+}
+
+// enumMemberName produces the name of the field representing an enum value, e.g. `AutomaticDuringMw` for `AUTOMATIC_DURING_MW`.
+// Values which don't result in a valid identifier are named after their display name
+func enumMemberName(value string, displayName string) string {
+	for _, candidate := range []string{value, displayName} {
+		var sb strings.Builder
+		for _, part := range splitName(candidate) {
+			runes := []rune(strings.ToLower(part))
+			sb.WriteRune(unicode.ToUpper(runes[0]))
+			sb.WriteString(string(runes[1:]))
+		}
+		if name := sb.String(); len(name) > 0 && !unicode.IsDigit([]rune(name)[0]) {
+			return name
+		}
+	}
+	return identifier(value)
+}
+
+func splitName(name string) []string {
+	return strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+}
+
+func identifier(name string) string {
+	if len(name) == 0 || unicode.IsDigit([]rune(name)[0]) {
+		return "Value" + name
+	}
+	return name
+}
+
+func plural(name string) string {
+	return name + "s"
+}
+
+func packageName(name string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
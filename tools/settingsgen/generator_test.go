@@ -0,0 +1,79 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/generic/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files within testdata/golden")
+
+func TestGenerate(t *testing.T) {
+	data, err := os.ReadFile("testdata/schema.json")
+	require.NoError(t, err)
+	schema, err := schemas.Parse(data)
+	require.NoError(t, err)
+
+	g := newGenerator(schema, modulePath+"/dynatrace/api/builtin/settingsgen/test")
+	files, err := g.Files()
+	require.NoError(t, err)
+	files["testdata/terraform/example_a.tf"] = g.exampleFile("dynatrace_settingsgen_test")
+	files["service_test.go"] = g.serviceTestFile("dynatrace_settingsgen_test")
+
+	for name, content := range files {
+		golden := filepath.Join("testdata", "golden", filepath.FromSlash(name)+".golden")
+		if *update {
+			require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0755))
+			require.NoError(t, os.WriteFile(golden, content, 0644))
+			continue
+		}
+		expected, err := os.ReadFile(golden)
+		require.NoError(t, err, "run `go test ./tools/settingsgen -update` after intended changes of the generated code")
+		assert.Equal(t, string(expected), string(content), name)
+	}
+}
+
+func TestNames(t *testing.T) {
+	assert.Equal(t, "AllowRules", goName("allowRules"))
+	assert.Equal(t, "Issuelabel", goName("issuelabel"))
+	assert.Equal(t, "allow_rules", hclName("allowRules"))
+	assert.Equal(t, "http_server_id", hclName("HTTPServerId"))
+	assert.Equal(t, "service_id_contributor", hclName("serviceIdContributor"))
+	assert.Equal(t, "AutomaticDuringMw", enumMemberName("AUTOMATIC_DURING_MW", "Automatic during maintenance windows"))
+	assert.Equal(t, "Webidentity", enumMemberName("webIdentity", "Web identity"))
+	assert.Equal(t, "ReduceCapturingByFactor128", enumMemberName("128", "Reduce capturing by factor 128"))
+}
+
+func TestEvaluate(t *testing.T) {
+	values := map[string]any{"mode": "AUTOMATIC", "enabled": true}
+	assert.True(t, evaluate(&precondition{Type: "EQUALS", Property: "enabled", ExpectedValue: true}, values))
+	assert.True(t, evaluate(&precondition{Type: "IN", Property: "mode", ExpectedValues: []any{"MANUAL", "AUTOMATIC"}}, values))
+	assert.True(t, evaluate(&precondition{Type: "NULL", Property: "token"}, values))
+	assert.False(t, evaluate(&precondition{Type: "NOT", Precondition: &precondition{Type: "EQUALS", Property: "mode", ExpectedValue: "AUTOMATIC"}}, values))
+	assert.True(t, evaluate(&precondition{Type: "OR", Preconditions: []*precondition{
+		{Type: "EQUALS", Property: "mode", ExpectedValue: "MANUAL"},
+		{Type: "EQUALS", Property: "enabled", ExpectedValue: true},
+	}}, values))
+}
@@ -0,0 +1,213 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/generic/schemas"
+)
+
+type options struct {
+	schemaFile  string
+	dir         string
+	resource    string
+	subcategory string
+	diff        bool
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.schemaFile, "schema", "", "the schema definition to generate the package for (default `<dir>/schema.json`)")
+	flag.StringVar(&opts.dir, "dir", ".", "the folder of the package to generate")
+	flag.StringVar(&opts.resource, "resource", "", "the name of the resource, e.g. `dynatrace_grail_metrics_allowlist`. Required for generating the example configuration and the documentation template")
+	flag.StringVar(&opts.subcategory, "subcategory", "Environment Settings", "the subcategory of the resource within the documentation")
+	flag.BoolVar(&opts.diff, "diff", false, "don't write any files, but print the differences between the existing and the generated files")
+	flag.Parse()
+
+	if err := run(opts); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(opts options) error {
+	dir, err := filepath.Abs(opts.dir)
+	if err != nil {
+		return err
+	}
+	schemaFile := opts.schemaFile
+	if len(schemaFile) == 0 {
+		schemaFile = filepath.Join(dir, "schema.json")
+	}
+	data, err := os.ReadFile(schemaFile)
+	if err != nil {
+		return err
+	}
+	schema, err := schemas.Parse(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", schemaFile, err)
+	}
+	root, module, err := moduleRoot(dir)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return err
+	}
+
+	g := newGenerator(schema, module+"/"+filepath.ToSlash(rel))
+	generated, err := g.Files()
+	if err != nil {
+		return err
+	}
+	files := map[string][]byte{}
+	for name, content := range generated {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		if strings.HasSuffix(name, ".go") {
+			content = append(header(name), content...)
+		}
+		files[name] = content
+	}
+
+	// hand curated files are only getting created if they don't exist yet
+	initial := map[string][]byte{
+		filepath.Join(dir, "service_test.go"): append(header(""), g.serviceTestFile(opts.resource)...),
+	}
+	if len(opts.resource) > 0 {
+		example := filepath.Join(dir, "testdata", "terraform", "example_a.tf")
+		examplePath, _ := filepath.Rel(root, example)
+		initial[example] = g.exampleFile(opts.resource)
+		initial[filepath.Join(root, "templates", "resources", strings.TrimPrefix(opts.resource, "dynatrace_")+".md.tmpl")] = g.templateFile(opts.resource, opts.subcategory, filepath.ToSlash(examplePath))
+	}
+	for name, content := range initial {
+		if _, err := os.Stat(name); errors.Is(err, fs.ErrNotExist) {
+			files[name] = content
+		}
+	}
+	if len(opts.resource) > 0 {
+		registrations, err := g.register(root, opts.resource)
+		if err != nil {
+			return err
+		}
+		for name, content := range registrations {
+			files[name] = content
+		}
+	}
+
+	if opts.diff {
+		changed, err := diff(os.Stdout, root, files)
+		if err != nil {
+			return err
+		}
+		for _, name := range obsolete(dir, files) {
+			fmt.Printf("not generated anymore: %s\n", name)
+		}
+		if changed == 0 {
+			fmt.Fprintf(os.Stderr, "%s is up to date with schema `%s` version %s\n", rel, schema.SchemaID, schema.Version)
+		}
+		return nil
+	}
+
+	for _, name := range sortedKeys(files) {
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(name, files[name], 0644); err != nil {
+			return err
+		}
+	}
+	for _, name := range obsolete(dir, files) {
+		fmt.Fprintf(os.Stderr, "not generated anymore, remove it unless it has been added manually: %s\n", name)
+	}
+	if len(opts.resource) > 0 {
+		fmt.Printf("run `gendoc.cmd` for generating the documentation of `%s`\n", opts.resource)
+	}
+	return nil
+}
+
+func (me *generator) serviceTestFile(resource string) []byte {
+	name := goName(strings.TrimPrefix(resource, "dynatrace_"))
+	if len(name) == 0 {
+		name = goName(me.pkg)
+	}
+	return []byte("package " + me.pkg + "_test\n\n" +
+		"import (\n\t\"testing\"\n\n\t\"" + modulePath + "/dynatrace/testing/api\"\n)\n\n" +
+		"func TestAcc" + name + "(t *testing.T) {\n\tapi.TestAcc(t)\n}\n")
+}
+
+// header produces the license header of a Go file.
+// Existing files keep their header, new ones get the current one
+func header(name string) []byte {
+	if len(name) > 0 {
+		if data, err := os.ReadFile(name); err == nil {
+			if idx := strings.Index(string(data), "\npackage "); idx >= 0 {
+				return data[:idx+1]
+			}
+		}
+	}
+	return []byte(licenseHeader)
+}
+
+// moduleRoot looks for the `go.mod` file the given folder belongs to and returns its folder and the module path
+func moduleRoot(dir string) (string, string, error) {
+	for current := dir; ; {
+		if file, err := os.Open(filepath.Join(current, "go.mod")); err == nil {
+			defer file.Close()
+			scanner := bufio.NewScanner(file)
+			for scanner.Scan() {
+				if line := strings.TrimSpace(scanner.Text()); strings.HasPrefix(line, "module ") {
+					return current, strings.TrimSpace(strings.TrimPrefix(line, "module ")), nil
+				}
+			}
+			return "", "", fmt.Errorf("%s doesn't declare a module", filepath.Join(current, "go.mod"))
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", "", fmt.Errorf("%s doesn't belong to a Go module", dir)
+		}
+		current = parent
+	}
+}
+
+const licenseHeader = `/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+`
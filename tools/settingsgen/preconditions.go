@@ -0,0 +1,225 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// precondition is the JSON representation of a precondition as found in schema definitions
+type precondition struct {
+	Type           string          `json:"type"`
+	Property       string          `json:"property"`
+	ExpectedValue  any             `json:"expectedValue"`
+	ExpectedValues []any           `json:"expectedValues"`
+	Precondition   *precondition   `json:"precondition"`
+	Preconditions  []*precondition `json:"preconditions"`
+}
+
+// check is a statement within `HandlePreconditions` together with the imports it requires
+type check struct {
+	code    string
+	imports map[string]bool
+}
+
+// condition is a Go expression that evaluates to `true` if a precondition is met
+type condition struct {
+	expr     string
+	property *field // the first property the condition refers to
+	imports  map[string]bool
+}
+
+func (me *generator) preconditions(def *structDef) []*check {
+	checks := []*check{}
+	for _, f := range def.fields {
+		if f.property.Precondition == nil || f.property.Nullable {
+			continue
+		}
+		var pre precondition
+		if err := json.Unmarshal(f.property.Precondition, &pre); err != nil {
+			continue
+		}
+		cond := me.condition(def, &pre)
+		if cond == nil {
+			checks = append(checks, &check{code: "\t// ---- " + f.name + " " + f.goType + " -> " + compactJSON(f.property.Precondition) + "\n"})
+			continue
+		}
+		imports := map[string]bool{"fmt": true}
+		for imp := range cond.imports {
+			imports[imp] = true
+		}
+		value := "me." + cond.property.name
+		if cond.property.pointer {
+			value = "*" + value
+		}
+		var sb strings.Builder
+		sb.WriteString("\tif me." + f.name + " == nil && " + cond.expr + " {\n")
+		sb.WriteString("\t\treturn fmt.Errorf(\"'" + f.hcl + "' must be specified if '" + cond.property.hcl + "' is set to '%v'\", " + value + ")\n\t}\n")
+		if len(f.wrapper) > 0 || (strings.HasPrefix(f.goType, "*") && !f.pointer) {
+			if negated := me.negate(def, &pre); negated != nil {
+				sb.WriteString("\tif me." + f.name + " != nil && " + negated.expr + " {\n")
+				sb.WriteString("\t\treturn fmt.Errorf(\"'" + f.hcl + "' must not be specified if '" + negated.property.hcl + "' is set to '%v'\", me." + negated.property.name + ")\n\t}\n")
+			}
+		}
+		checks = append(checks, &check{code: sb.String(), imports: imports})
+	}
+	return checks
+}
+
+func (me *generator) negate(def *structDef, pre *precondition) *condition {
+	return me.condition(def, &precondition{Type: "NOT", Precondition: pre})
+}
+
+// condition translates a precondition into a Go expression.
+// It returns `nil` for preconditions which cannot be expressed safely, e.g. because they refer to properties of other types
+func (me *generator) condition(def *structDef, pre *precondition) *condition {
+	switch pre.Type {
+	case "EQUALS", "IN":
+		f := lookupField(def, pre.Property)
+		if f == nil {
+			return nil
+		}
+		values := pre.ExpectedValues
+		if pre.Type == "EQUALS" {
+			values = []any{pre.ExpectedValue}
+		}
+		expr := valueCondition(f, values)
+		if len(expr) == 0 {
+			return nil
+		}
+		cond := &condition{expr: expr, property: f, imports: map[string]bool{}}
+		if strings.Contains(expr, "slices.Contains") {
+			cond.imports["golang.org/x/exp/slices"] = true
+		}
+		return cond
+	case "NOT":
+		if pre.Precondition == nil {
+			return nil
+		}
+		inner := me.condition(def, pre.Precondition)
+		// a negated condition on a nullable property would be met by a `nil` value, which cannot be reported
+		if inner == nil || inner.property.pointer || strings.Contains(inner.expr, "&&") || strings.Contains(inner.expr, "||") {
+			return nil
+		}
+		expr := inner.expr
+		switch {
+		case strings.HasPrefix(expr, "!"):
+			expr = strings.TrimPrefix(expr, "!")
+		case strings.Contains(expr, " == "):
+			expr = strings.Replace(expr, " == ", " != ", 1)
+		case strings.Contains(expr, " != "):
+			expr = strings.Replace(expr, " != ", " == ", 1)
+		default:
+			expr = "!" + expr
+		}
+		inner.expr = expr
+		return inner
+	case "AND":
+		if len(pre.Preconditions) == 0 {
+			return nil
+		}
+		var result *condition
+		parts := []string{}
+		for _, p := range pre.Preconditions {
+			cond := me.condition(def, p)
+			if cond == nil {
+				return nil
+			}
+			if result == nil {
+				result = &condition{property: cond.property, imports: map[string]bool{}}
+			}
+			for imp := range cond.imports {
+				result.imports[imp] = true
+			}
+			parts = append(parts, "("+cond.expr+")")
+		}
+		result.expr = "(" + strings.Join(parts, " && ") + ")"
+		return result
+	}
+	return nil
+}
+
+func valueCondition(f *field, values []any) string {
+	target := "me." + f.name
+	guard := ""
+	if f.pointer {
+		target = "*" + target
+		guard = "me." + f.name + " != nil && "
+	}
+	baseType := strings.TrimPrefix(f.goType, "*")
+	switch {
+	case strings.HasPrefix(baseType, "[]") || len(f.wrapper) > 0 || (strings.HasPrefix(f.goType, "*") && !f.pointer):
+		return ""
+	case baseType == "bool":
+		if len(values) != 1 {
+			return ""
+		}
+		b, ok := values[0].(bool)
+		if !ok {
+			return ""
+		}
+		if b {
+			return guard + target
+		}
+		return guard + "!" + target
+	case baseType == "int" || baseType == "float64":
+		if len(values) != 1 {
+			return ""
+		}
+		n, ok := values[0].(float64)
+		if !ok {
+			return ""
+		}
+		return guard + target + " == " + strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	literals := []string{}
+	for _, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			return ""
+		}
+		literals = append(literals, strconv.Quote(s))
+	}
+	switch len(literals) {
+	case 0:
+		return ""
+	case 1:
+		return guard + "string(" + target + ") == " + literals[0]
+	}
+	return guard + "slices.Contains([]string{" + strings.Join(literals, ", ") + "}, string(" + target + "))"
+}
+
+func lookupField(def *structDef, jsonName string) *field {
+	for _, f := range def.fields {
+		if f.jsonName == jsonName {
+			return f
+		}
+	}
+	return nil
+}
+
+func compactJSON(data []byte) string {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return string(data)
+	}
+	compact, _ := json.Marshal(v)
+	return string(compact)
+}
@@ -0,0 +1,111 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// insertion places a line at the end of the block that starts with `block` and ends with `end`
+type insertion struct {
+	block string
+	end   string
+	line  string
+}
+
+// register adds the resource to the resource types and resource descriptors of the export and to the resources of the provider.
+// It returns the modified files keyed by their path. Files that already know about the resource are not getting modified
+func (me *generator) register(root string, resource string) (map[string][]byte, error) {
+	field := goName(strings.TrimPrefix(resource, "dynatrace_"))
+	alias := packageName(strings.TrimPrefix(resource, "dynatrace_"))
+
+	registrations := map[string][]insertion{
+		filepath.Join(root, "dynatrace", "export", "enums.go"): {
+			{block: "var ResourceTypes = struct {\n", end: "\n}{\n", line: "\t" + field + " ResourceType"},
+			{block: "\n}{\n", end: "\n}\n", line: "\t" + strconv.Quote(resource) + ","},
+		},
+		filepath.Join(root, "dynatrace", "export", "resource_descriptor.go"): {
+			{block: "import (\n", end: "\n)\n", line: "\t" + alias + " " + strconv.Quote(me.importPath)},
+			{block: "var AllResources = map[ResourceType]ResourceDescriptor{\n", end: "\n}\n", line: "\tResourceTypes." + field + ": NewResourceDescriptor(" + alias + ".Service),"},
+		},
+		filepath.Join(root, "provider", "provider.go"): {
+			{block: "ResourcesMap: map[string]*schema.Resource{\n", end: "\n\t\t},\n", line: "\t\t\t" + strconv.Quote(resource) + ": resources.NewGeneric(export.ResourceTypes." + field + ").Resource(),"},
+		},
+	}
+
+	files := map[string][]byte{}
+	for _, name := range sortedKeys(registrations) {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(string(data), "\n\t"+alias+" \"") && !strings.Contains(string(data), "\n\t"+alias+" "+strconv.Quote(me.importPath)+"\n") {
+			return nil, fmt.Errorf("%s: the package alias `%s` is already in use", name, alias)
+		}
+		content, err := registerIn(string(data), registrations[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if content == string(data) {
+			continue
+		}
+		formatted, err := format.Source([]byte(content))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		files[name] = formatted
+	}
+	return files, nil
+}
+
+// registerIn applies the insertions to the source code of a file.
+// Lines the file already contains are getting skipped
+func registerIn(content string, insertions []insertion) (string, error) {
+	for _, ins := range insertions {
+		if containsLine(content, ins.line) {
+			continue
+		}
+		start := strings.Index(content, ins.block)
+		if start < 0 {
+			return "", fmt.Errorf("`%s` not found", strings.TrimSpace(ins.block))
+		}
+		end := strings.Index(content[start+len(ins.block)-1:], ins.end)
+		if end < 0 {
+			return "", fmt.Errorf("end of `%s` not found", strings.TrimSpace(ins.block))
+		}
+		pos := start + len(ins.block) - 1 + end + 1
+		content = content[:pos] + ins.line + "\n" + content[pos:]
+	}
+	return content, nil
+}
+
+// containsLine checks whether the content contains the given line, regardless of how it has been aligned
+func containsLine(content string, line string) bool {
+	expected := strings.Join(strings.Fields(line), " ")
+	for _, candidate := range strings.Split(content, "\n") {
+		if strings.Join(strings.Fields(candidate), " ") == expected {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+/**
+* @license
+* Copyright 2024 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/generic/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"dynatrace/export/enums.go", "dynatrace/export/resource_descriptor.go", "provider/provider.go"} {
+		data, err := os.ReadFile(filepath.Join("..", "..", filepath.FromSlash(name)))
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, filepath.FromSlash(name)), data, 0644))
+	}
+
+	g := &generator{importPath: modulePath + "/dynatrace/api/builtin/settingsgen/test"}
+	files, err := g.register(root, "dynatrace_settingsgen_test")
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	for name, content := range files {
+		_, err := parser.ParseFile(token.NewFileSet(), name, content, parser.AllErrors)
+		require.NoError(t, err, name)
+		require.NoError(t, os.WriteFile(name, content, 0644))
+	}
+	assert.True(t, containsLine(string(files[filepath.Join(root, "dynatrace", "export", "enums.go")]), `SettingsgenTest ResourceType`))
+	assert.True(t, containsLine(string(files[filepath.Join(root, "dynatrace", "export", "enums.go")]), `"dynatrace_settingsgen_test",`))
+	assert.True(t, containsLine(string(files[filepath.Join(root, "dynatrace", "export", "resource_descriptor.go")]), `settingsgentest "`+modulePath+`/dynatrace/api/builtin/settingsgen/test"`))
+	assert.True(t, containsLine(string(files[filepath.Join(root, "dynatrace", "export", "resource_descriptor.go")]), `ResourceTypes.SettingsgenTest: NewResourceDescriptor(settingsgentest.Service),`))
+	assert.True(t, containsLine(string(files[filepath.Join(root, "provider", "provider.go")]), `"dynatrace_settingsgen_test": resources.NewGeneric(export.ResourceTypes.SettingsgenTest).Resource(),`))
+
+	// registering the resource a second time doesn't modify anything
+	files, err = g.register(root, "dynatrace_settingsgen_test")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+// TestCompile ensures that the generated package compiles, including its test
+func TestCompile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("compiling the generated package requires the go tool")
+	}
+	data, err := os.ReadFile("testdata/schema.json")
+	require.NoError(t, err)
+	schema, err := schemas.Parse(data)
+	require.NoError(t, err)
+
+	// the folder needs to be located within the module. The prefix `_` hides it from `./...`, which is why the packages are listed explicitly
+	dir, err := os.MkdirTemp(".", "_compile")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	g := newGenerator(schema, modulePath+"/tools/settingsgen/"+filepath.Base(dir))
+	files, err := g.Files()
+	require.NoError(t, err)
+	files["service_test.go"] = g.serviceTestFile("dynatrace_settingsgen_test")
+	for name, content := range files {
+		if strings.HasSuffix(name, ".go") {
+			content = append(header(""), content...)
+		}
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0755))
+		require.NoError(t, os.WriteFile(name, content, 0644))
+	}
+
+	output, err := exec.Command("go", "vet", "./"+filepath.Base(dir), "./"+filepath.Base(dir)+"/settings").CombinedOutput()
+	require.NoError(t, err, string(output))
+}